@@ -0,0 +1,236 @@
+// Package ballistics aims projectiles under gravity, with optional linear drag,
+// at still or moving targets and gives their trajectories, in 2D and 3D.
+// Without drag the intercept times are the roots of a quartic; with drag they
+// are found numerically.
+// https://en.wikipedia.org/wiki/Projectile_motion
+package ballistics
+
+import (
+	"math"
+	"sort"
+)
+
+// Default longest time of flight searched with drag
+const defaultMaxTime = 60
+
+// Number of intervals scanned for intercepts with drag
+const scanSteps = 2000
+
+type p3 [3]float64
+
+func add3(a, b p3) p3           { return p3{a[0] + b[0], a[1] + b[1], a[2] + b[2]} }
+func sub3(a, b p3) p3           { return p3{a[0] - b[0], a[1] - b[1], a[2] - b[2]} }
+func scale3(a p3, s float64) p3 { return p3{a[0] * s, a[1] * s, a[2] * s} }
+func dot3(a, b p3) float64      { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+func norm3(a p3) float64        { return math.Sqrt(dot3(a, a)) }
+
+// Projectile motion p(t) = p0 + v0*t + g*t^2/2, or with drag k the solution of
+// acceleration = g - k*velocity
+type model struct {
+	speed   float64
+	g       p3
+	k       float64
+	maxTime float64
+}
+
+// Fraction of the launch velocity integrated by time t, (1 - e^(-kt))/k
+func (m model) decay(t float64) float64 {
+	if m.k == 0 {
+		return t
+	}
+	return -math.Expm1(-m.k*t) / m.k
+}
+
+// Displacement due to gravity by time t
+func (m model) fall(t float64) p3 {
+	if m.k == 0 {
+		return scale3(m.g, t*t/2)
+	}
+	return scale3(m.g, (t-m.decay(t))/m.k)
+}
+
+func (m model) position(p0, v0 p3, t float64) p3 {
+	return add3(add3(p0, scale3(v0, m.decay(t))), m.fall(t))
+}
+
+func (m model) velocity(v0 p3, t float64) p3 {
+	if m.k == 0 {
+		return add3(v0, scale3(m.g, t))
+	}
+	e := math.Exp(-m.k * t)
+	return add3(scale3(v0, e), scale3(m.g, (1-e)/m.k))
+}
+
+// Time to come back to the launch height along -g
+func (m model) timeOfFlight(v0 p3) float64 {
+	g := norm3(m.g)
+	if g == 0 {
+		return 0
+	}
+	up := scale3(m.g, -1/g)
+	vu := dot3(v0, up)
+	if vu <= 0 {
+		return 0
+	}
+	if m.k == 0 {
+		return 2 * vu / g
+	}
+	// from the apex the height decreases for ever
+	h := func(t float64) float64 { return dot3(m.position(p3{}, v0, t), up) }
+	a := math.Log1p(m.k*vu/g) / m.k
+	b := 2 * a
+	for h(b) > 0 {
+		b *= 2
+	}
+	return bisect(h, a, b, h(a))
+}
+
+// Launch velocity reaching d + vt*t at time t
+func (m model) launch(d, vt p3, t float64) p3 {
+	return scale3(sub3(add3(d, scale3(vt, t)), m.fall(t)), 1/m.decay(t))
+}
+
+// Times at which a projectile launched at the model speed can meet a target
+// at offset d moving with velocity vt, in increasing order
+func (m model) intercepts(d, vt p3) []float64 {
+	if m.k == 0 {
+		// |d + vt*t - g*t^2/2|^2 = s^2*t^2
+		c := []float64{
+			dot3(d, d),
+			2 * dot3(d, vt),
+			dot3(vt, vt) - dot3(m.g, d) - m.speed*m.speed,
+			-dot3(m.g, vt),
+			dot3(m.g, m.g) / 4,
+		}
+		var ts []float64
+		for _, t := range realRoots(c, 0, cauchyBound(c)) {
+			if t > 0 {
+				ts = append(ts, t)
+			}
+		}
+		return ts
+	}
+	// the required launch speed is infinite at t = 0, scan for the times it
+	// matches the model speed and refine by bisection
+	f := func(t float64) float64 { return norm3(m.launch(d, vt, t)) - m.speed }
+	var ts []float64
+	h := m.maxTime / scanSteps
+	a, fa := h*1e-3, f(h*1e-3)
+	for i := 1; i <= scanSteps; i++ {
+		b := h * float64(i)
+		fb := f(b)
+		if fa == 0 {
+			ts = append(ts, a)
+		} else if fa*fb < 0 {
+			ts = append(ts, bisect(f, a, b, fa))
+		}
+		a, fa = b, fb
+	}
+	return ts
+}
+
+func bisect(f func(float64) float64, a, b, fa float64) float64 {
+	for i := 0; i < 100 && b-a > 1e-12*math.Max(1, b); i++ {
+		mid := (a + b) / 2
+		fm := f(mid)
+		if fm == 0 {
+			return mid
+		}
+		if fa*fm < 0 {
+			b = mid
+		} else {
+			a, fa = mid, fm
+		}
+	}
+	return (a + b) / 2
+}
+
+// Value of the polynomial c[0] + c[1]*x + ... at x
+func eval(c []float64, x float64) float64 {
+	r := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		r = r*x + c[i]
+	}
+	return r
+}
+
+// Polynomial without its vanishing leading coefficients
+func trim(c []float64) []float64 {
+	scale := 0.0
+	for _, x := range c {
+		scale = math.Max(scale, math.Abs(x))
+	}
+	for len(c) > 0 && math.Abs(c[len(c)-1]) <= 1e-14*scale {
+		c = c[:len(c)-1]
+	}
+	return c
+}
+
+// Bound on the absolute value of the roots
+func cauchyBound(c []float64) float64 {
+	c = trim(c)
+	if len(c) < 2 {
+		return 0
+	}
+	lead := c[len(c)-1]
+	r := 0.0
+	for _, x := range c[:len(c)-1] {
+		r = math.Max(r, math.Abs(x/lead))
+	}
+	return 1 + r
+}
+
+// Real roots in [lo, hi] in increasing order. The extrema, from the roots of
+// the derivative, split the interval in monotone pieces each holding at most
+// one root; an extremum touching zero is a double root.
+func realRoots(c []float64, lo, hi float64) []float64 {
+	c = trim(c)
+	n := len(c) - 1
+	if n < 1 {
+		return nil
+	}
+	if n == 1 {
+		if r := -c[0] / c[1]; r >= lo && r <= hi {
+			return []float64{r}
+		}
+		return nil
+	}
+	d := make([]float64, n)
+	for i := 1; i <= n; i++ {
+		d[i-1] = float64(i) * c[i]
+	}
+	crit := realRoots(d, lo, hi)
+	pts := append(append([]float64{lo}, crit...), hi)
+	// size of the terms, for the tolerance of double roots
+	size := func(x float64) float64 {
+		s, p := 0.0, 1.0
+		for _, ci := range c {
+			s += math.Abs(ci) * p
+			p *= math.Abs(x)
+		}
+		return s
+	}
+	var roots []float64
+	add := func(r float64) {
+		if len(roots) == 0 || r-roots[len(roots)-1] > 1e-9*math.Max(1, math.Abs(r)) {
+			roots = append(roots, r)
+		}
+	}
+	f := func(x float64) float64 { return eval(c, x) }
+	for i := 0; i+1 < len(pts); i++ {
+		a, b := pts[i], pts[i+1]
+		fa, fb := f(a), f(b)
+		if math.Abs(fa) <= 1e-12*size(a) {
+			add(a)
+			continue
+		}
+		if fa*fb < 0 {
+			add(bisect(f, a, b, fa))
+		}
+	}
+	if math.Abs(f(hi)) <= 1e-12*size(hi) {
+		add(hi)
+	}
+	sort.Float64s(roots)
+	return roots
+}
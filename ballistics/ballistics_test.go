@@ -0,0 +1,74 @@
+package ballistics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestRealRoots(t *testing.T) {
+	tests := []struct {
+		name string
+		c    []float64
+		want []float64
+	}{
+		// (x-1)(x-2)(x-3)(x-4)
+		{"four roots", []float64{24, -50, 35, -10, 1}, []float64{1, 2, 3, 4}},
+		// (x-2)^2 (x+1)
+		{"double root", []float64{4, 0, -3, 1}, []float64{-1, 2}},
+		{"no real root", []float64{1, 0, 1}, nil},
+		{"linear", []float64{-3, 2}, []float64{1.5}},
+		{"vanishing leading terms", []float64{-3, 2, 0, 1e-20}, []float64{1.5}},
+		{"constant", []float64{5}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := realRoots(tt.c, -10, 10)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 1e-7)); diff != "" {
+				t.Errorf("realRoots() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCauchyBound(t *testing.T) {
+	c := []float64{24, -50, 35, -10, 1}
+	if b := cauchyBound(c); b < 4 {
+		t.Errorf("cauchyBound() = %v, below the largest root 4", b)
+	}
+}
+
+func TestDragModel(t *testing.T) {
+	// the closed form matches a fine numerical integration of p'' = g - k*p'
+	m := model{g: p3{0, -9.8, 0}, k: 0.7}
+	p, v := p3{}, p3{10, 20, -3}
+	v0 := v
+	const dt = 1e-5
+	for i := 0; i < 200000; i++ {
+		a := sub3(m.g, scale3(v, m.k))
+		v = add3(v, scale3(a, dt))
+		p = add3(p, scale3(v, dt))
+	}
+	got := m.position(p3{}, v0, 2)
+	for i := range p {
+		if math.Abs(got[i]-p[i]) > 1e-3 {
+			t.Errorf("position[%d] = %v, want %v", i, got[i], p[i])
+		}
+	}
+	gotV := m.velocity(v0, 2)
+	for i := range v {
+		if math.Abs(gotV[i]-v[i]) > 1e-3 {
+			t.Errorf("velocity[%d] = %v, want %v", i, gotV[i], v[i])
+		}
+	}
+	// back at launch height after the time of flight
+	tf := m.timeOfFlight(v0)
+	if h := m.position(p3{}, v0, tf)[1]; math.Abs(h) > 1e-9 {
+		t.Errorf("height after the time of flight = %v, want 0", h)
+	}
+	if tf >= 2*20/9.8 {
+		t.Errorf("time of flight %v, want shorter than without drag", tf)
+	}
+}
@@ -0,0 +1,99 @@
+package ballistics
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Projectile launched at a fixed speed, accelerated by gravity and slowed by
+// linear drag: the acceleration is Gravity - Drag*velocity
+type Projectile struct {
+	Speed   float32
+	Gravity *vector.Vector
+	// Drag coefficient per second, 0 for none
+	Drag float32
+	// Longest time of flight searched for intercepts with drag (default 60)
+	MaxTime float32
+}
+
+// Way to hit a target
+type Solution struct {
+	// Unit launch direction
+	Direction *vector.Vector
+	// Time of flight until the hit
+	Time float32
+	// Where the projectile meets the target
+	Impact *vector.Vector
+}
+
+func from3(v *vector.Vector) p3 { return p3{float64(v.X), float64(v.Y), float64(v.Z)} }
+func (a p3) vector() *vector.Vector {
+	return vector.New(float32(a[0]), float32(a[1]), float32(a[2]))
+}
+
+func (p Projectile) model() model {
+	m := model{speed: float64(p.Speed), k: float64(p.Drag), maxTime: float64(p.MaxTime)}
+	if p.Gravity != nil {
+		m.g = from3(p.Gravity)
+	}
+	if m.maxTime <= 0 {
+		m.maxTime = defaultMaxTime
+	}
+	return m
+}
+
+// Launch directions from shooter hitting a target at position moving with
+// velocity (nil for a still target). low is the fastest solution, the flat
+// arc, and high the slowest, the lob; they are the same when only one exists.
+// ok is false when the target is out of reach.
+func (p Projectile) Intercept(shooter, target, velocity *vector.Vector) (low, high Solution, ok bool) {
+	m := p.model()
+	d := sub3(from3(target), from3(shooter))
+	var vt p3
+	if velocity != nil {
+		vt = from3(velocity)
+	}
+	ts := m.intercepts(d, vt)
+	if len(ts) == 0 {
+		return Solution{}, Solution{}, false
+	}
+	solve := func(t float64) Solution {
+		v := m.launch(d, vt, t)
+		return Solution{
+			Direction: scale3(v, 1/norm3(v)).vector(),
+			Time:      float32(t),
+			Impact:    add3(from3(target), scale3(vt, t)).vector(),
+		}
+	}
+	return solve(ts[0]), solve(ts[len(ts)-1]), true
+}
+
+// Position at time t of a projectile launched from origin with velocity
+func (p Projectile) Position(origin, velocity *vector.Vector, t float32) *vector.Vector {
+	return p.model().position(from3(origin), from3(velocity), float64(t)).vector()
+}
+
+// Velocity at time t of a projectile launched with velocity
+func (p Projectile) Velocity(velocity *vector.Vector, t float32) *vector.Vector {
+	return p.model().velocity(from3(velocity), float64(t)).vector()
+}
+
+// Positions at n+1 evenly spaced times from 0 to duration of a projectile
+// launched from origin with velocity
+func (p Projectile) Trajectory(origin, velocity *vector.Vector, duration float32, n int) []*vector.Vector {
+	if n < 1 {
+		n = 1
+	}
+	m := p.model()
+	p0, v0 := from3(origin), from3(velocity)
+	points := make([]*vector.Vector, n+1)
+	for i := range points {
+		points[i] = m.position(p0, v0, float64(duration)*float64(i)/float64(n)).vector()
+	}
+	return points
+}
+
+// Time at which a projectile launched with velocity comes back down to its
+// launch height, measured against gravity. 0 if it isn't launched upwards.
+func (p Projectile) TimeOfFlight(velocity *vector.Vector) float32 {
+	return float32(p.model().timeOfFlight(from3(velocity)))
+}
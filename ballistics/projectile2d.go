@@ -0,0 +1,90 @@
+package ballistics
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Projectile in 2D, see Projectile
+type Projectile2D struct {
+	Speed   float32
+	Gravity *vector2d.Vector2D
+	// Drag coefficient per second, 0 for none
+	Drag float32
+	// Longest time of flight searched for intercepts with drag (default 60)
+	MaxTime float32
+}
+
+// Way to hit a target in 2D, see Solution
+type Solution2D struct {
+	Direction *vector2d.Vector2D
+	Time      float32
+	Impact    *vector2d.Vector2D
+}
+
+func from2(v *vector2d.Vector2D) p3 { return p3{float64(v.X), float64(v.Y), 0} }
+func (a p3) vector2D() *vector2d.Vector2D {
+	return vector2d.New(float32(a[0]), float32(a[1]))
+}
+
+func (p Projectile2D) model() model {
+	m := model{speed: float64(p.Speed), k: float64(p.Drag), maxTime: float64(p.MaxTime)}
+	if p.Gravity != nil {
+		m.g = from2(p.Gravity)
+	}
+	if m.maxTime <= 0 {
+		m.maxTime = defaultMaxTime
+	}
+	return m
+}
+
+// Launch directions from shooter hitting a target, see Projectile.Intercept
+func (p Projectile2D) Intercept(shooter, target, velocity *vector2d.Vector2D) (low, high Solution2D, ok bool) {
+	m := p.model()
+	d := sub3(from2(target), from2(shooter))
+	var vt p3
+	if velocity != nil {
+		vt = from2(velocity)
+	}
+	ts := m.intercepts(d, vt)
+	if len(ts) == 0 {
+		return Solution2D{}, Solution2D{}, false
+	}
+	solve := func(t float64) Solution2D {
+		v := m.launch(d, vt, t)
+		return Solution2D{
+			Direction: scale3(v, 1/norm3(v)).vector2D(),
+			Time:      float32(t),
+			Impact:    add3(from2(target), scale3(vt, t)).vector2D(),
+		}
+	}
+	return solve(ts[0]), solve(ts[len(ts)-1]), true
+}
+
+// Position at time t of a projectile launched from origin with velocity
+func (p Projectile2D) Position(origin, velocity *vector2d.Vector2D, t float32) *vector2d.Vector2D {
+	return p.model().position(from2(origin), from2(velocity), float64(t)).vector2D()
+}
+
+// Velocity at time t of a projectile launched with velocity
+func (p Projectile2D) Velocity(velocity *vector2d.Vector2D, t float32) *vector2d.Vector2D {
+	return p.model().velocity(from2(velocity), float64(t)).vector2D()
+}
+
+// Positions at n+1 evenly spaced times from 0 to duration, see Projectile.Trajectory
+func (p Projectile2D) Trajectory(origin, velocity *vector2d.Vector2D, duration float32, n int) []*vector2d.Vector2D {
+	if n < 1 {
+		n = 1
+	}
+	m := p.model()
+	p0, v0 := from2(origin), from2(velocity)
+	points := make([]*vector2d.Vector2D, n+1)
+	for i := range points {
+		points[i] = m.position(p0, v0, float64(duration)*float64(i)/float64(n)).vector2D()
+	}
+	return points
+}
+
+// Time to come back down to the launch height, see Projectile.TimeOfFlight
+func (p Projectile2D) TimeOfFlight(velocity *vector2d.Vector2D) float32 {
+	return float32(p.model().timeOfFlight(from2(velocity)))
+}
@@ -0,0 +1,60 @@
+package ballistics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestIntercept2D(t *testing.T) {
+	p := Projectile2D{Speed: 20, Gravity: vector2d.New(0, -9.8)}
+	low, high, ok := p.Intercept(vector2d.New(0, 0), vector2d.New(-30, 0), nil)
+	if !ok {
+		t.Fatal("Intercept() found no solution")
+	}
+	a := math.Asin(9.8*30/400) / 2
+	for i, s := range []Solution2D{low, high} {
+		angle := a
+		if i == 1 {
+			angle = math.Pi/2 - a
+		}
+		want := vector2d.New(-float32(math.Cos(angle)), float32(math.Sin(angle)))
+		if diff := cmp.Diff(want, s.Direction, getComparer(1e-4)); diff != "" {
+			t.Errorf("Direction %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	// moving target with drag
+	p.Drag = 0.1
+	target, velocity := vector2d.New(25, 8), vector2d.New(-2, 0)
+	low, high, ok = p.Intercept(vector2d.New(0, 0), target, velocity)
+	if !ok {
+		t.Fatal("Intercept() with drag found no solution")
+	}
+	for _, s := range []Solution2D{low, high} {
+		hit := p.Position(vector2d.New(0, 0), s.Direction.Copy().Mult(p.Speed), s.Time)
+		if diff := cmp.Diff(s.Impact, hit, getComparer(1e-2)); diff != "" {
+			t.Errorf("projectile misses (-want +got):\n%s", diff)
+		}
+	}
+	if low.Time >= high.Time {
+		t.Errorf("low arc time %v, want before the high arc %v", low.Time, high.Time)
+	}
+}
+
+func TestTrajectory2D(t *testing.T) {
+	p := Projectile2D{Gravity: vector2d.New(0, -10)}
+	got := p.Trajectory(vector2d.New(0, 0), vector2d.New(3, 10), 2, 2)
+	want := []*vector2d.Vector2D{vector2d.New(0, 0), vector2d.New(3, 5), vector2d.New(6, 0)}
+	if diff := cmp.Diff(want, got, getComparer(1e-5)); diff != "" {
+		t.Errorf("Trajectory() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(vector2d.New(3, -10), p.Velocity(vector2d.New(3, 10), 2), getComparer(1e-5)); diff != "" {
+		t.Errorf("Velocity() mismatch (-want +got):\n%s", diff)
+	}
+	if tf := p.TimeOfFlight(vector2d.New(3, 10)); tf != 2 {
+		t.Errorf("TimeOfFlight() = %v, want 2", tf)
+	}
+}
@@ -0,0 +1,125 @@
+package ballistics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b float32) bool { return math.Abs(float64(a-b)) <= float64(tolerance) }),
+	}
+}
+
+func TestInterceptStill(t *testing.T) {
+	p := Projectile{Speed: 20, Gravity: vector.New(0, -9.8, 0)}
+	low, high, ok := p.Intercept(vector.New(0, 0, 0), vector.New(0, 0, 30), nil)
+	if !ok {
+		t.Fatal("Intercept() found no solution")
+	}
+	// on flat ground sin(2*angle) = g*d/v^2
+	a := math.Asin(9.8*30/400) / 2
+	angles := []float64{a, math.Pi/2 - a}
+	for i, s := range []Solution{low, high} {
+		sin, cos := math.Sincos(angles[i])
+		want := Solution{
+			Direction: vector.New(0, float32(sin), float32(cos)),
+			Time:      float32(30 / (20 * cos)),
+			Impact:    vector.New(0, 0, 30),
+		}
+		if diff := cmp.Diff(want, s, getComparer(1e-4)); diff != "" {
+			t.Errorf("solution %d mismatch (-want +got):\n%s", i, diff)
+		}
+		if tf := p.TimeOfFlight(s.Direction.Copy().Mult(20)); math.Abs(float64(tf-s.Time)) > 1e-4 {
+			t.Errorf("TimeOfFlight() = %v, want %v", tf, s.Time)
+		}
+	}
+}
+
+func TestInterceptMoving(t *testing.T) {
+	shooter := vector.New(1, 2, 3)
+	target, velocity := vector.New(40, 5, -10), vector.New(-3, 1, 6)
+	tests := []struct {
+		name string
+		p    Projectile
+	}{
+		{"gravity", Projectile{Speed: 30, Gravity: vector.New(0, -9.8, 0)}},
+		{"no gravity", Projectile{Speed: 30}},
+		{"tilted gravity", Projectile{Speed: 30, Gravity: vector.New(1, -5, 2)}},
+		{"drag", Projectile{Speed: 40, Gravity: vector.New(0, -9.8, 0), Drag: 0.2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			low, high, ok := tt.p.Intercept(shooter, target, velocity)
+			if !ok {
+				t.Fatal("Intercept() found no solution")
+			}
+			if low.Time > high.Time {
+				t.Errorf("low arc time %v after high arc time %v", low.Time, high.Time)
+			}
+			for _, s := range []Solution{low, high} {
+				// the projectile and the target meet at the impact point
+				hit := tt.p.Position(shooter, s.Direction.Copy().Mult(tt.p.Speed), s.Time)
+				moved := vector.Add(target, velocity.Copy().Mult(s.Time))
+				if diff := cmp.Diff(moved, s.Impact, getComparer(1e-3)); diff != "" {
+					t.Errorf("Impact mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(s.Impact, hit, getComparer(1e-2)); diff != "" {
+					t.Errorf("projectile misses (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestInterceptOutOfReach(t *testing.T) {
+	p := Projectile{Speed: 10, Gravity: vector.New(0, -9.8, 0)}
+	// the range is v^2/g, about 10.2
+	if _, _, ok := p.Intercept(vector.New(0, 0, 0), vector.New(11, 0, 0), nil); ok {
+		t.Error("Intercept() of a target out of range succeeded")
+	}
+	// a target running away faster than the projectile
+	p = Projectile{Speed: 10}
+	if _, _, ok := p.Intercept(vector.New(0, 0, 0), vector.New(5, 0, 0), vector.New(20, 0, 0)); ok {
+		t.Error("Intercept() of a faster target succeeded")
+	}
+	// drag shortens the range
+	p = Projectile{Speed: 20, Gravity: vector.New(0, -9.8, 0)}
+	if _, _, ok := p.Intercept(vector.New(0, 0, 0), vector.New(35, 0, 0), nil); !ok {
+		t.Error("Intercept() without drag failed")
+	}
+	p.Drag = 0.5
+	if _, _, ok := p.Intercept(vector.New(0, 0, 0), vector.New(35, 0, 0), nil); ok {
+		t.Error("Intercept() with drag succeeded")
+	}
+}
+
+func TestTrajectory(t *testing.T) {
+	p := Projectile{Gravity: vector.New(0, 0, -10)}
+	got := p.Trajectory(vector.New(0, 0, 1), vector.New(2, 0, 10), 2, 4)
+	want := []*vector.Vector{
+		vector.New(0, 0, 1),
+		vector.New(1, 0, 4.75),
+		vector.New(2, 0, 6),
+		vector.New(3, 0, 4.75),
+		vector.New(4, 0, 1),
+	}
+	if diff := cmp.Diff(want, got, getComparer(1e-5)); diff != "" {
+		t.Errorf("Trajectory() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(vector.New(2, 0, -10), p.Velocity(vector.New(2, 0, 10), 2), getComparer(1e-5)); diff != "" {
+		t.Errorf("Velocity() mismatch (-want +got):\n%s", diff)
+	}
+	if tf := p.TimeOfFlight(vector.New(2, 0, 10)); tf != 2 {
+		t.Errorf("TimeOfFlight() = %v, want 2", tf)
+	}
+	if tf := p.TimeOfFlight(vector.New(2, 0, -1)); tf != 0 {
+		t.Errorf("TimeOfFlight() of a downward shot = %v, want 0", tf)
+	}
+}
@@ -0,0 +1,114 @@
+package barneshut
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Default opening angle
+const defaultTheta = 0.5
+
+// Point mass of a simulation
+type Body struct {
+	Position, Velocity *vector.Vector
+	Mass               float32
+}
+
+// Bodies attracting each other by gravity, approximated with an octree
+type Simulation struct {
+	Bodies []*Body
+	// Gravitational constant
+	G float32
+	// Opening angle: a cell of size s at distance d from a body acts as a
+	// single mass when s/d < Theta. 0 gives the exact O(n^2) forces, larger
+	// values are faster and less accurate.
+	Theta float32
+	// Length added in quadrature to the distances, avoiding the singular
+	// forces of close encounters; 0 for exact gravity
+	Softening float32
+	// Goroutines evaluating the forces, 0 for GOMAXPROCS
+	Workers int
+}
+
+// Energy, momentum and center of mass of a simulation. The potential energy
+// is computed with the tree, to the same accuracy as the forces.
+type Diagnostics struct {
+	Kinetic, Potential, Energy float32
+	Momentum                   *vector.Vector
+	// Angular momentum about the origin
+	AngularMomentum *vector.Vector
+	CenterOfMass    *vector.Vector
+}
+
+// Creates an empty simulation with gravitational constant g and opening
+// angle 0.5
+func NewSimulation(g float32) *Simulation {
+	return &Simulation{G: g, Theta: defaultTheta}
+}
+
+// Adds a body to the simulation
+func (s *Simulation) AddBody(position, velocity *vector.Vector, mass float32) *Body {
+	b := &Body{Position: position, Velocity: velocity, Mass: mass}
+	s.Bodies = append(s.Bodies, b)
+	return b
+}
+
+func (s *Simulation) params() params {
+	return params{g: float64(s.G), theta: float64(s.Theta), eps: float64(s.Softening), workers: s.Workers}
+}
+
+func (s *Simulation) state() (x, v []vector64.Vector, m []float64) {
+	x, v, m = make([]vector64.Vector, len(s.Bodies)), make([]vector64.Vector, len(s.Bodies)), make([]float64, len(s.Bodies))
+	for i, b := range s.Bodies {
+		x[i] = *vector64.FromVector(b.Position)
+		v[i] = *vector64.FromVector(b.Velocity)
+		m[i] = float64(b.Mass)
+	}
+	return x, v, m
+}
+
+// Gravitational accelerations of the bodies
+func (s *Simulation) Accelerations() []*vector.Vector {
+	x, _, m := s.state()
+	acc, _ := newTree(x, m, 3).fields(s.params())
+	out := make([]*vector.Vector, len(acc))
+	for i, a := range acc {
+		out[i] = a.Vector()
+	}
+	return out
+}
+
+// Advances the bodies by a time step dt with a drift-kick-drift leapfrog,
+// building the tree once per step
+func (s *Simulation) Step(dt float32) {
+	x, v, m := s.state()
+	h := float64(dt)
+	for i := range x {
+		drift := v[i]
+		x[i].Add(drift.Mult(h / 2))
+	}
+	acc, _ := newTree(x, m, 3).fields(s.params())
+	for i, b := range s.Bodies {
+		v[i].Add(acc[i].Mult(h))
+		drift := v[i]
+		x[i].Add(drift.Mult(h / 2))
+		b.Position.Assign(x[i].Vector())
+		b.Velocity.Assign(v[i].Vector())
+	}
+}
+
+// Energy and momentum of the bodies, conserved up to the errors of the tree
+// and of the time steps
+func (s *Simulation) Diagnostics() Diagnostics {
+	x, v, m := s.state()
+	_, pot := newTree(x, m, 3).fields(s.params())
+	dg := diagnose(x, v, m, pot)
+	return Diagnostics{
+		Kinetic:         float32(dg.kinetic),
+		Potential:       float32(dg.potential),
+		Energy:          float32(dg.kinetic + dg.potential),
+		Momentum:        dg.momentum.Vector(),
+		AngularMomentum: dg.angular.Vector(),
+		CenterOfMass:    dg.com.Vector(),
+	}
+}
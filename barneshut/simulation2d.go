@@ -0,0 +1,106 @@
+package barneshut
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector2d"
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Point mass of a 2D simulation
+type Body2D struct {
+	Position, Velocity *vector2d.Vector2D
+	Mass               float32
+}
+
+// Bodies in a plane attracting each other by gravity, approximated with a
+// quadtree, see Simulation
+type Simulation2D struct {
+	Bodies    []*Body2D
+	G         float32
+	Theta     float32
+	Softening float32
+	Workers   int
+}
+
+// Energy, momentum and center of mass of a 2D simulation, see Diagnostics
+type Diagnostics2D struct {
+	Kinetic, Potential, Energy float32
+	Momentum                   *vector2d.Vector2D
+	// Angular momentum about the origin, counter-clockwise positive
+	AngularMomentum float32
+	CenterOfMass    *vector2d.Vector2D
+}
+
+// Creates an empty 2D simulation with gravitational constant g and opening
+// angle 0.5
+func NewSimulation2D(g float32) *Simulation2D {
+	return &Simulation2D{G: g, Theta: defaultTheta}
+}
+
+// Adds a body to the simulation
+func (s *Simulation2D) AddBody(position, velocity *vector2d.Vector2D, mass float32) *Body2D {
+	b := &Body2D{Position: position, Velocity: velocity, Mass: mass}
+	s.Bodies = append(s.Bodies, b)
+	return b
+}
+
+func (s *Simulation2D) params() params {
+	return params{g: float64(s.G), theta: float64(s.Theta), eps: float64(s.Softening), workers: s.Workers}
+}
+
+func (s *Simulation2D) state() (x, v []vector64.Vector, m []float64) {
+	x, v, m = make([]vector64.Vector, len(s.Bodies)), make([]vector64.Vector, len(s.Bodies)), make([]float64, len(s.Bodies))
+	for i, b := range s.Bodies {
+		x[i] = vector64.Vector{X: float64(b.Position.X), Y: float64(b.Position.Y)}
+		v[i] = vector64.Vector{X: float64(b.Velocity.X), Y: float64(b.Velocity.Y)}
+		m[i] = float64(b.Mass)
+	}
+	return x, v, m
+}
+
+func vector2D(a vector64.Vector) *vector2d.Vector2D {
+	return vector2d.New(float32(a.X), float32(a.Y))
+}
+
+// Gravitational accelerations of the bodies
+func (s *Simulation2D) Accelerations() []*vector2d.Vector2D {
+	x, _, m := s.state()
+	acc, _ := newTree(x, m, 2).fields(s.params())
+	out := make([]*vector2d.Vector2D, len(acc))
+	for i, a := range acc {
+		out[i] = vector2D(a)
+	}
+	return out
+}
+
+// Advances the bodies by a time step dt, see Simulation.Step
+func (s *Simulation2D) Step(dt float32) {
+	x, v, m := s.state()
+	h := float64(dt)
+	for i := range x {
+		drift := v[i]
+		x[i].Add(drift.Mult(h / 2))
+	}
+	acc, _ := newTree(x, m, 2).fields(s.params())
+	for i, b := range s.Bodies {
+		v[i].Add(acc[i].Mult(h))
+		drift := v[i]
+		x[i].Add(drift.Mult(h / 2))
+		*b.Position = *vector2D(x[i])
+		*b.Velocity = *vector2D(v[i])
+	}
+}
+
+// Energy and momentum of the bodies, see Simulation.Diagnostics
+func (s *Simulation2D) Diagnostics() Diagnostics2D {
+	x, v, m := s.state()
+	_, pot := newTree(x, m, 2).fields(s.params())
+	dg := diagnose(x, v, m, pot)
+	return Diagnostics2D{
+		Kinetic:         float32(dg.kinetic),
+		Potential:       float32(dg.potential),
+		Energy:          float32(dg.kinetic + dg.potential),
+		Momentum:        vector2D(dg.momentum),
+		AngularMomentum: float32(dg.angular.Z),
+		CenterOfMass:    vector2D(dg.com),
+	}
+}
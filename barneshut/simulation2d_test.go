@@ -0,0 +1,78 @@
+package barneshut
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestAccelerations2D(t *testing.T) {
+	s := NewSimulation2D(1)
+	s.AddBody(vector2d.New(0, 0), vector2d.New(0, 0), 4)
+	s.AddBody(vector2d.New(2, 0), vector2d.New(0, 0), 1)
+	want := []*vector2d.Vector2D{vector2d.New(0.25, 0), vector2d.New(-1, 0)}
+	if diff := cmp.Diff(want, s.Accelerations(), getComparer(1e-6)); diff != "" {
+		t.Errorf("Accelerations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDisk2D(t *testing.T) {
+	// light bodies on circular orbits around a heavy center
+	r := rand.New(rand.NewSource(5))
+	s := NewSimulation2D(1)
+	s.Theta = 0.7
+	s.Softening = 0.05
+	s.AddBody(vector2d.New(0, 0), vector2d.New(0, 0), 100)
+	for i := 0; i < 200; i++ {
+		radius := 1 + 4*r.Float64()
+		angle := 2 * math.Pi * r.Float64()
+		pos := vector2d.FromAngle(float32(angle)).Mult(float32(radius))
+		vel := vector2d.FromAngle(float32(angle + math.Pi/2)).Mult(float32(math.Sqrt(100 / radius)))
+		s.AddBody(pos, vel, 1e-3)
+	}
+	d0 := s.Diagnostics()
+	if d0.AngularMomentum <= 0 {
+		t.Fatalf("counter-clockwise disk has angular momentum %v", d0.AngularMomentum)
+	}
+	for i := 0; i < 300; i++ {
+		s.Step(1e-3)
+	}
+	d := s.Diagnostics()
+	if e := math.Abs(float64(d.Energy/d0.Energy - 1)); e > 1e-3 {
+		t.Errorf("energy changed by %v from %v to %v", e, d0.Energy, d.Energy)
+	}
+	if e := math.Abs(float64(d.AngularMomentum/d0.AngularMomentum - 1)); e > 1e-3 {
+		t.Errorf("angular momentum changed by %v from %v to %v", e, d0.AngularMomentum, d.AngularMomentum)
+	}
+	// the approximated forces are not exactly opposite, the drift is small next
+	// to the momenta of the bodies, adding up to about 1
+	if diff := cmp.Diff(d0.Momentum, d.Momentum, getComparer(2e-2)); diff != "" {
+		t.Errorf("Momentum mismatch (-want +got):\n%s", diff)
+	}
+	// the bodies stay on their orbits
+	for _, b := range s.Bodies[1:] {
+		if m := b.Position.Mag(); m < 0.9 || m > 5.1 {
+			t.Fatalf("body left the disk, at radius %v", m)
+		}
+	}
+}
+
+func TestDiagnostics2D(t *testing.T) {
+	s := NewSimulation2D(2)
+	s.AddBody(vector2d.New(1, 0), vector2d.New(0, 1), 1)
+	s.AddBody(vector2d.New(-1, 0), vector2d.New(0, -1), 1)
+	want := Diagnostics2D{
+		Kinetic:         1,
+		Potential:       -1,
+		Energy:          0,
+		Momentum:        vector2d.New(0, 0),
+		AngularMomentum: 2,
+		CenterOfMass:    vector2d.New(0, 0),
+	}
+	if diff := cmp.Diff(want, s.Diagnostics(), getComparer(1e-6)); diff != "" {
+		t.Errorf("Diagnostics() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,102 @@
+package barneshut
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b float32) bool { return math.Abs(float64(a-b)) <= float64(tolerance) }),
+	}
+}
+
+func TestAccelerations(t *testing.T) {
+	s := NewSimulation(2)
+	s.AddBody(vector.New(0, 0, 0), vector.New(0, 0, 0), 3)
+	s.AddBody(vector.New(0, 2, 0), vector.New(0, 0, 0), 1)
+	s.AddBody(vector.New(0, 0, -4), vector.New(0, 0, 0), 2)
+	want := []*vector.Vector{
+		vector.New(0, 0.5, -0.25),
+		vector.New(0, -1.5-4/float32(math.Pow(20, 1.5))*2, -4/float32(math.Pow(20, 1.5))*4),
+		vector.New(0, 1/float32(math.Pow(20, 1.5))*2*2, 0.375+1/float32(math.Pow(20, 1.5))*2*4),
+	}
+	if diff := cmp.Diff(want, s.Accelerations(), getComparer(1e-6)); diff != "" {
+		t.Errorf("Accelerations() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCircularOrbit(t *testing.T) {
+	// two equal masses on a circular orbit around their center of mass
+	s := NewSimulation(1)
+	s.AddBody(vector.New(1, 0, 0), vector.New(0, 0.5, 0), 1)
+	s.AddBody(vector.New(-1, 0, 0), vector.New(0, -0.5, 0), 1)
+	period := float32(2 * math.Pi / 0.5)
+	const steps = 2000
+	for i := 0; i < steps; i++ {
+		s.Step(period / steps)
+	}
+	if diff := cmp.Diff(vector.New(1, 0, 0), s.Bodies[0].Position, getComparer(1e-3)); diff != "" {
+		t.Errorf("position after one period mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConservation(t *testing.T) {
+	// a cold collapsing cluster
+	r := rand.New(rand.NewSource(4))
+	s := NewSimulation(1)
+	s.Softening = 0.1
+	for i := 0; i < 300; i++ {
+		pos := vector.New(float32(r.NormFloat64()), float32(r.NormFloat64()), float32(r.NormFloat64()))
+		vel := vector.New(float32(r.NormFloat64()), float32(r.NormFloat64()), float32(r.NormFloat64())).Mult(0.1)
+		s.AddBody(pos, vel, 1/300.)
+	}
+	d0 := s.Diagnostics()
+	if math.Abs(float64(d0.Energy-d0.Kinetic-d0.Potential)) > 1e-6 || d0.Potential >= 0 {
+		t.Fatalf("Diagnostics() = %+v", d0)
+	}
+	for i := 0; i < 200; i++ {
+		s.Step(0.01)
+	}
+	d := s.Diagnostics()
+	if e := math.Abs(float64(d.Energy/d0.Energy - 1)); e > 1e-2 {
+		t.Errorf("energy changed by %v from %v to %v", e, d0.Energy, d.Energy)
+	}
+	if d.Kinetic <= d0.Kinetic {
+		t.Errorf("kinetic energy %v did not grow from %v while collapsing", d.Kinetic, d0.Kinetic)
+	}
+	if diff := cmp.Diff(d0.Momentum, d.Momentum, getComparer(1e-3)); diff != "" {
+		t.Errorf("Momentum mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(d0.AngularMomentum, d.AngularMomentum, getComparer(1e-3)); diff != "" {
+		t.Errorf("AngularMomentum mismatch (-want +got):\n%s", diff)
+	}
+	wantCOM := vector.Add(d0.CenterOfMass, d0.Momentum.Copy().Mult(2))
+	if diff := cmp.Diff(wantCOM, d.CenterOfMass, getComparer(1e-3)); diff != "" {
+		t.Errorf("CenterOfMass mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	s := NewSimulation(1)
+	s.AddBody(vector.New(1, 0, 0), vector.New(0, 1, 0), 2)
+	s.AddBody(vector.New(-1, 0, 0), vector.New(0, 0, 3), 1)
+	want := Diagnostics{
+		Kinetic:         1 + 4.5,
+		Potential:       -1,
+		Energy:          4.5,
+		Momentum:        vector.New(0, 2, 3),
+		AngularMomentum: vector.New(0, 3, 2),
+		CenterOfMass:    vector.New(1/3., 0, 0),
+	}
+	if diff := cmp.Diff(want, s.Diagnostics(), getComparer(1e-6)); diff != "" {
+		t.Errorf("Diagnostics() mismatch (-want +got):\n%s", diff)
+	}
+}
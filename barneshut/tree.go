@@ -0,0 +1,260 @@
+// Package barneshut simulates gravitating bodies with the Barnes-Hut
+// algorithm in 2D and 3D. Bodies are grouped in a quadtree or an octree and
+// the cells seen under an angle below the opening angle theta act as a single
+// mass at their center of mass, bringing the cost of the forces from O(n^2)
+// down to O(n log n). The forces are evaluated in parallel.
+// https://en.wikipedia.org/wiki/Barnes%E2%80%93Hut_simulation
+package barneshut
+
+import (
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Depth below which cells are not split any further, keeping coincident
+// bodies in the same leaf
+const maxDepth = 32
+
+// Component d of v, X, Y or Z
+func axis(v *vector64.Vector, d int) *float64 {
+	switch d {
+	case 0:
+		return &v.X
+	case 1:
+		return &v.Y
+	}
+	return &v.Z
+}
+
+// Cell of the tree
+type node struct {
+	center vector64.Vector
+	half   float64
+	mass   float64
+	com    vector64.Vector
+	// bodies of the cell, order[lo:hi]
+	lo, hi int
+	// children indices, 0 for none as the root is nobody's child
+	child [8]int32
+	leaf  bool
+}
+
+// Quadtree (dims = 2) or octree (dims = 3) over positions x with masses m
+type tree struct {
+	dims  int
+	x     []vector64.Vector
+	m     []float64
+	nodes []node
+	order []int
+	buf   []int
+}
+
+func newTree(x []vector64.Vector, m []float64, dims int) *tree {
+	t := &tree{dims: dims, x: x, m: m, order: make([]int, len(x)), buf: make([]int, len(x))}
+	if len(x) == 0 {
+		return t
+	}
+	lo, hi := x[0], x[0]
+	for i := range x {
+		t.order[i] = i
+		for d := 0; d < dims; d++ {
+			*axis(&lo, d) = math.Min(*axis(&lo, d), *axis(&x[i], d))
+			*axis(&hi, d) = math.Max(*axis(&hi, d), *axis(&x[i], d))
+		}
+	}
+	var center vector64.Vector
+	half := 0.0
+	for d := 0; d < dims; d++ {
+		*axis(&center, d) = (*axis(&lo, d) + *axis(&hi, d)) / 2
+		half = math.Max(half, (*axis(&hi, d)-*axis(&lo, d))/2)
+	}
+	// a little room so that no body sits on the boundary
+	half = half*(1+1e-9) + 1e-12
+	t.build(0, len(x), center, half, 0)
+	return t
+}
+
+func (t *tree) build(lo, hi int, center vector64.Vector, half float64, depth int) int32 {
+	idx := int32(len(t.nodes))
+	n := node{center: center, half: half, lo: lo, hi: hi}
+	for _, j := range t.order[lo:hi] {
+		n.mass += t.m[j]
+		x := t.x[j]
+		n.com.Add(x.Mult(t.m[j]))
+	}
+	if n.mass > 0 {
+		n.com.Mult(1 / n.mass)
+	} else {
+		n.com = center
+	}
+	n.leaf = hi-lo <= 1 || depth >= maxDepth
+	t.nodes = append(t.nodes, n)
+	if n.leaf {
+		return idx
+	}
+	// counting sort of the bodies by child cell
+	var count [9]int
+	for _, j := range t.order[lo:hi] {
+		count[t.octant(j, center)+1]++
+	}
+	for k := 1; k < len(count); k++ {
+		count[k] += count[k-1]
+	}
+	start := count
+	for _, j := range t.order[lo:hi] {
+		k := t.octant(j, center)
+		t.buf[lo+count[k]] = j
+		count[k]++
+	}
+	copy(t.order[lo:hi], t.buf[lo:hi])
+	for k := 0; k < 1<<t.dims; k++ {
+		if start[k] == start[k+1] {
+			continue
+		}
+		c := center
+		for d := 0; d < t.dims; d++ {
+			if k&(1<<d) != 0 {
+				*axis(&c, d) += half / 2
+			} else {
+				*axis(&c, d) -= half / 2
+			}
+		}
+		child := t.build(lo+start[k], lo+start[k+1], c, half/2, depth+1)
+		t.nodes[idx].child[k] = child
+	}
+	return idx
+}
+
+// Child cell of the body j in a cell around center
+func (t *tree) octant(j int, center vector64.Vector) int {
+	k := 0
+	for d := 0; d < t.dims; d++ {
+		if *axis(&t.x[j], d) >= *axis(&center, d) {
+			k |= 1 << d
+		}
+	}
+	return k
+}
+
+func (t *tree) contains(n *node, p vector64.Vector) bool {
+	for d := 0; d < t.dims; d++ {
+		if math.Abs(*axis(&p, d)-*axis(&n.center, d)) > n.half {
+			return false
+		}
+	}
+	return true
+}
+
+// Gravity parameters
+type params struct {
+	g, theta, eps float64
+	workers       int
+}
+
+// Acceleration and potential at p due to all the bodies but skip. Cells
+// holding p are always opened so that a body never attracts itself through
+// the center of mass of its own cell.
+func (t *tree) field(p vector64.Vector, skip int, pr params, stack []int32) (a vector64.Vector, phi float64, _ []int32) {
+	if len(t.nodes) == 0 {
+		return a, 0, stack
+	}
+	theta2, eps2 := pr.theta*pr.theta, pr.eps*pr.eps
+	add := func(q vector64.Vector, mass float64) {
+		d := q
+		d.Sub(&p)
+		r2 := d.Dot(&d) + eps2
+		if r2 == 0 {
+			return
+		}
+		inv := 1 / math.Sqrt(r2)
+		a.Add(d.Mult(pr.g * mass * inv * inv * inv))
+		phi -= pr.g * mass * inv
+	}
+	stack = append(stack[:0], 0)
+	for len(stack) > 0 {
+		n := &t.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if n.mass == 0 {
+			continue
+		}
+		if n.leaf {
+			for _, j := range t.order[n.lo:n.hi] {
+				if j != skip {
+					add(t.x[j], t.m[j])
+				}
+			}
+			continue
+		}
+		d := n.com
+		d.Sub(&p)
+		size := 2 * n.half
+		if size*size < theta2*d.Dot(&d) && !t.contains(n, p) {
+			add(n.com, n.mass)
+			continue
+		}
+		for _, c := range n.child[:1<<t.dims] {
+			if c != 0 {
+				stack = append(stack, c)
+			}
+		}
+	}
+	return a, phi, stack
+}
+
+// Accelerations and potentials of all the bodies, split across workers
+func (t *tree) fields(pr params) ([]vector64.Vector, []float64) {
+	n := len(t.x)
+	acc, pot := make([]vector64.Vector, n), make([]float64, n)
+	workers := pr.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunk {
+		hi := lo + chunk
+		if hi > n {
+			hi = n
+		}
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			var stack []int32
+			for i := lo; i < hi; i++ {
+				acc[i], pot[i], stack = t.field(t.x[i], i, pr, stack)
+			}
+		}(lo, hi)
+	}
+	wg.Wait()
+	return acc, pot
+}
+
+// Kinetic and potential energy, linear and angular momentum and center of
+// mass of bodies with positions x, velocities v and masses m
+type diagnostics struct {
+	kinetic, potential float64
+	momentum, angular  vector64.Vector
+	com                vector64.Vector
+}
+
+func diagnose(x, v []vector64.Vector, m []float64, pot []float64) diagnostics {
+	var dg diagnostics
+	mass := 0.0
+	for i := range x {
+		dg.kinetic += m[i] * v[i].Dot(&v[i]) / 2
+		// each pair is counted twice in the potentials
+		dg.potential += m[i] * pot[i] / 2
+		p, r := v[i], x[i]
+		dg.momentum.Add(p.Mult(m[i]))
+		dg.angular.Add(r.Cross(&p))
+		dg.com.Add(r.Mult(m[i]))
+		mass += m[i]
+	}
+	if mass > 0 {
+		dg.com.Mult(1 / mass)
+	}
+	return dg
+}
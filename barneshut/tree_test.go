@@ -0,0 +1,143 @@
+package barneshut
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+func randomBodies(r *rand.Rand, n, dims int) ([]vector64.Vector, []float64) {
+	x, m := make([]vector64.Vector, n), make([]float64, n)
+	for i := range x {
+		for d := 0; d < dims; d++ {
+			*axis(&x[i], d) = r.NormFloat64() * 10
+		}
+		m[i] = 0.5 + r.Float64()
+	}
+	return x, m
+}
+
+// Exact O(n^2) accelerations and potentials
+func direct(x []vector64.Vector, m []float64, pr params) ([]vector64.Vector, []float64) {
+	acc, pot := make([]vector64.Vector, len(x)), make([]float64, len(x))
+	for i := range x {
+		for j := range x {
+			if i == j {
+				continue
+			}
+			d := vector64.Sub(&x[j], &x[i])
+			r := math.Sqrt(d.Dot(d) + pr.eps*pr.eps)
+			acc[i].Add(d.Mult(pr.g * m[j] / (r * r * r)))
+			pot[i] -= pr.g * m[j] / r
+		}
+	}
+	return acc, pot
+}
+
+func relativeError(got, want []vector64.Vector) float64 {
+	num, den := 0.0, 0.0
+	for i := range got {
+		d := vector64.Sub(&got[i], &want[i])
+		num += d.Dot(d)
+		den += want[i].Dot(&want[i])
+	}
+	return math.Sqrt(num / den)
+}
+
+func TestFields(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name    string
+		dims    int
+		theta   float64
+		eps     float64
+		maxErr  float64
+		workers int
+	}{
+		{"octree exact", 3, 0, 0, 1e-12, 0},
+		{"octree", 3, 0.5, 0, 1e-2, 0},
+		{"octree coarse", 3, 1, 0.1, 5e-2, 3},
+		{"quadtree exact", 2, 0, 0.01, 1e-12, 1},
+		{"quadtree", 2, 0.5, 0, 1e-2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, m := randomBodies(r, 500, tt.dims)
+			pr := params{g: 2, theta: tt.theta, eps: tt.eps, workers: tt.workers}
+			acc, pot := newTree(x, m, tt.dims).fields(pr)
+			wantAcc, wantPot := direct(x, m, pr)
+			if e := relativeError(acc, wantAcc); e > tt.maxErr {
+				t.Errorf("acceleration error %v, want below %v", e, tt.maxErr)
+			}
+			potErr := 0.0
+			for i := range pot {
+				potErr = math.Max(potErr, math.Abs(pot[i]/wantPot[i]-1))
+			}
+			if potErr > tt.maxErr {
+				t.Errorf("potential error %v, want below %v", potErr, tt.maxErr)
+			}
+		})
+	}
+}
+
+func TestFieldsWorkers(t *testing.T) {
+	x, m := randomBodies(rand.New(rand.NewSource(2)), 300, 3)
+	tr := newTree(x, m, 3)
+	want, _ := tr.fields(params{g: 1, theta: 0.7, workers: 1})
+	for _, workers := range []int{2, 7, 1000} {
+		got, _ := tr.fields(params{g: 1, theta: 0.7, workers: workers})
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("%d workers mismatch (-want +got):\n%s", workers, diff)
+		}
+	}
+}
+
+func TestTreeStructure(t *testing.T) {
+	x, m := randomBodies(rand.New(rand.NewSource(3)), 200, 3)
+	tr := newTree(x, m, 3)
+	root := tr.nodes[0]
+	total := 0.0
+	for _, mi := range m {
+		total += mi
+	}
+	if math.Abs(root.mass-total) > 1e-9 {
+		t.Errorf("root mass %v, want %v", root.mass, total)
+	}
+	// every body is in exactly one leaf, inside its cell
+	seen := make([]int, len(x))
+	for _, n := range tr.nodes {
+		if !n.leaf {
+			continue
+		}
+		for _, j := range tr.order[n.lo:n.hi] {
+			seen[j]++
+			if !tr.contains(&n, x[j]) {
+				t.Errorf("body %d outside its leaf", j)
+			}
+		}
+	}
+	for j, c := range seen {
+		if c != 1 {
+			t.Errorf("body %d in %d leaves", j, c)
+		}
+	}
+}
+
+func TestCoincidentBodies(t *testing.T) {
+	x := []vector64.Vector{{X: 1, Y: 1, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: 4, Y: 1, Z: 1}}
+	m := []float64{1, 1, 1, 1}
+	acc, pot := newTree(x, m, 3).fields(params{g: 1, theta: 0.5})
+	want := []vector64.Vector{{X: 1. / 9}, {X: 1. / 9}, {X: 1. / 9}, {X: -1. / 3}}
+	if diff := cmp.Diff(want, acc); diff != "" {
+		t.Errorf("accelerations mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]float64{-1. / 3, -1. / 3, -1. / 3, -1}, pot); diff != "" {
+		t.Errorf("potentials mismatch (-want +got):\n%s", diff)
+	}
+	if acc, _ := newTree(nil, nil, 3).fields(params{g: 1}); len(acc) != 0 {
+		t.Errorf("empty tree gave %v", acc)
+	}
+}
@@ -0,0 +1,367 @@
+// Package bvh provides a bounding volume hierarchy over triangles for fast ray casting.
+package bvh
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Maximum number of triangles kept in a leaf
+const maxLeafSize = 4
+
+// Number of buckets used when evaluating the surface area heuristic
+const sahBins = 12
+
+// Triangle made of three vertices.
+// Vertices are kept as pointers, so animating them and calling Refit
+// updates the hierarchy without rebuilding it.
+type Triangle struct {
+	A, B, C *vector.Vector
+}
+
+// Axis aligned bounding box
+type AABB struct {
+	Min, Max vector.Vector
+}
+
+// Result of a ray query
+type Hit struct {
+	// Index of the triangle in the slice given to New
+	Index int
+	// Distance along the ray (in units of the ray direction)
+	T float32
+	// Barycentric coordinates of the hit point
+	U, V float32
+}
+
+type node struct {
+	bounds AABB
+	// for inner nodes, index of the left child (right child is left+1)
+	// for leaves, index of the first triangle in order
+	first int32
+	// number of triangles in a leaf, 0 for inner nodes
+	count int32
+}
+
+// Bounding volume hierarchy built with the surface area heuristic.
+type BVH struct {
+	tris  []Triangle
+	nodes []node
+	// order maps leaf slots to triangle indices
+	order []int32
+}
+
+func emptyAABB() AABB {
+	inf := float32(math.Inf(1))
+	return AABB{vector.Vector{X: inf, Y: inf, Z: inf}, vector.Vector{X: -inf, Y: -inf, Z: -inf}}
+}
+
+// minf and maxf only pick b when it compares, so a NaN in b is ignored
+func minf(a, b float32) float32 {
+	if b < a {
+		return b
+	}
+	return a
+}
+
+func maxf(a, b float32) float32 {
+	if b > a {
+		return b
+	}
+	return a
+}
+
+// Grows the box to contain the point
+func (b *AABB) Extend(p *vector.Vector) {
+	b.Min = vector.Vector{X: minf(b.Min.X, p.X), Y: minf(b.Min.Y, p.Y), Z: minf(b.Min.Z, p.Z)}
+	b.Max = vector.Vector{X: maxf(b.Max.X, p.X), Y: maxf(b.Max.Y, p.Y), Z: maxf(b.Max.Z, p.Z)}
+}
+
+// Grows the box to contain another box
+func (b *AABB) Union(o AABB) {
+	b.Extend(&o.Min)
+	b.Extend(&o.Max)
+}
+
+// Surface area of the box, 0 for an empty box
+func (b *AABB) SurfaceArea() float32 {
+	d := vector.Sub(&b.Max, &b.Min)
+	if d.X < 0 || d.Y < 0 || d.Z < 0 {
+		return 0
+	}
+	return 2 * (d.X*d.Y + d.Y*d.Z + d.Z*d.X)
+}
+
+// Checks the ray against the box using the slab method.
+// invDir holds the reciprocal of each direction component.
+// Returns the entry distance and whether the box is hit before maxT.
+func (b *AABB) intersect(origin, invDir *vector.Vector, maxT float32) (float32, bool) {
+	tmin, tmax := float32(math.Inf(-1)), float32(math.Inf(1))
+	tx1 := (b.Min.X - origin.X) * invDir.X
+	tx2 := (b.Max.X - origin.X) * invDir.X
+	tmin, tmax = maxf(tmin, minf(tx1, tx2)), minf(tmax, maxf(tx1, tx2))
+	ty1 := (b.Min.Y - origin.Y) * invDir.Y
+	ty2 := (b.Max.Y - origin.Y) * invDir.Y
+	tmin, tmax = maxf(tmin, minf(ty1, ty2)), minf(tmax, maxf(ty1, ty2))
+	tz1 := (b.Min.Z - origin.Z) * invDir.Z
+	tz2 := (b.Max.Z - origin.Z) * invDir.Z
+	tmin, tmax = maxf(tmin, minf(tz1, tz2)), minf(tmax, maxf(tz1, tz2))
+	return tmin, tmax >= maxf(tmin, 0) && tmin <= maxT
+}
+
+func (t *Triangle) bounds() AABB {
+	b := emptyAABB()
+	b.Extend(t.A)
+	b.Extend(t.B)
+	b.Extend(t.C)
+	return b
+}
+
+func (t *Triangle) centroid() *vector.Vector {
+	c := vector.Add(t.A, t.B)
+	c.Add(t.C)
+	return c.Mult(1.0 / 3)
+}
+
+func axis(v *vector.Vector, a int) float32 {
+	switch a {
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	}
+	return v.Z
+}
+
+// Builds a new hierarchy over the triangles.
+// The slice is kept by the hierarchy and should not be resized afterwards.
+func New(tris []Triangle) *BVH {
+	b := &BVH{tris: tris, order: make([]int32, len(tris))}
+	for i := range b.order {
+		b.order[i] = int32(i)
+	}
+	if len(tris) == 0 {
+		return b
+	}
+	bounds := make([]AABB, len(tris))
+	centroids := make([]*vector.Vector, len(tris))
+	for i := range tris {
+		bounds[i] = tris[i].bounds()
+		centroids[i] = tris[i].centroid()
+	}
+	b.nodes = make([]node, 1, 2*len(tris))
+	b.build(0, 0, len(tris), bounds, centroids)
+	return b
+}
+
+// Recursively splits order[start:end] into node n
+func (b *BVH) build(n, start, end int, bounds []AABB, centroids []*vector.Vector) {
+	box := emptyAABB()
+	cbox := emptyAABB()
+	for _, i := range b.order[start:end] {
+		box.Union(bounds[i])
+		cbox.Extend(centroids[i])
+	}
+	b.nodes[n] = node{bounds: box, first: int32(start), count: int32(end - start)}
+	count := end - start
+	if count <= 1 {
+		return
+	}
+
+	// find the cheapest split over all axes using binned SAH
+	bestAxis, bestBin := -1, 0
+	bestCost := float32(math.Inf(1))
+	for a := 0; a < 3; a++ {
+		lo, hi := axis(&cbox.Min, a), axis(&cbox.Max, a)
+		if hi <= lo {
+			continue
+		}
+		var binBounds [sahBins]AABB
+		var binCount [sahBins]int
+		for i := range binBounds {
+			binBounds[i] = emptyAABB()
+		}
+		scale := sahBins / (hi - lo)
+		for _, i := range b.order[start:end] {
+			k := binIndex(axis(centroids[i], a), lo, scale)
+			binCount[k]++
+			binBounds[k].Union(bounds[i])
+		}
+		// sweep from the right to collect suffix areas
+		var rightArea [sahBins]float32
+		var rightCount [sahBins]int
+		acc, cnt := emptyAABB(), 0
+		for k := sahBins - 1; k > 0; k-- {
+			acc.Union(binBounds[k])
+			cnt += binCount[k]
+			rightArea[k] = acc.SurfaceArea()
+			rightCount[k] = cnt
+		}
+		acc, cnt = emptyAABB(), 0
+		for k := 0; k < sahBins-1; k++ {
+			acc.Union(binBounds[k])
+			cnt += binCount[k]
+			if cnt == 0 || rightCount[k+1] == 0 {
+				continue
+			}
+			cost := acc.SurfaceArea()*float32(cnt) + rightArea[k+1]*float32(rightCount[k+1])
+			if cost < bestCost {
+				bestCost, bestAxis, bestBin = cost, a, k
+			}
+		}
+	}
+
+	leafCost := box.SurfaceArea() * float32(count)
+	if bestAxis < 0 || (count <= maxLeafSize && bestCost >= leafCost) {
+		return
+	}
+
+	// partition around the chosen bin boundary
+	lo, hi := axis(&cbox.Min, bestAxis), axis(&cbox.Max, bestAxis)
+	scale := sahBins / (hi - lo)
+	i, j := start, end-1
+	for i <= j {
+		if binIndex(axis(centroids[b.order[i]], bestAxis), lo, scale) <= bestBin {
+			i++
+		} else {
+			b.order[i], b.order[j] = b.order[j], b.order[i]
+			j--
+		}
+	}
+	mid := i
+	if mid == start || mid == end {
+		return
+	}
+
+	left := len(b.nodes)
+	b.nodes = append(b.nodes, node{}, node{})
+	b.nodes[n].first = int32(left)
+	b.nodes[n].count = 0
+	b.build(left, start, mid, bounds, centroids)
+	b.build(left+1, mid, end, bounds, centroids)
+}
+
+func binIndex(c, lo, scale float32) int {
+	k := int((c - lo) * scale)
+	if k >= sahBins {
+		k = sahBins - 1
+	}
+	if k < 0 {
+		k = 0
+	}
+	return k
+}
+
+// Number of triangles in the hierarchy
+func (b *BVH) Len() int {
+	return len(b.tris)
+}
+
+// Gives the bounding box of the whole hierarchy
+func (b *BVH) Bounds() AABB {
+	if len(b.nodes) == 0 {
+		return emptyAABB()
+	}
+	return b.nodes[0].bounds
+}
+
+// Recomputes all bounding boxes from the current vertex positions.
+// The tree topology is kept, so query speed degrades if the vertices move far;
+// build a new hierarchy in that case.
+func (b *BVH) Refit() {
+	for n := len(b.nodes) - 1; n >= 0; n-- {
+		nd := &b.nodes[n]
+		if nd.count > 0 {
+			box := emptyAABB()
+			for _, i := range b.order[nd.first : nd.first+nd.count] {
+				box.Union(b.tris[i].bounds())
+			}
+			nd.bounds = box
+			continue
+		}
+		// children are always stored after their parent
+		box := b.nodes[nd.first].bounds
+		box.Union(b.nodes[nd.first+1].bounds)
+		nd.bounds = box
+	}
+}
+
+func inverse(dir *vector.Vector) *vector.Vector {
+	return &vector.Vector{X: 1 / dir.X, Y: 1 / dir.Y, Z: 1 / dir.Z}
+}
+
+// Finds the closest triangle hit by the ray.
+// ok is false if no triangle is hit.
+func (b *BVH) ClosestHit(origin, dir *vector.Vector) (hit Hit, ok bool) {
+	if len(b.nodes) == 0 {
+		return Hit{}, false
+	}
+	invDir := inverse(dir)
+	hit.T = float32(math.Inf(1))
+	stack := make([]int32, 0, 64)
+	stack = append(stack, 0)
+	for len(stack) > 0 {
+		nd := &b.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if _, in := nd.bounds.intersect(origin, invDir, hit.T); !in {
+			continue
+		}
+		if nd.count > 0 {
+			for _, i := range b.order[nd.first : nd.first+nd.count] {
+				tri := &b.tris[i]
+				t, u, v, h := vector.RayTriangle(origin, dir, tri.A, tri.B, tri.C)
+				if h && t < hit.T {
+					hit = Hit{Index: int(i), T: t, U: u, V: v}
+					ok = true
+				}
+			}
+			continue
+		}
+		// visit the nearer child first
+		l, r := nd.first, nd.first+1
+		tl, inl := b.nodes[l].bounds.intersect(origin, invDir, hit.T)
+		tr, inr := b.nodes[r].bounds.intersect(origin, invDir, hit.T)
+		if inl && inr {
+			if tl < tr {
+				stack = append(stack, r, l)
+			} else {
+				stack = append(stack, l, r)
+			}
+		} else if inl {
+			stack = append(stack, l)
+		} else if inr {
+			stack = append(stack, r)
+		}
+	}
+	return hit, ok
+}
+
+// Checks whether the ray hits any triangle closer than maxT.
+// Useful for shadow and visibility rays, as it stops at the first hit found.
+func (b *BVH) AnyHit(origin, dir *vector.Vector, maxT float32) bool {
+	if len(b.nodes) == 0 {
+		return false
+	}
+	invDir := inverse(dir)
+	stack := make([]int32, 0, 64)
+	stack = append(stack, 0)
+	for len(stack) > 0 {
+		nd := &b.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if _, in := nd.bounds.intersect(origin, invDir, maxT); !in {
+			continue
+		}
+		if nd.count > 0 {
+			for _, i := range b.order[nd.first : nd.first+nd.count] {
+				tri := &b.tris[i]
+				if t, _, _, h := vector.RayTriangle(origin, dir, tri.A, tri.B, tri.C); h && t <= maxT {
+					return true
+				}
+			}
+			continue
+		}
+		stack = append(stack, nd.first, nd.first+1)
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package bvh
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func randomVector(r *rand.Rand, scale float32) *vector.Vector {
+	return vector.New((r.Float32()-0.5)*scale, (r.Float32()-0.5)*scale, (r.Float32()-0.5)*scale)
+}
+
+func randomTriangles(r *rand.Rand, n int) []Triangle {
+	tris := make([]Triangle, n)
+	for i := range tris {
+		c := randomVector(r, 20)
+		tris[i] = Triangle{
+			vector.Add(c, randomVector(r, 2)),
+			vector.Add(c, randomVector(r, 2)),
+			vector.Add(c, randomVector(r, 2)),
+		}
+	}
+	return tris
+}
+
+func bruteForce(tris []Triangle, origin, dir *vector.Vector) (Hit, bool) {
+	best := Hit{T: float32(math.Inf(1))}
+	ok := false
+	for i, tri := range tris {
+		if t, u, v, h := vector.RayTriangle(origin, dir, tri.A, tri.B, tri.C); h && t < best.T {
+			best = Hit{i, t, u, v}
+			ok = true
+		}
+	}
+	return best, ok
+}
+
+func checkAgainstBruteForce(t *testing.T, b *BVH, tris []Triangle, r *rand.Rand) {
+	t.Helper()
+	for i := 0; i < 500; i++ {
+		origin := randomVector(r, 40)
+		dir := vector.Sub(randomVector(r, 10), origin)
+		want, wantOk := bruteForce(tris, origin, dir)
+		got, ok := b.ClosestHit(origin, dir)
+		if ok != wantOk || (ok && got.Index != want.Index) {
+			t.Fatalf("ClosestHit(%v, %v) = %v, %v, want %v, %v", origin, dir, got, ok, want, wantOk)
+		}
+		if hit := b.AnyHit(origin, dir, float32(math.Inf(1))); hit != wantOk {
+			t.Fatalf("AnyHit(%v, %v, Inf) = %v, want %v", origin, dir, hit, wantOk)
+		}
+		if wantOk {
+			if hit := b.AnyHit(origin, dir, want.T*0.999); hit {
+				t.Fatalf("AnyHit(%v, %v, %v) = true before the closest hit", origin, dir, want.T*0.999)
+			}
+		}
+	}
+}
+
+func TestClosestHit(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 3, 10, 200, 2000} {
+		tris := randomTriangles(r, n)
+		checkAgainstBruteForce(t, New(tris), tris, r)
+	}
+}
+
+func TestAxisAlignedRay(t *testing.T) {
+	tris := []Triangle{
+		{vector.New(-1, -1, 5), vector.New(1, -1, 5), vector.New(0, 1, 5)},
+		{vector.New(-1, -1, 2), vector.New(1, -1, 2), vector.New(0, 1, 2)},
+		{vector.New(5, 5, 0), vector.New(6, 5, 0), vector.New(5, 6, 0)},
+	}
+	b := New(tris)
+	hit, ok := b.ClosestHit(vector.New(0, 0, 0), vector.New(0, 0, 1))
+	if !ok || hit.Index != 1 || hit.T != 2 {
+		t.Errorf("ClosestHit() = %v, %v, want triangle 1 at t=2", hit, ok)
+	}
+	if b.AnyHit(vector.New(0, 0, 0), vector.New(0, 0, 1), 1.5) {
+		t.Errorf("AnyHit(maxT=1.5) = true, want false")
+	}
+}
+
+func TestRefit(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	tris := randomTriangles(r, 300)
+	b := New(tris)
+	// animate every vertex
+	seen := map[*vector.Vector]bool{}
+	for _, tri := range tris {
+		for _, v := range []*vector.Vector{tri.A, tri.B, tri.C} {
+			if !seen[v] {
+				seen[v] = true
+				v.Add(vector.New(3, -2, 1)).Mult(1.2)
+			}
+		}
+	}
+	b.Refit()
+	checkAgainstBruteForce(t, b, tris, r)
+	box := b.Bounds()
+	for v := range seen {
+		if v.X < box.Min.X || v.Y < box.Min.Y || v.Z < box.Min.Z || v.X > box.Max.X || v.Y > box.Max.Y || v.Z > box.Max.Z {
+			t.Fatalf("vertex %v outside refitted bounds %v", v, box)
+		}
+	}
+}
+
+func TestSurfaceArea(t *testing.T) {
+	tests := []struct {
+		box  AABB
+		want float32
+	}{
+		{emptyAABB(), 0},
+		{AABB{vector.Vector{}, vector.Vector{X: 1, Y: 1, Z: 1}}, 6},
+		{AABB{vector.Vector{X: -1}, vector.Vector{X: 1, Y: 2, Z: 3}}, 2 * (4 + 6 + 6)},
+	}
+	for _, test := range tests {
+		if got := test.box.SurfaceArea(); got != test.want {
+			t.Errorf("%v.SurfaceArea() = %v, want %v", test.box, got, test.want)
+		}
+	}
+}
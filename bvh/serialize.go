@@ -0,0 +1,148 @@
+package bvh
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+var magic = [4]byte{'B', 'V', 'H', '1'}
+
+// Returned by Read when the data is not a serialized hierarchy
+var ErrFormat = errors.New("bvh: invalid serialized data")
+
+type header struct {
+	Magic [4]byte
+	Tris  uint32
+	Nodes uint32
+}
+
+type diskNode struct {
+	Min, Max     vector.Vector
+	First, Count int32
+}
+
+// Writes the built hierarchy, including the triangle vertices, to w.
+// All values are stored little endian.
+func (b *BVH) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: bufio.NewWriter(w)}
+	h := header{magic, uint32(len(b.tris)), uint32(len(b.nodes))}
+	if err := binary.Write(cw, binary.LittleEndian, h); err != nil {
+		return cw.n, err
+	}
+	for _, t := range b.tris {
+		verts := [3]vector.Vector{*t.A, *t.B, *t.C}
+		if err := binary.Write(cw, binary.LittleEndian, verts); err != nil {
+			return cw.n, err
+		}
+	}
+	for _, n := range b.nodes {
+		dn := diskNode{n.bounds.Min, n.bounds.Max, n.first, n.count}
+		if err := binary.Write(cw, binary.LittleEndian, dn); err != nil {
+			return cw.n, err
+		}
+	}
+	if err := binary.Write(cw, binary.LittleEndian, b.order); err != nil {
+		return cw.n, err
+	}
+	return cw.n, cw.w.(*bufio.Writer).Flush()
+}
+
+// Reads a hierarchy written by WriteTo.
+// Each triangle of the result owns its vertices.
+func Read(r io.Reader) (*BVH, error) {
+	var h header
+	if err := binary.Read(r, binary.LittleEndian, &h); err != nil {
+		return nil, err
+	}
+	if h.Magic != magic {
+		return nil, ErrFormat
+	}
+	// a tree has fewer nodes than twice its triangles, each leaf holding some
+	if uint64(h.Nodes) > 2*uint64(h.Tris) {
+		return nil, fmt.Errorf("%w: %d nodes for %d triangles", ErrFormat, h.Nodes, h.Tris)
+	}
+	// slices grow as the data arrives, so that a corrupt header fails at the
+	// end of the stream instead of allocating for counts it does not hold
+	b := &BVH{}
+	for i := uint32(0); i < h.Tris; i++ {
+		var verts [3]vector.Vector
+		if err := binary.Read(r, binary.LittleEndian, &verts); err != nil {
+			return nil, err
+		}
+		b.tris = append(b.tris, Triangle{&verts[0], &verts[1], &verts[2]})
+	}
+	for i := uint32(0); i < h.Nodes; i++ {
+		var dn diskNode
+		if err := binary.Read(r, binary.LittleEndian, &dn); err != nil {
+			return nil, err
+		}
+		b.nodes = append(b.nodes, node{AABB{dn.Min, dn.Max}, dn.First, dn.Count})
+	}
+	order, err := readIndices(r, len(b.tris))
+	if err != nil {
+		return nil, err
+	}
+	b.order = order
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Reads n indices a chunk at a time
+func readIndices(r io.Reader, n int) ([]int32, error) {
+	const chunk = 1024
+	s := []int32{}
+	for len(s) < n {
+		k := n - len(s)
+		if k > chunk {
+			k = chunk
+		}
+		buf := make([]int32, k)
+		if err := binary.Read(r, binary.LittleEndian, buf); err != nil {
+			return nil, err
+		}
+		s = append(s, buf...)
+	}
+	return s, nil
+}
+
+// Checks that all node and triangle references are in range
+func (b *BVH) validate() error {
+	nt, nn := int32(len(b.tris)), int32(len(b.nodes))
+	for _, i := range b.order {
+		if i < 0 || i >= nt {
+			return fmt.Errorf("%w: triangle index %d out of range", ErrFormat, i)
+		}
+	}
+	// the checks subtract instead of adding, which could overflow
+	for i, n := range b.nodes {
+		if n.count < 0 {
+			return fmt.Errorf("%w: node %d has a negative count", ErrFormat, i)
+		}
+		if n.count > 0 {
+			if n.first < 0 || n.count > nt-n.first {
+				return fmt.Errorf("%w: leaf %d out of range", ErrFormat, i)
+			}
+		} else if n.first <= int32(i) || n.first >= nn-1 {
+			return fmt.Errorf("%w: node %d has invalid children", ErrFormat, i)
+		}
+	}
+	return nil
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,85 @@
+package bvh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestWriteRead(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for _, n := range []int{0, 1, 500} {
+		tris := randomTriangles(r, n)
+		b := New(tris)
+		var buf bytes.Buffer
+		written, err := b.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("WriteTo() error = %v", err)
+		}
+		if written != int64(buf.Len()) {
+			t.Errorf("WriteTo() = %v, wrote %v bytes", written, buf.Len())
+		}
+		got, err := Read(&buf)
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		if !cmp.Equal(got.nodes, b.nodes, cmp.AllowUnexported(node{})) || !cmp.Equal(got.order, b.order) {
+			t.Errorf("Read() tree differs from the written tree")
+		}
+		for i := range tris {
+			want := []vector.Vector{*tris[i].A, *tris[i].B, *tris[i].C}
+			have := []vector.Vector{*got.tris[i].A, *got.tris[i].B, *got.tris[i].C}
+			if !cmp.Equal(have, want) {
+				t.Fatalf("triangle %d = %v, want %v", i, have, want)
+			}
+		}
+		checkAgainstBruteForce(t, got, tris, r)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	if _, err := Read(bytes.NewReader([]byte("nope, not a bvh"))); !errors.Is(err, ErrFormat) {
+		t.Errorf("Read(garbage) error = %v, want %v", err, ErrFormat)
+	}
+	var buf bytes.Buffer
+	if _, err := New(randomTriangles(rand.New(rand.NewSource(4)), 20)).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if _, err := Read(bytes.NewReader(data[:len(data)-3])); err == nil {
+		t.Errorf("Read(truncated) error = nil, want an error")
+	}
+	// headers announcing huge counts fail without allocating for them
+	var huge bytes.Buffer
+	binary.Write(&huge, binary.LittleEndian, header{magic, 1 << 31, 1 << 31})
+	if _, err := Read(&huge); err == nil {
+		t.Errorf("Read(huge counts) error = nil, want an error")
+	}
+	huge.Reset()
+	binary.Write(&huge, binary.LittleEndian, header{magic, 1, 3})
+	if _, err := Read(&huge); !errors.Is(err, ErrFormat) {
+		t.Errorf("Read(too many nodes) error = %v, want %v", err, ErrFormat)
+	}
+	// nodes pointing out of range, including by overflowing int32
+	for _, n := range []diskNode{
+		{First: 1, Count: math.MaxInt32},
+		{First: 0, Count: 2},
+		{First: 0, Count: -1},
+		{First: math.MaxInt32, Count: 0},
+	} {
+		var b bytes.Buffer
+		binary.Write(&b, binary.LittleEndian, header{magic, 1, 1})
+		binary.Write(&b, binary.LittleEndian, [3]vector.Vector{{}, {X: 1}, {Y: 1}})
+		binary.Write(&b, binary.LittleEndian, n)
+		binary.Write(&b, binary.LittleEndian, int32(0))
+		if _, err := Read(&b); !errors.Is(err, ErrFormat) {
+			t.Errorf("Read(node first %d count %d) error = %v, want %v", n.First, n.Count, err, ErrFormat)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Controller turning a camera around a target like a trackball under the
+// pointer, with Shoemake's arcball. Unlike Orbit it has no up direction: the
+// camera can roll and go over the poles. Dragging across the whole ball turns
+// by a full turn, and a drag always gives the same rotation whatever the path.
+// See Shoemake, ARCBALL: A User Interface for Specifying Three-Dimensional
+// Orientation Using a Mouse, 1992.
+type Arcball struct {
+	Target      *vector.Vector
+	Distance    float64
+	Orientation quat.Quat
+	// Size of the screen in pixels, the ball fills its smaller side
+	Width, Height float64
+
+	start    quat.Quat
+	from     [3]float64
+	pointer  vector2d.Vector2D
+	dragging bool
+}
+
+// Makes an arcball controller looking at target from distance along +Z
+func NewArcball(target *vector.Vector, distance, width, height float64) *Arcball {
+	return &Arcball{
+		Target:      target.Copy(),
+		Distance:    distance,
+		Orientation: quat.Identity(),
+		Width:       width,
+		Height:      height,
+	}
+}
+
+// Point of the ball under a pixel, in camera space. Pixels off the ball map
+// to its rim.
+func (a *Arcball) ball(p *vector2d.Vector2D) [3]float64 {
+	r := math.Min(a.Width, a.Height) / 2
+	x := (float64(p.X) - a.Width/2) / r
+	y := (a.Height/2 - float64(p.Y)) / r
+	d := x*x + y*y
+	if d > 1 {
+		n := math.Sqrt(d)
+		return [3]float64{x / n, y / n, 0}
+	}
+	return [3]float64{x, y, math.Sqrt(1 - d)}
+}
+
+// Starts a drag at a pixel
+func (a *Arcball) Begin(p *vector2d.Vector2D) {
+	a.start = a.Orientation
+	a.from = a.ball(p)
+	a.pointer = *p
+	a.dragging = true
+}
+
+// Moves the pointer of the drag by delta pixels, turning the camera. Starts a
+// drag at the center of the screen if none was begun.
+func (a *Arcball) Drag(delta *vector2d.Vector2D) {
+	if !a.dragging {
+		a.Begin(vector2d.New(float32(a.Width/2), float32(a.Height/2)))
+	}
+	a.pointer.Add(delta)
+	f, t := a.from, a.ball(&a.pointer)
+	// the quaternion (f·t, f×t) turns the ball by twice the angle from f to t
+	q := quat.New(f[0]*t[0]+f[1]*t[1]+f[2]*t[2],
+		f[1]*t[2]-f[2]*t[1], f[2]*t[0]-f[0]*t[2], f[0]*t[1]-f[1]*t[0])
+	// turning the scene by q in camera space turns the camera the other way
+	a.Orientation = a.start.Mul(q.Conjugate()).Normalize()
+}
+
+// Ends the drag
+func (a *Arcball) End() {
+	a.dragging = false
+}
+
+// Position of the camera
+func (a *Arcball) Position() *vector.Vector {
+	back := a.Orientation.Rotate(vector.New(0, 0, float32(a.Distance)))
+	return back.Add(a.Target)
+}
+
+// Places the camera
+func (a *Arcball) Apply(c *Camera) {
+	c.Position, c.Orientation = a.Position(), a.Orientation
+}
@@ -0,0 +1,72 @@
+package camera
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestArcball(t *testing.T) {
+	opt := getComparer(1e-4)
+	target := vector.New(0, 1, 0)
+	a := NewArcball(target, 5, 400, 400)
+	c := New(400, 400)
+	a.Apply(c)
+	if got, want := c.Position, vector.New(0, 1, 5); !cmp.Equal(got, want, opt) {
+		t.Errorf("Position() = %v, want %v", got, want)
+	}
+	// from the center to the right rim turns the scene by twice 90°, so the
+	// camera ends on the far side
+	a.Begin(vector2d.New(200, 200))
+	a.Drag(vector2d.New(200, 0))
+	a.Apply(c)
+	if got, want := c.Position, vector.New(0, 1, -5); !cmp.Equal(got, want, opt) {
+		t.Errorf("Position() after a full drag = %v, want %v", got, want)
+	}
+	a.End()
+	// a small drag to the right turns the scene to the right
+	a.Orientation = quat.Identity()
+	a.Begin(vector2d.New(200, 200))
+	a.Drag(vector2d.New(10, 0))
+	a.Apply(c)
+	if p := c.Position; p.X >= 0 || math.Abs(float64(p.Y)-1) > 1e-5 {
+		t.Errorf("Position() after a drag right = %v", p)
+	}
+	p := vector.New(0, 1, 1)
+	if s, _, _ := c.Project(p); s.X <= 200 {
+		t.Errorf("front of the target at %v, not right of the center", s)
+	}
+}
+
+func TestArcballPathIndependent(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 50; i++ {
+		a := NewArcball(vector.New(0, 0, 0), 3, 640, 480)
+		start := vector2d.New(r.Float32()*640, r.Float32()*480)
+		end := vector2d.New(r.Float32()*640, r.Float32()*480)
+		a.Begin(start)
+		a.Drag(vector2d.Sub(end, start))
+		direct := a.Orientation
+		a.End()
+		b := NewArcball(vector.New(0, 0, 0), 3, 640, 480)
+		b.Begin(start)
+		p := start.Copy()
+		for j := 0; j < 10; j++ {
+			step := vector2d.New(r.Float32()*100-50, r.Float32()*100-50)
+			p.Add(step)
+			b.Drag(step)
+		}
+		b.Drag(vector2d.Sub(end, p))
+		if !b.Orientation.SameRotation(direct, 1e-4) {
+			t.Fatalf("winding drag gave %v, direct drag %v", b.Orientation, direct)
+		}
+		if n := b.Orientation.Norm(); math.Abs(n-1) > 1e-12 {
+			t.Fatalf("Orientation norm %v", n)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package camera provides 3D cameras mapping vector.Vector world points to
+// vector2d.Vector2D screen pixels and back, with picking rays and view
+// frustum culling, and orbit, arcball and first person controllers driven by
+// 2D pointer moves. Cameras follow the OpenGL conventions: in its own space a
+// camera looks down -Z with +Y up, and projections map the view volume to
+// x, y, z in [-1, 1]. Screen pixels start at the top left corner with y
+// pointing down.
+package camera
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Kind of projection of a camera
+type Mode int
+
+const (
+	// Farther objects look smaller, with a vertical field of view FOV
+	Perspective Mode = iota
+	// Parallel projection of a view volume OrthoHeight high
+	Orthographic
+)
+
+// Camera looking from Position, turned by Orientation. Only the points
+// between the Near and Far planes are visible.
+type Camera struct {
+	Position    *vector.Vector
+	Orientation quat.Quat
+	Mode        Mode
+	// Vertical field of view (radians) of perspective cameras
+	FOV float64
+	// Height of the view volume of orthographic cameras
+	OrthoHeight float64
+	// Width over height of the view, 0 for Width / Height
+	Aspect float64
+	// Distances to the near and far planes
+	Near, Far float64
+	// Size of the screen in pixels
+	Width, Height float64
+}
+
+// Makes a perspective camera at the origin looking down -Z for a screen of
+// the given size, with a 60° field of view and planes at 0.1 and 1000
+func New(width, height float64) *Camera {
+	return &Camera{
+		Position:    vector.New(0, 0, 0),
+		Orientation: quat.Identity(),
+		FOV:         math.Pi / 3,
+		OrthoHeight: 2,
+		Near:        0.1,
+		Far:         1000,
+		Width:       width,
+		Height:      height,
+	}
+}
+
+func (c *Camera) aspect() float64 {
+	if c.Aspect != 0 {
+		return c.Aspect
+	}
+	if c.Width == 0 || c.Height == 0 {
+		return 1
+	}
+	return c.Width / c.Height
+}
+
+// Turns the camera to look at target, keeping up as close to the top of the
+// screen as possible. Does nothing if target is at the position.
+// Modify + Returns self
+func (c *Camera) LookAt(target, up *vector.Vector) *Camera {
+	f := vector.Sub(target, c.Position)
+	if f.MagSq() == 0 {
+		return c
+	}
+	f.Normalize()
+	r := vector.Cross(f, up)
+	if r.MagSq() < 1e-12 {
+		// up is along the view, use any side
+		r = vector.Cross(f, vector.New(1, 0, 0))
+		if r.MagSq() < 1e-12 {
+			r = vector.Cross(f, vector.New(0, 1, 0))
+		}
+	}
+	r.Normalize()
+	u := vector.Cross(r, f)
+	c.Orientation = quat.FromMat3(mat.FromColumns3(r, u, f.Mult(-1)))
+	return c
+}
+
+// Unit direction the camera looks along
+func (c *Camera) Forward() *vector.Vector {
+	return c.Orientation.Rotate(vector.New(0, 0, -1))
+}
+
+// Unit direction to the right of the screen
+func (c *Camera) Right() *vector.Vector {
+	return c.Orientation.Rotate(vector.New(1, 0, 0))
+}
+
+// Unit direction to the top of the screen
+func (c *Camera) Up() *vector.Vector {
+	return c.Orientation.Rotate(vector.New(0, 1, 0))
+}
+
+// Transform from the world to the space of the camera
+func (c *Camera) View() mat.Mat4 {
+	// R^T and -R^T p, with the translation kept in float64
+	rt := c.Orientation.Normalize().Conjugate().Mat3()
+	v := mat.Affine4(rt, vector.New(0, 0, 0))
+	x, y, z := float64(c.Position.X), float64(c.Position.Y), float64(c.Position.Z)
+	for i := 0; i < 3; i++ {
+		v[i][3] = -(rt[i][0]*x + rt[i][1]*y + rt[i][2]*z)
+	}
+	return v
+}
+
+// Transform from the space of the camera to clip space
+func (c *Camera) Projection() mat.Mat4 {
+	n, f := c.Near, c.Far
+	if c.Mode == Orthographic {
+		h := c.OrthoHeight
+		w := h * c.aspect()
+		return mat.Mat4{
+			{2 / w, 0, 0, 0},
+			{0, 2 / h, 0, 0},
+			{0, 0, -2 / (f - n), -(f + n) / (f - n)},
+			{0, 0, 0, 1},
+		}
+	}
+	t := 1 / math.Tan(c.FOV/2)
+	return mat.Mat4{
+		{t / c.aspect(), 0, 0, 0},
+		{0, t, 0, 0},
+		{0, 0, (f + n) / (n - f), 2 * f * n / (n - f)},
+		{0, 0, -1, 0},
+	}
+}
+
+// Transform from the world to clip space, Projection() * View()
+func (c *Camera) ViewProjection() mat.Mat4 {
+	return c.Projection().Mul(c.View())
+}
+
+// Screen pixel of a world point, with its depth from 0 on the near plane to
+// 1 on the far plane. ok is false for points behind the camera, which have no
+// meaningful pixel.
+func (c *Camera) Project(p *vector.Vector) (screen *vector2d.Vector2D, depth float64, ok bool) {
+	v := c.View().MulVec4([4]float64{float64(p.X), float64(p.Y), float64(p.Z), 1})
+	clip := c.Projection().MulVec4(v)
+	x, y, z := clip[0]/clip[3], clip[1]/clip[3], clip[2]/clip[3]
+	sx, sy := c.toScreen(x, y)
+	return vector2d.New(float32(sx), float32(sy)), (z + 1) / 2, v[2] < 0
+}
+
+// Pixel of normalized device coordinates
+func (c *Camera) toScreen(x, y float64) (float64, float64) {
+	return (x + 1) / 2 * c.Width, (1 - y) / 2 * c.Height
+}
+
+// Normalized device coordinates of a pixel
+func (c *Camera) fromScreen(s *vector2d.Vector2D) (float64, float64) {
+	return 2*float64(s.X)/c.Width - 1, 1 - 2*float64(s.Y)/c.Height
+}
+
+// World point at a screen pixel and a depth from 0 on the near plane to 1 on
+// the far plane, the inverse of Project
+func (c *Camera) Unproject(screen *vector2d.Vector2D, depth float64) *vector.Vector {
+	x, y, z := c.unproject(screen, depth)
+	return vector.New(float32(x), float32(y), float32(z))
+}
+
+func (c *Camera) unproject(screen *vector2d.Vector2D, depth float64) (float64, float64, float64) {
+	inv, _ := c.ViewProjection().Inverse()
+	x, y := c.fromScreen(screen)
+	p := inv.MulVec4([4]float64{x, y, 2*depth - 1, 1})
+	return p[0] / p[3], p[1] / p[3], p[2] / p[3]
+}
+
+// Picking ray through a screen pixel, starting on the near plane with a unit
+// direction. Perspective rays spread from the position, orthographic rays
+// are parallel to Forward.
+func (c *Camera) Ray(screen *vector2d.Vector2D) (origin, direction *vector.Vector) {
+	x0, y0, z0 := c.unproject(screen, 0)
+	x1, y1, z1 := c.unproject(screen, 1)
+	dx, dy, dz := x1-x0, y1-y0, z1-z0
+	d := math.Sqrt(dx*dx + dy*dy + dz*dz)
+	return vector.New(float32(x0), float32(y0), float32(z0)),
+		vector.New(float32(dx/d), float32(dy/d), float32(dz/d))
+}
+
+// View frustum of the camera in world space
+func (c *Camera) Frustum() Frustum {
+	return FrustumFromMatrix(c.ViewProjection())
+}
@@ -0,0 +1,133 @@
+package camera
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, tolerance) }),
+	}
+}
+
+// Random point in the view of the camera
+func randomVisible(r *rand.Rand, c *Camera) *vector.Vector {
+	s := vector2d.New(r.Float32()*float32(c.Width), r.Float32()*float32(c.Height))
+	return c.Unproject(s, r.Float64()*0.99)
+}
+
+func TestProject(t *testing.T) {
+	opt := getComparer(1e-3)
+	c := New(800, 600)
+	c.FOV = math.Pi / 2
+	c.Aspect = 1
+	tests := []struct {
+		p      *vector.Vector
+		screen *vector2d.Vector2D
+		ok     bool
+	}{
+		{vector.New(0, 0, -10), vector2d.New(400, 300), true},
+		{vector.New(10, 0, -10), vector2d.New(800, 300), true},
+		{vector.New(-5, 5, -5), vector2d.New(0, 0), true},
+		{vector.New(0, -1, -2), vector2d.New(400, 450), true},
+		{vector.New(0, 0, 10), vector2d.New(400, 300), false},
+	}
+	for _, tt := range tests {
+		s, _, ok := c.Project(tt.p)
+		if !cmp.Equal(s, tt.screen, opt) || ok != tt.ok {
+			t.Errorf("Project(%v) = %v, %v, want %v, %v", tt.p, s, ok, tt.screen, tt.ok)
+		}
+	}
+	for _, tt := range []struct{ z, depth float64 }{{-0.1, 0}, {-1000, 1}} {
+		if _, depth, _ := c.Project(vector.New(0, 0, float32(tt.z))); math.Abs(depth-tt.depth) > 1e-6 {
+			t.Errorf("Project() depth at z = %v is %v, want %v", tt.z, depth, tt.depth)
+		}
+	}
+}
+
+func TestLookAt(t *testing.T) {
+	opt := getComparer(1e-5)
+	c := New(640, 480)
+	c.Position = vector.New(5, 5, 5)
+	c.LookAt(vector.New(5, 5, -5), vector.New(0, 1, 0))
+	if !c.Orientation.SameRotation(quat.Identity(), 1e-9) {
+		t.Errorf("LookAt() along -Z = %v", c.Orientation)
+	}
+	c.LookAt(vector.New(0, 0, 0), vector.New(0, 1, 0))
+	if got, want := c.Forward(), vector.New(-1, -1, -1).Normalize(); !cmp.Equal(got, want, opt) {
+		t.Errorf("Forward() = %v, want %v", got, want)
+	}
+	if got := c.Right(); math.Abs(float64(got.Y)) > 1e-6 {
+		t.Errorf("Right() = %v is not level", got)
+	}
+	if s, _, ok := c.Project(vector.New(0, 0, 0)); !ok || !cmp.Equal(s, vector2d.New(320, 240), getComparer(1e-3)) {
+		t.Errorf("Project(target) = %v, %v", s, ok)
+	}
+	// looking straight down with up along the view
+	c.LookAt(vector.New(5, -5, 5), vector.New(0, 1, 0))
+	if got := c.Forward(); !cmp.Equal(got, vector.New(0, -1, 0), opt) {
+		t.Errorf("Forward() looking down = %v", got)
+	}
+}
+
+func TestUnproject(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, mode := range []Mode{Perspective, Orthographic} {
+		c := New(1024, 768)
+		c.Mode = mode
+		c.OrthoHeight = 20
+		c.Far = 100
+		c.Position = vector.New(3, -2, 7)
+		c.Orientation = quat.FromAxisAngle(vector.New(1, 2, 0.5), 0.9)
+		for i := 0; i < 100; i++ {
+			s := vector2d.New(r.Float32()*1024, r.Float32()*768)
+			depth := r.Float64()
+			p := c.Unproject(s, depth)
+			gs, gd, ok := c.Project(p)
+			if !ok || !cmp.Equal(gs, s, getComparer(0.05)) || math.Abs(gd-depth) > 1e-4 {
+				t.Fatalf("mode %v: Project(Unproject(%v, %v)) = %v, %v, %v", mode, s, depth, gs, gd, ok)
+			}
+			// the picking ray goes through the unprojected point
+			origin, dir := c.Ray(s)
+			if got := dir.Mag(); math.Abs(float64(got)-1) > 1e-6 {
+				t.Fatalf("Ray() direction length %v", got)
+			}
+			off := vector.Sub(p, origin)
+			along := off.Dot(dir)
+			if miss := vector.Sub(off, dir.Copy().Mult(along)).Mag(); along < 0 || float64(miss) > 1e-3*math.Max(1, float64(along)) {
+				t.Fatalf("mode %v: Ray(%v) misses %v by %v", mode, s, p, miss)
+			}
+			if _, depth, _ := c.Project(origin); math.Abs(depth) > 1e-4 {
+				t.Fatalf("Ray() origin depth %v, want 0", depth)
+			}
+			if mode == Orthographic && !cmp.Equal(dir, c.Forward(), getComparer(1e-5)) {
+				t.Fatalf("orthographic Ray() direction %v, want %v", dir, c.Forward())
+			}
+		}
+	}
+}
+
+func TestOrthographic(t *testing.T) {
+	opt := getComparer(1e-3)
+	c := New(400, 200)
+	c.Mode = Orthographic
+	c.OrthoHeight = 10
+	// the same screen point at any distance
+	for _, z := range []float32{-1, -10, -100} {
+		s, _, ok := c.Project(vector.New(10, 2.5, z))
+		if want := vector2d.New(400, 50); !ok || !cmp.Equal(s, want, opt) {
+			t.Errorf("Project(z = %v) = %v, %v, want %v", z, s, ok, want)
+		}
+	}
+	if _, _, ok := c.Project(vector.New(0, 0, 1)); ok {
+		t.Errorf("Project() behind the camera ok = true")
+	}
+}
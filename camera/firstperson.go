@@ -0,0 +1,113 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// First person controller. Pointer moves turn the view and a move input
+// walks, or flies along the view when Fly is set. Turning is smoothed and
+// moving has inertia, both with exponential decays frame rate independent.
+type FirstPerson struct {
+	Position *vector.Vector
+	// Direction of the view, Pitch kept in [-MaxPitch, MaxPitch]. Yaw and
+	// Pitch follow the pointer with smoothing, set them with Face.
+	Yaw, Pitch, MaxPitch float64
+	// Radians turned per pixel moved
+	Sensitivity float64
+	// Speed of the move input, in units per second
+	Speed float64
+	// Move along the view, up and down included, instead of on the ground
+	Fly bool
+	// Time (seconds) for the view to turn about 63% of the way to the
+	// pointer, 0 to turn at once
+	Smoothing float64
+	// Time (seconds) for the velocity to change about 63% of the way to the
+	// move input, 0 to start and stop at once
+	Inertia float64
+
+	targetYaw, targetPitch float64
+	velocity               [3]float64
+}
+
+// Makes a first person controller at position facing -Z, with pitches up to
+// 89°, a sensitivity of 0.002 radians per pixel and a speed of 5
+func NewFirstPerson(position *vector.Vector) *FirstPerson {
+	return &FirstPerson{
+		Position:    position.Copy(),
+		MaxPitch:    89 * math.Pi / 180,
+		Sensitivity: 0.002,
+		Speed:       5,
+	}
+}
+
+// Turns the view by a pointer move in pixels, right turns right and down
+// looks down
+func (f *FirstPerson) Look(delta *vector2d.Vector2D) {
+	f.targetYaw -= float64(delta.X) * f.Sensitivity
+	f.targetPitch = clamp(f.targetPitch-float64(delta.Y)*f.Sensitivity, -f.MaxPitch, f.MaxPitch)
+}
+
+// Turns the view at once to yaw and pitch (radians)
+func (f *FirstPerson) Face(yaw, pitch float64) {
+	pitch = clamp(pitch, -f.MaxPitch, f.MaxPitch)
+	f.Yaw, f.Pitch, f.targetYaw, f.targetPitch = yaw, pitch, yaw, pitch
+}
+
+// Fraction of the way to cover in dt with the time constant tau
+func decay(dt, tau float64) float64 {
+	if tau <= 0 {
+		return 1
+	}
+	return 1 - math.Exp(-dt/tau)
+}
+
+// Advances by dt seconds with the move input, X to the right and Y forward,
+// each in [-1, 1]. A nil move stops the input, the camera glides to a halt
+// with inertia.
+func (f *FirstPerson) Update(move *vector2d.Vector2D, dt float64) {
+	k := decay(dt, f.Smoothing)
+	f.Yaw += (f.targetYaw - f.Yaw) * k
+	f.Pitch += (f.targetPitch - f.Pitch) * k
+
+	var want [3]float64
+	if move != nil {
+		var forward, right *vector.Vector
+		if f.Fly {
+			q := f.Orientation()
+			forward, right = q.Rotate(vector.New(0, 0, -1)), q.Rotate(vector.New(1, 0, 0))
+		} else {
+			q := yawPitch(f.Yaw, 0)
+			forward, right = q.Rotate(vector.New(0, 0, -1)), q.Rotate(vector.New(1, 0, 0))
+		}
+		mx, my := float64(move.X), float64(move.Y)
+		want = [3]float64{
+			(float64(right.X)*mx + float64(forward.X)*my) * f.Speed,
+			(float64(right.Y)*mx + float64(forward.Y)*my) * f.Speed,
+			(float64(right.Z)*mx + float64(forward.Z)*my) * f.Speed,
+		}
+	}
+	k = decay(dt, f.Inertia)
+	for i := range want {
+		f.velocity[i] += (want[i] - f.velocity[i]) * k
+	}
+	f.Position.Add(vector.New(float32(f.velocity[0]*dt), float32(f.velocity[1]*dt), float32(f.velocity[2]*dt)))
+}
+
+// Velocity of the camera in units per second
+func (f *FirstPerson) Velocity() *vector.Vector {
+	return vector.New(float32(f.velocity[0]), float32(f.velocity[1]), float32(f.velocity[2]))
+}
+
+// Orientation of the camera
+func (f *FirstPerson) Orientation() quat.Quat {
+	return yawPitch(f.Yaw, f.Pitch)
+}
+
+// Places the camera
+func (f *FirstPerson) Apply(c *Camera) {
+	c.Position, c.Orientation = f.Position.Copy(), f.Orientation()
+}
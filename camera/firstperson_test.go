@@ -0,0 +1,85 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestFirstPersonLook(t *testing.T) {
+	opt := getComparer(1e-5)
+	f := NewFirstPerson(vector.New(0, 1.8, 0))
+	c := New(800, 600)
+	// turning right by a quarter turn, at once without smoothing
+	f.Look(vector2d.New(float32(math.Pi/2/f.Sensitivity), 0))
+	f.Update(nil, 0.01)
+	f.Apply(c)
+	if got, want := c.Forward(), vector.New(1, 0, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("Forward() = %v, want %v", got, want)
+	}
+	// looking down is clamped
+	f.Look(vector2d.New(0, 1e6))
+	f.Update(nil, 0.01)
+	if f.Pitch != -f.MaxPitch {
+		t.Errorf("Pitch = %v, want %v", f.Pitch, -f.MaxPitch)
+	}
+	f.Face(0, 0)
+	f.Apply(c)
+	if got, want := c.Forward(), vector.New(0, 0, -1); !cmp.Equal(got, want, opt) {
+		t.Errorf("Forward() after Face() = %v, want %v", got, want)
+	}
+	// with smoothing the view eases in, independently of the frame rate
+	f.Smoothing = 0.1
+	f.Look(vector2d.New(-100, 0))
+	g := *f
+	for i := 0; i < 10; i++ {
+		f.Update(nil, 0.01)
+	}
+	g.Update(nil, 0.1)
+	want := 100 * f.Sensitivity * (1 - math.Exp(-1))
+	if math.Abs(f.Yaw-want) > 1e-9 || math.Abs(g.Yaw-want) > 1e-9 {
+		t.Errorf("smoothed Yaw = %v, %v, want %v", f.Yaw, g.Yaw, want)
+	}
+}
+
+func TestFirstPersonMove(t *testing.T) {
+	opt := getComparer(1e-4)
+	f := NewFirstPerson(vector.New(0, 0, 0))
+	// walking forward while looking down stays on the ground
+	f.Face(math.Pi/2, -0.5)
+	f.Update(vector2d.New(0, 1), 1)
+	if got, want := f.Position, vector.New(-5, 0, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("Position after walking = %v, want %v", got, want)
+	}
+	f.Update(vector2d.New(1, 0), 0.5)
+	if got, want := f.Position, vector.New(-5, 0, -2.5); !cmp.Equal(got, want, opt) {
+		t.Errorf("Position after strafing = %v, want %v", got, want)
+	}
+	// flying follows the pitch
+	f.Fly = true
+	f.Position = vector.New(0, 0, 0)
+	f.Face(0, math.Pi/6)
+	f.Update(vector2d.New(0, 1), 2)
+	if got, want := f.Position, vector.New(0, 5, float32(-10*math.Cos(math.Pi/6))); !cmp.Equal(got, want, opt) {
+		t.Errorf("Position after flying = %v, want %v", got, want)
+	}
+	// with inertia the camera speeds up and glides to a halt
+	f.Inertia = 0.2
+	f.Update(nil, 0.2)
+	if got := float64(f.Velocity().Mag()); math.Abs(got-5*math.Exp(-1)) > 1e-4 {
+		t.Errorf("speed after releasing = %v, want %v", got, 5*math.Exp(-1))
+	}
+	for i := 0; i < 100; i++ {
+		f.Update(nil, 0.05)
+	}
+	if got := f.Velocity().Mag(); got > 1e-3 {
+		t.Errorf("speed after gliding = %v", got)
+	}
+	f.Update(vector2d.New(0, 1), 0.2)
+	if got := float64(f.Velocity().Mag()); math.Abs(got-5*(1-math.Exp(-1))) > 1e-3 {
+		t.Errorf("speed after pushing = %v, want %v", got, 5*(1-math.Exp(-1)))
+	}
+}
@@ -0,0 +1,97 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Plane of the points p with Normal·p + D = 0. Normal is a unit vector
+// pointing to the inside of the frustum.
+type Plane struct {
+	Normal vector64.Vector
+	D      float64
+}
+
+// Signed distance of a point to the plane, positive on the inside
+func (p Plane) Distance(v *vector.Vector) float64 {
+	return p.Normal.X*float64(v.X) + p.Normal.Y*float64(v.Y) + p.Normal.Z*float64(v.Z) + p.D
+}
+
+// Indices of the planes of a frustum
+const (
+	LeftPlane = iota
+	RightPlane
+	BottomPlane
+	TopPlane
+	NearPlane
+	FarPlane
+)
+
+// View frustum, the six planes bounding what a camera sees
+type Frustum struct {
+	Planes [6]Plane
+}
+
+// Frustum of the points that a view-projection matrix maps into the clip
+// volume, with the Gribb-Hartmann method
+func FrustumFromMatrix(m mat.Mat4) Frustum {
+	var f Frustum
+	for i := 0; i < 3; i++ {
+		for k, sign := range [2]float64{1, -1} {
+			// w + x >= 0 is the left plane, w - x >= 0 the right one, ...
+			var p [4]float64
+			for j := 0; j < 4; j++ {
+				p[j] = m[3][j] + sign*m[i][j]
+			}
+			n := math.Sqrt(p[0]*p[0] + p[1]*p[1] + p[2]*p[2])
+			f.Planes[2*i+k] = Plane{Normal: vector64.Vector{X: p[0] / n, Y: p[1] / n, Z: p[2] / n}, D: p[3] / n}
+		}
+	}
+	return f
+}
+
+// Tells if a point is inside the frustum
+func (f Frustum) ContainsPoint(p *vector.Vector) bool {
+	for _, pl := range f.Planes {
+		if pl.Distance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Tells if a sphere may be visible. Spheres entirely outside one plane are
+// culled; a few spheres near the corners pass without being visible.
+func (f Frustum) IntersectsSphere(center *vector.Vector, radius float64) bool {
+	for _, pl := range f.Planes {
+		if pl.Distance(center) < -radius {
+			return false
+		}
+	}
+	return true
+}
+
+// Tells if an axis aligned box may be visible. Boxes entirely outside one
+// plane are culled; a few boxes near the edges pass without being visible.
+func (f Frustum) IntersectsAABB(min, max *vector.Vector) bool {
+	for _, pl := range f.Planes {
+		// the corner farthest along the normal
+		p := vector.New(min.X, min.Y, min.Z)
+		if pl.Normal.X >= 0 {
+			p.X = max.X
+		}
+		if pl.Normal.Y >= 0 {
+			p.Y = max.Y
+		}
+		if pl.Normal.Z >= 0 {
+			p.Z = max.Z
+		}
+		if pl.Distance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
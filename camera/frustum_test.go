@@ -0,0 +1,102 @@
+package camera
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestFrustumPlanes(t *testing.T) {
+	c := New(100, 100)
+	c.FOV = math.Pi / 2
+	c.Near, c.Far = 1, 10
+	f := c.Frustum()
+	tests := []struct {
+		plane int
+		p     *vector.Vector
+		dist  float64
+	}{
+		{NearPlane, vector.New(0, 0, -3), 2},
+		{FarPlane, vector.New(0, 0, -3), 7},
+		{LeftPlane, vector.New(0, 0, -2), math.Sqrt2},
+		{RightPlane, vector.New(1, 0, -2), math.Sqrt2 / 2},
+		{TopPlane, vector.New(0, 3, -2), -math.Sqrt2 / 2},
+		{BottomPlane, vector.New(0, 3, -2), 5 * math.Sqrt2 / 2},
+	}
+	for _, tt := range tests {
+		if got := f.Planes[tt.plane].Distance(tt.p); math.Abs(got-tt.dist) > 1e-6 {
+			t.Errorf("plane %d Distance(%v) = %v, want %v", tt.plane, tt.p, got, tt.dist)
+		}
+	}
+}
+
+func TestFrustumCulling(t *testing.T) {
+	c := New(100, 100)
+	c.FOV = math.Pi / 2
+	c.Near, c.Far = 1, 10
+	c.Position = vector.New(0, 0, 0)
+	f := c.Frustum()
+	spheres := []struct {
+		center  *vector.Vector
+		radius  float64
+		visible bool
+	}{
+		{vector.New(0, 0, -5), 1, true},
+		{vector.New(0, 0, 5), 1, false},
+		{vector.New(0, 0, -0.5), 1, true},
+		{vector.New(0, 0, 0.5), 1, false},
+		{vector.New(0, 0, -12), 1, false},
+		{vector.New(0, 0, -10.5), 1, true},
+		{vector.New(8, 0, -5), 2, false},
+		{vector.New(6, 0, -5), 1, true},
+	}
+	for _, s := range spheres {
+		if got := f.IntersectsSphere(s.center, s.radius); got != s.visible {
+			t.Errorf("IntersectsSphere(%v, %v) = %v, want %v", s.center, s.radius, got, s.visible)
+		}
+	}
+	boxes := []struct {
+		min, max *vector.Vector
+		visible  bool
+	}{
+		{vector.New(-1, -1, -6), vector.New(1, 1, -4), true},
+		{vector.New(-1, -1, 1), vector.New(1, 1, 3), false},
+		{vector.New(-20, -20, -9), vector.New(20, 20, -8), true},
+		{vector.New(6, -1, -5), vector.New(7, 1, -4), false},
+		{vector.New(3, -1, -5), vector.New(7, 1, -4), true},
+		{vector.New(-1, -1, -20), vector.New(1, 1, -11), false},
+	}
+	for _, b := range boxes {
+		if got := f.IntersectsAABB(b.min, b.max); got != b.visible {
+			t.Errorf("IntersectsAABB(%v, %v) = %v, want %v", b.min, b.max, got, b.visible)
+		}
+	}
+}
+
+func TestFrustumContainsProjected(t *testing.T) {
+	// points the camera sees are in its frustum, points out of the screen are not
+	r := rand.New(rand.NewSource(2))
+	for _, mode := range []Mode{Perspective, Orthographic} {
+		c := New(320, 200)
+		c.Mode = mode
+		c.Far = 50
+		c.OrthoHeight = 8
+		c.Position = vector.New(-4, 1, 2)
+		c.Orientation = quat.FromAxisAngle(vector.New(0.3, 1, -0.2), 2)
+		f := c.Frustum()
+		for i := 0; i < 200; i++ {
+			p := randomVisible(r, c)
+			if !f.ContainsPoint(p) || !f.IntersectsSphere(p, 0) || !f.IntersectsAABB(p, p) {
+				t.Fatalf("mode %v: visible %v culled", mode, p)
+			}
+			s, _, _ := c.Project(p)
+			s.X += 330
+			if q := c.Unproject(s, 0.99); f.ContainsPoint(q) || f.IntersectsSphere(q, 0.01) {
+				t.Fatalf("mode %v: %v off screen not culled", mode, q)
+			}
+		}
+	}
+}
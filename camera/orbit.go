@@ -0,0 +1,87 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Controllers take +Y as the world up. A yaw of 0 faces -Z and positive yaws
+// turn left, counter-clockwise around +Y. Positive pitches look up.
+
+// Orientation facing yaw and pitch (radians)
+func yawPitch(yaw, pitch float64) quat.Quat {
+	return quat.FromEuler(mat.YXZ, yaw, pitch, 0)
+}
+
+// Controller turning a camera around a target on a sphere. Dragging right
+// turns the camera to the left around the target so the scene follows the
+// pointer, dragging down raises it.
+type Orbit struct {
+	Target *vector.Vector
+	// Distance from the target, kept in [MinDistance, MaxDistance]
+	Distance, MinDistance, MaxDistance float64
+	// Direction the camera faces, Pitch is negative above the target and
+	// kept in [MinPitch, MaxPitch] so the camera never flips over the poles
+	Yaw, Pitch, MinPitch, MaxPitch float64
+	// Radians turned per pixel dragged
+	Sensitivity float64
+}
+
+// Makes an orbit controller looking at target from distance along +Z, with
+// pitches up to 89° and a sensitivity of 0.005 radians per pixel
+func NewOrbit(target *vector.Vector, distance float64) *Orbit {
+	limit := 89 * math.Pi / 180
+	return &Orbit{
+		Target:      target.Copy(),
+		Distance:    distance,
+		MaxDistance: math.Inf(1),
+		MinPitch:    -limit,
+		MaxPitch:    limit,
+		Sensitivity: 0.005,
+	}
+}
+
+// Turns the camera around the target by a pointer drag in pixels
+func (o *Orbit) Rotate(delta *vector2d.Vector2D) {
+	o.Yaw -= float64(delta.X) * o.Sensitivity
+	o.Pitch = clamp(o.Pitch-float64(delta.Y)*o.Sensitivity, o.MinPitch, o.MaxPitch)
+}
+
+// Moves the target in the view plane by a pointer drag in pixels, so that
+// the scene follows the pointer at the distance of the target
+func (o *Orbit) Pan(delta *vector2d.Vector2D) {
+	q := o.Orientation()
+	s := o.Sensitivity * o.Distance
+	right := q.Rotate(vector.New(1, 0, 0)).Mult(float32(-float64(delta.X) * s))
+	up := q.Rotate(vector.New(0, 1, 0)).Mult(float32(float64(delta.Y) * s))
+	o.Target.Add(right).Add(up)
+}
+
+// Multiplies the distance by factor, below 1 to get closer
+func (o *Orbit) Zoom(factor float64) {
+	o.Distance = clamp(o.Distance*factor, o.MinDistance, o.MaxDistance)
+}
+
+// Orientation of the camera
+func (o *Orbit) Orientation() quat.Quat {
+	return yawPitch(o.Yaw, o.Pitch)
+}
+
+// Position of the camera
+func (o *Orbit) Position() *vector.Vector {
+	back := o.Orientation().Rotate(vector.New(0, 0, float32(o.Distance)))
+	return back.Add(o.Target)
+}
+
+// Places the camera
+func (o *Orbit) Apply(c *Camera) {
+	c.Position, c.Orientation = o.Position(), o.Orientation()
+}
+
+func clamp(x, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
@@ -0,0 +1,73 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestOrbit(t *testing.T) {
+	opt := getComparer(1e-4)
+	target := vector.New(1, 2, 3)
+	o := NewOrbit(target, 10)
+	c := New(800, 600)
+	o.Apply(c)
+	if got, want := c.Position, vector.New(1, 2, 13); !cmp.Equal(got, want, opt) {
+		t.Errorf("Position() = %v, want %v", got, want)
+	}
+	if s, _, ok := c.Project(target); !ok || !cmp.Equal(s, vector2d.New(400, 300), opt) {
+		t.Errorf("target projects to %v, %v", s, ok)
+	}
+	// a quarter turn to the right moves the camera to the left of the target
+	o.Rotate(vector2d.New(float32(math.Pi/2/o.Sensitivity), 0))
+	o.Apply(c)
+	if got, want := c.Position, vector.New(-9, 2, 3); !cmp.Equal(got, want, opt) {
+		t.Errorf("Position() after turning = %v, want %v", got, want)
+	}
+	if s, _, ok := c.Project(target); !ok || !cmp.Equal(s, vector2d.New(400, 300), getComparer(1e-3)) {
+		t.Errorf("target after turning projects to %v, %v", s, ok)
+	}
+	// dragging down raises the camera, up to the pitch limit
+	o.Rotate(vector2d.New(0, 1e5))
+	if o.Pitch != o.MinPitch {
+		t.Errorf("Pitch = %v, want %v", o.Pitch, o.MinPitch)
+	}
+	o.Apply(c)
+	if c.Position.Y <= 2 || !cmp.Equal(vector.Dist(c.Position, target), float32(10), getComparer(1e-4)) {
+		t.Errorf("Position() above = %v", c.Position)
+	}
+	if up := c.Up(); up.Y <= 0 {
+		t.Errorf("Up() = %v flipped over the pole", up)
+	}
+	o.Zoom(0.5)
+	if got := vector.Dist(o.Position(), target); math.Abs(float64(got)-5) > 1e-4 {
+		t.Errorf("distance after Zoom() = %v, want 5", got)
+	}
+	o.MinDistance = 2
+	o.Zoom(0.1)
+	if o.Distance != 2 {
+		t.Errorf("Distance = %v, want 2", o.Distance)
+	}
+}
+
+func TestOrbitPan(t *testing.T) {
+	c := New(800, 600)
+	o := NewOrbit(vector.New(0, 0, 0), 10)
+	o.Rotate(vector2d.New(123, -45))
+	o.Apply(c)
+	p := vector.New(0.5, -0.5, 0.2)
+	before, _, _ := c.Project(p)
+	// the scene follows the pointer
+	o.Pan(vector2d.New(20, -10))
+	o.Apply(c)
+	after, _, _ := c.Project(p)
+	if moved := vector2d.Sub(after, before); moved.X <= 0 || moved.Y >= 0 {
+		t.Errorf("Pan() moved the scene by %v on screen", moved)
+	}
+	if got := vector.Dist(c.Position, o.Target); math.Abs(float64(got)-10) > 1e-4 {
+		t.Errorf("distance after Pan() = %v, want 10", got)
+	}
+}
@@ -0,0 +1,102 @@
+package ccd
+
+import (
+	"github.com/vaibhav11s/gopkgs/gjk"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Maximum number of conservative advancement steps
+const maxAdvances = 64
+
+// Default distance under which moving shapes are in contact
+const defaultTolerance = 1e-3
+
+// Convex shape moving over one step with constant linear and angular velocity
+type Motion struct {
+	// Shape in local coordinates
+	Shape gjk.Support
+	// Position of the local origin at the start of the step
+	Position *vector.Vector
+	// Displacement of the local origin over the step (nil for none)
+	Displacement *vector.Vector
+	// Orientation at the start of the step, the zero value is the identity
+	Orientation quat.Quat
+	// Rotation over the step as a rotation vector (nil for none)
+	Rotation *vector.Vector
+	// Distance of the farthest point of the shape from its local origin,
+	// only needed with a Rotation
+	Radius float32
+}
+
+// Shape at time t of the step
+func (m Motion) at(t float32) gjk.Transformed {
+	s := gjk.Transformed{Shape: m.Shape, Position: m.Position.Copy(), Orientation: m.Orientation}
+	if s.Orientation == (quat.Quat{}) {
+		s.Orientation = quat.Identity()
+	}
+	if m.Displacement != nil {
+		s.Position = vector.Lerp(m.Position, vector.Add(m.Position, m.Displacement), t)
+	}
+	if m.Rotation != nil {
+		s.Orientation = quat.FromRotationVector(m.Rotation.Copy().Mult(t)).Mul(s.Orientation)
+	}
+	return s
+}
+
+// Largest speed of a point of the shape from its rotation
+func (m Motion) angularBound() float32 {
+	if m.Rotation == nil {
+		return 0
+	}
+	return m.Rotation.Mag() * m.Radius
+}
+
+func (m Motion) displacement() *vector.Vector {
+	if m.Displacement == nil {
+		return vector.New(0, 0, 0)
+	}
+	return m.Displacement
+}
+
+// Time of impact in [0, 1] of two moving convex shapes by conservative advancement:
+// the shapes are moved forward by their distance divided by a bound of their
+// approach speed, which never goes past the first contact.
+// normal is the unit contact normal pointing from a to b.
+// Shapes overlapping at the start give a time of impact of 0 and the
+// penetration normal. Optional tolerance is the distance at which the
+// shapes are considered touching (default 1e-3). If the advancement doesn't
+// converge within 64 steps the last time known to be free of contact is given.
+// (Mirtich, Impulse-based Dynamic Simulation of Rigid Body Systems, 1996)
+func TimeOfImpact(a, b Motion, tolerance ...float32) (toi float32, normal *vector.Vector, ok bool) {
+	tol := float32(defaultTolerance)
+	if len(tolerance) >= 1 {
+		tol = tolerance[0]
+	}
+	rel := vector.Sub(a.displacement(), b.displacement())
+	angular := a.angularBound() + b.angularBound()
+	for iter := 0; iter < maxAdvances; iter++ {
+		sa, sb := a.at(toi), b.at(toi)
+		d, pa, pb := gjk.Distance(sa, sb)
+		if d == 0 {
+			if iter == 0 {
+				_, normal, _, _, _ = gjk.Penetration(sa, sb)
+			}
+			return toi, normal, true
+		}
+		normal = vector.Sub(pb, pa).Mult(1 / d)
+		if d <= tol {
+			return toi, normal, true
+		}
+		bound := vector.Dot(rel, normal) + angular
+		if bound <= 0 {
+			// moving apart
+			return 0, nil, false
+		}
+		toi += d / bound
+		if toi > 1 {
+			return 0, nil, false
+		}
+	}
+	return toi, normal, true
+}
@@ -0,0 +1,89 @@
+package ccd
+
+import (
+	"github.com/vaibhav11s/gopkgs/gjk"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Convex 2D shape moving over one step with constant linear and angular velocity
+type Motion2D struct {
+	// Shape in local coordinates
+	Shape gjk.Support2D
+	// Position of the local origin at the start of the step
+	Position *vector2d.Vector2D
+	// Displacement of the local origin over the step (nil for none)
+	Displacement *vector2d.Vector2D
+	// Angle at the start of the step and rotation over the step (radians, counter clockwise)
+	Angle, Rotation float32
+	// Distance of the farthest point of the shape from its local origin,
+	// only needed with a Rotation
+	Radius float32
+}
+
+// Shape placed at a position and angle
+type placed2D struct {
+	shape    gjk.Support2D
+	position *vector2d.Vector2D
+	angle    float32
+}
+
+func (p placed2D) Support(dir *vector2d.Vector2D) *vector2d.Vector2D {
+	return p.shape.Support(dir.Copy().Rotate(-p.angle)).Rotate(p.angle).Add(p.position)
+}
+
+// Shape at time t of the step
+func (m Motion2D) at(t float32) placed2D {
+	p := placed2D{shape: m.Shape, position: m.Position.Copy(), angle: m.Angle + m.Rotation*t}
+	if m.Displacement != nil {
+		p.position.Add(m.Displacement.Copy().Mult(t))
+	}
+	return p
+}
+
+func (m Motion2D) displacement() *vector2d.Vector2D {
+	if m.Displacement == nil {
+		return vector2d.New(0, 0)
+	}
+	return m.Displacement
+}
+
+func abs(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Time of impact in [0, 1] of two moving convex 2D shapes, see TimeOfImpact
+func TimeOfImpact2D(a, b Motion2D, tolerance ...float32) (toi float32, normal *vector2d.Vector2D, ok bool) {
+	tol := float32(defaultTolerance)
+	if len(tolerance) >= 1 {
+		tol = tolerance[0]
+	}
+	rel := vector2d.Sub(a.displacement(), b.displacement())
+	angular := abs(a.Rotation)*a.Radius + abs(b.Rotation)*b.Radius
+	for iter := 0; iter < maxAdvances; iter++ {
+		sa, sb := a.at(toi), b.at(toi)
+		d, pa, pb := gjk.Distance2D(sa, sb)
+		if d == 0 {
+			if iter == 0 {
+				_, normal, _, _, _ = gjk.Penetration2D(sa, sb)
+			}
+			return toi, normal, true
+		}
+		normal = vector2d.Sub(pb, pa).Mult(1 / d)
+		if d <= tol {
+			return toi, normal, true
+		}
+		bound := vector2d.Dot(rel, normal) + angular
+		if bound <= 0 {
+			// moving apart
+			return 0, nil, false
+		}
+		toi += d / bound
+		if toi > 1 {
+			return 0, nil, false
+		}
+	}
+	return toi, normal, true
+}
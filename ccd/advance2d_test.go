@@ -0,0 +1,72 @@
+package ccd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/gjk"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestTimeOfImpact2D(t *testing.T) {
+	circle := gjk.Circle{Center: vector2d.New(0, 0), Radius: 1}
+	box := gjk.Box2D{Center: vector2d.New(0, 0), HalfExtents: vector2d.New(1, 1)}
+	tests := []struct {
+		name string
+		a, b Motion2D
+		want hit2D
+	}{
+		{
+			"circle into box",
+			Motion2D{Shape: circle, Position: vector2d.New(-6, 0), Displacement: vector2d.New(8, 0)},
+			Motion2D{Shape: box, Position: vector2d.New(0, 0)},
+			hit2D{0.5, vector2d.New(1, 0), true},
+		},
+		{
+			"box onto box",
+			Motion2D{Shape: box, Position: vector2d.New(0.5, 10), Displacement: vector2d.New(0, -16)},
+			Motion2D{Shape: box, Position: vector2d.New(0, 0)},
+			hit2D{0.5, vector2d.New(0, -1), true},
+		},
+		{
+			"miss",
+			Motion2D{Shape: circle, Position: vector2d.New(-6, 3), Displacement: vector2d.New(12, 0)},
+			Motion2D{Shape: box, Position: vector2d.New(0, 0)},
+			hit2D{},
+		},
+		{
+			"overlapping",
+			Motion2D{Shape: box, Position: vector2d.New(0, 0)},
+			Motion2D{Shape: box, Position: vector2d.New(1.5, 0)},
+			hit2D{0, vector2d.New(1, 0), true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hit2D
+			got.TOI, got.Normal, got.OK = TimeOfImpact2D(tt.a, tt.b, 1e-4)
+			if diff := cmp.Diff(tt.want, got, getComparer(1e-3)); diff != "" {
+				t.Errorf("TimeOfImpact2D() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTimeOfImpact2DRotating(t *testing.T) {
+	bar := gjk.Box2D{Center: vector2d.New(0, 0), HalfExtents: vector2d.New(2, 0.1)}
+	a := Motion2D{Shape: bar, Position: vector2d.New(0, 0), Rotation: 1.5, Radius: vector2d.New(2, 0.1).Mag()}
+	b := Motion2D{Shape: gjk.Circle{Center: vector2d.New(0, 0), Radius: 0.5}, Position: vector2d.New(0, 1.5)}
+	toi, _, ok := TimeOfImpact2D(a, b, 1e-4)
+	if !ok {
+		t.Fatal("TimeOfImpact2D() missed")
+	}
+	if d, _, _ := gjk.Distance2D(a.at(toi), b.at(toi)); d > 1e-3 {
+		t.Errorf("distance at toi = %v, want about 0", d)
+	}
+	for i := 0; i < 10; i++ {
+		s := toi * float32(i) / 10
+		if gjk.Intersect2D(a.at(s), b.at(s)) {
+			t.Errorf("shapes overlap at %v before toi %v", s, toi)
+		}
+	}
+}
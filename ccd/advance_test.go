@@ -0,0 +1,92 @@
+package ccd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/gjk"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func sphere(radius float32) gjk.Sphere {
+	return gjk.Sphere{Center: vector.New(0, 0, 0), Radius: radius}
+}
+
+func cube(half float32) gjk.Box {
+	return gjk.Box{Center: vector.New(0, 0, 0), HalfExtents: vector.New(half, half, half)}
+}
+
+func TestTimeOfImpact(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Motion
+		want hit
+	}{
+		{
+			"spheres head on",
+			Motion{Shape: sphere(1), Position: vector.New(-5, 0, 0), Displacement: vector.New(10, 0, 0)},
+			Motion{Shape: sphere(1), Position: vector.New(0, 0, 0)},
+			hit{0.3, vector.New(1, 0, 0), true},
+		},
+		{
+			"both moving",
+			Motion{Shape: cube(1), Position: vector.New(0, -5, 0), Displacement: vector.New(0, 8, 0)},
+			Motion{Shape: sphere(1), Position: vector.New(0, 5, 0), Displacement: vector.New(0, -8, 0)},
+			hit{0.5, vector.New(0, 1, 0), true},
+		},
+		{
+			"miss",
+			Motion{Shape: sphere(1), Position: vector.New(-5, 3, 0), Displacement: vector.New(10, 0, 0)},
+			Motion{Shape: sphere(1), Position: vector.New(0, 0, 0)},
+			hit{},
+		},
+		{
+			"too short",
+			Motion{Shape: cube(1), Position: vector.New(-5, 0, 0), Displacement: vector.New(2, 0, 0)},
+			Motion{Shape: cube(1), Position: vector.New(0, 0, 0)},
+			hit{},
+		},
+		{
+			"overlapping",
+			Motion{Shape: sphere(1), Position: vector.New(0, 0, 0)},
+			Motion{Shape: sphere(1), Position: vector.New(0, 0, 1.5)},
+			hit{0, vector.New(0, 0, 1), true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hit
+			got.TOI, got.Normal, got.OK = TimeOfImpact(tt.a, tt.b, 1e-4)
+			if diff := cmp.Diff(tt.want, got, getComparer(1e-3)); diff != "" {
+				t.Errorf("TimeOfImpact() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTimeOfImpactRotating(t *testing.T) {
+	// a long thin box spinning a quarter turn about z sweeps into a box beside it
+	bar := gjk.Box{Center: vector.New(0, 0, 0), HalfExtents: vector.New(2, 0.1, 0.1)}
+	a := Motion{
+		Shape:    bar,
+		Position: vector.New(0, 0, 0),
+		Rotation: vector.New(0, 0, math.Pi/2),
+		Radius:   vector.New(2, 0.1, 0.1).Mag(),
+	}
+	b := Motion{Shape: cube(0.5), Position: vector.New(0, 1.5, 0)}
+	toi, _, ok := TimeOfImpact(a, b, 1e-4)
+	if !ok {
+		t.Fatal("TimeOfImpact() missed")
+	}
+	// the shapes don't overlap before toi and touch at toi
+	if d, _, _ := gjk.Distance(a.at(toi), b.at(toi)); d > 1e-3 {
+		t.Errorf("distance at toi = %v, want about 0", d)
+	}
+	for i := 0; i < 10; i++ {
+		s := toi * float32(i) / 10
+		if gjk.Intersect(a.at(s), b.at(s)) {
+			t.Errorf("shapes overlap at %v before toi %v", s, toi)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Package ccd provides continuous collision detection: swept shape queries
+// and time of impact between moving convex shapes, in 2D and 3D.
+// Shapes move from their start position by a displacement over one step and
+// the time of impact is the fraction of that displacement, in [0, 1].
+// https://en.wikipedia.org/wiki/Collision_detection#A_posteriori_(discrete)_versus_a_priori_(continuous)
+package ccd
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/bvh"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Sphere of the given radius moving from center by motion against the triangle abc.
+// Gives the first time of impact in [0, 1] and the unit normal of the triangle
+// at the contact point, pointing towards the sphere. A sphere already touching
+// the triangle gives a time of impact of 0.
+// The sphere center at the time of impact is vector.Lerp(center, center+motion, toi).
+func SweptSphereTriangle(center, motion *vector.Vector, radius float32, a, b, c *vector.Vector) (toi float32, normal *vector.Vector, ok bool) {
+	closest := closestOnTriangle(center, a, b, c)
+	if d := vector.Sub(center, closest); d.MagSq() <= radius*radius {
+		n := vector.Cross(vector.Sub(b, a), vector.Sub(c, a))
+		if d.MagSq() > 0 {
+			n = d
+		} else if vector.Dot(n, motion) > 0 {
+			n.Mult(-1)
+		}
+		return 0, unitOrNil(n), true
+	}
+
+	toi, best := float32(2), (*vector.Vector)(nil)
+	// face: the sphere touches the plane when its center is radius above it
+	if n := vector.Cross(vector.Sub(b, a), vector.Sub(c, a)); n.MagSq() > 0 {
+		n.Normalize()
+		dist := vector.Dot(vector.Sub(center, a), n)
+		if dist < 0 {
+			n.Mult(-1)
+			dist = -dist
+		}
+		if s := vector.Dot(motion, n); s < 0 {
+			t := (radius - dist) / s
+			if t >= 0 && t <= 1 {
+				p := vector.RayAt(center, motion, t).Sub(n.Copy().Mult(radius))
+				if insideTriangle(p, a, b, c) {
+					return t, n, true
+				}
+			}
+		}
+	}
+	// edges and vertices: the first one hit is the contact
+	for _, e := range [3][2]*vector.Vector{{a, b}, {b, c}, {c, a}} {
+		if t, n, hit := sweptSphereSegment(center, motion, radius, e[0], e[1]); hit && t < toi {
+			toi, best = t, n
+		}
+	}
+	for _, v := range [3]*vector.Vector{a, b, c} {
+		if t, hit := sweptSpherePoint(center, motion, radius, v); hit && t < toi {
+			toi, best = t, vector.Sub(vector.RayAt(center, motion, t), v).Normalize()
+		}
+	}
+	if best == nil {
+		return 0, nil, false
+	}
+	return toi, best, true
+}
+
+// Sphere against the point p
+func sweptSpherePoint(center, motion *vector.Vector, radius float32, p *vector.Vector) (float32, bool) {
+	m := vector.Sub(center, p)
+	return firstRoot(vector.Dot(motion, motion), vector.Dot(m, motion), vector.Dot(m, m)-radius*radius)
+}
+
+// Sphere against the cylinder around the segment ab, ignoring the end caps
+func sweptSphereSegment(center, motion *vector.Vector, radius float32, a, b *vector.Vector) (float32, *vector.Vector, bool) {
+	e := vector.Sub(b, a)
+	ee := vector.Dot(e, e)
+	if ee == 0 {
+		return 0, nil, false
+	}
+	// components perpendicular to the segment
+	m := vector.Sub(center, a)
+	mp := vector.Sub(m, e.Copy().Mult(vector.Dot(m, e)/ee))
+	dp := vector.Sub(motion, e.Copy().Mult(vector.Dot(motion, e)/ee))
+	t, ok := firstRoot(vector.Dot(dp, dp), vector.Dot(mp, dp), vector.Dot(mp, mp)-radius*radius)
+	if !ok {
+		return 0, nil, false
+	}
+	if u := vector.Dot(vector.RayAt(m, motion, t), e) / ee; u < 0 || u > 1 {
+		return 0, nil, false
+	}
+	return t, vector.RayAt(mp, dp, t).Normalize(), true
+}
+
+// Smallest root in [0, 1] of a*t^2 + 2*b*t + c, for a start outside (c > 0)
+func firstRoot(a, b, c float32) (float32, bool) {
+	if a <= 0 || b >= 0 {
+		// not moving or moving away
+		return 0, false
+	}
+	disc := float64(b)*float64(b) - float64(a)*float64(c)
+	if disc < 0 {
+		return 0, false
+	}
+	t := float32((-float64(b) - math.Sqrt(disc)) / float64(a))
+	if t < 0 || t > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// Whether p, on the plane of abc, is inside the triangle
+func insideTriangle(p, a, b, c *vector.Vector) bool {
+	n := vector.Cross(vector.Sub(b, a), vector.Sub(c, a))
+	for _, e := range [3][2]*vector.Vector{{a, b}, {b, c}, {c, a}} {
+		if vector.Dot(vector.Cross(vector.Sub(e[1], e[0]), vector.Sub(p, e[0])), n) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Point of the triangle abc closest to p, from Ericson's Real-Time Collision Detection
+func closestOnTriangle(p, a, b, c *vector.Vector) *vector.Vector {
+	ab, ac, ap := vector.Sub(b, a), vector.Sub(c, a), vector.Sub(p, a)
+	d1, d2 := vector.Dot(ab, ap), vector.Dot(ac, ap)
+	if d1 <= 0 && d2 <= 0 {
+		return a.Copy()
+	}
+	bp := vector.Sub(p, b)
+	d3, d4 := vector.Dot(ab, bp), vector.Dot(ac, bp)
+	if d3 >= 0 && d4 <= d3 {
+		return b.Copy()
+	}
+	if vc := d1*d4 - d3*d2; vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return vector.RayAt(a, ab, d1/(d1-d3))
+	}
+	cp := vector.Sub(p, c)
+	d5, d6 := vector.Dot(ab, cp), vector.Dot(ac, cp)
+	if d6 >= 0 && d5 <= d6 {
+		return c.Copy()
+	}
+	if vb := d5*d2 - d1*d6; vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return vector.RayAt(a, ac, d2/(d2-d6))
+	}
+	if va := d3*d6 - d5*d4; va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		return vector.RayAt(b, vector.Sub(c, b), (d4-d3)/((d4-d3)+(d5-d6)))
+	}
+	va, vb, vc := d3*d6-d5*d4, d5*d2-d1*d6, d1*d4-d3*d2
+	denom := 1 / (va + vb + vc)
+	return vector.Add(a, ab.Mult(vb*denom)).Add(ac.Mult(vc * denom))
+}
+
+func unitOrNil(v *vector.Vector) *vector.Vector {
+	if v.MagSq() == 0 {
+		return nil
+	}
+	return v.Normalize()
+}
+
+// Box a moving by motion against the box b.
+// Gives the first time of impact in [0, 1] and the unit normal of the face of b
+// that is hit, pointing towards a. Boxes already overlapping give a time of
+// impact of 0 and the normal of the axis of least overlap.
+func SweptAABB(a bvh.AABB, motion *vector.Vector, b bvh.AABB) (toi float32, normal *vector.Vector, ok bool) {
+	t, axis, sign, ok := sweptBoxes(
+		[]float32{a.Min.X, a.Min.Y, a.Min.Z}, []float32{a.Max.X, a.Max.Y, a.Max.Z},
+		[]float32{b.Min.X, b.Min.Y, b.Min.Z}, []float32{b.Max.X, b.Max.Y, b.Max.Z},
+		[]float32{motion.X, motion.Y, motion.Z})
+	if !ok {
+		return 0, nil, false
+	}
+	n := [3]float32{}
+	n[axis] = sign
+	return t, vector.New(n[0], n[1], n[2]), true
+}
+
+// Slab test of the moving box [minA, maxA] against [minB, maxB], in any dimension.
+// Gives the time of impact, the axis of the hit face and the sign of its normal.
+func sweptBoxes(minA, maxA, minB, maxB, d []float32) (toi float32, axis int, sign float32, ok bool) {
+	overlapping := true
+	for i := range d {
+		if maxA[i] <= minB[i] || minA[i] >= maxB[i] {
+			overlapping = false
+		}
+	}
+	if overlapping {
+		// push out along the axis of least overlap
+		least := float32(math.Inf(1))
+		for i := range d {
+			if o := maxB[i] - minA[i]; o < least {
+				least, axis, sign = o, i, 1
+			}
+			if o := maxA[i] - minB[i]; o < least {
+				least, axis, sign = o, i, -1
+			}
+		}
+		return 0, axis, sign, true
+	}
+	enter, exit := float32(math.Inf(-1)), float32(math.Inf(1))
+	for i := range d {
+		if d[i] == 0 {
+			if maxA[i] <= minB[i] || minA[i] >= maxB[i] {
+				return 0, 0, 0, false
+			}
+			continue
+		}
+		// times at which the slabs start and stop overlapping
+		t0 := (minB[i] - maxA[i]) / d[i]
+		t1 := (maxB[i] - minA[i]) / d[i]
+		s := float32(-1)
+		if t0 > t1 {
+			t0, t1, s = t1, t0, 1
+		}
+		// the axis entered last is the one hit
+		if t0 > enter {
+			enter, axis, sign = t0, i, s
+		}
+		exit = minf(exit, t1)
+	}
+	if enter >= exit || enter < 0 || enter > 1 {
+		return 0, 0, 0, false
+	}
+	return enter, axis, sign, true
+}
+
+func minf(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
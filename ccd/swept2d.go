@@ -0,0 +1,91 @@
+package ccd
+
+import (
+	"github.com/vaibhav11s/gopkgs/rtree"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Circle of the given radius moving from center by motion against the segment ab.
+// Gives the first time of impact in [0, 1] and the unit normal of the segment
+// at the contact point, pointing towards the circle. A circle already touching
+// the segment gives a time of impact of 0.
+func SweptCircleSegment(center, motion *vector2d.Vector2D, radius float32, a, b *vector2d.Vector2D) (toi float32, normal *vector2d.Vector2D, ok bool) {
+	e := vector2d.Sub(b, a)
+	ee := vector2d.Dot(e, e)
+	m := vector2d.Sub(center, a)
+	u := float32(0)
+	if ee > 0 {
+		u = clamp(vector2d.Dot(m, e)/ee, 0, 1)
+	}
+	if d := vector2d.Sub(m, e.Copy().Mult(u)); d.MagSq() <= radius*radius {
+		if d.MagSq() == 0 {
+			// center on the segment: oppose the motion
+			d = vector2d.New(-e.Y, e.X)
+			if vector2d.Dot(d, motion) > 0 {
+				d.Mult(-1)
+			}
+		}
+		if d.MagSq() == 0 {
+			return 0, nil, true
+		}
+		return 0, d.Normalize(), true
+	}
+
+	// side: the circle touches the line when its center is radius away from it
+	if ee > 0 {
+		n := vector2d.New(-e.Y, e.X).Normalize()
+		dist := vector2d.Dot(m, n)
+		if dist < 0 {
+			n.Mult(-1)
+			dist = -dist
+		}
+		if s := vector2d.Dot(motion, n); s < 0 {
+			t := (radius - dist) / s
+			p := vector2d.Add(m, motion.Copy().Mult(t))
+			if u := vector2d.Dot(p, e) / ee; t >= 0 && t <= 1 && u >= 0 && u <= 1 {
+				return t, n, true
+			}
+		}
+	}
+	// ends: the first one hit is the contact
+	toi = 2
+	for _, p := range [2]*vector2d.Vector2D{a, b} {
+		m := vector2d.Sub(center, p)
+		t, hit := firstRoot(vector2d.Dot(motion, motion), vector2d.Dot(m, motion), vector2d.Dot(m, m)-radius*radius)
+		if hit && t < toi {
+			toi, normal = t, m.Add(motion.Copy().Mult(t)).Normalize()
+		}
+	}
+	if normal == nil {
+		return 0, nil, false
+	}
+	return toi, normal, true
+}
+
+// Rectangle a moving by motion against the rectangle b.
+// Gives the first time of impact in [0, 1] and the unit normal of the side of b
+// that is hit, pointing towards a. Rectangles already overlapping give a time
+// of impact of 0 and the normal of the axis of least overlap.
+func SweptRect(a rtree.Rect, motion *vector2d.Vector2D, b rtree.Rect) (toi float32, normal *vector2d.Vector2D, ok bool) {
+	t, axis, sign, ok := sweptBoxes(
+		[]float32{a.Min.X, a.Min.Y}, []float32{a.Max.X, a.Max.Y},
+		[]float32{b.Min.X, b.Min.Y}, []float32{b.Max.X, b.Max.Y},
+		[]float32{motion.X, motion.Y})
+	if !ok {
+		return 0, nil, false
+	}
+	if axis == 0 {
+		return t, vector2d.New(sign, 0), true
+	}
+	return t, vector2d.New(0, sign), true
+}
+
+func clamp(x, lo, hi float32) float32 {
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
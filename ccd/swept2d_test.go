@@ -0,0 +1,74 @@
+package ccd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/rtree"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+type hit2D struct {
+	TOI    float32
+	Normal *vector2d.Vector2D
+	OK     bool
+}
+
+func TestSweptCircleSegment(t *testing.T) {
+	a, b := vector2d.New(-2, 0), vector2d.New(2, 0)
+	tests := []struct {
+		name           string
+		center, motion *vector2d.Vector2D
+		want           hit2D
+	}{
+		{"side", vector2d.New(0, 5), vector2d.New(0, -8), hit2D{0.5, vector2d.New(0, 1), true}},
+		{"below", vector2d.New(1, -3), vector2d.New(0, 4), hit2D{0.5, vector2d.New(0, -1), true}},
+		{"end", vector2d.New(5, 0), vector2d.New(-4, 0), hit2D{0.5, vector2d.New(1, 0), true}},
+		{"corner", vector2d.New(5, 3), vector2d.New(-3, -3), hit2D{
+			(3*float32(math.Sqrt2) - 1) / (3 * float32(math.Sqrt2)),
+			vector2d.New(1, 1).Normalize(), true}},
+		{"short", vector2d.New(0, 5), vector2d.New(0, -2), hit2D{}},
+		{"away", vector2d.New(0, 5), vector2d.New(0, 2), hit2D{}},
+		{"beside", vector2d.New(5, 5), vector2d.New(0, -10), hit2D{}},
+		{"touching", vector2d.New(1, 0.5), vector2d.New(0, 1), hit2D{0, vector2d.New(0, 1), true}},
+		{"on the segment", vector2d.New(1, 0), vector2d.New(0, 1), hit2D{0, vector2d.New(0, -1), true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hit2D
+			got.TOI, got.Normal, got.OK = SweptCircleSegment(tt.center, tt.motion, 1, a, b)
+			if diff := cmp.Diff(tt.want, got, getComparer(1e-5)); diff != "" {
+				t.Errorf("SweptCircleSegment() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSweptRect(t *testing.T) {
+	rect := func(x, y, half float32) rtree.Rect {
+		return rtree.NewRect(vector2d.New(x-half, y-half), vector2d.New(x+half, y+half))
+	}
+	b := rect(0, 0, 1)
+	tests := []struct {
+		name   string
+		a      rtree.Rect
+		motion *vector2d.Vector2D
+		want   hit2D
+	}{
+		{"x", rect(-5, 0, 1), vector2d.New(6, 0), hit2D{0.5, vector2d.New(-1, 0), true}},
+		{"y", rect(0, 5, 1), vector2d.New(0, -6), hit2D{0.5, vector2d.New(0, 1), true}},
+		{"corner first on x", rect(-4, 3, 1), vector2d.New(8, -8), hit2D{0.25, vector2d.New(-1, 0), true}},
+		{"miss", rect(-5, 5, 1), vector2d.New(10, 0), hit2D{}},
+		{"overlapping", rect(0, -1.5, 1), vector2d.New(1, 0), hit2D{0, vector2d.New(0, -1), true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hit2D
+			got.TOI, got.Normal, got.OK = SweptRect(tt.a, tt.motion, b)
+			if diff := cmp.Diff(tt.want, got, getComparer(1e-6)); diff != "" {
+				t.Errorf("SweptRect() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package ccd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/bvh"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector.Vector) bool { return a == b || a != nil && b != nil && a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a == b || a != nil && b != nil && a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b float32) bool { return math.Abs(float64(a-b)) <= float64(tolerance) }),
+	}
+}
+
+type hit struct {
+	TOI    float32
+	Normal *vector.Vector
+	OK     bool
+}
+
+func TestSweptSphereTriangle(t *testing.T) {
+	// right triangle in the z = 0 plane
+	a, b, c := vector.New(0, 0, 0), vector.New(4, 0, 0), vector.New(0, 4, 0)
+	tests := []struct {
+		name           string
+		center, motion *vector.Vector
+		want           hit
+	}{
+		{"face", vector.New(1, 1, 5), vector.New(0, 0, -8), hit{0.5, vector.New(0, 0, 1), true}},
+		{"face from below", vector.New(1, 1, -3), vector.New(0, 0, 4), hit{0.5, vector.New(0, 0, -1), true}},
+		{"too short", vector.New(1, 1, 5), vector.New(0, 0, -2), hit{}},
+		{"moving away", vector.New(1, 1, 5), vector.New(0, 0, 2), hit{}},
+		{"edge", vector.New(2, -3, 0), vector.New(0, 4, 0), hit{0.5, vector.New(0, -1, 0), true}},
+		{"vertex", vector.New(-3, -3, 0), vector.New(4, 4, 0), hit{
+			(3*float32(math.Sqrt2) - 1) / (4 * float32(math.Sqrt2)),
+			vector.New(-1, -1, 0).Normalize(), true}},
+		{"miss beside", vector.New(6, 6, 5), vector.New(0, 0, -8), hit{}},
+		{"touching", vector.New(1, 1, 0.5), vector.New(1, 0, 0), hit{0, vector.New(0, 0, 1), true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hit
+			got.TOI, got.Normal, got.OK = SweptSphereTriangle(tt.center, tt.motion, 1, a, b, c)
+			if diff := cmp.Diff(tt.want, got, getComparer(1e-5)); diff != "" {
+				t.Errorf("SweptSphereTriangle() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSweptSphereTriangleNoTunneling(t *testing.T) {
+	// a fast sphere passing through the triangle in one step is caught
+	a, b, c := vector.New(-5, -5, 0), vector.New(5, -5, 0), vector.New(0, 5, 0)
+	start, end := vector.New(0, 0, 10), vector.New(0, 0, -10)
+	motion := vector.Sub(end, start)
+	toi, normal, ok := SweptSphereTriangle(start, motion, 0.5, a, b, c)
+	if !ok {
+		t.Fatal("SweptSphereTriangle() missed")
+	}
+	at := vector.Lerp(start, end, toi)
+	if diff := cmp.Diff(vector.New(0, 0, 0.5), at, getComparer(1e-5)); diff != "" {
+		t.Errorf("center at impact mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(vector.New(0, 0, 1), normal, getComparer(1e-6)); diff != "" {
+		t.Errorf("normal mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSweptAABB(t *testing.T) {
+	box := func(x, y, z, half float32) bvh.AABB {
+		return bvh.AABB{Min: *vector.New(x-half, y-half, z-half), Max: *vector.New(x+half, y+half, z+half)}
+	}
+	b := box(0, 0, 0, 1)
+	tests := []struct {
+		name   string
+		a      bvh.AABB
+		motion *vector.Vector
+		want   hit
+	}{
+		{"x", box(-5, 0, 0, 1), vector.New(6, 0, 0), hit{0.5, vector.New(-1, 0, 0), true}},
+		{"-y", box(0, 4, 0, 1), vector.New(0, -4, 0), hit{0.5, vector.New(0, 1, 0), true}},
+		{"diagonal", box(-4, -6, 0, 1), vector.New(8, 8, 0), hit{0.5, vector.New(0, -1, 0), true}},
+		{"short", box(-5, 0, 0, 1), vector.New(2, 0, 0), hit{}},
+		{"parallel miss", box(-5, 3, 0, 1), vector.New(10, 0, 0), hit{}},
+		{"passes beside", box(-5, -5, 0, 1), vector.New(10, 2, 0), hit{}},
+		{"overlapping", box(0.5, 0, 0, 1), vector.New(1, 0, 0), hit{0, vector.New(1, 0, 0), true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got hit
+			got.TOI, got.Normal, got.OK = SweptAABB(tt.a, tt.motion, b)
+			if diff := cmp.Diff(tt.want, got, getComparer(1e-6)); diff != "" {
+				t.Errorf("SweptAABB() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cluster
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Label given to points that belong to no cluster
+const Noise = -1
+
+// Groups points that have at least minPts neighbors (itself included) within eps,
+// together with the points reachable from them.
+// labels[i] is the cluster of points[i] or Noise; centroids[c] is the mean of cluster c.
+// https://en.wikipedia.org/wiki/DBSCAN
+func DBSCAN(points []*vector.Vector, eps float32, minPts int) (labels []int, centroids []*vector.Vector) {
+	labels, cs := dbscan(fromVectors(points), float64(eps), minPts)
+	return labels, toVectors(cs)
+}
+
+// Groups 2D points by density, see DBSCAN
+func DBSCAN2D(points []*vector2d.Vector2D, eps float32, minPts int) (labels []int, centroids []*vector2d.Vector2D) {
+	labels, cs := dbscan(fromVectors2D(points), float64(eps), minPts)
+	return labels, toVectors2D(cs)
+}
+
+type cell [3]int64
+
+// Uniform grid with cells of size eps, so the neighbors of a point are
+// always in the 27 cells around it
+type grid struct {
+	size  float64
+	cells map[cell][]int
+}
+
+func newGrid(ps []point, size float64) *grid {
+	g := &grid{size: size, cells: map[cell][]int{}}
+	for i, p := range ps {
+		c := g.cellOf(p)
+		g.cells[c] = append(g.cells[c], i)
+	}
+	return g
+}
+
+func (g *grid) cellOf(p point) cell {
+	return cell{
+		int64(math.Floor(p[0] / g.size)),
+		int64(math.Floor(p[1] / g.size)),
+		int64(math.Floor(p[2] / g.size)),
+	}
+}
+
+// Appends to out the indexes of all points within eps of ps[i], cell by cell
+// over the 27 cells around it and in index order within each cell
+func (g *grid) neighbors(ps []point, i int, out []int) []int {
+	out = out[:0]
+	c := g.cellOf(ps[i])
+	epsSq := g.size * g.size
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for dz := int64(-1); dz <= 1; dz++ {
+				for _, j := range g.cells[cell{c[0] + dx, c[1] + dy, c[2] + dz}] {
+					if distSq(ps[i], ps[j]) <= epsSq {
+						out = append(out, j)
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+func dbscan(ps []point, eps float64, minPts int) ([]int, []point) {
+	const unvisited = -2
+	labels := make([]int, len(ps))
+	for i := range labels {
+		labels[i] = unvisited
+	}
+	if eps <= 0 {
+		for i := range labels {
+			labels[i] = Noise
+		}
+		return labels, nil
+	}
+	g := newGrid(ps, eps)
+	var centroids []point
+	var counts []int
+	var nb []int
+	cluster := 0
+	for i := range ps {
+		if labels[i] != unvisited {
+			continue
+		}
+		nb = g.neighbors(ps, i, nb)
+		if len(nb) < minPts {
+			labels[i] = Noise
+			continue
+		}
+		labels[i] = cluster
+		queue := append([]int(nil), nb...)
+		for len(queue) > 0 {
+			j := queue[0]
+			queue = queue[1:]
+			if labels[j] == Noise {
+				// border point
+				labels[j] = cluster
+			}
+			if labels[j] != unvisited {
+				continue
+			}
+			labels[j] = cluster
+			nb = g.neighbors(ps, j, nb)
+			if len(nb) >= minPts {
+				queue = append(queue, nb...)
+			}
+		}
+		centroids = append(centroids, point{})
+		counts = append(counts, 0)
+		cluster++
+	}
+	for i, p := range ps {
+		if c := labels[i]; c >= 0 {
+			centroids[c][0] += p[0]
+			centroids[c][1] += p[1]
+			centroids[c][2] += p[2]
+			counts[c]++
+		}
+	}
+	for c := range centroids {
+		n := float64(counts[c])
+		centroids[c] = point{centroids[c][0] / n, centroids[c][1] / n, centroids[c][2] / n}
+	}
+	return labels, centroids
+}
@@ -0,0 +1,116 @@
+package cluster
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Comparer(func(x, y float32) bool {
+		diff := math.Abs(float64(x - y))
+		return diff <= tolerance
+	})
+}
+
+// DBSCAN without a spatial index
+func bruteDBSCAN(ps []point, eps float64, minPts int) []int {
+	labels := make([]int, len(ps))
+	for i := range labels {
+		labels[i] = -2
+	}
+	neighbors := func(i int) []int {
+		var out []int
+		for j := range ps {
+			if distSq(ps[i], ps[j]) <= eps*eps {
+				out = append(out, j)
+			}
+		}
+		return out
+	}
+	c := 0
+	for i := range ps {
+		if labels[i] != -2 {
+			continue
+		}
+		nb := neighbors(i)
+		if len(nb) < minPts {
+			labels[i] = Noise
+			continue
+		}
+		labels[i] = c
+		for len(nb) > 0 {
+			j := nb[0]
+			nb = nb[1:]
+			if labels[j] == Noise {
+				labels[j] = c
+			}
+			if labels[j] != -2 {
+				continue
+			}
+			labels[j] = c
+			if more := neighbors(j); len(more) >= minPts {
+				nb = append(nb, more...)
+			}
+		}
+		c++
+	}
+	return labels
+}
+
+func TestDBSCAN(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ps := blobs(r, []*vector.Vector{vector.New(0, 0, 0), vector.New(10, 10, 10)}, 60, 0.5)
+	outlier := vector.New(-20, 5, 5)
+	ps = append(ps, outlier)
+	labels, centroids := DBSCAN(ps, 1.5, 4)
+	if len(centroids) != 2 {
+		t.Fatalf("DBSCAN() found %d clusters, want 2", len(centroids))
+	}
+	if labels[len(ps)-1] != Noise {
+		t.Errorf("outlier label = %d, want Noise", labels[len(ps)-1])
+	}
+	if !centroids[0].Equal(vector.New(0, 0, 0), 0.3) || !centroids[1].Equal(vector.New(10, 10, 10), 0.3) {
+		t.Errorf("centroids = %v", centroids)
+	}
+}
+
+func TestDBSCANMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	ps := make([]*vector.Vector, 600)
+	for i := range ps {
+		ps[i] = vector.New(r.Float32()*20, r.Float32()*20, r.Float32()*5)
+	}
+	for _, eps := range []float32{0.5, 1, 2} {
+		got, _ := DBSCAN(ps, eps, 5)
+		want := bruteDBSCAN(fromVectors(ps), float64(eps), 5)
+		if !cmp.Equal(got, want) {
+			t.Errorf("DBSCAN(eps=%v) labels differ from brute force", eps)
+		}
+	}
+}
+
+func TestDBSCAN2D(t *testing.T) {
+	ps := []*vector2d.Vector2D{
+		vector2d.New(0, 0), vector2d.New(0, 1), vector2d.New(1, 0), vector2d.New(1, 1),
+		vector2d.New(5, 5),
+		vector2d.New(-10, -10), vector2d.New(-10, -11), vector2d.New(-11, -10),
+	}
+	labels, centroids := DBSCAN2D(ps, 1.1, 3)
+	want := []int{0, 0, 0, 0, Noise, 1, 1, 1}
+	if !cmp.Equal(labels, want) {
+		t.Errorf("DBSCAN2D() labels = %v, want %v", labels, want)
+	}
+	opt := getComparer(1e-5)
+	wantC := []*vector2d.Vector2D{vector2d.New(0.5, 0.5), vector2d.New(-31.0/3, -31.0/3)}
+	if !cmp.Equal(centroids, wantC, opt) {
+		t.Errorf("DBSCAN2D() centroids = %v, want %v", centroids, wantC)
+	}
+	if labels, _ := DBSCAN2D(ps, 0, 1); labels[0] != Noise {
+		t.Errorf("DBSCAN2D(eps=0) labels = %v, want all Noise", labels)
+	}
+}
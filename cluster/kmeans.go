@@ -0,0 +1,214 @@
+// Package cluster provides k-means and DBSCAN clustering of 2D and 3D points.
+package cluster
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Returned when k is not between 1 and the number of points
+var ErrK = errors.New("cluster: k must be between 1 and the number of points")
+
+// Parameters of k-means. Zero fields take their default value.
+type KMeansConfig struct {
+	// Maximum number of Lloyd iterations (default 100)
+	MaxIterations int
+	// Stop once no centroid moves more than this (default 1e-4)
+	Tolerance float64
+	// Source of randomness for k-means++ seeding (default seeded from time)
+	Rand *rand.Rand
+}
+
+func (c KMeansConfig) withDefaults() KMeansConfig {
+	if c.MaxIterations <= 0 {
+		c.MaxIterations = 100
+	}
+	if c.Tolerance <= 0 {
+		c.Tolerance = 1e-4
+	}
+	if c.Rand == nil {
+		c.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return c
+}
+
+type point [3]float64
+
+func distSq(a, b point) float64 {
+	dx, dy, dz := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dx*dx + dy*dy + dz*dz
+}
+
+func fromVectors(vs []*vector.Vector) []point {
+	ps := make([]point, len(vs))
+	for i, v := range vs {
+		ps[i] = point{float64(v.X), float64(v.Y), float64(v.Z)}
+	}
+	return ps
+}
+
+func fromVectors2D(vs []*vector2d.Vector2D) []point {
+	ps := make([]point, len(vs))
+	for i, v := range vs {
+		ps[i] = point{float64(v.X), float64(v.Y), 0}
+	}
+	return ps
+}
+
+func toVectors(ps []point) []*vector.Vector {
+	vs := make([]*vector.Vector, len(ps))
+	for i, p := range ps {
+		vs[i] = vector.New(float32(p[0]), float32(p[1]), float32(p[2]))
+	}
+	return vs
+}
+
+func toVectors2D(ps []point) []*vector2d.Vector2D {
+	vs := make([]*vector2d.Vector2D, len(ps))
+	for i, p := range ps {
+		vs[i] = vector2d.New(float32(p[0]), float32(p[1]))
+	}
+	return vs
+}
+
+// Partitions the points into k clusters with Lloyd's algorithm and k-means++ seeding.
+// labels[i] is the cluster of points[i], in 0..k-1.
+func KMeans(points []*vector.Vector, k int, cfg KMeansConfig) (labels []int, centroids []*vector.Vector, err error) {
+	labels, cs, err := kmeans(fromVectors(points), k, cfg.withDefaults())
+	if err != nil {
+		return nil, nil, err
+	}
+	return labels, toVectors(cs), nil
+}
+
+// Partitions the 2D points into k clusters, see KMeans
+func KMeans2D(points []*vector2d.Vector2D, k int, cfg KMeansConfig) (labels []int, centroids []*vector2d.Vector2D, err error) {
+	labels, cs, err := kmeans(fromVectors2D(points), k, cfg.withDefaults())
+	if err != nil {
+		return nil, nil, err
+	}
+	return labels, toVectors2D(cs), nil
+}
+
+func kmeans(ps []point, k int, cfg KMeansConfig) ([]int, []point, error) {
+	if k < 1 || k > len(ps) {
+		return nil, nil, ErrK
+	}
+	labels, centroids := lloyd(ps, seedPlusPlus(ps, k, cfg.Rand), cfg)
+	return labels, centroids, nil
+}
+
+// Lloyd iterations from the given centroids
+func lloyd(ps []point, centroids []point, cfg KMeansConfig) ([]int, []point) {
+	k := len(centroids)
+	labels := make([]int, len(ps))
+	for iter := 0; iter < cfg.MaxIterations; iter++ {
+		for i, p := range ps {
+			labels[i] = nearest(centroids, p)
+		}
+		sums := make([]point, k)
+		counts := make([]int, k)
+		for i, p := range ps {
+			c := labels[i]
+			sums[c][0] += p[0]
+			sums[c][1] += p[1]
+			sums[c][2] += p[2]
+			counts[c]++
+		}
+		for c := range centroids {
+			if counts[c] > 0 {
+				continue
+			}
+			// empty cluster: restart it at the point worst served by its
+			// centroid, moved over so that other empty clusters take others
+			i := farthest(ps, labels, centroids, counts)
+			old := labels[i]
+			sums[old][0] -= ps[i][0]
+			sums[old][1] -= ps[i][1]
+			sums[old][2] -= ps[i][2]
+			counts[old]--
+			labels[i], sums[c], counts[c] = c, ps[i], 1
+		}
+		moved := 0.0
+		for c := range centroids {
+			n := float64(counts[c])
+			next := point{sums[c][0] / n, sums[c][1] / n, sums[c][2] / n}
+			moved = math.Max(moved, distSq(next, centroids[c]))
+			centroids[c] = next
+		}
+		if moved <= cfg.Tolerance*cfg.Tolerance {
+			break
+		}
+	}
+	for i, p := range ps {
+		labels[i] = nearest(centroids, p)
+	}
+	return labels, centroids
+}
+
+// Chooses k initial centroids, each picked with probability proportional to
+// its squared distance from the centroids chosen so far.
+// https://en.wikipedia.org/wiki/K-means%2B%2B
+func seedPlusPlus(ps []point, k int, r *rand.Rand) []point {
+	centroids := make([]point, 0, k)
+	centroids = append(centroids, ps[r.Intn(len(ps))])
+	d := make([]float64, len(ps))
+	for i, p := range ps {
+		d[i] = distSq(p, centroids[0])
+	}
+	for len(centroids) < k {
+		total := 0.0
+		for _, x := range d {
+			total += x
+		}
+		pick := 0
+		if total == 0 {
+			// all remaining points coincide with a centroid
+			pick = r.Intn(len(ps))
+		} else {
+			target := r.Float64() * total
+			for pick = 0; pick < len(d)-1; pick++ {
+				target -= d[pick]
+				if target < 0 {
+					break
+				}
+			}
+		}
+		c := ps[pick]
+		centroids = append(centroids, c)
+		for i, p := range ps {
+			d[i] = math.Min(d[i], distSq(p, c))
+		}
+	}
+	return centroids
+}
+
+func nearest(centroids []point, p point) int {
+	best, bestD := 0, math.Inf(1)
+	for c, q := range centroids {
+		if d := distSq(p, q); d < bestD {
+			best, bestD = c, d
+		}
+	}
+	return best
+}
+
+// Point farthest from its centroid among the clusters of more than one point,
+// which exist while a cluster is empty as k is at most the number of points
+func farthest(ps []point, labels []int, centroids []point, counts []int) int {
+	best, bestD := 0, -1.0
+	for i, p := range ps {
+		if counts[labels[i]] < 2 {
+			continue
+		}
+		if d := distSq(p, centroids[labels[i]]); d > bestD {
+			best, bestD = i, d
+		}
+	}
+	return best
+}
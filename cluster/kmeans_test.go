@@ -0,0 +1,121 @@
+package cluster
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Points scattered around each of the centers
+func blobs(r *rand.Rand, centers []*vector.Vector, n int, spread float32) []*vector.Vector {
+	var ps []*vector.Vector
+	for _, c := range centers {
+		for i := 0; i < n; i++ {
+			off := vector.New(float32(r.NormFloat64()), float32(r.NormFloat64()), float32(r.NormFloat64())).Mult(spread)
+			ps = append(ps, vector.Add(c, off))
+		}
+	}
+	return ps
+}
+
+func TestKMeans(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	centers := []*vector.Vector{vector.New(0, 0, 0), vector.New(20, 0, 0), vector.New(0, 20, 20)}
+	ps := blobs(r, centers, 100, 1)
+	labels, centroids, err := KMeans(ps, 3, KMeansConfig{Rand: rand.New(rand.NewSource(2))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for b, c := range centers {
+		// all points of one blob share a label, and its centroid is near the center
+		l := labels[b*100]
+		for i := b * 100; i < (b+1)*100; i++ {
+			if labels[i] != l {
+				t.Fatalf("point %d labelled %d, want %d", i, labels[i], l)
+			}
+		}
+		if !centroids[l].Equal(c, 0.5) {
+			t.Errorf("centroid %d = %v, want near %v", l, centroids[l], c)
+		}
+	}
+}
+
+func TestKMeansDeterministic(t *testing.T) {
+	ps := blobs(rand.New(rand.NewSource(3)), []*vector.Vector{vector.New(0, 0, 0), vector.New(5, 5, 5)}, 50, 2)
+	run := func() ([]int, []*vector.Vector) {
+		l, c, _ := KMeans(ps, 4, KMeansConfig{Rand: rand.New(rand.NewSource(7))})
+		return l, c
+	}
+	l1, c1 := run()
+	l2, c2 := run()
+	if !cmp.Equal(l1, l2) || !cmp.Equal(c1, c2) {
+		t.Errorf("KMeans() with the same seed gave different results")
+	}
+}
+
+func TestKMeans2D(t *testing.T) {
+	ps := []*vector2d.Vector2D{
+		vector2d.New(0, 0), vector2d.New(0, 1), vector2d.New(1, 0),
+		vector2d.New(10, 10), vector2d.New(10, 11), vector2d.New(11, 10),
+	}
+	labels, centroids, err := KMeans2D(ps, 2, KMeansConfig{Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels[0] != labels[1] || labels[0] != labels[2] || labels[3] != labels[4] || labels[3] != labels[5] || labels[0] == labels[3] {
+		t.Fatalf("KMeans2D() labels = %v", labels)
+	}
+	opt := getComparer(1e-5)
+	if want := vector2d.New(1.0/3, 1.0/3); !cmp.Equal(centroids[labels[0]], want, opt) {
+		t.Errorf("centroid = %v, want %v", centroids[labels[0]], want)
+	}
+	if want := vector2d.New(31.0/3, 31.0/3); !cmp.Equal(centroids[labels[3]], want, opt) {
+		t.Errorf("centroid = %v, want %v", centroids[labels[3]], want)
+	}
+}
+
+func TestKMeansEmptyClusters(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	centers := []*vector.Vector{vector.New(0, 0, 0), vector.New(10, 0, 0), vector.New(20, 0, 0), vector.New(30, 0, 0)}
+	ps := fromVectors(blobs(r, centers, 10, 0.5))
+	// the last two centroids are far from every point, both clusters empty
+	start := []point{{5, 0, 0}, {25, 0, 0}, {1000, 1000, 1000}, {-1000, -1000, -1000}}
+	labels, centroids := lloyd(ps, start, KMeansConfig{}.withDefaults())
+	counts := make([]int, len(centroids))
+	for _, l := range labels {
+		counts[l]++
+	}
+	for c := range centroids {
+		if counts[c] == 0 {
+			t.Errorf("cluster %d is empty, centroids %v", c, centroids)
+		}
+		for d := 0; d < c; d++ {
+			if centroids[c] == centroids[d] {
+				t.Errorf("clusters %d and %d share the centroid %v", d, c, centroids[c])
+			}
+		}
+	}
+}
+
+func TestKMeansEdgeCases(t *testing.T) {
+	ps := []*vector.Vector{vector.New(1, 1, 1), vector.New(1, 1, 1), vector.New(1, 1, 1)}
+	for _, k := range []int{0, 4} {
+		if _, _, err := KMeans(ps, k, KMeansConfig{}); !errors.Is(err, ErrK) {
+			t.Errorf("KMeans(k=%d) error = %v, want %v", k, err, ErrK)
+		}
+	}
+	// more clusters than distinct points
+	labels, centroids, err := KMeans(ps, 3, KMeansConfig{Rand: rand.New(rand.NewSource(1))})
+	if err != nil || len(labels) != 3 || len(centroids) != 3 {
+		t.Fatalf("KMeans(duplicates, 3) = %v, %v, %v", labels, centroids, err)
+	}
+	for _, c := range centroids {
+		if !c.Equal(ps[0]) {
+			t.Errorf("centroid %v, want %v", c, ps[0])
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package coords
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Cylindrical coordinates: distance Rho from the Z axis, Azimuth
+// counter-clockwise from +X in the unit of the convention and height Z. The
+// naming and polar reference of the convention do not apply.
+type Cylindrical struct {
+	Rho, Azimuth, Z float64
+	Convention      Convention
+}
+
+// Cylindrical coordinates of a vector
+func ToCylindrical(v *vector.Vector, c Convention) Cylindrical {
+	x, y := float64(v.X), float64(v.Y)
+	return Cylindrical{Rho: math.Hypot(x, y), Azimuth: c.fromRadians(math.Atan2(y, x)), Z: float64(v.Z), Convention: c}
+}
+
+// Vector of the cylindrical coordinates
+func (cy Cylindrical) Vector() *vector.Vector {
+	sin, cos := math.Sincos(cy.Convention.toRadians(cy.Azimuth))
+	return vector.New(component(cy.Rho, cos), component(cy.Rho, sin), float32(cy.Z))
+}
+
+// The same coordinates in another convention
+func (cy Cylindrical) In(c Convention) Cylindrical {
+	return Cylindrical{Rho: cy.Rho, Azimuth: c.fromRadians(cy.Convention.toRadians(cy.Azimuth)), Z: cy.Z, Convention: c}
+}
+
+// Spherical coordinates of the same point
+func (cy Cylindrical) Spherical() Spherical {
+	c := cy.Convention
+	return c.spherical(math.Hypot(cy.Rho, cy.Z), c.toRadians(cy.Azimuth), math.Atan2(cy.Rho, cy.Z))
+}
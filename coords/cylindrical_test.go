@@ -0,0 +1,54 @@
+package coords
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestToCylindrical(t *testing.T) {
+	deg := Convention{Unit: Degrees}
+	tests := []struct {
+		v    *vector.Vector
+		c    Convention
+		want Cylindrical
+	}{
+		{vector.New(3, 4, 5), Convention{}, Cylindrical{Rho: 5, Azimuth: math.Atan2(4, 3), Z: 5}},
+		{vector.New(0, -2, 1), deg, Cylindrical{Rho: 2, Azimuth: -90, Z: 1, Convention: deg}},
+		{vector.New(0, 0, -3), deg, Cylindrical{Z: -3, Convention: deg}},
+	}
+	for _, tt := range tests {
+		got := ToCylindrical(tt.v, tt.c)
+		if diff := cmp.Diff(tt.want, got, getComparer(1e-6)); diff != "" {
+			t.Errorf("ToCylindrical(%v) mismatch (-want +got):\n%s", tt.v, diff)
+		}
+		if diff := cmp.Diff(tt.v, got.Vector()); diff != "" {
+			t.Errorf("Vector() mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestCylindricalRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	vs := []*vector.Vector{vector.New(0, 1, 0), vector.New(-2, 0, 5), vector.New(0, 0, 0)}
+	for i := 0; i < 1000; i++ {
+		vs = append(vs, vector.New(float32(r.NormFloat64()), float32(r.NormFloat64()), float32(r.NormFloat64())).Mult(float32(math.Exp(r.NormFloat64()*5))))
+	}
+	for _, c := range conventions {
+		for _, v := range vs {
+			cy := ToCylindrical(v, c)
+			if diff := cmp.Diff(v, cy.Vector()); diff != "" {
+				t.Fatalf("round trip in %+v mismatch (-want +got):\n%s", c, diff)
+			}
+			if diff := cmp.Diff(v, cy.In(conventions[4]).Vector(), getComparer(1e-6*float64(v.Mag()))); diff != "" {
+				t.Fatalf("In() in %+v mismatch (-want +got):\n%s", c, diff)
+			}
+			if diff := cmp.Diff(v, cy.Spherical().Vector(), getComparer(1e-6*float64(v.Mag()))); diff != "" {
+				t.Fatalf("Spherical() in %+v mismatch (-want +got):\n%s", c, diff)
+			}
+		}
+	}
+}
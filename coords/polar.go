@@ -0,0 +1,32 @@
+package coords
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Polar coordinates: distance R from the origin and angle Theta
+// counter-clockwise from +X, in the unit of the convention. The naming and
+// polar reference of the convention do not apply.
+type Polar struct {
+	R, Theta   float64
+	Convention Convention
+}
+
+// Polar coordinates of a vector, matching vector2d.Heading
+func ToPolar(v *vector2d.Vector2D, c Convention) Polar {
+	x, y := float64(v.X), float64(v.Y)
+	return Polar{R: math.Hypot(x, y), Theta: c.fromRadians(math.Atan2(y, x)), Convention: c}
+}
+
+// Vector of the polar coordinates
+func (p Polar) Vector2D() *vector2d.Vector2D {
+	sin, cos := math.Sincos(p.Convention.toRadians(p.Theta))
+	return vector2d.New(component(p.R, cos), component(p.R, sin))
+}
+
+// The same coordinates in another convention
+func (p Polar) In(c Convention) Polar {
+	return Polar{R: p.R, Theta: c.fromRadians(p.Convention.toRadians(p.Theta)), Convention: c}
+}
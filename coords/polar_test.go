@@ -0,0 +1,85 @@
+package coords
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b float64) bool { return a == b || math.Abs(a-b) <= tolerance }),
+		cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, float32(tolerance)) }),
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, float32(tolerance)) }),
+	}
+}
+
+var conventions = []Convention{
+	{},
+	{Unit: Degrees},
+	{Naming: Physics},
+	{Polar: Elevation},
+	{Unit: Degrees, Naming: Physics, Polar: Elevation},
+}
+
+func TestToPolar(t *testing.T) {
+	tests := []struct {
+		v    *vector2d.Vector2D
+		c    Convention
+		want Polar
+	}{
+		{vector2d.New(1, 0), Convention{}, Polar{R: 1}},
+		{vector2d.New(0, 2), Convention{}, Polar{R: 2, Theta: math.Pi / 2}},
+		{vector2d.New(0, 2), Convention{Unit: Degrees}, Polar{R: 2, Theta: 90, Convention: Convention{Unit: Degrees}}},
+		{vector2d.New(-3, -3), Convention{Unit: Degrees}, Polar{R: 3 * math.Sqrt2, Theta: -135, Convention: Convention{Unit: Degrees}}},
+		{vector2d.New(-1, 0), Convention{}, Polar{R: 1, Theta: math.Pi}},
+		{vector2d.New(0, 0), Convention{}, Polar{}},
+	}
+	for _, tt := range tests {
+		got := ToPolar(tt.v, tt.c)
+		if diff := cmp.Diff(tt.want, got, getComparer(1e-6)); diff != "" {
+			t.Errorf("ToPolar(%v) mismatch (-want +got):\n%s", tt.v, diff)
+		}
+		if diff := cmp.Diff(tt.v, got.Vector2D()); diff != "" {
+			t.Errorf("Vector2D() mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestPolarRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vs := []*vector2d.Vector2D{vector2d.New(0, 1), vector2d.New(0, -7), vector2d.New(-5, 0)}
+	for i := 0; i < 1000; i++ {
+		vs = append(vs, vector2d.New(float32(r.NormFloat64()), float32(r.NormFloat64())).Mult(float32(math.Exp(r.NormFloat64()*5))))
+	}
+	for _, c := range conventions {
+		for _, v := range vs {
+			if diff := cmp.Diff(v, ToPolar(v, c).Vector2D()); diff != "" {
+				t.Fatalf("round trip in %+v mismatch (-want +got):\n%s", c, diff)
+			}
+		}
+	}
+}
+
+func TestPolarHeading(t *testing.T) {
+	// the default convention matches vector2d
+	v := vector2d.FromAngle(2, 3)
+	p := ToPolar(v, Convention{})
+	if diff := cmp.Diff(Polar{R: 3, Theta: 2}, p, getComparer(1e-6)); diff != "" {
+		t.Errorf("ToPolar(FromAngle()) mismatch (-want +got):\n%s", diff)
+	}
+	if h := v.Heading(); math.Abs(float64(h)-p.Theta) > 1e-6 {
+		t.Errorf("Heading() = %v, want %v", h, p.Theta)
+	}
+	deg := p.In(Convention{Unit: Degrees})
+	if diff := cmp.Diff(Polar{R: 3, Theta: 2 * 180 / math.Pi, Convention: Convention{Unit: Degrees}}, deg, getComparer(1e-5)); diff != "" {
+		t.Errorf("In() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(p, deg.In(Convention{}), getComparer(1e-15)); diff != "" {
+		t.Errorf("In() back mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,83 @@
+package coords
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Spherical coordinates: distance R from the origin and the angles Theta and
+// Phi, which are the azimuth and the polar angle in the math naming and the
+// other way around in the physics naming.
+type Spherical struct {
+	R, Theta, Phi float64
+	Convention    Convention
+}
+
+// Coordinates in the convention from a distance, an azimuth and an
+// inclination in radians
+func (c Convention) spherical(r, azimuth, inclination float64) Spherical {
+	s := Spherical{R: r, Convention: c}
+	a, p := c.fromRadians(azimuth), c.polar(inclination)
+	if c.Naming == Physics {
+		s.Theta, s.Phi = p, a
+	} else {
+		s.Theta, s.Phi = a, p
+	}
+	return s
+}
+
+// Spherical coordinates of a vector. The zero vector has zero angles.
+func ToSpherical(v *vector.Vector, c Convention) Spherical {
+	x, y, z := float64(v.X), float64(v.Y), float64(v.Z)
+	rho := math.Hypot(x, y)
+	return c.spherical(math.Hypot(rho, z), math.Atan2(y, x), math.Atan2(rho, z))
+}
+
+// Azimuth counter-clockwise from +X, in the unit of the convention
+func (s Spherical) Azimuth() float64 {
+	if s.Convention.Naming == Physics {
+		return s.Phi
+	}
+	return s.Theta
+}
+
+// Polar angle, an inclination or an elevation in the unit of the convention
+func (s Spherical) PolarAngle() float64 {
+	if s.Convention.Naming == Physics {
+		return s.Theta
+	}
+	return s.Phi
+}
+
+// Azimuth and inclination in radians
+func (s Spherical) angles() (azimuth, inclination float64) {
+	c := s.Convention
+	return c.toRadians(s.Azimuth()), c.inclination(s.PolarAngle())
+}
+
+// Vector of the spherical coordinates
+func (s Spherical) Vector() *vector.Vector {
+	azimuth, inclination := s.angles()
+	sinA, cosA := math.Sincos(azimuth)
+	sinI, cosI := math.Sincos(inclination)
+	rho := s.R * sinI
+	if math.Abs(rho) <= 1e-15*math.Abs(s.R) {
+		rho = 0
+	}
+	return vector.New(component(rho, cosA), component(rho, sinA), component(s.R, cosI))
+}
+
+// The same coordinates in another convention
+func (s Spherical) In(c Convention) Spherical {
+	azimuth, inclination := s.angles()
+	return c.spherical(s.R, azimuth, inclination)
+}
+
+// Cylindrical coordinates of the same point
+func (s Spherical) Cylindrical() Cylindrical {
+	azimuth, inclination := s.angles()
+	sin, cos := math.Sincos(inclination)
+	c := s.Convention
+	return Cylindrical{Rho: s.R * sin, Azimuth: c.fromRadians(azimuth), Z: s.R * cos, Convention: c}
+}
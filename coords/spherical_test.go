@@ -0,0 +1,122 @@
+package coords
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestToSpherical(t *testing.T) {
+	v := vector.New(1, 1, float32(math.Sqrt2))
+	tests := []struct {
+		name string
+		c    Convention
+		want Spherical
+	}{
+		{"math", Convention{}, Spherical{R: 2, Theta: math.Pi / 4, Phi: math.Pi / 4}},
+		{"physics degrees", Convention{Unit: Degrees, Naming: Physics}, Spherical{R: 2, Theta: 45, Phi: 45}},
+		{"elevation", Convention{Polar: Elevation}, Spherical{R: 2, Theta: math.Pi / 4, Phi: math.Pi / 4}},
+	}
+	for _, tt := range tests {
+		tt.want.Convention = tt.c
+		if diff := cmp.Diff(tt.want, ToSpherical(v, tt.c), getComparer(1e-6)); diff != "" {
+			t.Errorf("%s ToSpherical() mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+	// a point below the XY plane in each convention
+	w := vector.New(0, 2, -2)
+	c := Convention{Unit: Degrees, Naming: Physics, Polar: Elevation}
+	want := Spherical{R: 2 * math.Sqrt2, Theta: -45, Phi: 90, Convention: c}
+	if diff := cmp.Diff(want, ToSpherical(w, c), getComparer(1e-5)); diff != "" {
+		t.Errorf("ToSpherical() mismatch (-want +got):\n%s", diff)
+	}
+	got := ToSpherical(w, Convention{Unit: Degrees})
+	if a, p := got.Azimuth(), got.PolarAngle(); math.Abs(a-90) > 1e-5 || math.Abs(p-135) > 1e-5 {
+		t.Errorf("Azimuth(), PolarAngle() = %v, %v, want 90, 135", a, p)
+	}
+	if diff := cmp.Diff(Spherical{}, ToSpherical(vector.New(0, 0, 0), Convention{})); diff != "" {
+		t.Errorf("ToSpherical() of the zero vector mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSphericalFromAngles(t *testing.T) {
+	// the default convention matches vector.FromAngles and Heading
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		theta, phi := float32(r.Float64()*2*math.Pi-math.Pi), float32(r.Float64()*math.Pi)
+		v := vector.FromAngles(theta, phi, 2)
+		s := ToSpherical(v, Convention{})
+		h1, h2 := v.Heading()
+		want := Spherical{R: 2, Theta: float64(h1), Phi: float64(h2)}
+		if diff := cmp.Diff(want, s, getComparer(1e-5)); diff != "" {
+			t.Fatalf("ToSpherical() mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(v, Spherical{R: 2, Theta: float64(theta), Phi: float64(phi)}.Vector(), getComparer(1e-6)); diff != "" {
+			t.Fatalf("Vector() mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestSphericalRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	vs := []*vector.Vector{
+		vector.New(0, 0, 1), vector.New(0, 0, -2), vector.New(1, 0, 0), vector.New(0, -3, 0),
+		vector.New(0, 0, 0), vector.New(4, 0, -4),
+	}
+	for i := 0; i < 1000; i++ {
+		vs = append(vs, vector.New(float32(r.NormFloat64()), float32(r.NormFloat64()), float32(r.NormFloat64())).Mult(float32(math.Exp(r.NormFloat64()*5))))
+	}
+	for _, c := range conventions {
+		for _, v := range vs {
+			s := ToSpherical(v, c)
+			if diff := cmp.Diff(v, s.Vector()); diff != "" {
+				t.Fatalf("round trip in %+v mismatch (-want +got):\n%s", c, diff)
+			}
+			for _, c2 := range conventions {
+				if diff := cmp.Diff(ToSpherical(v, c2), s.In(c2), getComparer(1e-12*math.Max(1, s.R))); diff != "" {
+					t.Fatalf("In(%+v) from %+v mismatch (-want +got):\n%s", c2, c, diff)
+				}
+			}
+			if diff := cmp.Diff(ToCylindrical(v, c), s.Cylindrical(), getComparer(1e-12*math.Max(1, s.R))); diff != "" {
+				t.Fatalf("Cylindrical() in %+v mismatch (-want +got):\n%s", c, diff)
+			}
+		}
+	}
+}
+
+// Checks a component against the precision promised in the package doc
+func roundTripped(want, got float32, length float64) bool {
+	diff := math.Abs(float64(got) - float64(want))
+	if math.Abs(float64(want)) >= 1e-8*length {
+		ulp := float64(math.Nextafter32(float32(math.Abs(float64(want))), float32(math.Inf(1)))) - math.Abs(float64(want))
+		return diff <= ulp
+	}
+	return diff <= 2e-15*length
+}
+
+func TestRoundTripPrecision(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 20000; i++ {
+		v := vector.New(float32(r.NormFloat64()), float32(r.NormFloat64()), float32(r.NormFloat64())).Mult(float32(math.Exp(r.NormFloat64() * 5)))
+		// components from the length down to far below the precision of the angles
+		v.X *= float32(math.Exp(-r.Float64() * 40))
+		v.Z *= float32(math.Exp(-r.Float64() * 40))
+		p := vector2d.New(v.X, v.Y)
+		for _, c := range conventions {
+			for _, got := range []*vector.Vector{ToSpherical(v, c).Vector(), ToCylindrical(v, c).Vector()} {
+				l := float64(v.Mag())
+				if !roundTripped(v.X, got.X, l) || !roundTripped(v.Y, got.Y, l) || !roundTripped(v.Z, got.Z, l) {
+					t.Fatalf("round trip of %v in %+v = %v", v, c, got)
+				}
+			}
+			got := ToPolar(p, c).Vector2D()
+			if l := float64(p.Mag()); !roundTripped(p.X, got.X, l) || !roundTripped(p.Y, got.Y, l) {
+				t.Fatalf("round trip of %v in %+v = %v", p, c, got)
+			}
+		}
+	}
+}
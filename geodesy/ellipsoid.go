@@ -0,0 +1,92 @@
+// Package geodesy converts between geodetic coordinates on the WGS84
+// ellipsoid, earth-centered earth-fixed (ECEF) positions and local east-north-up
+// and north-east-down frames, and computes distances, bearings and
+// destinations on the sphere and on the ellipsoid. Latitudes, longitudes and
+// bearings are in degrees, lengths in meters and vectors are double precision.
+// https://en.wikipedia.org/wiki/Geographic_coordinate_conversion
+package geodesy
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// Geodetic position: latitude and longitude in degrees, altitude in meters
+// above the ellipsoid
+type Geodetic struct {
+	Lat, Lon, Alt float64
+}
+
+// Reference ellipsoid of revolution around the Z axis
+type Ellipsoid struct {
+	// Equatorial radius
+	A float64
+	// Flattening (a - b) / a
+	F float64
+}
+
+// World Geodetic System 1984 ellipsoid, used by GPS
+var WGS84 = Ellipsoid{A: 6378137, F: 1 / 298.257223563}
+
+// Polar radius
+func (e Ellipsoid) B() float64 {
+	return e.A * (1 - e.F)
+}
+
+// Square of the first eccentricity
+func (e Ellipsoid) E2() float64 {
+	return e.F * (2 - e.F)
+}
+
+// ECEF position of a geodetic position
+func (e Ellipsoid) ToECEF(g Geodetic) *vector64.Vector {
+	sinLat, cosLat := math.Sincos(radians(g.Lat))
+	sinLon, cosLon := math.Sincos(radians(g.Lon))
+	e2 := e.E2()
+	// prime vertical radius of curvature
+	n := e.A / math.Sqrt(1-e2*sinLat*sinLat)
+	return vector64.New(
+		(n+g.Alt)*cosLat*cosLon,
+		(n+g.Alt)*cosLat*sinLon,
+		(n*(1-e2)+g.Alt)*sinLat,
+	)
+}
+
+// Geodetic position of an ECEF position, with the closed form of Heikkinen.
+// Positions near the center of the earth have no meaningful result.
+func (e Ellipsoid) FromECEF(v *vector64.Vector) Geodetic {
+	a, b, e2 := e.A, e.B(), e.E2()
+	ep2 := (a*a - b*b) / (b * b)
+	x, y, z := v.X, v.Y, v.Z
+	p := math.Hypot(x, y)
+	F := 54 * b * b * z * z
+	G := p*p + (1-e2)*z*z - e2*(a*a-b*b)
+	c := e2 * e2 * F * p * p / (G * G * G)
+	s := math.Cbrt(1 + c + math.Sqrt(c*c+2*c))
+	k := s + 1 + 1/s
+	P := F / (3 * k * k * G * G)
+	Q := math.Sqrt(1 + 2*e2*e2*P)
+	r0 := -P*e2*p/(1+Q) + math.Sqrt(math.Max(0, a*a/2*(1+1/Q)-P*(1-e2)*z*z/(Q*(1+Q))-P*p*p/2))
+	U := math.Hypot(p-e2*r0, z)
+	V := math.Sqrt((p-e2*r0)*(p-e2*r0) + (1-e2)*z*z)
+	z0 := b * b * z / (a * V)
+	return Geodetic{
+		Lat: degrees(math.Atan2(z+ep2*z0, p)),
+		Lon: degrees(math.Atan2(y, x)),
+		Alt: U * (1 - b*b/(a*V)),
+	}
+}
+
+// ECEF position of a geodetic position on WGS84
+func ToECEF(g Geodetic) *vector64.Vector {
+	return WGS84.ToECEF(g)
+}
+
+// Geodetic position on WGS84 of an ECEF position
+func FromECEF(v *vector64.Vector) Geodetic {
+	return WGS84.FromECEF(v)
+}
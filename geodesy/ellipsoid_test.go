@@ -0,0 +1,81 @@
+package geodesy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector64.Vector) bool {
+			return a == b || a != nil && b != nil && a.Equal(b, tolerance)
+		}),
+		cmp.Comparer(func(a, b float64) bool { return a == b || math.Abs(a-b) <= tolerance }),
+	}
+}
+
+func TestToECEF(t *testing.T) {
+	a, b := WGS84.A, WGS84.B()
+	tests := []struct {
+		g    Geodetic
+		want *vector64.Vector
+	}{
+		{Geodetic{0, 0, 0}, vector64.New(a, 0, 0)},
+		{Geodetic{0, 90, 100}, vector64.New(0, a+100, 0)},
+		{Geodetic{0, 180, -50}, vector64.New(-a+50, 0, 0)},
+		{Geodetic{90, 0, 0}, vector64.New(0, 0, b)},
+		{Geodetic{-90, 30, 1000}, vector64.New(0, 0, -b-1000)},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, ToECEF(tt.g), getComparer(1e-8)); diff != "" {
+			t.Errorf("ToECEF(%v) mismatch (-want +got):\n%s", tt.g, diff)
+		}
+	}
+	if b := WGS84.B(); math.Abs(b-6356752.314245) > 1e-6 {
+		t.Errorf("B() = %v, want 6356752.314245", b)
+	}
+}
+
+func TestFromECEF(t *testing.T) {
+	tests := []Geodetic{
+		{0, 0, 0},
+		{90, 0, 0},
+		{-90, 0, 10},
+		{45, 45, 0},
+		{-33.8688, 151.2093, 58},
+		{89.999, -179.5, 12000},
+		{1e-7, 1e-7, -400},
+		{12.5, -100, 35786000},
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		tests = append(tests, Geodetic{r.Float64()*180 - 90, r.Float64()*360 - 180, r.Float64()*2e4 - 1e3})
+	}
+	for _, g := range tests {
+		got := FromECEF(ToECEF(g))
+		// a nanodegree is about 0.1 mm
+		if math.Abs(got.Lat-g.Lat) > 1e-9 || math.Abs(got.Alt-g.Alt) > 1e-6 {
+			t.Errorf("FromECEF(ToECEF(%v)) = %v", g, got)
+		}
+		if math.Abs(g.Lat) < 90 && math.Abs(math.Remainder(got.Lon-g.Lon, 360)) > 1e-9 {
+			t.Errorf("FromECEF(ToECEF(%v)) = %v", g, got)
+		}
+	}
+}
+
+func TestSphereEllipsoid(t *testing.T) {
+	s := Ellipsoid{A: 1000}
+	g := Geodetic{30, 60, 10}
+	p := s.ToECEF(g)
+	want := vector64.FromAngles(math.Pi/3, math.Pi/3, 1010)
+	if diff := cmp.Diff(want, p, getComparer(1e-9)); diff != "" {
+		t.Errorf("ToECEF() on a sphere mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(g, s.FromECEF(p), getComparer(1e-9)); diff != "" {
+		t.Errorf("FromECEF() on a sphere mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,76 @@
+package geodesy
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Local tangent plane at a reference point, with axes east, north and up
+// along the ellipsoid normal. Positions are converted to east-north-up (ENU)
+// or north-east-down (NED) coordinates relative to the reference point.
+// https://en.wikipedia.org/wiki/Local_tangent_plane_coordinates
+type Frame struct {
+	Origin              Geodetic
+	Ellipsoid           Ellipsoid
+	origin              *vector64.Vector
+	east, north, normal *vector64.Vector
+}
+
+// Local frame at a reference point on WGS84
+func NewFrame(origin Geodetic) *Frame {
+	return WGS84.NewFrame(origin)
+}
+
+// Local frame at a reference point on the ellipsoid
+func (e Ellipsoid) NewFrame(origin Geodetic) *Frame {
+	sinLat, cosLat := math.Sincos(radians(origin.Lat))
+	sinLon, cosLon := math.Sincos(radians(origin.Lon))
+	return &Frame{
+		Origin:    origin,
+		Ellipsoid: e,
+		origin:    e.ToECEF(origin),
+		east:      vector64.New(-sinLon, cosLon, 0),
+		north:     vector64.New(-sinLat*cosLon, -sinLat*sinLon, cosLat),
+		normal:    vector64.New(cosLat*cosLon, cosLat*sinLon, sinLat),
+	}
+}
+
+// East-north-up coordinates of an ECEF position
+func (f *Frame) ToENU(ecef *vector64.Vector) *vector64.Vector {
+	d := vector64.Sub(ecef, f.origin)
+	return vector64.New(d.Dot(f.east), d.Dot(f.north), d.Dot(f.normal))
+}
+
+// ECEF position of east-north-up coordinates
+func (f *Frame) FromENU(enu *vector64.Vector) *vector64.Vector {
+	return f.fromLocal(enu.X, enu.Y, enu.Z)
+}
+
+// North-east-down coordinates of an ECEF position
+func (f *Frame) ToNED(ecef *vector64.Vector) *vector64.Vector {
+	enu := f.ToENU(ecef)
+	return vector64.New(enu.Y, enu.X, -enu.Z)
+}
+
+// ECEF position of north-east-down coordinates
+func (f *Frame) FromNED(ned *vector64.Vector) *vector64.Vector {
+	return f.fromLocal(ned.Y, ned.X, -ned.Z)
+}
+
+// East-north-up coordinates of a geodetic position
+func (f *Frame) GeodeticToENU(g Geodetic) *vector64.Vector {
+	return f.ToENU(f.Ellipsoid.ToECEF(g))
+}
+
+// Geodetic position of east-north-up coordinates
+func (f *Frame) ENUToGeodetic(enu *vector64.Vector) Geodetic {
+	return f.Ellipsoid.FromECEF(f.fromLocal(enu.X, enu.Y, enu.Z))
+}
+
+func (f *Frame) fromLocal(east, north, up float64) *vector64.Vector {
+	p := f.origin.Copy()
+	p.Add(f.east.Copy().Mult(east))
+	p.Add(f.north.Copy().Mult(north))
+	return p.Add(f.normal.Copy().Mult(up))
+}
@@ -0,0 +1,66 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+func TestFrameAxes(t *testing.T) {
+	f := NewFrame(Geodetic{0, 0, 0})
+	a := WGS84.A
+	tests := []struct {
+		name     string
+		ecef     *vector64.Vector
+		enu, ned *vector64.Vector
+	}{
+		{"origin", vector64.New(a, 0, 0), vector64.New(0, 0, 0), vector64.New(0, 0, 0)},
+		{"east", vector64.New(a, 3, 0), vector64.New(3, 0, 0), vector64.New(0, 3, 0)},
+		{"north", vector64.New(a, 0, 4), vector64.New(0, 4, 0), vector64.New(4, 0, 0)},
+		{"up", vector64.New(a+5, 0, 0), vector64.New(0, 0, 5), vector64.New(0, 0, -5)},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.enu, f.ToENU(tt.ecef), getComparer(1e-9)); diff != "" {
+			t.Errorf("%s ToENU() mismatch (-want +got):\n%s", tt.name, diff)
+		}
+		if diff := cmp.Diff(tt.ned, f.ToNED(tt.ecef), getComparer(1e-9)); diff != "" {
+			t.Errorf("%s ToNED() mismatch (-want +got):\n%s", tt.name, diff)
+		}
+		if diff := cmp.Diff(tt.ecef, f.FromENU(tt.enu), getComparer(1e-9)); diff != "" {
+			t.Errorf("%s FromENU() mismatch (-want +got):\n%s", tt.name, diff)
+		}
+		if diff := cmp.Diff(tt.ecef, f.FromNED(tt.ned), getComparer(1e-9)); diff != "" {
+			t.Errorf("%s FromNED() mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
+
+func TestFrameGeodetic(t *testing.T) {
+	origin := Geodetic{47.3769, 8.5417, 408}
+	f := NewFrame(origin)
+	// straight above the origin
+	if diff := cmp.Diff(vector64.New(0, 0, 120), f.GeodeticToENU(Geodetic{origin.Lat, origin.Lon, 528}), getComparer(1e-8)); diff != "" {
+		t.Errorf("GeodeticToENU() above the origin mismatch (-want +got):\n%s", diff)
+	}
+	// a point to the north east is ahead on both axes and below the plane
+	enu := f.GeodeticToENU(Geodetic{47.38, 8.55, 408})
+	if enu.X <= 0 || enu.Y <= 0 || enu.Z >= 0 {
+		t.Errorf("GeodeticToENU() to the north east = %v", enu)
+	}
+	// the horizontal distance is close to the distance on the sphere
+	if d, h := math.Hypot(enu.X, enu.Y), Haversine(origin, Geodetic{47.38, 8.55, 408}); math.Abs(d/h-1) > 5e-3 {
+		t.Errorf("horizontal distance %v, want about %v", d, h)
+	}
+	for _, p := range []*vector64.Vector{vector64.New(10, -20, 30), vector64.New(-5000, 12000, -300)} {
+		g := f.ENUToGeodetic(p)
+		if diff := cmp.Diff(p, f.GeodeticToENU(g), getComparer(1e-6)); diff != "" {
+			t.Errorf("GeodeticToENU(ENUToGeodetic()) mismatch (-want +got):\n%s", diff)
+		}
+		ned := vector64.New(p.Y, p.X, -p.Z)
+		if diff := cmp.Diff(f.FromENU(p), f.FromNED(ned), getComparer(1e-6)); diff != "" {
+			t.Errorf("FromNED() mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package geodesy
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Mean radius of the earth in meters, used by the spherical functions by
+// default
+const EarthRadius = 6371008.8
+
+func sphereRadius(radius []float64) float64 {
+	if len(radius) >= 1 {
+		return radius[0]
+	}
+	return EarthRadius
+}
+
+// Bearing in degrees wrapped to [0, 360)
+func wrapBearing(b float64) float64 {
+	b = math.Mod(b, 360)
+	if b < 0 {
+		b += 360
+	}
+	return b
+}
+
+// Angle at the center of the sphere between two positions, in radians
+func centralAngle(a, b Geodetic) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat, dLon := lat2-lat1, radians(b.Lon-a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * math.Atan2(math.Sqrt(h), math.Sqrt(math.Max(0, 1-h)))
+}
+
+// Great-circle distance between two positions on a sphere, with the haversine
+// formula. The radius defaults to EarthRadius; altitudes are ignored.
+// https://en.wikipedia.org/wiki/Haversine_formula
+func Haversine(a, b Geodetic, radius ...float64) float64 {
+	return centralAngle(a, b) * sphereRadius(radius)
+}
+
+// Initial bearing in degrees clockwise from north, in [0, 360), of the great
+// circle from a to b
+func InitialBearing(a, b Geodetic) float64 {
+	sinLat1, cosLat1 := math.Sincos(radians(a.Lat))
+	sinLat2, cosLat2 := math.Sincos(radians(b.Lat))
+	sinDLon, cosDLon := math.Sincos(radians(b.Lon - a.Lon))
+	y := sinDLon * cosLat2
+	x := cosLat1*sinLat2 - sinLat1*cosLat2*cosDLon
+	return wrapBearing(degrees(math.Atan2(y, x)))
+}
+
+// Bearing in degrees, in [0, 360), on arrival at b of the great circle from a
+func FinalBearing(a, b Geodetic) float64 {
+	return wrapBearing(InitialBearing(b, a) + 180)
+}
+
+// Position reached from start after a distance along the great circle of
+// initial bearing in degrees, with the longitude in [-180, 180]. The radius
+// defaults to EarthRadius; the altitude is kept.
+func Destination(start Geodetic, bearing, distance float64, radius ...float64) Geodetic {
+	d := distance / sphereRadius(radius)
+	sinLat, cosLat := math.Sincos(radians(start.Lat))
+	sinB, cosB := math.Sincos(radians(bearing))
+	sinD, cosD := math.Sincos(d)
+	lat := math.Asin(math.Max(-1, math.Min(1, sinLat*cosD+cosLat*sinD*cosB)))
+	lon := radians(start.Lon) + math.Atan2(sinB*sinD*cosLat, cosD-sinLat*math.Sin(lat))
+	return Geodetic{
+		Lat: degrees(lat),
+		Lon: math.Remainder(degrees(lon), 360),
+		Alt: start.Alt,
+	}
+}
+
+// Unit vector of a position on the sphere
+func nvector(g Geodetic) *vector64.Vector {
+	sinLat, cosLat := math.Sincos(radians(g.Lat))
+	sinLon, cosLon := math.Sincos(radians(g.Lon))
+	return vector64.New(cosLat*cosLon, cosLat*sinLon, sinLat)
+}
+
+// Position at fraction t of the great circle arc from a to b, t = 0 giving a
+// and t = 1 giving b. The altitude is interpolated linearly. The arc between
+// antipodal positions is undefined and a is returned.
+func Interpolate(a, b Geodetic, t float64) Geodetic {
+	d := centralAngle(a, b)
+	sinD := math.Sin(d)
+	if sinD < 1e-15 {
+		if d < 1 {
+			return Geodetic{a.Lat, a.Lon, a.Alt + (b.Alt-a.Alt)*t}
+		}
+		return a
+	}
+	na, nb := nvector(a), nvector(b)
+	n := na.Mult(math.Sin((1-t)*d) / sinD).Add(nb.Mult(math.Sin(t*d) / sinD))
+	return Geodetic{
+		Lat: degrees(math.Atan2(n.Z, math.Hypot(n.X, n.Y))),
+		Lon: degrees(math.Atan2(n.Y, n.X)),
+		Alt: a.Alt + (b.Alt-a.Alt)*t,
+	}
+}
@@ -0,0 +1,99 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHaversine(t *testing.T) {
+	tests := []struct {
+		a, b   Geodetic
+		radius []float64
+		want   float64
+	}{
+		{Geodetic{0, 0, 0}, Geodetic{0, 90, 0}, nil, EarthRadius * math.Pi / 2},
+		{Geodetic{0, 0, 0}, Geodetic{90, 0, 0}, []float64{1}, math.Pi / 2},
+		{Geodetic{45, 10, 0}, Geodetic{45, 10, 500}, nil, 0},
+		{Geodetic{0, 0, 0}, Geodetic{0, 180, 0}, []float64{2}, 2 * math.Pi},
+		{Geodetic{10, 170, 0}, Geodetic{10, -170, 0}, []float64{1}, 2 * math.Asin(math.Cos(math.Pi/18)*math.Sin(math.Pi/18))},
+	}
+	for _, tt := range tests {
+		if got := Haversine(tt.a, tt.b, tt.radius...); math.Abs(got-tt.want) > 1e-9*math.Max(1, tt.want) {
+			t.Errorf("Haversine(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		a, b           Geodetic
+		initial, final float64
+	}{
+		{Geodetic{0, 0, 0}, Geodetic{10, 0, 0}, 0, 0},
+		{Geodetic{0, 0, 0}, Geodetic{0, 10, 0}, 90, 90},
+		{Geodetic{0, 0, 0}, Geodetic{-10, 0, 0}, 180, 180},
+		{Geodetic{0, 10, 0}, Geodetic{0, 0, 0}, 270, 270},
+		// along a great circle the bearing turns
+		{Geodetic{45, 0, 0}, Geodetic{45, 90, 0}, 54.735610317245346, 125.26438968275465},
+	}
+	for _, tt := range tests {
+		if got := InitialBearing(tt.a, tt.b); math.Abs(got-tt.initial) > 1e-9 {
+			t.Errorf("InitialBearing(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.initial)
+		}
+		if got := FinalBearing(tt.a, tt.b); math.Abs(got-tt.final) > 1e-9 {
+			t.Errorf("FinalBearing(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.final)
+		}
+	}
+}
+
+func TestDestination(t *testing.T) {
+	quarter := EarthRadius * math.Pi / 2
+	tests := []struct {
+		start             Geodetic
+		bearing, distance float64
+		want              Geodetic
+	}{
+		{Geodetic{0, 0, 10}, 90, quarter, Geodetic{0, 90, 10}},
+		{Geodetic{0, 0, 0}, 0, quarter / 2, Geodetic{45, 0, 0}},
+		{Geodetic{0, 170, 0}, 90, quarter / 9, Geodetic{0, 180, 0}},
+		{Geodetic{0, 170, 0}, 90, quarter * 2 / 9, Geodetic{0, -170, 0}},
+		{Geodetic{30, 40, 0}, 123, 0, Geodetic{30, 40, 0}},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, Destination(tt.start, tt.bearing, tt.distance), getComparer(1e-9)); diff != "" {
+			t.Errorf("Destination(%v, %v, %v) mismatch (-want +got):\n%s", tt.start, tt.bearing, tt.distance, diff)
+		}
+	}
+	// going back along the bearing and distance between two positions
+	a, b := Geodetic{51.5, -0.12, 0}, Geodetic{40.7, -74, 0}
+	if diff := cmp.Diff(b, Destination(a, InitialBearing(a, b), Haversine(a, b)), getComparer(1e-9)); diff != "" {
+		t.Errorf("Destination() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	a, b := Geodetic{51.5, -0.12, 0}, Geodetic{40.7, -74, 1000}
+	d := Haversine(a, b)
+	for _, f := range []float64{0, 0.25, 0.5, 0.9, 1} {
+		p := Interpolate(a, b, f)
+		if got := Haversine(a, p); math.Abs(got-f*d) > 1e-6 {
+			t.Errorf("Interpolate(%v) at %v, want %v from the start", f, got, f*d)
+		}
+		if got := Haversine(p, b); math.Abs(got-(1-f)*d) > 1e-6 {
+			t.Errorf("Interpolate(%v) at %v, want %v from the end", f, got, (1-f)*d)
+		}
+		if math.Abs(p.Alt-1000*f) > 1e-9 {
+			t.Errorf("Interpolate(%v) altitude %v", f, p.Alt)
+		}
+	}
+	want := Geodetic{0, 45, 0}
+	if diff := cmp.Diff(want, Interpolate(Geodetic{0, 0, 0}, Geodetic{0, 90, 0}, 0.5), getComparer(1e-12)); diff != "" {
+		t.Errorf("Interpolate() mismatch (-want +got):\n%s", diff)
+	}
+	same := Geodetic{10, 20, 0}
+	if diff := cmp.Diff(same, Interpolate(same, same, 0.3)); diff != "" {
+		t.Errorf("Interpolate() of a point mismatch (-want +got):\n%s", diff)
+	}
+}
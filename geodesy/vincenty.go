@@ -0,0 +1,71 @@
+package geodesy
+
+import (
+	"math"
+)
+
+// Iterations of the longitude on the auxiliary sphere in Vincenty's formula
+const maxVincentyIterations = 200
+
+// Distance in meters along the geodesic between two positions on the WGS84
+// ellipsoid, with the initial and final bearings in degrees, see
+// Ellipsoid.Vincenty
+func Vincenty(a, b Geodetic) (distance, initialBearing, finalBearing float64, ok bool) {
+	return WGS84.Vincenty(a, b)
+}
+
+// Distance along the geodesic between two positions on the ellipsoid, with the
+// initial and final bearings in degrees in [0, 360), from Vincenty's inverse
+// formula, accurate to less than a millimeter. Altitudes are ignored. The
+// iteration does not converge for nearly antipodal positions and ok is false.
+// https://en.wikipedia.org/wiki/Vincenty%27s_formulae
+func (e Ellipsoid) Vincenty(a, b Geodetic) (distance, initialBearing, finalBearing float64, ok bool) {
+	f := e.F
+	semiMinor := e.B()
+	L := radians(math.Remainder(b.Lon-a.Lon, 360))
+	// reduced latitudes
+	sinU1, cosU1 := math.Sincos(math.Atan((1 - f) * math.Tan(radians(a.Lat))))
+	sinU2, cosU2 := math.Sincos(math.Atan((1 - f) * math.Tan(radians(b.Lat))))
+
+	lambda := L
+	var sinLambda, cosLambda, sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM float64
+	for i := 0; ; i++ {
+		if i == maxVincentyIterations {
+			return 0, 0, 0, false
+		}
+		sinLambda, cosLambda = math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// coincident positions
+			return 0, 0, 0, true
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		// on the equator the midpoint term vanishes
+		cos2SigmaM = 0
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		C := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		prev := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) <= 1e-12 {
+			break
+		}
+		if math.Abs(lambda) > math.Pi {
+			return 0, 0, 0, false
+		}
+	}
+
+	u2 := cos2Alpha * (e.A*e.A - semiMinor*semiMinor) / (semiMinor * semiMinor)
+	A := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	B := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	distance = semiMinor * A * (sigma - deltaSigma)
+	initialBearing = wrapBearing(degrees(math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)))
+	finalBearing = wrapBearing(degrees(math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda)))
+	return distance, initialBearing, finalBearing, true
+}
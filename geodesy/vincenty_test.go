@@ -0,0 +1,91 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+)
+
+func dms(d, m, s float64) float64 {
+	return math.Copysign(math.Abs(d)+m/60+s/3600, d)
+}
+
+func TestVincenty(t *testing.T) {
+	tests := []struct {
+		name                            string
+		a, b                            Geodetic
+		distance, initial, final, delta float64
+	}{
+		// Vincenty's Flinders Peak to Buninyong example (GRS80, which differs
+		// from WGS84 below a millimeter here)
+		{
+			"Flinders Peak",
+			Geodetic{dms(-37, 57, 3.72030), dms(144, 25, 29.52440), 0},
+			Geodetic{dms(-37, 39, 10.15610), dms(143, 55, 35.38390), 0},
+			54972.271, dms(306, 52, 5.37), dms(307, 10, 25.07), 1e-3,
+		},
+		{"equator", Geodetic{0, 0, 0}, Geodetic{0, 1, 0}, 111319.49079327357, 90, 90, 1e-6},
+		{"meridian quadrant", Geodetic{0, 0, 0}, Geodetic{90, 0, 0}, 10001965.729, 0, 0, 1e-3},
+		{"south", Geodetic{10, 5, 0}, Geodetic{-10, 5, 0}, 2211709.9, 180, 180, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, initial, final, ok := Vincenty(tt.a, tt.b)
+			if !ok {
+				t.Fatal("Vincenty() did not converge")
+			}
+			if math.Abs(d-tt.distance) > tt.delta {
+				t.Errorf("distance = %v, want %v", d, tt.distance)
+			}
+			if math.Abs(initial-tt.initial) > 1e-5 || math.Abs(final-tt.final) > 1e-5 {
+				t.Errorf("bearings = %v, %v, want %v, %v", initial, final, tt.initial, tt.final)
+			}
+		})
+	}
+}
+
+func TestVincentySphere(t *testing.T) {
+	// on a sphere the geodesic is the great circle
+	s := Ellipsoid{A: EarthRadius}
+	a, b := Geodetic{51.5, -0.12, 0}, Geodetic{40.7, -74, 0}
+	d, initial, final, ok := s.Vincenty(a, b)
+	if !ok {
+		t.Fatal("Vincenty() did not converge")
+	}
+	if h := Haversine(a, b); math.Abs(d-h) > 1e-6 {
+		t.Errorf("distance = %v, want %v", d, h)
+	}
+	if math.Abs(initial-InitialBearing(a, b)) > 1e-9 || math.Abs(final-FinalBearing(a, b)) > 1e-9 {
+		t.Errorf("bearings = %v, %v, want %v, %v", initial, final, InitialBearing(a, b), FinalBearing(a, b))
+	}
+}
+
+func TestVincentyAntimeridian(t *testing.T) {
+	// crossing the antimeridian is the same as the pair shifted in longitude
+	tests := [][4]Geodetic{
+		{{10, 170, 0}, {10, -170, 0}, {10, -10, 0}, {10, 10, 0}},
+		// Fiji to Samoa
+		{{-18, 178, 0}, {-14, -171, 0}, {-18, -2, 0}, {-14, 9, 0}},
+		{{-14, -171, 0}, {-18, 178, 0}, {-14, 9, 0}, {-18, -2, 0}},
+	}
+	for _, tt := range tests {
+		d, initial, final, ok := Vincenty(tt[0], tt[1])
+		if !ok {
+			t.Errorf("Vincenty(%v, %v) did not converge", tt[0], tt[1])
+			continue
+		}
+		wd, wi, wf, _ := Vincenty(tt[2], tt[3])
+		if math.Abs(d-wd) > 1e-6 || math.Abs(initial-wi) > 1e-9 || math.Abs(final-wf) > 1e-9 {
+			t.Errorf("Vincenty(%v, %v) = %v, %v, %v, want %v, %v, %v", tt[0], tt[1], d, initial, final, wd, wi, wf)
+		}
+	}
+}
+
+func TestVincentyLimits(t *testing.T) {
+	p := Geodetic{12, 34, 0}
+	if d, _, _, ok := Vincenty(p, p); !ok || d != 0 {
+		t.Errorf("Vincenty() of a point = %v, %v", d, ok)
+	}
+	if _, _, _, ok := Vincenty(Geodetic{0, 0, 0}, Geodetic{0.5, 179.7, 0}); ok {
+		t.Error("Vincenty() of nearly antipodal points converged")
+	}
+}
@@ -0,0 +1,157 @@
+package gjk
+
+import "math"
+
+type epaFace struct {
+	v    [3]int
+	n    p3
+	d    float64
+	dead bool
+}
+
+// Expanding polytope algorithm: grows a polytope inside A - B from the GJK
+// simplex towards the boundary point closest to the origin.
+// Returns the depth, the outward normal and the witness points.
+func epa(support minkowski, start []vertex) (depth float64, normal, pa, pb p3, ok bool) {
+	vs, ok := tetrahedron(support, start)
+	if !ok {
+		return 0, p3{}, p3{}, p3{}, false
+	}
+	var faces []*epaFace
+	addFace := func(i, j, k int) bool {
+		n := cross3(sub3(vs[j].w, vs[i].w), sub3(vs[k].w, vs[i].w))
+		l := math.Sqrt(dot3(n, n))
+		if l == 0 {
+			return false
+		}
+		n = scale3(n, 1/l)
+		faces = append(faces, &epaFace{v: [3]int{i, j, k}, n: n, d: dot3(n, vs[i].w)})
+		return true
+	}
+	addFace(0, 1, 2)
+	addFace(0, 3, 1)
+	addFace(1, 3, 2)
+	addFace(2, 3, 0)
+
+	for iter := 0; iter < maxIterations; iter++ {
+		best := closestFace(faces)
+		w := support(best.n)
+		if dot3(w.w, best.n)-best.d <= 1e-9*math.Max(1, best.d) {
+			break
+		}
+		vs = append(vs, w)
+		wi := len(vs) - 1
+		var edges [][2]int
+		visible := map[[2]int]bool{}
+		for _, f := range faces {
+			if dot3(f.n, sub3(w.w, vs[f.v[0]].w)) > 0 {
+				f.dead = true
+				for k := 0; k < 3; k++ {
+					e := [2]int{f.v[k], f.v[(k+1)%3]}
+					edges = append(edges, e)
+					visible[e] = true
+				}
+			}
+		}
+		alive := faces[:0]
+		for _, f := range faces {
+			if !f.dead {
+				alive = append(alive, f)
+			}
+		}
+		faces = alive
+		for _, e := range edges {
+			if !visible[[2]int{e[1], e[0]}] {
+				addFace(e[0], e[1], wi)
+			}
+		}
+		if len(faces) == 0 {
+			return 0, p3{}, p3{}, p3{}, false
+		}
+	}
+
+	// the origin projected on the closest face, in barycentric coordinates
+	best := closestFace(faces)
+	a, b, c := vs[best.v[0]], vs[best.v[1]], vs[best.v[2]]
+	l := barycentric(scale3(best.n, best.d), a.w, b.w, c.w)
+	for i, v := range []vertex{a, b, c} {
+		pa = add3(pa, scale3(v.a, l[i]))
+		pb = add3(pb, scale3(v.b, l[i]))
+	}
+	return best.d, best.n, pa, pb, true
+}
+
+func closestFace(faces []*epaFace) *epaFace {
+	best := faces[0]
+	for _, f := range faces[1:] {
+		if f.d < best.d {
+			best = f
+		}
+	}
+	return best
+}
+
+// Turns the GJK simplex into a tetrahedron holding the origin, adding
+// support points in directions away from its span. Vertices are ordered so
+// that the face (0, 1, 2) has the fourth vertex behind it.
+func tetrahedron(support minkowski, vs []vertex) ([]vertex, bool) {
+	vs = append([]vertex(nil), vs...)
+	axes := []p3{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}}
+	if len(vs) == 1 {
+		for _, d := range axes {
+			if w := support(d); dot3(sub3(w.w, vs[0].w), sub3(w.w, vs[0].w)) > 1e-12 {
+				vs = append(vs, w)
+				break
+			}
+		}
+	}
+	if len(vs) == 2 {
+		ab := sub3(vs[1].w, vs[0].w)
+		// directions around the segment
+		e := p3{1, 0, 0}
+		if math.Abs(ab[0]) > math.Abs(ab[1]) {
+			e = p3{0, 1, 0}
+		}
+		u := cross3(ab, e)
+		v := cross3(ab, u)
+		for k := 0; k < 6 && len(vs) == 2; k++ {
+			s, c := math.Sincos(float64(k) * math.Pi / 3)
+			w := support(add3(scale3(u, c), scale3(v, s)))
+			if n := cross3(ab, sub3(w.w, vs[0].w)); dot3(n, n) > 1e-12*dot3(ab, ab) {
+				vs = append(vs, w)
+			}
+		}
+	}
+	if len(vs) == 3 {
+		n := cross3(sub3(vs[1].w, vs[0].w), sub3(vs[2].w, vs[0].w))
+		for _, d := range []p3{n, neg3(n)} {
+			w := support(d)
+			if math.Abs(dot3(n, sub3(w.w, vs[0].w))) > 1e-9*math.Sqrt(dot3(n, n)) {
+				vs = append(vs, w)
+				break
+			}
+		}
+	}
+	if len(vs) < 4 {
+		return nil, false
+	}
+	n := cross3(sub3(vs[1].w, vs[0].w), sub3(vs[2].w, vs[0].w))
+	if dot3(n, sub3(vs[3].w, vs[0].w)) > 0 {
+		vs[1], vs[2] = vs[2], vs[1]
+	}
+	return vs, true
+}
+
+// Barycentric coordinates of p in the triangle abc
+func barycentric(p, a, b, c p3) [3]float64 {
+	v0, v1, v2 := sub3(b, a), sub3(c, a), sub3(p, a)
+	d00, d01, d11 := dot3(v0, v0), dot3(v0, v1), dot3(v1, v1)
+	d20, d21 := dot3(v2, v0), dot3(v2, v1)
+	den := d00*d11 - d01*d01
+	if den == 0 {
+		return [3]float64{1, 0, 0}
+	}
+	v := (d11*d20 - d01*d21) / den
+	w := (d00*d21 - d01*d20) / den
+	return [3]float64{1 - v - w, v, w}
+}
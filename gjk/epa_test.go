@@ -0,0 +1,79 @@
+package gjk
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestPenetration(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Support
+		depth  float32
+		normal *vector.Vector
+	}{
+		{"spheres", Sphere{vector.New(0, 0, 0), 1}, Sphere{vector.New(0, 1.5, 0), 1}, 0.5, vector.New(0, 1, 0)},
+		{"boxes", cube(vector.New(0, 0, 0), 1), Box{Center: vector.New(1.5, 0.2, 0), HalfExtents: vector.New(1, 2, 2)}, 0.5, vector.New(1, 0, 0)},
+		{"box sphere", cube(vector.New(0, 0, 0), 1), Sphere{vector.New(0, 0, -1.5), 0.75}, 0.25, vector.New(0, 0, -1)},
+		{"capsule box", Capsule{vector.New(-2, 0, 0.8), vector.New(2, 0, 0.8), 0.5}, cube(vector.New(0, 0, -0.5), 1), 0.2, vector.New(0, 0, -1)},
+		// touching centers: any axis is a minimum
+		{"same cube", cube(vector.New(0, 0, 0), 1), cube(vector.New(0, 0, 0), 1), 2, nil},
+	}
+	for _, tt := range tests {
+		depth, normal, pa, pb, ok := Penetration(tt.a, tt.b)
+		if !ok {
+			t.Errorf("%s: Penetration() not ok", tt.name)
+			continue
+		}
+		if math.Abs(float64(depth-tt.depth)) > 1e-4 {
+			t.Errorf("%s: depth = %v, want %v", tt.name, depth, tt.depth)
+		}
+		if tt.normal != nil && !cmp.Equal(normal, tt.normal, getComparer(1e-4)) {
+			t.Errorf("%s: normal = %v, want %v", tt.name, normal, tt.normal)
+		}
+		// pa - pb spans the penetration
+		if got := vector.Sub(pa, pb); !cmp.Equal(got, normal.Copy().Mult(depth), getComparer(1e-4)) {
+			t.Errorf("%s: pa - pb = %v, want %v", tt.name, got, normal.Copy().Mult(depth))
+		}
+	}
+	if _, _, _, _, ok := Penetration(Sphere{vector.New(0, 0, 0), 1}, Sphere{vector.New(3, 0, 0), 1}); ok {
+		t.Error("Penetration(apart) ok")
+	}
+	flat := Polytope{vector.New(0, 0, 0), vector.New(1, 0, 0), vector.New(0, 1, 0)}
+	if _, _, _, _, ok := Penetration(flat, flat); ok {
+		t.Error("Penetration(flat, flat) ok")
+	}
+}
+
+// Moving b out along the normal leaves the shapes just touching
+func TestPenetrationSeparates(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 200; i++ {
+		qa := quat.New(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()).Normalize()
+		qb := quat.New(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()).Normalize()
+		a := Box{Center: vector.New(0, 0, 0), HalfExtents: vector.New(1, 0.5, 0.7), Orientation: qa}
+		bc := vector.New(r.Float32()*2-1, r.Float32()*2-1, r.Float32()*2-1)
+		b := Box{Center: bc, HalfExtents: vector.New(0.6, 0.8, 0.4), Orientation: qb}
+		depth, normal, _, _, ok := Penetration(a, b)
+		if !ok {
+			if Intersect(a, b) {
+				t.Fatalf("Penetration(%v) not ok for intersecting boxes", bc)
+			}
+			continue
+		}
+		moved := b
+		moved.Center = vector.Add(bc, normal.Copy().Mult(depth+1e-3))
+		if Intersect(a, moved) {
+			t.Fatalf("boxes still intersect after moving by %v along %v", depth, normal)
+		}
+		moved.Center = vector.Add(bc, normal.Copy().Mult(depth-1e-2))
+		if depth > 2e-2 && !Intersect(a, moved) {
+			t.Fatalf("boxes separate before moving by %v along %v", depth, normal)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Package gjk provides distance, intersection and penetration queries between
+// convex shapes given by support functions, in 2D and 3D, with the
+// Gilbert–Johnson–Keerthi algorithm and the expanding polytope algorithm (EPA).
+// https://en.wikipedia.org/wiki/Gilbert%E2%80%93Johnson%E2%80%93Keerthi_distance_algorithm
+package gjk
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+const (
+	maxIterations = 64
+	// relative progress under which the search stops
+	tolerance = 1e-12
+)
+
+type p3 [3]float64
+
+func add3(a, b p3) p3           { return p3{a[0] + b[0], a[1] + b[1], a[2] + b[2]} }
+func sub3(a, b p3) p3           { return p3{a[0] - b[0], a[1] - b[1], a[2] - b[2]} }
+func scale3(a p3, s float64) p3 { return p3{a[0] * s, a[1] * s, a[2] * s} }
+func dot3(a, b p3) float64      { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+func cross3(a, b p3) p3 {
+	return p3{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+func neg3(a p3) p3                   { return p3{-a[0], -a[1], -a[2]} }
+func fromVector(v *vector.Vector) p3 { return p3{float64(v.X), float64(v.Y), float64(v.Z)} }
+func (a p3) vector() *vector.Vector  { return vector.New(float32(a[0]), float32(a[1]), float32(a[2])) }
+
+// Point of the Minkowski difference A - B with the points of A and B it comes from
+type vertex struct {
+	w, a, b p3
+}
+
+// Support function of the Minkowski difference
+type minkowski func(d p3) vertex
+
+func minkowski3(a, b Support) minkowski {
+	return func(d p3) vertex {
+		pa := fromVector(a.Support(d.vector()))
+		pb := fromVector(b.Support(neg3(d).vector()))
+		return vertex{w: sub3(pa, pb), a: pa, b: pb}
+	}
+}
+
+// Simplex with the barycentric coordinates of its point closest to the origin
+type simplex struct {
+	v      []vertex
+	lambda []float64
+}
+
+func (s *simplex) point() (w, a, b p3) {
+	for i, v := range s.v {
+		w = add3(w, scale3(v.w, s.lambda[i]))
+		a = add3(a, scale3(v.a, s.lambda[i]))
+		b = add3(b, scale3(v.b, s.lambda[i]))
+	}
+	return w, a, b
+}
+
+// Runs GJK on the Minkowski difference. Returns the final simplex, whose
+// closest point to the origin gives the distance, and whether the origin
+// is inside A - B.
+func run(support minkowski) (s simplex, intersect bool) {
+	s = simplex{v: []vertex{support(p3{1, 0, 0})}, lambda: []float64{1}}
+	v := s.v[0].w
+	for iter := 0; iter < maxIterations; iter++ {
+		vv := dot3(v, v)
+		if vv <= 1e-24 {
+			return s, true
+		}
+		w := support(neg3(v))
+		// no support point beyond the current closest point: converged
+		if vv-dot3(v, w.w) <= tolerance*vv {
+			return s, false
+		}
+		for _, u := range s.v {
+			if u.w == w.w {
+				return s, false
+			}
+		}
+		next := simplex{v: append(append([]vertex(nil), s.v...), w)}
+		next.closest()
+		if len(next.v) == 4 {
+			return next, true
+		}
+		nv, _, _ := next.point()
+		if dot3(nv, nv) >= vv {
+			// rounding keeps it from getting closer
+			return s, false
+		}
+		s, v = next, nv
+	}
+	return s, false
+}
+
+// Reduces the simplex to the smallest face holding its point closest to the
+// origin and sets the barycentric coordinates of that point.
+// https://realtimecollisiondetection.net/ (Ericson, chapter 5.1)
+func (s *simplex) closest() {
+	switch len(s.v) {
+	case 1:
+		s.lambda = []float64{1}
+	case 2:
+		s.v, s.lambda = closestSegment(s.v[0], s.v[1])
+	case 3:
+		s.v, s.lambda = closestTriangle(s.v[0], s.v[1], s.v[2])
+	case 4:
+		s.v, s.lambda = closestTetrahedron(s.v[0], s.v[1], s.v[2], s.v[3])
+	}
+}
+
+func closestSegment(a, b vertex) ([]vertex, []float64) {
+	ab := sub3(b.w, a.w)
+	den := dot3(ab, ab)
+	t := 0.0
+	if den > 0 {
+		t = -dot3(a.w, ab) / den
+	}
+	switch {
+	case t <= 0:
+		return []vertex{a}, []float64{1}
+	case t >= 1:
+		return []vertex{b}, []float64{1}
+	}
+	return []vertex{a, b}, []float64{1 - t, t}
+}
+
+func closestTriangle(a, b, c vertex) ([]vertex, []float64) {
+	ab, ac := sub3(b.w, a.w), sub3(c.w, a.w)
+	d1, d2 := -dot3(ab, a.w), -dot3(ac, a.w)
+	if d1 <= 0 && d2 <= 0 {
+		return []vertex{a}, []float64{1}
+	}
+	d3, d4 := -dot3(ab, b.w), -dot3(ac, b.w)
+	if d3 >= 0 && d4 <= d3 {
+		return []vertex{b}, []float64{1}
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		t := d1 / (d1 - d3)
+		return []vertex{a, b}, []float64{1 - t, t}
+	}
+	d5, d6 := -dot3(ab, c.w), -dot3(ac, c.w)
+	if d6 >= 0 && d5 <= d6 {
+		return []vertex{c}, []float64{1}
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		t := d2 / (d2 - d6)
+		return []vertex{a, c}, []float64{1 - t, t}
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		t := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		return []vertex{b, c}, []float64{1 - t, t}
+	}
+	den := va + vb + vc
+	if den <= 0 {
+		// degenerate triangle, fall back to its edges
+		return closestOf([][]vertex{{a, b}, {a, c}, {b, c}})
+	}
+	v, w := vb/den, vc/den
+	return []vertex{a, b, c}, []float64{1 - v - w, v, w}
+}
+
+func closestTetrahedron(a, b, c, d vertex) ([]vertex, []float64) {
+	faces := [][]vertex{{a, b, c}, {a, c, d}, {a, d, b}, {b, d, c}}
+	opposite := []vertex{d, b, c, a}
+	var outside [][]vertex
+	for i, f := range faces {
+		n := cross3(sub3(f[1].w, f[0].w), sub3(f[2].w, f[0].w))
+		sideO := -dot3(n, f[0].w)
+		sideD := dot3(n, sub3(opposite[i].w, f[0].w))
+		// a flat tetrahedron has no inside, every face counts
+		if sideO*sideD < 0 || math.Abs(sideD) <= 1e-12*dot3(n, n) {
+			outside = append(outside, f)
+		}
+	}
+	if len(outside) == 0 {
+		// the origin is inside, its coordinates are ratios of the volumes of
+		// the tetrahedra it makes with each face
+		o := p3{}
+		v := volume(a.w, b.w, c.w, d.w)
+		return []vertex{a, b, c, d}, []float64{
+			volume(o, b.w, c.w, d.w) / v,
+			volume(a.w, o, c.w, d.w) / v,
+			volume(a.w, b.w, o, d.w) / v,
+			volume(a.w, b.w, c.w, o) / v,
+		}
+	}
+	return closestOf(outside)
+}
+
+// Six times the signed volume of the tetrahedron abcd
+func volume(a, b, c, d p3) float64 {
+	return dot3(sub3(b, a), cross3(sub3(c, a), sub3(d, a)))
+}
+
+// Closest of the segments or triangles to the origin
+func closestOf(features [][]vertex) ([]vertex, []float64) {
+	var best []vertex
+	var bestL []float64
+	bestD := math.Inf(1)
+	for _, f := range features {
+		s := simplex{v: f}
+		s.closest()
+		p, _, _ := s.point()
+		if d := dot3(p, p); d < bestD {
+			best, bestL, bestD = s.v, s.lambda, d
+		}
+	}
+	return best, bestL
+}
+
+// Distance between two convex shapes with the closest points pa on a and pb on b.
+// Overlapping shapes give a zero distance and a point in both as pa and pb.
+func Distance(a, b Support) (dist float32, pa, pb *vector.Vector) {
+	s, intersect := run(minkowski3(a, b))
+	w, ca, cb := s.point()
+	if intersect {
+		return 0, ca.vector(), ca.vector()
+	}
+	return float32(math.Sqrt(dot3(w, w))), ca.vector(), cb.vector()
+}
+
+// Whether two convex shapes overlap
+func Intersect(a, b Support) bool {
+	_, intersect := run(minkowski3(a, b))
+	return intersect
+}
+
+// Penetration of two overlapping convex shapes: moving b by depth along the
+// unit normal (pointing from a into b) separates them, pa and pb are the
+// deepest points of a in b and of b in a.
+// ok is false if the shapes don't overlap or the overlap has no volume.
+func Penetration(a, b Support) (depth float32, normal, pa, pb *vector.Vector, ok bool) {
+	support := minkowski3(a, b)
+	s, intersect := run(support)
+	if !intersect {
+		return 0, nil, nil, nil, false
+	}
+	d, n, ca, cb, ok := epa(support, s.v)
+	if !ok {
+		return 0, nil, nil, nil, false
+	}
+	return float32(d), n.vector(), ca.vector(), cb.vector(), true
+}
@@ -0,0 +1,126 @@
+package gjk
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// 2D shapes run through the 3D algorithm in the plane z = 0
+func minkowski2(a, b Support2D) minkowski {
+	return func(d p3) vertex {
+		va := a.Support(vector2d.New(float32(d[0]), float32(d[1])))
+		vb := b.Support(vector2d.New(float32(-d[0]), float32(-d[1])))
+		pa, pb := p3{float64(va.X), float64(va.Y)}, p3{float64(vb.X), float64(vb.Y)}
+		return vertex{w: sub3(pa, pb), a: pa, b: pb}
+	}
+}
+
+func (a p3) vector2D() *vector2d.Vector2D { return vector2d.New(float32(a[0]), float32(a[1])) }
+
+// Distance between two convex 2D shapes with the closest points pa on a and pb on b.
+// Overlapping shapes give a zero distance and a point in both as pa and pb.
+func Distance2D(a, b Support2D) (dist float32, pa, pb *vector2d.Vector2D) {
+	s, intersect := run(minkowski2(a, b))
+	w, ca, cb := s.point()
+	// in the plane the origin is inside as soon as it is on the simplex
+	if intersect || dot3(w, w) <= 1e-24 {
+		return 0, ca.vector2D(), ca.vector2D()
+	}
+	return float32(math.Sqrt(dot3(w, w))), ca.vector2D(), cb.vector2D()
+}
+
+// Whether two convex 2D shapes overlap
+func Intersect2D(a, b Support2D) bool {
+	dist, _, _ := Distance2D(a, b)
+	return dist == 0
+}
+
+// Penetration of two overlapping convex 2D shapes: moving b by depth along
+// the unit normal (pointing from a into b) separates them, pa and pb are the
+// deepest points of a in b and of b in a.
+// ok is false if the shapes don't overlap or the overlap has no area.
+func Penetration2D(a, b Support2D) (depth float32, normal, pa, pb *vector2d.Vector2D, ok bool) {
+	support := minkowski2(a, b)
+	s, intersect := run(support)
+	if w, _, _ := s.point(); !intersect && dot3(w, w) > 1e-24 {
+		return 0, nil, nil, nil, false
+	}
+	d, n, ca, cb, ok := epa2D(support, s.v)
+	if !ok {
+		return 0, nil, nil, nil, false
+	}
+	return float32(d), n.vector2D(), ca.vector2D(), cb.vector2D(), true
+}
+
+// Expanding polygon: grows a counter clockwise polygon inside A - B from the
+// GJK simplex towards the boundary point closest to the origin.
+func epa2D(support minkowski, start []vertex) (depth float64, normal, pa, pb p3, ok bool) {
+	vs, ok := triangle(support, start)
+	if !ok {
+		return 0, p3{}, p3{}, p3{}, false
+	}
+	// outward normal and distance of the edge from vs[i] to vs[i+1]
+	edge := func(i int) (n p3, d float64) {
+		e := sub3(vs[(i+1)%len(vs)].w, vs[i].w)
+		n = p3{e[1], -e[0]}
+		n = scale3(n, 1/math.Sqrt(dot3(n, n)))
+		return n, dot3(n, vs[i].w)
+	}
+	closest := func() (int, p3, float64) {
+		bi, bd, bn := 0, math.Inf(1), p3{}
+		for i := range vs {
+			if n, d := edge(i); d < bd {
+				bi, bd, bn = i, d, n
+			}
+		}
+		return bi, bn, bd
+	}
+	for iter := 0; iter < maxIterations; iter++ {
+		i, n, d := closest()
+		w := support(n)
+		if dot3(w.w, n)-d <= 1e-9*math.Max(1, d) {
+			break
+		}
+		vs = append(vs[:i+1], append([]vertex{w}, vs[i+1:]...)...)
+	}
+	i, n, d := closest()
+	a, b := vs[i], vs[(i+1)%len(vs)]
+	// the origin projected on the edge
+	ab := sub3(b.w, a.w)
+	t := math.Max(0, math.Min(1, dot3(sub3(scale3(n, d), a.w), ab)/dot3(ab, ab)))
+	l := [2]float64{1 - t, t}
+	pa = add3(scale3(a.a, l[0]), scale3(b.a, l[1]))
+	pb = add3(scale3(a.b, l[0]), scale3(b.b, l[1]))
+	return d, n, pa, pb, true
+}
+
+// Turns the GJK simplex into a counter clockwise triangle holding the origin
+func triangle(support minkowski, vs []vertex) ([]vertex, bool) {
+	vs = append([]vertex(nil), vs...)
+	if len(vs) == 1 {
+		for _, d := range []p3{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			if w := support(d); dot3(sub3(w.w, vs[0].w), sub3(w.w, vs[0].w)) > 1e-12 {
+				vs = append(vs, w)
+				break
+			}
+		}
+	}
+	if len(vs) == 2 {
+		ab := sub3(vs[1].w, vs[0].w)
+		for _, d := range []p3{{-ab[1], ab[0]}, {ab[1], -ab[0]}} {
+			w := support(d)
+			if c := cross3(ab, sub3(w.w, vs[0].w)); math.Abs(c[2]) > 1e-12*dot3(ab, ab) {
+				vs = append(vs, w)
+				break
+			}
+		}
+	}
+	if len(vs) != 3 {
+		return nil, false
+	}
+	if cross3(sub3(vs[1].w, vs[0].w), sub3(vs[2].w, vs[0].w))[2] < 0 {
+		vs[1], vs[2] = vs[2], vs[1]
+	}
+	return vs, true
+}
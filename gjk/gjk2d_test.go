@@ -0,0 +1,85 @@
+package gjk
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func square(x, y, half float32) Polygon {
+	return Polygon{vector2d.New(x-half, y-half), vector2d.New(x+half, y-half), vector2d.New(x+half, y+half), vector2d.New(x-half, y+half)}
+}
+
+func TestDistance2D(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Support2D
+		dist   float32
+		pa, pb *vector2d.Vector2D
+	}{
+		{"circles", Circle{vector2d.New(0, 0), 1}, Circle{vector2d.New(3, 4), 1}, 3, vector2d.New(0.6, 0.8), vector2d.New(2.4, 3.2)},
+		{"squares", square(0, 0, 1), square(4, 0.5, 1), 2, nil, nil},
+		{"corner circle", square(0, 0, 1), Circle{vector2d.New(4, 5), 1}, 4, vector2d.New(1, 1), vector2d.New(3.4, 4.2)},
+		{"capsule box", Capsule2D{vector2d.New(-3, 2), vector2d.New(3, 2), 0.5}, Box2D{Center: vector2d.New(0, 0), HalfExtents: vector2d.New(1, 1), Angle: math.Pi / 4}, 1.5 - float32(math.Sqrt2), vector2d.New(0, 1.5), vector2d.New(0, float32(math.Sqrt2))},
+		{"overlap", square(0, 0, 1), Circle{vector2d.New(1.5, 0), 1}, 0, nil, nil},
+		{"touching inside", square(0, 0, 2), square(0, 0, 1), 0, nil, nil},
+	}
+	// float32 supports of curved shapes pin the closest points only to about
+	// the square root of their precision along the surface
+	opt := getComparer(1e-3)
+	for _, tt := range tests {
+		dist, pa, pb := Distance2D(tt.a, tt.b)
+		if math.Abs(float64(dist-tt.dist)) > 1e-4 {
+			t.Errorf("%s: Distance2D() = %v, want %v", tt.name, dist, tt.dist)
+		}
+		if tt.pa != nil && !cmp.Equal(pa, tt.pa, opt) {
+			t.Errorf("%s: pa = %v, want %v", tt.name, pa, tt.pa)
+		}
+		if tt.pb != nil && !cmp.Equal(pb, tt.pb, opt) {
+			t.Errorf("%s: pb = %v, want %v", tt.name, pb, tt.pb)
+		}
+		if got := Intersect2D(tt.a, tt.b); got != (tt.dist == 0) {
+			t.Errorf("%s: Intersect2D() = %v", tt.name, got)
+		}
+		if tt.dist == 0 {
+			// the common point is in both shapes
+			if d, _, _ := Distance2D(tt.a, Polygon{pa}); d > 1e-4 {
+				t.Errorf("%s: common point %v is %v away from a", tt.name, pa, d)
+			}
+			if d, _, _ := Distance2D(tt.b, Polygon{pa}); d > 1e-4 {
+				t.Errorf("%s: common point %v is %v away from b", tt.name, pa, d)
+			}
+		}
+	}
+}
+
+func TestPenetration2D(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Support2D
+		depth  float32
+		normal *vector2d.Vector2D
+	}{
+		{"circles", Circle{vector2d.New(0, 0), 1}, Circle{vector2d.New(-1.5, 0), 1}, 0.5, vector2d.New(-1, 0)},
+		{"squares", square(0, 0, 1), square(0.3, 1.8, 1), 0.2, vector2d.New(0, 1)},
+		{"box circle", Box2D{Center: vector2d.New(0, 0), HalfExtents: vector2d.New(2, 1)}, Circle{vector2d.New(0.5, -1.25), 0.5}, 0.25, vector2d.New(0, -1)},
+	}
+	for _, tt := range tests {
+		depth, normal, pa, pb, ok := Penetration2D(tt.a, tt.b)
+		if !ok {
+			t.Errorf("%s: Penetration2D() not ok", tt.name)
+			continue
+		}
+		if math.Abs(float64(depth-tt.depth)) > 1e-4 || !cmp.Equal(normal, tt.normal, getComparer(1e-4)) {
+			t.Errorf("%s: Penetration2D() = %v, %v, want %v, %v", tt.name, depth, normal, tt.depth, tt.normal)
+		}
+		if got := vector2d.Sub(pa, pb); !cmp.Equal(got, normal.Copy().Mult(depth), getComparer(1e-4)) {
+			t.Errorf("%s: pa - pb = %v, want %v", tt.name, got, normal.Copy().Mult(depth))
+		}
+	}
+	if _, _, _, _, ok := Penetration2D(square(0, 0, 1), square(3, 0, 1)); ok {
+		t.Error("Penetration2D(apart) ok")
+	}
+}
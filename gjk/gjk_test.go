@@ -0,0 +1,104 @@
+package gjk
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func cube(center *vector.Vector, half float32) Box {
+	return Box{Center: center, HalfExtents: vector.New(half, half, half)}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Support
+		dist   float32
+		pa, pb *vector.Vector
+	}{
+		{"spheres", Sphere{vector.New(0, 0, 0), 1}, Sphere{vector.New(3, 4, 0), 2}, 2, vector.New(0.6, 0.8, 0), vector.New(1.8, 2.4, 0)},
+		{"boxes", cube(vector.New(0, 0, 0), 1), cube(vector.New(0, 0, 5), 1), 3, nil, nil},
+		{"box corner sphere", cube(vector.New(0, 0, 0), 1), Sphere{vector.New(3, 3, 3), 1}, 2*float32(math.Sqrt(3)) - 1, vector.New(1, 1, 1), nil},
+		{"point box edge", Polytope{vector.New(2, 2, 0.5)}, cube(vector.New(0, 0, 0), 1), float32(math.Sqrt2), vector.New(2, 2, 0.5), vector.New(1, 1, 0.5)},
+		// skew segments one unit apart along z
+		{"capsules", Capsule{vector.New(-1, 0, 0), vector.New(1, 0, 0), 0.25}, Capsule{vector.New(0, -1, 1), vector.New(0, 1, 1), 0.25}, 0.5, vector.New(0, 0, 0.25), vector.New(0, 0, 0.75)},
+		{"rounded box", Rounded{cube(vector.New(0, 0, 0), 1), 0.5}, Polytope{vector.New(0, 3, 0)}, 1.5, vector.New(0, 1.5, 0), vector.New(0, 3, 0)},
+		{"overlap", cube(vector.New(0, 0, 0), 1), Sphere{vector.New(1, 0, 0), 1}, 0, nil, nil},
+		{"overlap off center", cube(vector.New(0, 0, 0), 0.5), Sphere{vector.New(1.5, 0.3, 0.2), 1}, 0, nil, nil},
+		{"overlapping boxes", cube(vector.New(0, 0, 0), 1), cube(vector.New(1.8, 0.5, 0), 1), 0, nil, nil},
+	}
+	opt := getComparer(1e-4)
+	for _, tt := range tests {
+		dist, pa, pb := Distance(tt.a, tt.b)
+		if math.Abs(float64(dist-tt.dist)) > 1e-4 {
+			t.Errorf("%s: Distance() = %v, want %v", tt.name, dist, tt.dist)
+		}
+		if tt.pa != nil && !cmp.Equal(pa, tt.pa, opt) {
+			t.Errorf("%s: pa = %v, want %v", tt.name, pa, tt.pa)
+		}
+		if tt.pb != nil && !cmp.Equal(pb, tt.pb, opt) {
+			t.Errorf("%s: pb = %v, want %v", tt.name, pb, tt.pb)
+		}
+		if got := vector.Dist(pa, pb); math.Abs(float64(got-dist)) > 1e-4 {
+			t.Errorf("%s: |pa - pb| = %v, want %v", tt.name, got, dist)
+		}
+		if got := Intersect(tt.a, tt.b); got != (tt.dist == 0) {
+			t.Errorf("%s: Intersect() = %v", tt.name, got)
+		}
+		if tt.dist == 0 {
+			// the common point is in both shapes
+			if d, _, _ := Distance(tt.a, Polytope{pa}); d > 1e-4 {
+				t.Errorf("%s: common point %v is %v away from a", tt.name, pa, d)
+			}
+			if d, _, _ := Distance(tt.b, Polytope{pa}); d > 1e-4 {
+				t.Errorf("%s: common point %v is %v away from b", tt.name, pa, d)
+			}
+		}
+	}
+}
+
+// Point against a turned box, checked in box space where it is a clamp
+func TestDistanceRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		q := quat.New(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()).Normalize()
+		half := vector.New(r.Float32()+0.1, r.Float32()+0.1, r.Float32()+0.1)
+		center := vector.New(r.Float32(), r.Float32(), r.Float32())
+		box := Box{Center: center, HalfExtents: half, Orientation: q}
+		p := vector.New(r.Float32()*6-3, r.Float32()*6-3, r.Float32()*6-3)
+		local := q.Conjugate().Rotate(vector.Sub(p, center))
+		clamped := vector.New(clamp(local.X, half.X), clamp(local.Y, half.Y), clamp(local.Z, half.Z))
+		want := vector.Dist(local, clamped)
+		dist, _, pb := Distance(Polytope{p}, box)
+		if math.Abs(float64(dist-want)) > 1e-4 {
+			t.Fatalf("Distance(%v, box) = %v, want %v", p, dist, want)
+		}
+		if want > 0 {
+			if wantP := q.Rotate(clamped).Add(center); !cmp.Equal(pb, wantP, getComparer(1e-4)) {
+				t.Fatalf("closest point = %v, want %v", pb, wantP)
+			}
+		}
+	}
+}
+
+func clamp(x, h float32) float32 {
+	return float32(math.Max(-float64(h), math.Min(float64(h), float64(x))))
+}
+
+func TestIntersectTransformed(t *testing.T) {
+	// a long thin box turned 45° reaches a sphere its axis aligned version misses
+	bar := Box{Center: vector.New(0, 0, 0), HalfExtents: vector.New(3, 0.1, 0.1)}
+	s := Sphere{vector.New(1.5, 1.5, 0), 0.5}
+	if Intersect(bar, s) {
+		t.Error("straight bar hits the sphere")
+	}
+	turned := Transformed{Shape: bar, Position: vector.New(0, 0, 0), Orientation: quat.FromAxisAngle(vector.New(0, 0, 1), math.Pi/4)}
+	if !Intersect(turned, s) {
+		t.Error("turned bar misses the sphere")
+	}
+}
@@ -0,0 +1,108 @@
+package gjk
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Convex shape described by its support function
+type Support interface {
+	// Point of the shape farthest along dir. dir is not normalized and may be zero.
+	Support(dir *vector.Vector) *vector.Vector
+}
+
+// Convex hull of a set of points
+type Polytope []*vector.Vector
+
+// Vertex with the largest projection on dir
+func (p Polytope) Support(dir *vector.Vector) *vector.Vector {
+	best, bestD := p[0], float32(math.Inf(-1))
+	for _, v := range p {
+		if d := v.Dot(dir); d > bestD {
+			best, bestD = v, d
+		}
+	}
+	return best.Copy()
+}
+
+// Ball
+type Sphere struct {
+	Center *vector.Vector
+	Radius float32
+}
+
+// Point of the sphere in direction dir
+func (s Sphere) Support(dir *vector.Vector) *vector.Vector {
+	return s.Center.Copy().Add(unitOrZero(dir).Mult(s.Radius))
+}
+
+// Segment AB swept by a sphere
+type Capsule struct {
+	A, B   *vector.Vector
+	Radius float32
+}
+
+// Point of the capsule in direction dir
+func (c Capsule) Support(dir *vector.Vector) *vector.Vector {
+	p := c.A
+	if c.B.Dot(dir) > c.A.Dot(dir) {
+		p = c.B
+	}
+	return p.Copy().Add(unitOrZero(dir).Mult(c.Radius))
+}
+
+// Oriented box
+type Box struct {
+	Center      *vector.Vector
+	HalfExtents *vector.Vector
+	// Rotation from box to world space, the zero value is the identity
+	Orientation quat.Quat
+}
+
+// Corner of the box farthest along dir
+func (b Box) Support(dir *vector.Vector) *vector.Vector {
+	d := b.Orientation.Conjugate().Rotate(dir)
+	c := vector.New(sign(d.X)*b.HalfExtents.X, sign(d.Y)*b.HalfExtents.Y, sign(d.Z)*b.HalfExtents.Z)
+	return b.Orientation.Rotate(c).Add(b.Center)
+}
+
+// Shape grown by a radius in every direction (Minkowski sum with a sphere),
+// e.g. a rounded box
+type Rounded struct {
+	Shape  Support
+	Radius float32
+}
+
+// Support of the inner shape pushed out by the radius
+func (r Rounded) Support(dir *vector.Vector) *vector.Vector {
+	return r.Shape.Support(dir).Add(unitOrZero(dir).Mult(r.Radius))
+}
+
+// Shape defined in its own space, placed in the world by a rotation then a translation
+type Transformed struct {
+	Shape       Support
+	Position    *vector.Vector
+	Orientation quat.Quat
+}
+
+// Support of the shape in world space
+func (t Transformed) Support(dir *vector.Vector) *vector.Vector {
+	local := t.Shape.Support(t.Orientation.Conjugate().Rotate(dir))
+	return t.Orientation.Rotate(local).Add(t.Position)
+}
+
+func unitOrZero(v *vector.Vector) *vector.Vector {
+	if v.MagSq() == 0 {
+		return vector.New(0, 0, 0)
+	}
+	return vector.Unit(v)
+}
+
+func sign(x float32) float32 {
+	if x < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,86 @@
+package gjk
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Convex 2D shape described by its support function
+type Support2D interface {
+	// Point of the shape farthest along dir. dir is not normalized and may be zero.
+	Support(dir *vector2d.Vector2D) *vector2d.Vector2D
+}
+
+// Convex hull of a set of 2D points
+type Polygon []*vector2d.Vector2D
+
+// Vertex with the largest projection on dir
+func (p Polygon) Support(dir *vector2d.Vector2D) *vector2d.Vector2D {
+	best, bestD := p[0], float32(math.Inf(-1))
+	for _, v := range p {
+		if d := v.Dot(dir); d > bestD {
+			best, bestD = v, d
+		}
+	}
+	return best.Copy()
+}
+
+// Disc
+type Circle struct {
+	Center *vector2d.Vector2D
+	Radius float32
+}
+
+// Point of the circle in direction dir
+func (c Circle) Support(dir *vector2d.Vector2D) *vector2d.Vector2D {
+	return c.Center.Copy().Add(unitOrZero2D(dir).Mult(c.Radius))
+}
+
+// Segment AB swept by a circle
+type Capsule2D struct {
+	A, B   *vector2d.Vector2D
+	Radius float32
+}
+
+// Point of the capsule in direction dir
+func (c Capsule2D) Support(dir *vector2d.Vector2D) *vector2d.Vector2D {
+	p := c.A
+	if c.B.Dot(dir) > c.A.Dot(dir) {
+		p = c.B
+	}
+	return p.Copy().Add(unitOrZero2D(dir).Mult(c.Radius))
+}
+
+// Oriented rectangle
+type Box2D struct {
+	Center      *vector2d.Vector2D
+	HalfExtents *vector2d.Vector2D
+	// Counter clockwise rotation in radians
+	Angle float32
+}
+
+// Corner of the box farthest along dir
+func (b Box2D) Support(dir *vector2d.Vector2D) *vector2d.Vector2D {
+	d := dir.Copy().Rotate(-b.Angle)
+	c := vector2d.New(sign(d.X)*b.HalfExtents.X, sign(d.Y)*b.HalfExtents.Y)
+	return c.Rotate(b.Angle).Add(b.Center)
+}
+
+// 2D shape grown by a radius in every direction, e.g. a rounded rectangle
+type Rounded2D struct {
+	Shape  Support2D
+	Radius float32
+}
+
+// Support of the inner shape pushed out by the radius
+func (r Rounded2D) Support(dir *vector2d.Vector2D) *vector2d.Vector2D {
+	return r.Shape.Support(dir).Add(unitOrZero2D(dir).Mult(r.Radius))
+}
+
+func unitOrZero2D(v *vector2d.Vector2D) *vector2d.Vector2D {
+	if v.MagSq() == 0 {
+		return vector2d.New(0, 0)
+	}
+	return vector2d.Unit(v)
+}
@@ -0,0 +1,62 @@
+package gjk
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, tolerance) }),
+	}
+}
+
+func TestSupport(t *testing.T) {
+	box := Box{Center: vector.New(1, 0, 0), HalfExtents: vector.New(1, 2, 3)}
+	tests := []struct {
+		name  string
+		shape Support
+		dir   *vector.Vector
+		want  *vector.Vector
+	}{
+		{"polytope", Polytope{vector.New(0, 0, 0), vector.New(1, 1, 0), vector.New(2, -1, 0)}, vector.New(0, 1, 0), vector.New(1, 1, 0)},
+		{"sphere", Sphere{vector.New(1, 2, 3), 2}, vector.New(0, 0, -5), vector.New(1, 2, 1)},
+		{"sphere zero dir", Sphere{vector.New(1, 2, 3), 2}, vector.New(0, 0, 0), vector.New(1, 2, 3)},
+		{"capsule", Capsule{vector.New(0, 0, 0), vector.New(0, 4, 0), 1}, vector.New(1, 1, 0), vector.New(math.Sqrt2/2, 4+math.Sqrt2/2, 0)},
+		{"box", box, vector.New(-1, 1, -1), vector.New(0, 2, -3)},
+		{"turned box", Box{Center: vector.New(0, 0, 0), HalfExtents: vector.New(1, 2, 3), Orientation: quat.FromAxisAngle(vector.New(0, 0, 1), math.Pi/2)}, vector.New(1, 0.1, 0.1), vector.New(2, 1, 3)},
+		{"rounded", Rounded{box, 0.5}, vector.New(0, 0, 1), vector.New(2, 2, 3.5)},
+		{"transformed", Transformed{Shape: box, Position: vector.New(0, 10, 0), Orientation: quat.FromAxisAngle(vector.New(0, 1, 0), math.Pi)}, vector.New(1, 1, 1), vector.New(0, 12, 3)},
+	}
+	for _, tt := range tests {
+		if got := tt.shape.Support(tt.dir); !cmp.Equal(got, tt.want, getComparer(1e-5)) {
+			t.Errorf("%s: Support(%v) = %v, want %v", tt.name, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestSupport2D(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape Support2D
+		dir   *vector2d.Vector2D
+		want  *vector2d.Vector2D
+	}{
+		{"polygon", Polygon{vector2d.New(0, 0), vector2d.New(1, 1), vector2d.New(2, -1)}, vector2d.New(1, 0), vector2d.New(2, -1)},
+		{"circle", Circle{vector2d.New(1, 2), 2}, vector2d.New(0, 3), vector2d.New(1, 4)},
+		{"capsule", Capsule2D{vector2d.New(0, 0), vector2d.New(4, 0), 1}, vector2d.New(-1, 0), vector2d.New(-1, 0)},
+		{"box", Box2D{Center: vector2d.New(0, 0), HalfExtents: vector2d.New(2, 1), Angle: math.Pi / 2}, vector2d.New(1, 1), vector2d.New(1, 2)},
+		{"rounded", Rounded2D{Polygon{vector2d.New(0, 0)}, 3}, vector2d.New(0, -2), vector2d.New(0, -3)},
+	}
+	for _, tt := range tests {
+		if got := tt.shape.Support(tt.dir); !cmp.Equal(got, tt.want, getComparer(1e-5)) {
+			t.Errorf("%s: Support(%v) = %v, want %v", tt.name, tt.dir, got, tt.want)
+		}
+	}
+}
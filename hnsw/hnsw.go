@@ -0,0 +1,507 @@
+// Package hnsw provides an approximate nearest neighbor index over n-dimensional
+// vectors using Hierarchical Navigable Small World graphs.
+// https://arxiv.org/abs/1603.09320
+package hnsw
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/vaibhav11s/gopkgs/vectorn"
+)
+
+var (
+	// Returned when a vector does not match the dimension of the index
+	ErrDimension = errors.New("hnsw: vector dimension does not match the index")
+	// Returned when inserting an id that is already in the index
+	ErrDuplicateID = errors.New("hnsw: id already in the index")
+)
+
+// Parameters of an index. Zero fields take their default value.
+type Config struct {
+	// Distance function (default Euclidean)
+	Metric Metric
+	// Number of links per node on the upper layers (default 16), layer 0 keeps 2*M
+	M int
+	// Size of the candidate list while building (default 200)
+	EfConstruction int
+	// Minimum size of the candidate list while searching (default 50)
+	EfSearch int
+	// Seed of the level generator, the same seed and inserts give the same
+	// graph, also when the index is saved and read back in between
+	Seed int64
+}
+
+func (c Config) withDefaults() Config {
+	if c.M <= 1 {
+		c.M = 16
+	}
+	if c.EfConstruction <= 0 {
+		c.EfConstruction = 200
+	}
+	if c.EfSearch <= 0 {
+		c.EfSearch = 50
+	}
+	return c
+}
+
+// Search result
+type Result struct {
+	ID       int
+	Distance float32
+}
+
+type node struct {
+	id  int
+	vec vectorn.VecN
+	// links[l] holds the neighbors on layer l
+	links [][]int32
+	// in[l] holds the nodes linking to this one on layer l
+	in []map[int32]bool
+}
+
+func newNode(id int, vec vectorn.VecN, levels int) *node {
+	n := &node{id: id, vec: vec, links: make([][]int32, levels), in: make([]map[int32]bool, levels)}
+	for l := range n.in {
+		n.in[l] = map[int32]bool{}
+	}
+	return n
+}
+
+// Replaces the links of node i on layer l, keeping the reverse links in step.
+// Returns the nodes no longer linked.
+func (ix *Index) setLinks(i int32, l int, links []int32) (dropped []int32) {
+	n := ix.nodes[i]
+	for _, j := range n.links[l] {
+		delete(ix.nodes[j].in[l], i)
+	}
+	for _, j := range links {
+		ix.nodes[j].in[l][i] = true
+	}
+	for _, j := range n.links[l] {
+		if !ix.nodes[j].in[l][i] {
+			dropped = append(dropped, j)
+		}
+	}
+	n.links[l] = links
+	return dropped
+}
+
+// Approximate nearest neighbor index.
+// Not safe for concurrent use; guard writes with a lock if needed.
+type Index struct {
+	dim   int
+	cfg   Config
+	nodes []*node
+	// free slots in nodes left by deletions
+	free  []int32
+	ids   map[int]int32
+	entry int32
+	// highest layer of the graph, -1 while empty
+	maxLevel int
+	levelMul float64
+	rng      splitMix
+}
+
+// Generator of the levels, SplitMix64, whose state is a single number saved
+// with the index so that a loaded index continues the same sequence.
+// https://prng.di.unimi.it/splitmix64.c
+type splitMix uint64
+
+// Uniform number in [0, 1)
+func (s *splitMix) float64() float64 {
+	*s += 0x9e3779b97f4a7c15
+	z := uint64(*s)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return float64(z>>11) / (1 << 53)
+}
+
+// Creates an empty index for vectors of the given dimension
+func New(dim int, cfg Config) *Index {
+	cfg = cfg.withDefaults()
+	if !cfg.Metric.valid() {
+		panic(fmt.Sprintf("hnsw: unknown metric %v", cfg.Metric))
+	}
+	return &Index{
+		dim:      dim,
+		cfg:      cfg,
+		ids:      map[int]int32{},
+		entry:    -1,
+		maxLevel: -1,
+		levelMul: 1 / math.Log(float64(cfg.M)),
+		rng:      splitMix(cfg.Seed),
+	}
+}
+
+// Number of vectors in the index
+func (ix *Index) Len() int {
+	return len(ix.ids)
+}
+
+// Dimension of the indexed vectors
+func (ix *Index) Dim() int {
+	return ix.dim
+}
+
+// Metric used by the index
+func (ix *Index) Metric() Metric {
+	return ix.cfg.Metric
+}
+
+// Gets a copy of the stored vector for id.
+// For the Cosine metric this is the normalized vector.
+func (ix *Index) Get(id int) (vectorn.VecN, bool) {
+	i, ok := ix.ids[id]
+	if !ok {
+		return nil, false
+	}
+	return ix.nodes[i].vec.Copy(), true
+}
+
+func (ix *Index) prepare(v vectorn.VecN) (vectorn.VecN, error) {
+	if v.Dim() != ix.dim {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrDimension, v.Dim(), ix.dim)
+	}
+	v = v.Copy()
+	if ix.cfg.Metric == Cosine {
+		v.Normalize()
+	}
+	return v, nil
+}
+
+func (ix *Index) maxLinks(level int) int {
+	if level == 0 {
+		return 2 * ix.cfg.M
+	}
+	return ix.cfg.M
+}
+
+func (ix *Index) dist(v vectorn.VecN, i int32) float32 {
+	return ix.cfg.Metric.distance(v, ix.nodes[i].vec)
+}
+
+// Adds a vector under the given id
+func (ix *Index) Insert(id int, v vectorn.VecN) error {
+	if _, ok := ix.ids[id]; ok {
+		return fmt.Errorf("%w: %d", ErrDuplicateID, id)
+	}
+	v, err := ix.prepare(v)
+	if err != nil {
+		return err
+	}
+	level := int(-math.Log(1-ix.rng.float64()) * ix.levelMul)
+	n := newNode(id, v, level+1)
+	var idx int32
+	if len(ix.free) > 0 {
+		idx = ix.free[len(ix.free)-1]
+		ix.free = ix.free[:len(ix.free)-1]
+		ix.nodes[idx] = n
+	} else {
+		idx = int32(len(ix.nodes))
+		ix.nodes = append(ix.nodes, n)
+	}
+	ix.ids[id] = idx
+
+	if ix.entry < 0 {
+		ix.entry, ix.maxLevel = idx, level
+		return nil
+	}
+
+	ep := []candidate{{ix.dist(v, ix.entry), ix.entry}}
+	for l := ix.maxLevel; l > level; l-- {
+		ep = ix.searchLayer(v, ep, 1, l)
+	}
+	for l := min(level, ix.maxLevel); l >= 0; l-- {
+		found := ix.searchLayer(v, ep, ix.cfg.EfConstruction, l)
+		neighbors := ix.selectNeighbors(found, ix.cfg.M)
+		ix.setLinks(idx, l, ids(neighbors))
+		for _, nb := range neighbors {
+			ix.link(nb.idx, idx, l)
+		}
+		ep = found
+	}
+	if level > ix.maxLevel {
+		ix.entry, ix.maxLevel = idx, level
+	}
+	return nil
+}
+
+// Adds a link from a to b on layer l, pruning a's links if there are too many.
+// Returns the nodes pruned.
+func (ix *Index) link(a, b int32, l int) []int32 {
+	na := ix.nodes[a]
+	links := append(na.links[l], b)
+	if len(links) > ix.maxLinks(l) {
+		cands := make([]candidate, len(links))
+		for i, j := range links {
+			cands[i] = candidate{ix.dist(na.vec, j), j}
+		}
+		sortCandidates(cands)
+		links = ids(ix.selectNeighbors(cands, ix.maxLinks(l)))
+	}
+	return ix.setLinks(a, l, links)
+}
+
+// Picks up to m neighbors from candidates sorted by distance, preferring
+// candidates that are closer to the base than to any neighbor already picked
+// (the heuristic of the HNSW paper). Fills up with the closest skipped ones.
+func (ix *Index) selectNeighbors(cands []candidate, m int) []candidate {
+	if len(cands) <= m {
+		return cands
+	}
+	picked := make([]candidate, 0, m)
+	var skipped []candidate
+	for _, c := range cands {
+		if len(picked) >= m {
+			break
+		}
+		good := true
+		for _, p := range picked {
+			if ix.cfg.Metric.distance(ix.nodes[c.idx].vec, ix.nodes[p.idx].vec) < c.dist {
+				good = false
+				break
+			}
+		}
+		if good {
+			picked = append(picked, c)
+		} else {
+			skipped = append(skipped, c)
+		}
+	}
+	for _, c := range skipped {
+		if len(picked) >= m {
+			break
+		}
+		picked = append(picked, c)
+	}
+	return picked
+}
+
+// Greedy beam search on one layer. Returns up to ef candidates sorted by distance.
+func (ix *Index) searchLayer(q vectorn.VecN, entry []candidate, ef, l int) []candidate {
+	visited := make(map[int32]bool, ef*4)
+	cands := &minQueue{}
+	results := &maxQueue{}
+	for _, e := range entry {
+		visited[e.idx] = true
+		cands.push(e)
+		results.push(e)
+	}
+	for results.Len() > ef {
+		results.pop()
+	}
+	for cands.Len() > 0 {
+		c := cands.pop()
+		if results.Len() >= ef && c.dist > results.top().dist {
+			break
+		}
+		for _, nb := range ix.nodes[c.idx].links[l] {
+			if visited[nb] {
+				continue
+			}
+			visited[nb] = true
+			d := ix.dist(q, nb)
+			if results.Len() < ef || d < results.top().dist {
+				cands.push(candidate{d, nb})
+				results.push(candidate{d, nb})
+				if results.Len() > ef {
+					results.pop()
+				}
+			}
+		}
+	}
+	out := make([]candidate, results.Len())
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = results.pop()
+	}
+	return out
+}
+
+// Finds the k nearest vectors to q, closest first.
+// optional value overrides the search beam width (default Config.EfSearch);
+// larger values are slower but more accurate.
+func (ix *Index) Search(q vectorn.VecN, k int, ef ...int) ([]Result, error) {
+	q, err := ix.prepare(q)
+	if err != nil {
+		return nil, err
+	}
+	if ix.entry < 0 || k <= 0 {
+		return nil, nil
+	}
+	width := ix.cfg.EfSearch
+	if len(ef) >= 1 && ef[0] > 0 {
+		width = ef[0]
+	}
+	if width < k {
+		width = k
+	}
+	ep := []candidate{{ix.dist(q, ix.entry), ix.entry}}
+	for l := ix.maxLevel; l > 0; l-- {
+		ep = ix.searchLayer(q, ep, 1, l)
+	}
+	found := ix.searchLayer(q, ep, width, 0)
+	if len(found) > k {
+		found = found[:k]
+	}
+	res := make([]Result, len(found))
+	for i, c := range found {
+		res[i] = Result{ix.nodes[c.idx].id, ix.cfg.Metric.report(c.dist)}
+	}
+	return res, nil
+}
+
+// Removes the vector with the given id and repairs the links around it, so
+// that the nodes it linked to stay linked to. Only the neighborhood of the
+// node is visited, but for deleting the entry point which scans the index.
+// Returns false if the id is not in the index.
+func (ix *Index) Delete(id int) bool {
+	idx, ok := ix.ids[id]
+	if !ok {
+		return false
+	}
+	del := ix.nodes[idx]
+	delete(ix.ids, id)
+
+	for l := range del.links {
+		out := del.links[l]
+		ix.setLinks(idx, l, nil)
+		// nodes that lost an in-link, checked below
+		lost := append([]int32(nil), out...)
+		// every node still pointing at the deleted one gets new links chosen
+		// among its other links and the links of the deleted node, in index
+		// order so that the same operations give the same graph
+		in := make([]int32, 0, len(del.in[l]))
+		for i := range del.in[l] {
+			in = append(in, i)
+		}
+		sort.Slice(in, func(a, b int) bool { return in[a] < in[b] })
+		for _, i := range in {
+			n := ix.nodes[i]
+			pool := map[int32]bool{}
+			for _, j := range n.links[l] {
+				pool[j] = true
+			}
+			for _, j := range out {
+				pool[j] = true
+			}
+			delete(pool, idx)
+			delete(pool, i)
+			lost = append(lost, ix.setLinks(i, l, ix.closest(n.vec, pool, ix.maxLinks(l)))...)
+		}
+		// nodes left without in-links get linked from the closest node around
+		// them, which may prune another node in turn
+		for steps := 0; len(lost) > 0 && steps < len(ix.nodes); steps++ {
+			j := lost[0]
+			lost = lost[1:]
+			if j == idx || j == ix.entry || len(ix.nodes[j].in[l]) > 0 {
+				continue
+			}
+			pool := map[int32]bool{}
+			for _, k := range out {
+				pool[k] = true
+			}
+			for _, k := range in {
+				pool[k] = true
+			}
+			for _, k := range ix.nodes[j].links[l] {
+				pool[k] = true
+			}
+			delete(pool, idx)
+			delete(pool, j)
+			lost = append(lost, ix.relink(j, l, pool)...)
+		}
+	}
+	ix.nodes[idx] = nil
+	ix.free = append(ix.free, idx)
+
+	if idx == ix.entry {
+		ix.entry, ix.maxLevel = -1, -1
+		for i, n := range ix.nodes {
+			if n != nil && len(n.links)-1 > ix.maxLevel {
+				ix.entry, ix.maxLevel = int32(i), len(n.links)-1
+			}
+		}
+	}
+	return true
+}
+
+// Picks up to m neighbors of v among the pool
+func (ix *Index) closest(v vectorn.VecN, pool map[int32]bool, m int) []int32 {
+	return ids(ix.selectNeighbors(ix.byDistance(v, pool), m))
+}
+
+// Candidates of the pool sorted by distance to v
+func (ix *Index) byDistance(v vectorn.VecN, pool map[int32]bool) []candidate {
+	cands := make([]candidate, 0, len(pool))
+	for j := range pool {
+		cands = append(cands, candidate{ix.dist(v, j), j})
+	}
+	sortCandidates(cands)
+	return cands
+}
+
+// Links a node left without in-links on layer l from the closest node of the
+// pool, preferring nodes with room for a link so that no other link is
+// pruned. Returns the nodes pruned.
+func (ix *Index) relink(j int32, l int, pool map[int32]bool) []int32 {
+	cands := ix.byDistance(ix.nodes[j].vec, pool)
+	for _, c := range cands {
+		if len(ix.nodes[c.idx].links[l]) < ix.maxLinks(l) {
+			return ix.link(c.idx, j, l)
+		}
+	}
+	for _, c := range cands {
+		if dropped := ix.link(c.idx, j, l); len(ix.nodes[j].in[l]) > 0 {
+			return dropped
+		}
+	}
+	// the heuristic prunes the node everywhere: trade the farthest link of
+	// the closest node for it, to a node that other nodes still link to
+	for _, c := range cands {
+		links := ix.nodes[c.idx].links[l]
+		far, farD := -1, float32(-1)
+		for i, t := range links {
+			if len(ix.nodes[t].in[l]) < 2 {
+				continue
+			}
+			if d := ix.dist(ix.nodes[c.idx].vec, t); d > farD {
+				far, farD = i, d
+			}
+		}
+		if far >= 0 {
+			next := append([]int32(nil), links...)
+			next[far] = j
+			return ix.setLinks(c.idx, l, next)
+		}
+	}
+	return nil
+}
+
+func ids(cands []candidate) []int32 {
+	out := make([]int32, len(cands))
+	for i, c := range cands {
+		out[i] = c.idx
+	}
+	return out
+}
+
+// sorts by distance, ties broken by slot so results are deterministic
+func sortCandidates(cands []candidate) {
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].dist != cands[j].dist {
+			return cands[i].dist < cands[j].dist
+		}
+		return cands[i].idx < cands[j].idx
+	})
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
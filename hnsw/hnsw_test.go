@@ -0,0 +1,260 @@
+package hnsw
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/vaibhav11s/gopkgs/vectorn"
+)
+
+func randomVecs(r *rand.Rand, n, dim int) []vectorn.VecN {
+	vs := make([]vectorn.VecN, n)
+	for i := range vs {
+		vs[i] = vectorn.Zero(dim)
+		for j := range vs[i] {
+			vs[i][j] = float32(r.NormFloat64())
+		}
+	}
+	return vs
+}
+
+func exact(m Metric, vs []vectorn.VecN, alive map[int]bool, q vectorn.VecN, k int) []int {
+	var idx []int
+	for i := range vs {
+		if alive == nil || alive[i] {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return m.Distance(q, vs[idx[a]]) < m.Distance(q, vs[idx[b]]) })
+	if len(idx) > k {
+		idx = idx[:k]
+	}
+	return idx
+}
+
+// Fraction of the true k nearest neighbors found by the index
+func recall(t *testing.T, ix *Index, vs []vectorn.VecN, alive map[int]bool, queries []vectorn.VecN, k int) float64 {
+	t.Helper()
+	hits, total := 0, 0
+	for _, q := range queries {
+		res, err := ix.Search(q, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[int]bool{}
+		for _, i := range exact(ix.Metric(), vs, alive, q, k) {
+			want[i] = true
+		}
+		total += len(want)
+		for i, r := range res {
+			if want[r.ID] {
+				hits++
+			}
+			if i > 0 && res[i-1].Distance > r.Distance {
+				t.Fatalf("Search() results not sorted: %v", res)
+			}
+			if alive != nil && !alive[r.ID] {
+				t.Fatalf("Search() returned deleted id %d", r.ID)
+			}
+		}
+	}
+	return float64(hits) / float64(total)
+}
+
+func TestSearchRecall(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vs := randomVecs(r, 2000, 16)
+	queries := randomVecs(r, 50, 16)
+	for _, m := range []Metric{Euclidean, Cosine, DotProduct} {
+		ix := New(16, Config{Metric: m, M: 12, Seed: 7})
+		for i, v := range vs {
+			if err := ix.Insert(i, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := recall(t, ix, vs, nil, queries, 10); got < 0.9 {
+			t.Errorf("%v recall = %v, want >= 0.9", m, got)
+		}
+	}
+}
+
+func TestSearchDistance(t *testing.T) {
+	ix := New(3, Config{})
+	ix.Insert(1, vectorn.New(0, 0, 0))
+	ix.Insert(2, vectorn.New(3, 4, 0))
+	res, err := ix.Search(vectorn.New(3, 4, 12), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Result{{2, 12}, {1, 13}}
+	if len(res) != 2 || res[0] != want[0] || res[1] != want[1] {
+		t.Errorf("Search() = %v, want %v", res, want)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	vs := randomVecs(r, 1000, 8)
+	ix := New(8, Config{M: 8, Seed: 3})
+	alive := map[int]bool{}
+	for i, v := range vs {
+		ix.Insert(i, v)
+		alive[i] = true
+	}
+	entryID := ix.nodes[ix.entry].id
+	if !ix.Delete(entryID) {
+		t.Fatalf("Delete(entry point) = false")
+	}
+	delete(alive, entryID)
+	for i := 0; i < len(vs); i += 3 {
+		if alive[i] {
+			if !ix.Delete(i) {
+				t.Fatalf("Delete(%d) = false", i)
+			}
+			delete(alive, i)
+		}
+	}
+	if ix.Delete(0) {
+		t.Errorf("Delete(0) twice = true, want false")
+	}
+	if ix.Len() != len(alive) {
+		t.Errorf("Len() = %v, want %v", ix.Len(), len(alive))
+	}
+	if got := recall(t, ix, vs, alive, randomVecs(r, 30, 8), 5); got < 0.9 {
+		t.Errorf("recall after deletes = %v, want >= 0.9", got)
+	}
+	// deleted slots get reused
+	slots := len(ix.nodes)
+	for i := 0; i < len(vs); i += 3 {
+		if err := ix.Insert(i, vs[i]); err != nil {
+			t.Fatal(err)
+		}
+		alive[i] = true
+	}
+	if len(ix.nodes) != slots {
+		t.Errorf("Insert() after Delete() grew slots from %d to %d", slots, len(ix.nodes))
+	}
+	if got := recall(t, ix, vs, alive, randomVecs(r, 30, 8), 5); got < 0.9 {
+		t.Errorf("recall after reinserting = %v, want >= 0.9", got)
+	}
+	for id := range alive {
+		ix.Delete(id)
+	}
+	if res, _ := ix.Search(vs[0], 3); len(res) != 0 || ix.entry != -1 {
+		t.Errorf("Search() on emptied index = %v", res)
+	}
+}
+
+// Checks the reverse links and gives the nodes other than the entry point
+// that no node links to, with their layer
+func checkGraph(t *testing.T, ix *Index) map[[2]int]bool {
+	t.Helper()
+	orphans := map[[2]int]bool{}
+	for i, n := range ix.nodes {
+		if n == nil {
+			continue
+		}
+		for l := range n.links {
+			for _, j := range n.links[l] {
+				if !ix.nodes[j].in[l][int32(i)] {
+					t.Fatalf("link %d -> %d on layer %d missing from the reverse links", i, j, l)
+				}
+			}
+			for j := range n.in[l] {
+				found := false
+				for _, k := range ix.nodes[j].links[l] {
+					found = found || k == int32(i)
+				}
+				if !found {
+					t.Fatalf("reverse link %d <- %d on layer %d has no link", i, j, l)
+				}
+			}
+			if len(n.in[l]) == 0 && int32(i) != ix.entry {
+				orphans[[2]int{n.id, l}] = true
+			}
+		}
+	}
+	return orphans
+}
+
+func TestDeleteMost(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	vs := randomVecs(r, 1000, 8)
+	ix := New(8, Config{M: 4, Seed: 5})
+	alive := map[int]bool{}
+	for i, v := range vs {
+		ix.Insert(i, v)
+		alive[i] = true
+	}
+	// insertions may prune the only link to a node, deletions never do
+	before := checkGraph(t, ix)
+	for _, i := range r.Perm(len(vs))[:800] {
+		ix.Delete(i)
+		delete(alive, i)
+	}
+	for o := range checkGraph(t, ix) {
+		if !before[o] {
+			t.Errorf("node %d lost its last in-link on layer %d", o[0], o[1])
+		}
+	}
+	if got := recall(t, ix, vs, alive, randomVecs(r, 30, 8), 5); got < 0.9 {
+		t.Errorf("recall after deleting most vectors = %v, want >= 0.9", got)
+	}
+	found := 0
+	for id := range alive {
+		if res, _ := ix.Search(vs[id], 1); len(res) == 1 && res[0].ID == id {
+			found++
+		}
+	}
+	if found < len(alive)*98/100 {
+		t.Errorf("%d of %d vectors find themselves after deletes", found, len(alive))
+	}
+}
+
+func TestInsertErrors(t *testing.T) {
+	ix := New(3, Config{})
+	if err := ix.Insert(1, vectorn.New(1, 2)); !errors.Is(err, ErrDimension) {
+		t.Errorf("Insert(wrong dim) error = %v, want %v", err, ErrDimension)
+	}
+	if err := ix.Insert(1, vectorn.New(1, 2, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Insert(1, vectorn.New(1, 2, 3)); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Insert(duplicate) error = %v, want %v", err, ErrDuplicateID)
+	}
+	if _, err := ix.Search(vectorn.New(1), 1); !errors.Is(err, ErrDimension) {
+		t.Errorf("Search(wrong dim) error = %v, want %v", err, ErrDimension)
+	}
+}
+
+func TestGet(t *testing.T) {
+	ix := New(2, Config{Metric: Cosine})
+	ix.Insert(5, vectorn.New(3, 4))
+	got, ok := ix.Get(5)
+	if !ok || !got.Equal(vectorn.New(0.6, 0.8), 1e-6) {
+		t.Errorf("Get(5) = %v, %v, want normalized vector", got, ok)
+	}
+	if _, ok := ix.Get(6); ok {
+		t.Errorf("Get(6) ok = true for missing id")
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	vs := randomVecs(rand.New(rand.NewSource(4)), 300, 4)
+	build := func() []Result {
+		ix := New(4, Config{Seed: 42})
+		for i, v := range vs {
+			ix.Insert(i, v)
+		}
+		res, _ := ix.Search(vs[7], 20)
+		return res
+	}
+	a, b := build(), build()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("same seed gave different results: %v vs %v", a, b)
+		}
+	}
+}
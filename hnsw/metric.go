@@ -0,0 +1,69 @@
+package hnsw
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vectorn"
+)
+
+// Distance function used by an index
+type Metric uint8
+
+const (
+	// Euclidean distance, vectorn.Dist
+	Euclidean Metric = iota
+	// One minus the cosine similarity. Vectors are normalized when added.
+	Cosine
+	// Negated dot product, so larger products are closer
+	DotProduct
+)
+
+// String representation of the metric
+func (m Metric) String() string {
+	switch m {
+	case Euclidean:
+		return "Euclidean"
+	case Cosine:
+		return "Cosine"
+	case DotProduct:
+		return "DotProduct"
+	}
+	return fmt.Sprintf("Metric(%d)", uint8(m))
+}
+
+// Calculates the distance between two vectors, smaller is closer.
+// For Cosine the vectors do not need to be normalized.
+func (m Metric) Distance(v1, v2 vectorn.VecN) float32 {
+	switch m {
+	case Cosine:
+		return 1 - vectorn.CosineSimilarity(v1, v2)
+	case DotProduct:
+		return -vectorn.Dot(v1, v2)
+	}
+	return vectorn.Dist(v1, v2)
+}
+
+// distance between vectors already prepared by the index
+func (m Metric) distance(v1, v2 vectorn.VecN) float32 {
+	switch m {
+	case Cosine:
+		return 1 - vectorn.Dot(v1, v2)
+	case DotProduct:
+		return -vectorn.Dot(v1, v2)
+	}
+	// squared distance keeps the same order and skips the square root
+	return vectorn.DistSq(v1, v2)
+}
+
+// converts an internal distance back to the value reported by Distance
+func (m Metric) report(d float32) float32 {
+	if m == Euclidean {
+		return float32(math.Sqrt(float64(d)))
+	}
+	return d
+}
+
+func (m Metric) valid() bool {
+	return m <= DotProduct
+}
@@ -0,0 +1,55 @@
+package hnsw
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vectorn"
+)
+
+func TestDistance(t *testing.T) {
+	opt := cmp.Comparer(func(x, y float32) bool {
+		return math.Abs(float64(x-y)) <= 1e-6
+	})
+	a, b := vectorn.New(1, 0, 0), vectorn.New(3, 4, 0)
+	tests := []struct {
+		m    Metric
+		want float32
+	}{
+		{Euclidean, float32(math.Sqrt(20))},
+		{Cosine, 1 - 0.6},
+		{DotProduct, -3},
+	}
+	for _, test := range tests {
+		if got := test.m.Distance(a, b); !cmp.Equal(got, test.want, opt) {
+			t.Errorf("%v.Distance(%v, %v) = %v, want %v", test.m, a, b, got, test.want)
+		}
+		// the internal distance reports the same value once vectors are prepared
+		pa, pb := a.Copy(), b.Copy()
+		if test.m == Cosine {
+			pa.Normalize()
+			pb.Normalize()
+		}
+		if got := test.m.report(test.m.distance(pa, pb)); !cmp.Equal(got, test.want, opt) {
+			t.Errorf("%v internal distance = %v, want %v", test.m, got, test.want)
+		}
+	}
+}
+
+func TestMetricString(t *testing.T) {
+	tests := []struct {
+		m    Metric
+		want string
+	}{
+		{Euclidean, "Euclidean"},
+		{Cosine, "Cosine"},
+		{DotProduct, "DotProduct"},
+		{Metric(9), "Metric(9)"},
+	}
+	for _, test := range tests {
+		if got := test.m.String(); got != test.want {
+			t.Errorf("String() = %v, want %v", got, test.want)
+		}
+	}
+}
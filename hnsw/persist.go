@@ -0,0 +1,216 @@
+package hnsw
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vaibhav11s/gopkgs/vectorn"
+)
+
+var magic = [4]byte{'H', 'N', 'S', 'W'}
+
+// Returned by Read when the data is not a serialized index
+var ErrFormat = errors.New("hnsw: invalid serialized data")
+
+type header struct {
+	Magic          [4]byte
+	Metric         uint8
+	Dim            uint32
+	M              uint32
+	EfConstruction uint32
+	EfSearch       uint32
+	Seed           int64
+	Entry          int32
+	MaxLevel       int32
+	Slots          uint32
+	// state of the level generator
+	RNG uint64
+}
+
+// Writes the index to w. All values are stored little endian.
+func (ix *Index) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	cw := &countWriter{w: bw}
+	h := header{
+		Magic:          magic,
+		Metric:         uint8(ix.cfg.Metric),
+		Dim:            uint32(ix.dim),
+		M:              uint32(ix.cfg.M),
+		EfConstruction: uint32(ix.cfg.EfConstruction),
+		EfSearch:       uint32(ix.cfg.EfSearch),
+		Seed:           ix.cfg.Seed,
+		Entry:          ix.entry,
+		MaxLevel:       int32(ix.maxLevel),
+		Slots:          uint32(len(ix.nodes)),
+		RNG:            uint64(ix.rng),
+	}
+	put := func(data interface{}) error {
+		return binary.Write(cw, binary.LittleEndian, data)
+	}
+	if err := put(h); err != nil {
+		return cw.n, err
+	}
+	for _, n := range ix.nodes {
+		// deleted slots are stored as a level count of 0
+		if n == nil {
+			if err := put(uint32(0)); err != nil {
+				return cw.n, err
+			}
+			continue
+		}
+		if err := put(uint32(len(n.links))); err != nil {
+			return cw.n, err
+		}
+		if err := put(int64(n.id)); err != nil {
+			return cw.n, err
+		}
+		if err := put([]float32(n.vec)); err != nil {
+			return cw.n, err
+		}
+		for _, links := range n.links {
+			if err := put(uint32(len(links))); err != nil {
+				return cw.n, err
+			}
+			if err := put(links); err != nil {
+				return cw.n, err
+			}
+		}
+	}
+	return cw.n, bw.Flush()
+}
+
+// Reads an index written by WriteTo
+func Read(r io.Reader) (*Index, error) {
+	br := bufio.NewReader(r)
+	get := func(data interface{}) error {
+		return binary.Read(br, binary.LittleEndian, data)
+	}
+	var h header
+	if err := get(&h); err != nil {
+		return nil, err
+	}
+	if h.Magic != magic || !Metric(h.Metric).valid() {
+		return nil, ErrFormat
+	}
+	ix := New(int(h.Dim), Config{
+		Metric:         Metric(h.Metric),
+		M:              int(h.M),
+		EfConstruction: int(h.EfConstruction),
+		EfSearch:       int(h.EfSearch),
+		Seed:           h.Seed,
+	})
+	ix.entry = h.Entry
+	ix.maxLevel = int(h.MaxLevel)
+	// slices grow as the data arrives, so that a corrupt header fails at the
+	// end of the stream instead of allocating for counts it does not hold
+	for i := 0; uint32(i) < h.Slots; i++ {
+		var levels uint32
+		if err := get(&levels); err != nil {
+			return nil, err
+		}
+		if levels == 0 {
+			ix.free = append(ix.free, int32(i))
+			ix.nodes = append(ix.nodes, nil)
+			continue
+		}
+		if int64(levels) > int64(h.MaxLevel)+1 {
+			return nil, fmt.Errorf("%w: node %d is above the top layer", ErrFormat, i)
+		}
+		var id int64
+		if err := get(&id); err != nil {
+			return nil, err
+		}
+		if _, ok := ix.ids[int(id)]; ok {
+			return nil, fmt.Errorf("%w: duplicate id %d", ErrFormat, id)
+		}
+		vec, err := readChunked[float32](get, ix.dim)
+		if err != nil {
+			return nil, err
+		}
+		n := newNode(int(id), vectorn.VecN(vec), 0)
+		for l := 0; uint32(l) < levels; l++ {
+			var count uint32
+			if err := get(&count); err != nil {
+				return nil, err
+			}
+			if int64(count) > int64(ix.maxLinks(l)) {
+				return nil, fmt.Errorf("%w: node %d has too many links", ErrFormat, i)
+			}
+			links, err := readChunked[int32](get, int(count))
+			if err != nil {
+				return nil, err
+			}
+			n.links = append(n.links, links)
+			n.in = append(n.in, map[int32]bool{})
+		}
+		ix.nodes = append(ix.nodes, n)
+		ix.ids[n.id] = int32(i)
+	}
+	if err := ix.validate(); err != nil {
+		return nil, err
+	}
+	for i, n := range ix.nodes {
+		if n == nil {
+			continue
+		}
+		for l, links := range n.links {
+			for _, j := range links {
+				ix.nodes[j].in[l][int32(i)] = true
+			}
+		}
+	}
+	ix.rng = splitMix(h.RNG)
+	return ix, nil
+}
+
+// Reads n values a chunk at a time, so that a short stream fails before a
+// large allocation
+func readChunked[T float32 | int32](get func(interface{}) error, n int) ([]T, error) {
+	const chunk = 1024
+	s := make([]T, 0, min(n, chunk))
+	for len(s) < n {
+		buf := make([]T, min(n-len(s), chunk))
+		if err := get(buf); err != nil {
+			return nil, err
+		}
+		s = append(s, buf...)
+	}
+	return s, nil
+}
+
+// Checks that all links point at live nodes on layers they exist on
+func (ix *Index) validate() error {
+	if (ix.entry < 0) != (len(ix.ids) == 0) {
+		return fmt.Errorf("%w: bad entry point", ErrFormat)
+	}
+	if ix.entry >= 0 && (int(ix.entry) >= len(ix.nodes) || ix.nodes[ix.entry] == nil || len(ix.nodes[ix.entry].links)-1 != ix.maxLevel) {
+		return fmt.Errorf("%w: bad entry point", ErrFormat)
+	}
+	for i, n := range ix.nodes {
+		if n == nil {
+			continue
+		}
+		for l, links := range n.links {
+			for _, j := range links {
+				if j < 0 || int(j) >= len(ix.nodes) || ix.nodes[j] == nil || len(ix.nodes[j].links) <= l {
+					return fmt.Errorf("%w: node %d has an invalid link", ErrFormat, i)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
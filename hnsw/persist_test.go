@@ -0,0 +1,118 @@
+package hnsw
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestWriteRead(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	vs := randomVecs(r, 500, 6)
+	ix := New(6, Config{Metric: Cosine, M: 6, EfSearch: 40, Seed: 9})
+	for i, v := range vs {
+		ix.Insert(i*10, v)
+	}
+	ix.Delete(30)
+	ix.Delete(40)
+
+	var buf bytes.Buffer
+	n, err := ix.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo() = %v, wrote %v bytes", n, buf.Len())
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got.Len() != ix.Len() || got.Dim() != 6 || got.cfg != ix.cfg {
+		t.Fatalf("Read() = len %d dim %d cfg %v, want len %d dim 6 cfg %v", got.Len(), got.Dim(), got.cfg, ix.Len(), ix.cfg)
+	}
+	for _, q := range randomVecs(r, 20, 6) {
+		want, _ := ix.Search(q, 5)
+		res, _ := got.Search(q, 5)
+		for i := range want {
+			if res[i] != want[i] {
+				t.Fatalf("Search() after Read = %v, want %v", res, want)
+			}
+		}
+	}
+	// the loaded index keeps working
+	if err := got.Insert(30, vs[3]); err != nil {
+		t.Fatal(err)
+	}
+	if res, _ := got.Search(vs[3], 1); len(res) != 1 || res[0].Distance > 1e-6 {
+		t.Errorf("Search() for reinserted vector = %v", res)
+	}
+}
+
+func TestReadContinues(t *testing.T) {
+	vs := randomVecs(rand.New(rand.NewSource(10)), 300, 4)
+	cfg := Config{M: 4, Seed: 11}
+	whole := New(4, cfg)
+	for i, v := range vs {
+		whole.Insert(i, v)
+	}
+	half := New(4, cfg)
+	for i, v := range vs[:150] {
+		half.Insert(i, v)
+	}
+	var buf bytes.Buffer
+	half.WriteTo(&buf)
+	loaded, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range vs[150:] {
+		loaded.Insert(150+i, v)
+	}
+	var a, b bytes.Buffer
+	whole.WriteTo(&a)
+	loaded.WriteTo(&b)
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Errorf("inserting after Read() built a different graph than inserting without saving")
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	if _, err := Read(bytes.NewReader(make([]byte, 64))); !errors.Is(err, ErrFormat) {
+		t.Errorf("Read(zeros) error = %v, want %v", err, ErrFormat)
+	}
+	ix := New(2, Config{})
+	ix.Insert(1, randomVecs(rand.New(rand.NewSource(6)), 1, 2)[0])
+	var buf bytes.Buffer
+	ix.WriteTo(&buf)
+	data := buf.Bytes()
+	if _, err := Read(bytes.NewReader(data[:len(data)-2])); err == nil {
+		t.Errorf("Read(truncated) error = nil")
+	}
+	// a header announcing huge counts fails at the end of the data instead of
+	// allocating for them
+	var huge bytes.Buffer
+	binary.Write(&huge, binary.LittleEndian, header{Magic: magic, Dim: 1 << 31, MaxLevel: 1 << 30, Slots: 1 << 31})
+	binary.Write(&huge, binary.LittleEndian, uint32(1))
+	binary.Write(&huge, binary.LittleEndian, int64(0))
+	if _, err := Read(&huge); err == nil {
+		t.Errorf("Read(huge counts) error = nil")
+	}
+	// a node above the top layer
+	var high bytes.Buffer
+	binary.Write(&high, binary.LittleEndian, header{Magic: magic, Dim: 1, MaxLevel: 0, Slots: 1})
+	binary.Write(&high, binary.LittleEndian, uint32(1<<30))
+	if _, err := Read(&high); !errors.Is(err, ErrFormat) {
+		t.Errorf("Read(node above the top layer) error = %v, want %v", err, ErrFormat)
+	}
+	// two live slots with the same id
+	ix.Insert(2, randomVecs(rand.New(rand.NewSource(7)), 1, 2)[0])
+	ix.nodes[ix.ids[2]].id = 1
+	buf.Reset()
+	ix.WriteTo(&buf)
+	if _, err := Read(&buf); !errors.Is(err, ErrFormat) {
+		t.Errorf("Read(duplicate ids) error = %v, want %v", err, ErrFormat)
+	}
+}
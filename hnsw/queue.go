@@ -0,0 +1,47 @@
+package hnsw
+
+import "container/heap"
+
+type candidate struct {
+	dist float32
+	idx  int32
+}
+
+func closer(a, b candidate) bool {
+	if a.dist != b.dist {
+		return a.dist < b.dist
+	}
+	return a.idx < b.idx
+}
+
+type minHeap []candidate
+
+func (h minHeap) Len() int            { return len(h) }
+func (h minHeap) Less(i, j int) bool  { return closer(h[i], h[j]) }
+func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *minHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+type maxHeap struct{ minHeap }
+
+func (h maxHeap) Less(i, j int) bool { return closer(h.minHeap[j], h.minHeap[i]) }
+
+// Priority queue giving the closest candidate first
+type minQueue struct{ h minHeap }
+
+func (q *minQueue) Len() int         { return q.h.Len() }
+func (q *minQueue) push(c candidate) { heap.Push(&q.h, c) }
+func (q *minQueue) pop() candidate   { return heap.Pop(&q.h).(candidate) }
+
+// Priority queue giving the farthest candidate first
+type maxQueue struct{ h maxHeap }
+
+func (q *maxQueue) Len() int         { return q.h.Len() }
+func (q *maxQueue) push(c candidate) { heap.Push(&q.h, c) }
+func (q *maxQueue) pop() candidate   { return heap.Pop(&q.h).(candidate) }
+func (q *maxQueue) top() candidate   { return q.h.minHeap[0] }
@@ -0,0 +1,26 @@
+package hnsw
+
+import "testing"
+
+func TestQueues(t *testing.T) {
+	in := []candidate{{3, 0}, {1, 1}, {2, 2}, {1, 0}, {5, 4}}
+	mq, xq := &minQueue{}, &maxQueue{}
+	for _, c := range in {
+		mq.push(c)
+		xq.push(c)
+	}
+	wantMin := []candidate{{1, 0}, {1, 1}, {2, 2}, {3, 0}, {5, 4}}
+	for i, want := range wantMin {
+		if got := mq.pop(); got != want {
+			t.Errorf("minQueue pop %d = %v, want %v", i, got, want)
+		}
+	}
+	if xq.top() != (candidate{5, 4}) {
+		t.Errorf("maxQueue top = %v, want {5 4}", xq.top())
+	}
+	for i := len(wantMin) - 1; i >= 0; i-- {
+		if got := xq.pop(); got != wantMin[i] {
+			t.Errorf("maxQueue pop = %v, want %v", got, wantMin[i])
+		}
+	}
+}
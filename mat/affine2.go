@@ -0,0 +1,39 @@
+package mat
+
+import "github.com/vaibhav11s/gopkgs/vector2d"
+
+// Makes the 2D affine transform in homogeneous coordinates applying m, then
+// moving by t
+func Affine2(m Mat2, t *vector2d.Vector2D) Mat3 {
+	return Mat3{
+		{m[0][0], m[0][1], float64(t.X)},
+		{m[1][0], m[1][1], float64(t.Y)},
+		{0, 0, 1},
+	}
+}
+
+// Gives the upper left 2x2 block, the linear part of a 2D affine transform
+func (m Mat3) Mat2() Mat2 {
+	return Mat2{{m[0][0], m[0][1]}, {m[1][0], m[1][1]}}
+}
+
+// Gives the translation of a 2D affine transform
+func (m Mat3) Translation2() *vector2d.Vector2D {
+	return vector2d.New(float32(m[0][2]), float32(m[1][2]))
+}
+
+// Transforms a 2D point, m*(v, 1), dividing by the resulting w of projective
+// matrices
+func (m Mat3) MulPoint2(v *vector2d.Vector2D) *vector2d.Vector2D {
+	x, y, w := m.apply(float64(v.X), float64(v.Y), 1)
+	if w != 1 {
+		x, y = x/w, y/w
+	}
+	return vector2d.New(float32(x), float32(y))
+}
+
+// Transforms a 2D direction, m*(v, 0), which ignores the translation
+func (m Mat3) MulDirection2(v *vector2d.Vector2D) *vector2d.Vector2D {
+	x, y, _ := m.apply(float64(v.X), float64(v.Y), 0)
+	return vector2d.New(float32(x), float32(y))
+}
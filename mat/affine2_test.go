@@ -0,0 +1,29 @@
+package mat
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestAffine2(t *testing.T) {
+	opt := getComparer(1e-5)
+	rot := Rotation2(0.5)
+	tr := vector2d.New(3, -2)
+	m := Affine2(rot.Scale(2), tr)
+	v := vector2d.New(1, 4)
+	if got, want := m.MulPoint2(v), vector2d.Add(rot.MulVec(v).Mult(2), tr); !cmp.Equal(got, want, opt) {
+		t.Errorf("MulPoint2() = %v, want %v", got, want)
+	}
+	if got, want := m.MulDirection2(v), rot.MulVec(v).Mult(2); !cmp.Equal(got, want, opt) {
+		t.Errorf("MulDirection2() = %v, want %v", got, want)
+	}
+	if !m.Mat2().Equal(rot.Scale(2)) || !cmp.Equal(m.Translation2(), tr) {
+		t.Errorf("Mat2(), Translation2() = %v, %v", m.Mat2(), m.Translation2())
+	}
+	inv, ok := m.Inverse()
+	if !ok || !cmp.Equal(inv.MulPoint2(m.MulPoint2(v)), v, opt) {
+		t.Errorf("Inverse() = %v, %v", inv, ok)
+	}
+}
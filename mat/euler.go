@@ -0,0 +1,158 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+)
+
+// Order of the axes of Euler angles. Angles (a, b, c) in order IJK are
+// intrinsic: turn by a around I, then by b around the new J, then by c around
+// the newest K, which is R = Ri(a) Rj(b) Rk(c). The same angles are the
+// extrinsic rotation by c around the fixed K, then b around J, then a around I.
+// https://en.wikipedia.org/wiki/Euler_angles
+type EulerOrder int
+
+const (
+	// Tait–Bryan angles, b in [-π/2, π/2]. ZYX is yaw, pitch and roll.
+	XYZ EulerOrder = iota
+	XZY
+	YXZ
+	YZX
+	ZXY
+	ZYX
+	// Proper Euler angles, b in [0, π]
+	XYX
+	XZX
+	YXY
+	YZY
+	ZXZ
+	ZYZ
+)
+
+var eulerAxes = [...][3]int{
+	XYZ: {0, 1, 2}, XZY: {0, 2, 1}, YXZ: {1, 0, 2}, YZX: {1, 2, 0}, ZXY: {2, 0, 1}, ZYX: {2, 1, 0},
+	XYX: {0, 1, 0}, XZX: {0, 2, 0}, YXY: {1, 0, 1}, YZY: {1, 2, 1}, ZXZ: {2, 0, 2}, ZYZ: {2, 1, 2},
+}
+
+// String representation of the order, like "ZYX"
+func (o EulerOrder) String() string {
+	if o < XYZ || o > ZYZ {
+		return fmt.Sprintf("EulerOrder(%d)", int(o))
+	}
+	s := make([]byte, 3)
+	for i, a := range eulerAxes[o] {
+		s[i] = "XYZ"[a]
+	}
+	return string(s)
+}
+
+// Gives the indices of the three axes, 0 for X to 2 for Z
+func (o EulerOrder) Axes() (i, j, k int) {
+	a := eulerAxes[o]
+	return a[0], a[1], a[2]
+}
+
+// Tells if the first and last axes are the same
+func (o EulerOrder) Proper() bool {
+	return o >= XYX
+}
+
+// Rotation around a coordinate axis, 0 for X to 2 for Z
+func axisRotation(axis int, angle float64) Mat3 {
+	s, c := math.Sincos(angle)
+	m := Identity3()
+	j, k := (axis+1)%3, (axis+2)%3
+	m[j][j], m[j][k] = c, -s
+	m[k][j], m[k][k] = s, c
+	return m
+}
+
+// Makes the rotation matrix of Euler angles (radians) in the given order,
+// Ri(a) Rj(b) Rk(c)
+func RotationEuler(order EulerOrder, a, b, c float64) Mat3 {
+	i, j, k := order.Axes()
+	return axisRotation(i, a).Mul(axisRotation(j, b)).Mul(axisRotation(k, c))
+}
+
+// Gives the Euler angles (radians) of a rotation matrix in the given order,
+// with a and c in [-π, π] and b in the range of the order.
+//
+// At gimbal lock, when b is ±π/2 for Tait–Bryan or 0 or π for proper Euler
+// angles, the first and last axes line up and only a combination of a and c
+// is defined. Then a is 0 and c carries the whole turn. Close to gimbal lock
+// the angles stay exact but a and c grow sensitive to rounding.
+func (m Mat3) Euler(order EulerOrder) (a, b, c float64) {
+	i, j, k := order.Axes()
+	if order.Proper() {
+		k = 3 - i - j
+	}
+	// relabel the axes as X, Y, Z
+	var n Mat3
+	axes := [3]int{i, j, k}
+	for r := 0; r < 3; r++ {
+		for s := 0; s < 3; s++ {
+			n[r][s] = m[axes[r]][axes[s]]
+		}
+	}
+	// an odd permutation mirrors the space, which negates all the angles
+	sign := 1.0
+	if (j-i+3)%3 != 1 {
+		sign = -1
+	}
+	if order.Proper() {
+		a, b, c = n.eulerXYX(sign)
+	} else {
+		a, b, c = n.eulerXYZ()
+	}
+	a, b, c = sign*a, sign*b, sign*c
+	if order.Proper() && b < 0 {
+		// only from rounding around gimbal lock: (a+π, -b, c+π) is the same
+		// rotation, and at b = -π so is b = π
+		if b == -math.Pi {
+			b = math.Pi
+		} else {
+			a, b, c = halfTurn(a), -b, halfTurn(c)
+		}
+	}
+	return a, b, c
+}
+
+// Adds π to an angle in [-π, π], keeping it in range
+func halfTurn(a float64) float64 {
+	if a > 0 {
+		return a - math.Pi
+	}
+	return a + math.Pi
+}
+
+// Gives atan2(y, x), or 0 when both are about zero at gimbal lock
+func lockedAtan2(y, x float64) float64 {
+	if math.Abs(y) < 1e-12 && math.Abs(x) < 1e-12 {
+		return 0
+	}
+	return math.Atan2(y, x)
+}
+
+// Angles of m = Rx(a) Ry(b) Rz(c)
+func (m Mat3) eulerXYZ() (a, b, c float64) {
+	// m[1][2] = -sin(a) cos(b), m[2][2] = cos(a) cos(b)
+	a = lockedAtan2(-m[1][2], m[2][2])
+	// Rx(-a) m = Ry(b) Rz(c) has rows (.., .., sin(b)), (sin(c), cos(c), 0)
+	// and (.., .., cos(b)), defined for any b
+	sa, ca := math.Sincos(a)
+	sinC, cosC := ca*m[1][0]+sa*m[2][0], ca*m[1][1]+sa*m[2][1]
+	cosB := -sa*m[1][2] + ca*m[2][2]
+	return a, math.Atan2(m[0][2], cosB), math.Atan2(sinC, cosC)
+}
+
+// Angles of m = Rx(a) Ry(b) Rx(c), with b of the given sign
+func (m Mat3) eulerXYX(sign float64) (a, b, c float64) {
+	// m[1][0] = sin(a) sin(b), m[2][0] = -cos(a) sin(b)
+	a = lockedAtan2(sign*m[1][0], -sign*m[2][0])
+	// Rx(-a) m = Ry(b) Rx(c) has rows (cos(b), .., ..), (0, cos(c), -sin(c))
+	// and (-sin(b), .., ..), defined for any b
+	sa, ca := math.Sincos(a)
+	sinC, cosC := -ca*m[1][2]-sa*m[2][2], ca*m[1][1]+sa*m[2][1]
+	sinB := sa*m[1][0] - ca*m[2][0]
+	return a, math.Atan2(sinB, m[0][0]), math.Atan2(sinC, cosC)
+}
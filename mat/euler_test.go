@@ -0,0 +1,115 @@
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+var eulerOrders = []EulerOrder{XYZ, XZY, YXZ, YZX, ZXY, ZYX, XYX, XZX, YXY, YZY, ZXZ, ZYZ}
+
+var basis = [3]*vector.Vector{vector.New(1, 0, 0), vector.New(0, 1, 0), vector.New(0, 0, 1)}
+
+// Random angles in the range of the order
+func randomEuler(r *rand.Rand, order EulerOrder) (a, b, c float64) {
+	a, c = (r.Float64()*2-1)*math.Pi, (r.Float64()*2-1)*math.Pi
+	if order.Proper() {
+		return a, r.Float64() * math.Pi, c
+	}
+	return a, (r.Float64() - 0.5) * math.Pi, c
+}
+
+func TestEulerOrderString(t *testing.T) {
+	want := []string{"XYZ", "XZY", "YXZ", "YZX", "ZXY", "ZYX", "XYX", "XZX", "YXY", "YZY", "ZXZ", "ZYZ"}
+	for i, o := range eulerOrders {
+		if got := o.String(); got != want[i] {
+			t.Errorf("String() = %v, want %v", got, want[i])
+		}
+	}
+	if got := EulerOrder(12).String(); got != "EulerOrder(12)" {
+		t.Errorf("String() = %v", got)
+	}
+}
+
+func TestRotationEuler(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	opt := getComparer(1e-5)
+	for _, order := range eulerOrders {
+		i, j, k := order.Axes()
+		for n := 0; n < 50; n++ {
+			a, b, c := randomEuler(r, order)
+			v := vector.New(r.Float32()*4-2, r.Float32()*4-2, r.Float32()*4-2)
+			m := RotationEuler(order, a, b, c)
+			// extrinsic: c around the fixed k first, then b around j, a around i
+			want := vector.RotateAlongAxis(v, basis[k], float32(c))
+			want = vector.RotateAlongAxis(want, basis[j], float32(b))
+			want = vector.RotateAlongAxis(want, basis[i], float32(a))
+			if got := m.MulVec(v); !cmp.Equal(got, want, opt) {
+				t.Fatalf("RotationEuler(%v, %v, %v, %v).MulVec(%v) = %v, want %v", order, a, b, c, v, got, want)
+			}
+			// intrinsic: a around i, then b around the turned j, c around the turned k
+			m1 := RotationAxisAngle(basis[i], a)
+			m2 := RotationAxisAngle(m1.MulVec(basis[j]), b).Mul(m1)
+			m3 := RotationAxisAngle(m2.MulVec(basis[k]), c).Mul(m2)
+			if !m3.Equal(m, 1e-6) {
+				t.Fatalf("RotationEuler(%v, %v, %v, %v) = %v, want intrinsic %v", order, a, b, c, m, m3)
+			}
+		}
+	}
+}
+
+func TestEulerRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, order := range eulerOrders {
+		for n := 0; n < 200; n++ {
+			a, b, c := randomEuler(r, order)
+			m := RotationEuler(order, a, b, c)
+			ga, gb, gc := m.Euler(order)
+			if math.Abs(ga-a) > 1e-9 || math.Abs(gb-b) > 1e-9 || math.Abs(gc-c) > 1e-9 {
+				t.Fatalf("Euler(%v) = %v, %v, %v, want %v, %v, %v", order, ga, gb, gc, a, b, c)
+			}
+			// any rotation, in every order
+			for _, o := range eulerOrders {
+				a, b, c := m.Euler(o)
+				if got := RotationEuler(o, a, b, c); !got.Equal(m, 1e-12) {
+					t.Fatalf("RotationEuler(%v, %v.Euler()) = %v, want %v", o, o, got, m)
+				}
+			}
+		}
+	}
+}
+
+func TestEulerGimbalLock(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for _, order := range eulerOrders {
+		locks := []float64{-math.Pi / 2, math.Pi / 2}
+		if order.Proper() {
+			locks = []float64{0, math.Pi}
+		}
+		for _, b := range locks {
+			for _, eps := range []float64{0, 1e-14, 1e-9, 1e-6} {
+				for n := 0; n < 20; n++ {
+					a, _, c := randomEuler(r, order)
+					lb := b + eps
+					if b > 0 {
+						lb = b - eps
+					}
+					m := RotationEuler(order, a, lb, c)
+					ga, gb, gc := m.Euler(order)
+					if got := RotationEuler(order, ga, gb, gc); !got.Equal(m, 1e-12) {
+						t.Fatalf("near gimbal lock RotationEuler(%v, %v.Euler()) = %v, want %v", order, order, got, m)
+					}
+					if eps == 0 && (ga != 0 || math.Abs(gb-b) > 1e-12) {
+						t.Fatalf("at gimbal lock %v.Euler() = %v, %v, %v, want 0, %v, ..", order, ga, gb, gc, b)
+					}
+				}
+			}
+		}
+	}
+	if a, b, c := Identity3().Euler(ZYZ); a != 0 || b != 0 || c != 0 {
+		t.Errorf("Identity3().Euler(ZYZ) = %v, %v, %v", a, b, c)
+	}
+}
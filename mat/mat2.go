@@ -0,0 +1,114 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// 2x2 matrix, M[row][col]
+type Mat2 [2][2]float64
+
+// Gives the 2x2 identity matrix
+func Identity2() Mat2 {
+	return Mat2{{1, 0}, {0, 1}}
+}
+
+// Makes a matrix whose columns are the given vectors
+func FromColumns2(c0, c1 *vector2d.Vector2D) Mat2 {
+	return Mat2{
+		{float64(c0.X), float64(c1.X)},
+		{float64(c0.Y), float64(c1.Y)},
+	}
+}
+
+// Makes the matrix rotating by angle (radians, counter clockwise)
+func Rotation2(angle float64) Mat2 {
+	s, c := math.Sincos(angle)
+	return Mat2{{c, -s}, {s, c}}
+}
+
+// String representation of the matrix
+func (m Mat2) String() string {
+	return fmt.Sprintf("[%v %v]", m[0], m[1])
+}
+
+// Checks whether two matrices are equal.
+// optional tolerence value can be passed as a parameter to check for equality within a tolerance.
+func (m Mat2) Equal(m2 Mat2, tolerance ...float64) bool {
+	t := 1e-15
+	if len(tolerance) >= 1 {
+		t += tolerance[0]
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			if math.Abs(m[i][j]-m2[i][j]) > t {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Gives column i as a vector
+func (m Mat2) Col(i int) *vector2d.Vector2D {
+	return vector2d.New(float32(m[0][i]), float32(m[1][i]))
+}
+
+// Sum of two matrices
+func (m Mat2) Add(m2 Mat2) Mat2 {
+	return Mat2{{m[0][0] + m2[0][0], m[0][1] + m2[0][1]}, {m[1][0] + m2[1][0], m[1][1] + m2[1][1]}}
+}
+
+// Multiplies every element by a scalar
+func (m Mat2) Scale(s float64) Mat2 {
+	return Mat2{{m[0][0] * s, m[0][1] * s}, {m[1][0] * s, m[1][1] * s}}
+}
+
+// Matrix product m*m2
+func (m Mat2) Mul(m2 Mat2) Mat2 {
+	return Mat2{
+		{m[0][0]*m2[0][0] + m[0][1]*m2[1][0], m[0][0]*m2[0][1] + m[0][1]*m2[1][1]},
+		{m[1][0]*m2[0][0] + m[1][1]*m2[1][0], m[1][0]*m2[0][1] + m[1][1]*m2[1][1]},
+	}
+}
+
+// Applies the matrix to a vector, m*v
+func (m Mat2) MulVec(v *vector2d.Vector2D) *vector2d.Vector2D {
+	x, y := float64(v.X), float64(v.Y)
+	return vector2d.New(float32(m[0][0]*x+m[0][1]*y), float32(m[1][0]*x+m[1][1]*y))
+}
+
+// Transposed matrix
+func (m Mat2) Transpose() Mat2 {
+	return Mat2{{m[0][0], m[1][0]}, {m[0][1], m[1][1]}}
+}
+
+// Determinant of the matrix
+func (m Mat2) Det() float64 {
+	return m[0][0]*m[1][1] - m[0][1]*m[1][0]
+}
+
+// Inverse of the matrix, ok is false if the matrix is singular
+func (m Mat2) Inverse() (inv Mat2, ok bool) {
+	det := m.Det()
+	if det == 0 {
+		return Mat2{}, false
+	}
+	return Mat2{{m[1][1], -m[0][1]}, {-m[1][0], m[0][0]}}.Scale(1 / det), true
+}
+
+// Eigen-decomposition of a symmetric matrix in closed form.
+// Eigenvalues are sorted in decreasing order and the matching unit
+// eigenvectors are the columns of vectors, which form a rotation (det = +1).
+// Only the upper triangle of m is read.
+func (m Mat2) SymEigen() (values [2]float64, vectors Mat2) {
+	a, b, d := m[0][0], m[0][1], m[1][1]
+	mean := (a + d) / 2
+	r := math.Hypot((a-d)/2, b)
+	values = [2]float64{mean + r, mean - r}
+	// angle of the first eigenvector
+	angle := 0.5 * math.Atan2(2*b, a-d)
+	return values, Rotation2(angle)
+}
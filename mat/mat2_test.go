@@ -0,0 +1,64 @@
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestMat2Basics(t *testing.T) {
+	m := Mat2{{1, 2}, {3, 4}}
+	if got := m.Det(); got != -2 {
+		t.Errorf("Det() = %v, want -2", got)
+	}
+	inv, ok := m.Inverse()
+	if !ok || !m.Mul(inv).Equal(Identity2(), 1e-12) {
+		t.Errorf("Inverse() = %v, %v", inv, ok)
+	}
+	if _, ok := (Mat2{{1, 2}, {2, 4}}).Inverse(); ok {
+		t.Errorf("Inverse() of a singular matrix ok = true")
+	}
+	if got, want := m.Transpose(), (Mat2{{1, 3}, {2, 4}}); got != want {
+		t.Errorf("Transpose() = %v, want %v", got, want)
+	}
+	if got, want := m.Add(m).Scale(0.5), m; got != want {
+		t.Errorf("Add/Scale = %v, want %v", got, want)
+	}
+	opt := getComparer(1e-6)
+	if got, want := Rotation2(math.Pi/2).MulVec(vector2d.New(1, 0)), vector2d.New(0, 1); !cmp.Equal(got, want, opt) {
+		t.Errorf("Rotation2(Pi/2).MulVec({1, 0}) = %v, want %v", got, want)
+	}
+	c := FromColumns2(vector2d.New(1, 2), vector2d.New(3, 4))
+	if !cmp.Equal(c.Col(1), vector2d.New(3, 4)) || c != (Mat2{{1, 3}, {2, 4}}) {
+		t.Errorf("FromColumns2() = %v", c)
+	}
+	// 1e-15 like vector64, plus the optional tolerance
+	if !m.Equal(Mat2{{1, 2}, {3, 4 + 5e-16}}) || m.Equal(Mat2{{1, 2}, {3, 4.001}}) || !m.Equal(Mat2{{1, 2}, {3, 4.001}}, 0.01) {
+		t.Errorf("Equal() tolerances")
+	}
+}
+
+func TestSymEigen2(t *testing.T) {
+	tests := []Mat2{
+		{{2, 0}, {0, 1}},
+		{{1, 0}, {0, 2}},
+		{{2, 1}, {1, 2}},
+		{{3, -2}, {-2, 0}},
+		{{5, 0}, {0, 5}},
+	}
+	for _, m := range tests {
+		values, vectors := m.SymEigen()
+		if values[0] < values[1] {
+			t.Errorf("%v.SymEigen() values %v not decreasing", m, values)
+		}
+		if math.Abs(vectors.Det()-1) > 1e-12 {
+			t.Errorf("%v.SymEigen() vectors %v not a rotation", m, vectors)
+		}
+		back := vectors.Mul(Mat2{{values[0], 0}, {0, values[1]}}).Mul(vectors.Transpose())
+		if !back.Equal(m, 1e-12) {
+			t.Errorf("%v.SymEigen() reconstructs %v", m, back)
+		}
+	}
+}
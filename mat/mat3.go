@@ -0,0 +1,307 @@
+// Package mat provides small fixed size matrices to use with the vector packages.
+// Matrices are stored row major and multiply column vectors, M*v.
+package mat
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// 3x3 matrix, M[row][col]
+type Mat3 [3][3]float64
+
+// Gives the 3x3 identity matrix
+func Identity3() Mat3 {
+	return Mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+}
+
+// Makes a matrix whose columns are the given vectors
+func FromColumns3(c0, c1, c2 *vector.Vector) Mat3 {
+	return Mat3{
+		{float64(c0.X), float64(c1.X), float64(c2.X)},
+		{float64(c0.Y), float64(c1.Y), float64(c2.Y)},
+		{float64(c0.Z), float64(c1.Z), float64(c2.Z)},
+	}
+}
+
+// Makes a diagonal matrix
+func Diag3(a, b, c float64) Mat3 {
+	return Mat3{{a, 0, 0}, {0, b, 0}, {0, 0, c}}
+}
+
+// Matrix of the cross product, Skew3(v).MulVec(u) == v x u
+func Skew3(v *vector.Vector) Mat3 {
+	x, y, z := float64(v.X), float64(v.Y), float64(v.Z)
+	return Mat3{{0, -z, y}, {z, 0, -x}, {-y, x, 0}}
+}
+
+// Outer product u*v^T
+func Outer3(u, v *vector.Vector) Mat3 {
+	a := [3]float64{float64(u.X), float64(u.Y), float64(u.Z)}
+	b := [3]float64{float64(v.X), float64(v.Y), float64(v.Z)}
+	var m Mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[i][j] = a[i] * b[j]
+		}
+	}
+	return m
+}
+
+// String representation of the matrix
+func (m Mat3) String() string {
+	return fmt.Sprintf("[%v %v %v]", m[0], m[1], m[2])
+}
+
+// Checks whether two matrices are equal.
+// optional tolerence value can be passed as a parameter to check for equality within a tolerance.
+func (m Mat3) Equal(m2 Mat3, tolerance ...float64) bool {
+	t := 1e-15
+	if len(tolerance) >= 1 {
+		t += tolerance[0]
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if math.Abs(m[i][j]-m2[i][j]) > t {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Gives column i as a vector
+func (m Mat3) Col(i int) *vector.Vector {
+	return vector.New(float32(m[0][i]), float32(m[1][i]), float32(m[2][i]))
+}
+
+// Gives row i as a vector
+func (m Mat3) Row(i int) *vector.Vector {
+	return vector.New(float32(m[i][0]), float32(m[i][1]), float32(m[i][2]))
+}
+
+// Sum of two matrices
+func (m Mat3) Add(m2 Mat3) Mat3 {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[i][j] += m2[i][j]
+		}
+	}
+	return m
+}
+
+// Difference of two matrices
+func (m Mat3) Sub(m2 Mat3) Mat3 {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[i][j] -= m2[i][j]
+		}
+	}
+	return m
+}
+
+// Multiplies every element by a scalar
+func (m Mat3) Scale(s float64) Mat3 {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[i][j] *= s
+		}
+	}
+	return m
+}
+
+// Matrix product m*m2
+func (m Mat3) Mul(m2 Mat3) Mat3 {
+	var r Mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = m[i][0]*m2[0][j] + m[i][1]*m2[1][j] + m[i][2]*m2[2][j]
+		}
+	}
+	return r
+}
+
+// Applies the matrix to a vector, m*v
+func (m Mat3) MulVec(v *vector.Vector) *vector.Vector {
+	x, y, z := m.apply(float64(v.X), float64(v.Y), float64(v.Z))
+	return vector.New(float32(x), float32(y), float32(z))
+}
+
+func (m Mat3) apply(x, y, z float64) (float64, float64, float64) {
+	return m[0][0]*x + m[0][1]*y + m[0][2]*z,
+		m[1][0]*x + m[1][1]*y + m[1][2]*z,
+		m[2][0]*x + m[2][1]*y + m[2][2]*z
+}
+
+// Transposed matrix
+func (m Mat3) Transpose() Mat3 {
+	return Mat3{
+		{m[0][0], m[1][0], m[2][0]},
+		{m[0][1], m[1][1], m[2][1]},
+		{m[0][2], m[1][2], m[2][2]},
+	}
+}
+
+// Sum of the diagonal
+func (m Mat3) Trace() float64 {
+	return m[0][0] + m[1][1] + m[2][2]
+}
+
+// Determinant of the matrix
+func (m Mat3) Det() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// Inverse of the matrix, ok is false if the matrix is singular
+func (m Mat3) Inverse() (inv Mat3, ok bool) {
+	det := m.Det()
+	if det == 0 {
+		return Mat3{}, false
+	}
+	inv = Mat3{
+		{m[1][1]*m[2][2] - m[1][2]*m[2][1], m[0][2]*m[2][1] - m[0][1]*m[2][2], m[0][1]*m[1][2] - m[0][2]*m[1][1]},
+		{m[1][2]*m[2][0] - m[1][0]*m[2][2], m[0][0]*m[2][2] - m[0][2]*m[2][0], m[0][2]*m[1][0] - m[0][0]*m[1][2]},
+		{m[1][0]*m[2][1] - m[1][1]*m[2][0], m[0][1]*m[2][0] - m[0][0]*m[2][1], m[0][0]*m[1][1] - m[0][1]*m[1][0]},
+	}
+	return inv.Scale(1 / det), true
+}
+
+// Eigen-decomposition of a symmetric matrix with the cyclic Jacobi method.
+// Eigenvalues are sorted in decreasing order and the matching unit
+// eigenvectors are the columns of vectors, which form a rotation (det = +1).
+// Only the upper triangle of m is read.
+// https://en.wikipedia.org/wiki/Jacobi_eigenvalue_algorithm
+func (m Mat3) SymEigen() (values [3]float64, vectors Mat3) {
+	a := m
+	for i := 0; i < 3; i++ {
+		for j := 0; j < i; j++ {
+			a[i][j] = a[j][i]
+		}
+	}
+	v := Identity3()
+	for sweep := 0; sweep < 50; sweep++ {
+		off := a[0][1]*a[0][1] + a[0][2]*a[0][2] + a[1][2]*a[1][2]
+		if off < 1e-30 {
+			break
+		}
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				// a = J^T a J, v = v J with J the rotation in the (p, q) plane
+				for k := 0; k < 3; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p] = c*akp - s*akq
+					a[k][q] = s*akp + c*akq
+				}
+				for k := 0; k < 3; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k] = c*apk - s*aqk
+					a[q][k] = s*apk + c*aqk
+				}
+				for k := 0; k < 3; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p] = c*vkp - s*vkq
+					v[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	order := []int{0, 1, 2}
+	sort.SliceStable(order, func(i, j int) bool { return a[order[i]][order[i]] > a[order[j]][order[j]] })
+	for c, k := range order {
+		values[c] = a[k][k]
+		for r := 0; r < 3; r++ {
+			vectors[r][c] = v[r][k]
+		}
+	}
+	if vectors.Det() < 0 {
+		for r := 0; r < 3; r++ {
+			vectors[r][2] = -vectors[r][2]
+		}
+	}
+	return values, vectors
+}
+
+// Singular value decomposition m = u * diag(s) * v^T.
+// u and v are orthogonal and s is sorted in decreasing order, s >= 0.
+// Computed from the eigen-decomposition of m^T*m, which is accurate enough
+// for the float32 data of the vector package.
+func (m Mat3) SVD() (u Mat3, s [3]float64, v Mat3) {
+	values, v := m.Transpose().Mul(m).SymEigen()
+	for i := range values {
+		s[i] = math.Sqrt(math.Max(values[i], 0))
+	}
+	// singular values below eps are noise from squaring m
+	eps := 1e-7 * s[0]
+	var cols [3][3]float64
+	switch {
+	case s[0] == 0:
+		cols[0] = [3]float64{1, 0, 0}
+		cols[1] = [3]float64{0, 1, 0}
+	case s[1] <= eps:
+		s[1] = 0
+		cols[0] = unit3(m.apply(v[0][0], v[1][0], v[2][0]))
+		cols[1] = perpendicular(cols[0])
+	default:
+		cols[0] = unit3(m.apply(v[0][0], v[1][0], v[2][0]))
+		x, y, z := m.apply(v[0][1], v[1][1], v[2][1])
+		// keep u orthogonal despite rounding
+		d := x*cols[0][0] + y*cols[0][1] + z*cols[0][2]
+		cols[1] = unit3(x-d*cols[0][0], y-d*cols[0][1], z-d*cols[0][2])
+	}
+	cols[2] = cross3(cols[0], cols[1])
+	if s[2] > eps {
+		x, y, z := m.apply(v[0][2], v[1][2], v[2][2])
+		if x*cols[2][0]+y*cols[2][1]+z*cols[2][2] < 0 {
+			cols[2] = [3]float64{-cols[2][0], -cols[2][1], -cols[2][2]}
+		}
+	} else {
+		s[2] = 0
+	}
+	for i := 0; i < 3; i++ {
+		for r := 0; r < 3; r++ {
+			u[r][i] = cols[i][r]
+		}
+	}
+	return u, s, v
+}
+
+func unit3(x, y, z float64) [3]float64 {
+	n := math.Sqrt(x*x + y*y + z*z)
+	return [3]float64{x / n, y / n, z / n}
+}
+
+func cross3(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+// Unit vector perpendicular to the unit vector a
+func perpendicular(a [3]float64) [3]float64 {
+	// cross with the axis least aligned with a
+	var e [3]float64
+	switch {
+	case math.Abs(a[0]) <= math.Abs(a[1]) && math.Abs(a[0]) <= math.Abs(a[2]):
+		e[0] = 1
+	case math.Abs(a[1]) <= math.Abs(a[2]):
+		e[1] = 1
+	default:
+		e[2] = 1
+	}
+	p := cross3(a, e)
+	n := math.Sqrt(p[0]*p[0] + p[1]*p[1] + p[2]*p[2])
+	return [3]float64{p[0] / n, p[1] / n, p[2] / n}
+}
@@ -0,0 +1,135 @@
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Comparer(func(x, y float32) bool {
+		diff := math.Abs(float64(x - y))
+		return diff <= tolerance
+	})
+}
+
+func TestMat3Basics(t *testing.T) {
+	m := Mat3{{1, 2, 3}, {4, 5, 6}, {7, 8, 10}}
+	if got := m.Det(); math.Abs(got-(-3)) > 1e-12 {
+		t.Errorf("Det() = %v, want -3", got)
+	}
+	if got := m.Trace(); got != 16 {
+		t.Errorf("Trace() = %v, want 16", got)
+	}
+	if got, want := m.Transpose(), (Mat3{{1, 4, 7}, {2, 5, 8}, {3, 6, 10}}); got != want {
+		t.Errorf("Transpose() = %v, want %v", got, want)
+	}
+	inv, ok := m.Inverse()
+	if !ok || !m.Mul(inv).Equal(Identity3(), 1e-12) {
+		t.Errorf("Inverse() = %v, %v", inv, ok)
+	}
+	if _, ok := (Mat3{{1, 2, 3}, {2, 4, 6}, {0, 0, 1}}).Inverse(); ok {
+		t.Errorf("Inverse() of a singular matrix ok = true")
+	}
+	if got, want := m.Add(Identity3()).Sub(Identity3()).Scale(2), m.Mul(Diag3(2, 2, 2)); got != want {
+		t.Errorf("Add/Sub/Scale = %v, want %v", got, want)
+	}
+	if got := m.String(); got != "[[1 2 3] [4 5 6] [7 8 10]]" {
+		t.Errorf("String() = %v", got)
+	}
+	// 1e-15 like vector64, plus the optional tolerance
+	n := m
+	n[2][2] += 5e-16
+	if !m.Equal(n) || m.Equal(m.Scale(1.001)) || !m.Equal(m.Scale(1.001), 0.1) {
+		t.Errorf("Equal() tolerances")
+	}
+}
+
+func TestMat3Vectors(t *testing.T) {
+	opt := getComparer(1e-6)
+	a, b, c := vector.New(1, 2, 3), vector.New(4, 5, 6), vector.New(7, 8, 9)
+	m := FromColumns3(a, b, c)
+	if !cmp.Equal(m.Col(1), b) || !cmp.Equal(m.Row(0), vector.New(1, 4, 7)) {
+		t.Errorf("FromColumns3() = %v", m)
+	}
+	if got, want := m.MulVec(vector.New(1, 0, 2)), vector.New(15, 18, 21); !cmp.Equal(got, want, opt) {
+		t.Errorf("MulVec() = %v, want %v", got, want)
+	}
+	u := vector.New(-2, 0.5, 3)
+	if got, want := Skew3(a).MulVec(u), vector.Cross(a, u); !cmp.Equal(got, want, opt) {
+		t.Errorf("Skew3(%v).MulVec(%v) = %v, want %v", a, u, got, want)
+	}
+	if got, want := Outer3(a, u).MulVec(b), vector.New(1, 2, 3).Mult(vector.Dot(u, b)); !cmp.Equal(got, want, opt) {
+		t.Errorf("Outer3().MulVec() = %v, want %v", got, want)
+	}
+}
+
+func TestSymEigen3(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tests := []Mat3{
+		Diag3(1, 3, 2),
+		{{2, 1, 0}, {1, 2, 0}, {0, 0, 5}},
+		{{4, 4, 4}, {4, 4, 4}, {4, 4, 4}},
+		Identity3(),
+	}
+	for i := 0; i < 20; i++ {
+		var a Mat3
+		for j := range a {
+			for k := range a[j] {
+				a[j][k] = r.NormFloat64()
+			}
+		}
+		tests = append(tests, a.Add(a.Transpose()))
+	}
+	for _, m := range tests {
+		values, vectors := m.SymEigen()
+		if values[0] < values[1] || values[1] < values[2] {
+			t.Errorf("%v.SymEigen() values %v not decreasing", m, values)
+		}
+		if math.Abs(vectors.Det()-1) > 1e-9 || !vectors.Transpose().Mul(vectors).Equal(Identity3(), 1e-9) {
+			t.Errorf("%v.SymEigen() vectors %v not a rotation", m, vectors)
+		}
+		// m = V D V^T
+		back := vectors.Mul(Diag3(values[0], values[1], values[2])).Mul(vectors.Transpose())
+		if !back.Equal(m, 1e-9) {
+			t.Errorf("%v.SymEigen() reconstructs %v", m, back)
+		}
+	}
+}
+
+func TestSVD(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	tests := []Mat3{
+		Identity3(),
+		{},
+		Diag3(3, -2, 0),
+		Outer3(vector.New(1, 2, 3), vector.New(-1, 0, 2)),
+		{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+	}
+	for i := 0; i < 20; i++ {
+		var a Mat3
+		for j := range a {
+			for k := range a[j] {
+				a[j][k] = r.NormFloat64()
+			}
+		}
+		tests = append(tests, a)
+	}
+	for _, m := range tests {
+		u, s, v := m.SVD()
+		if s[0] < s[1] || s[1] < s[2] || s[2] < 0 {
+			t.Errorf("%v.SVD() s = %v", m, s)
+		}
+		for _, o := range []Mat3{u, v} {
+			if !o.Transpose().Mul(o).Equal(Identity3(), 1e-6) {
+				t.Errorf("%v.SVD() gives non orthogonal %v", m, o)
+			}
+		}
+		if back := u.Mul(Diag3(s[0], s[1], s[2])).Mul(v.Transpose()); !back.Equal(m, 1e-6) {
+			t.Errorf("%v.SVD() reconstructs %v", m, back)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package mat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// 4x4 matrix, M[row][col], for affine and projective transforms of
+// vector.Vector in homogeneous coordinates
+type Mat4 [4][4]float64
+
+// Gives the 4x4 identity matrix
+func Identity4() Mat4 {
+	return Mat4{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+}
+
+// Makes the affine transform applying m, then moving by t
+func Affine4(m Mat3, t *vector.Vector) Mat4 {
+	return Mat4{
+		{m[0][0], m[0][1], m[0][2], float64(t.X)},
+		{m[1][0], m[1][1], m[1][2], float64(t.Y)},
+		{m[2][0], m[2][1], m[2][2], float64(t.Z)},
+		{0, 0, 0, 1},
+	}
+}
+
+// Makes the transform moving by t
+func Translation4(t *vector.Vector) Mat4 {
+	return Affine4(Identity3(), t)
+}
+
+// String representation of the matrix
+func (m Mat4) String() string {
+	return fmt.Sprintf("[%v %v %v %v]", m[0], m[1], m[2], m[3])
+}
+
+// Checks whether two matrices are equal.
+// optional tolerence value can be passed as a parameter to check for equality within a tolerance.
+func (m Mat4) Equal(m2 Mat4, tolerance ...float64) bool {
+	t := 1e-15
+	if len(tolerance) >= 1 {
+		t += tolerance[0]
+	}
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if math.Abs(m[i][j]-m2[i][j]) > t {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Gives the upper left 3x3 block, the linear part of an affine transform
+func (m Mat4) Mat3() Mat3 {
+	return Mat3{
+		{m[0][0], m[0][1], m[0][2]},
+		{m[1][0], m[1][1], m[1][2]},
+		{m[2][0], m[2][1], m[2][2]},
+	}
+}
+
+// Gives the translation of an affine transform, the last column
+func (m Mat4) Translation() *vector.Vector {
+	return vector.New(float32(m[0][3]), float32(m[1][3]), float32(m[2][3]))
+}
+
+// Matrix product m*m2
+func (m Mat4) Mul(m2 Mat4) Mat4 {
+	var r Mat4
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			r[i][j] = m[i][0]*m2[0][j] + m[i][1]*m2[1][j] + m[i][2]*m2[2][j] + m[i][3]*m2[3][j]
+		}
+	}
+	return r
+}
+
+// Applies the matrix to a homogeneous vector, m*v
+func (m Mat4) MulVec4(v [4]float64) [4]float64 {
+	var r [4]float64
+	for i := 0; i < 4; i++ {
+		r[i] = m[i][0]*v[0] + m[i][1]*v[1] + m[i][2]*v[2] + m[i][3]*v[3]
+	}
+	return r
+}
+
+// Transforms a point, m*(v, 1), dividing by the resulting w of projective
+// matrices
+func (m Mat4) MulPoint(v *vector.Vector) *vector.Vector {
+	r := m.MulVec4([4]float64{float64(v.X), float64(v.Y), float64(v.Z), 1})
+	if r[3] != 1 {
+		r[0], r[1], r[2] = r[0]/r[3], r[1]/r[3], r[2]/r[3]
+	}
+	return vector.New(float32(r[0]), float32(r[1]), float32(r[2]))
+}
+
+// Transforms a direction, m*(v, 0), which ignores the translation
+func (m Mat4) MulDirection(v *vector.Vector) *vector.Vector {
+	x, y, z := m.Mat3().apply(float64(v.X), float64(v.Y), float64(v.Z))
+	return vector.New(float32(x), float32(y), float32(z))
+}
+
+// Transposed matrix
+func (m Mat4) Transpose() Mat4 {
+	var r Mat4
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			r[i][j] = m[j][i]
+		}
+	}
+	return r
+}
+
+// Determinants of the 2x2 blocks of the top two and the bottom two rows
+func (m Mat4) minors() (s, c [6]float64) {
+	pairs := [6][2]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}
+	for k, p := range pairs {
+		s[k] = m[0][p[0]]*m[1][p[1]] - m[1][p[0]]*m[0][p[1]]
+		c[k] = m[2][p[0]]*m[3][p[1]] - m[3][p[0]]*m[2][p[1]]
+	}
+	return s, c
+}
+
+// Determinant of the matrix
+func (m Mat4) Det() float64 {
+	s, c := m.minors()
+	return s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+}
+
+// Inverse of the matrix, ok is false if the matrix is singular.
+// Uses the Laplace expansion along the top two and bottom two rows.
+func (m Mat4) Inverse() (inv Mat4, ok bool) {
+	s, c := m.minors()
+	det := s[0]*c[5] - s[1]*c[4] + s[2]*c[3] + s[3]*c[2] - s[4]*c[1] + s[5]*c[0]
+	if det == 0 {
+		return Mat4{}, false
+	}
+	inv = Mat4{
+		{
+			m[1][1]*c[5] - m[1][2]*c[4] + m[1][3]*c[3],
+			-m[0][1]*c[5] + m[0][2]*c[4] - m[0][3]*c[3],
+			m[3][1]*s[5] - m[3][2]*s[4] + m[3][3]*s[3],
+			-m[2][1]*s[5] + m[2][2]*s[4] - m[2][3]*s[3],
+		},
+		{
+			-m[1][0]*c[5] + m[1][2]*c[2] - m[1][3]*c[1],
+			m[0][0]*c[5] - m[0][2]*c[2] + m[0][3]*c[1],
+			-m[3][0]*s[5] + m[3][2]*s[2] - m[3][3]*s[1],
+			m[2][0]*s[5] - m[2][2]*s[2] + m[2][3]*s[1],
+		},
+		{
+			m[1][0]*c[4] - m[1][1]*c[2] + m[1][3]*c[0],
+			-m[0][0]*c[4] + m[0][1]*c[2] - m[0][3]*c[0],
+			m[3][0]*s[4] - m[3][1]*s[2] + m[3][3]*s[0],
+			-m[2][0]*s[4] + m[2][1]*s[2] - m[2][3]*s[0],
+		},
+		{
+			-m[1][0]*c[3] + m[1][1]*c[1] - m[1][2]*c[0],
+			m[0][0]*c[3] - m[0][1]*c[1] + m[0][2]*c[0],
+			-m[3][0]*s[3] + m[3][1]*s[1] - m[3][2]*s[0],
+			m[2][0]*s[3] - m[2][1]*s[1] + m[2][2]*s[0],
+		},
+	}
+	for i := range inv {
+		for j := range inv[i] {
+			inv[i][j] /= det
+		}
+	}
+	return inv, true
+}
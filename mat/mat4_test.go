@@ -0,0 +1,85 @@
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func randomMat4(r *rand.Rand) Mat4 {
+	var m Mat4
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = r.NormFloat64()
+		}
+	}
+	return m
+}
+
+func TestMat4Basics(t *testing.T) {
+	m := Mat4{{2, 0, 0, 1}, {0, 3, 0, 2}, {1, 0, 4, 3}, {0, 0, 0, 1}}
+	if got := m.Det(); math.Abs(got-24) > 1e-12 {
+		t.Errorf("Det() = %v, want 24", got)
+	}
+	if got := m.Transpose().Transpose(); got != m {
+		t.Errorf("Transpose().Transpose() = %v", got)
+	}
+	if got := m.String(); got != "[[2 0 0 1] [0 3 0 2] [1 0 4 3] [0 0 0 1]]" {
+		t.Errorf("String() = %v", got)
+	}
+	// 1e-15 like vector64, plus the optional tolerance
+	n := m
+	n[0][3] += 5e-16
+	if !m.Equal(n) || m.Equal(Translation4(vector.New(0, 0, 0.001)).Mul(m)) || !m.Equal(Translation4(vector.New(0, 0, 0.001)).Mul(m), 0.01) {
+		t.Errorf("Equal() tolerances")
+	}
+	if _, ok := (Mat4{{1, 2, 3, 4}, {2, 4, 6, 8}, {0, 0, 1, 0}, {0, 0, 0, 1}}).Inverse(); ok {
+		t.Errorf("Inverse() of a singular matrix ok = true")
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		a, b := randomMat4(r), randomMat4(r)
+		inv, ok := a.Inverse()
+		if !ok || !a.Mul(inv).Equal(Identity4(), 1e-9) || !inv.Mul(a).Equal(Identity4(), 1e-9) {
+			t.Fatalf("%v.Inverse() = %v, %v", a, inv, ok)
+		}
+		if got, want := a.Mul(b).Det(), a.Det()*b.Det(); math.Abs(got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+			t.Fatalf("Det(a*b) = %v, want %v", got, want)
+		}
+		if got, want := a.Transpose().Det(), a.Det(); math.Abs(got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+			t.Fatalf("Det(a^T) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMat4Affine(t *testing.T) {
+	opt := getComparer(1e-5)
+	rot := RotationAxisAngle(vector.New(1, 2, 3), 0.8)
+	tr := vector.New(4, -5, 6)
+	m := Affine4(rot.Scale(2), tr)
+	v := vector.New(1, -1, 0.5)
+	if got, want := m.MulPoint(v), vector.Add(rot.MulVec(v).Mult(2), tr); !cmp.Equal(got, want, opt) {
+		t.Errorf("MulPoint() = %v, want %v", got, want)
+	}
+	if got, want := m.MulDirection(v), rot.MulVec(v).Mult(2); !cmp.Equal(got, want, opt) {
+		t.Errorf("MulDirection() = %v, want %v", got, want)
+	}
+	if !m.Mat3().Equal(rot.Scale(2)) || !cmp.Equal(m.Translation(), tr) {
+		t.Errorf("Mat3(), Translation() = %v, %v", m.Mat3(), m.Translation())
+	}
+	if got, want := Translation4(tr).Mul(Affine4(rot, vector.New(0, 0, 0))), Affine4(rot, tr); !got.Equal(want) {
+		t.Errorf("Translation4() * rotation = %v, want %v", got, want)
+	}
+	// projective matrices divide by w
+	p := Identity4()
+	p[3] = [4]float64{0, 0, 1, 0}
+	if got, want := p.MulPoint(vector.New(2, 4, 2)), vector.New(1, 2, 1); !cmp.Equal(got, want) {
+		t.Errorf("MulPoint() of a projection = %v, want %v", got, want)
+	}
+	if got := p.MulVec4([4]float64{2, 4, 2, 1}); got != [4]float64{2, 4, 2, 2} {
+		t.Errorf("MulVec4() = %v", got)
+	}
+}
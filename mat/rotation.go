@@ -0,0 +1,64 @@
+package mat
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Makes the matrix rotating by angle (radians) around axis, following the
+// right hand rule like vector.RotateAlongAxis. A zero axis gives the identity.
+// https://en.wikipedia.org/wiki/Rodrigues%27_rotation_formula
+func RotationAxisAngle(axis *vector.Vector, angle float64) Mat3 {
+	x, y, z := float64(axis.X), float64(axis.Y), float64(axis.Z)
+	m := math.Sqrt(x*x + y*y + z*z)
+	if m == 0 {
+		return Identity3()
+	}
+	x, y, z = x/m, y/m, z/m
+	s, c := math.Sincos(angle)
+	t := 1 - c
+	return Mat3{
+		{t*x*x + c, t*x*y - s*z, t*x*z + s*y},
+		{t*x*y + s*z, t*y*y + c, t*y*z - s*x},
+		{t*x*z - s*y, t*y*z + s*x, t*z*z + c},
+	}
+}
+
+// Gives the unit axis and the angle in [0, π] of a rotation matrix,
+// so that v.RotateAlongAxis(axis, angle) equals m.MulVec(v).
+// The identity gives the x axis and a zero angle.
+func (m Mat3) AxisAngle() (axis *vector.Vector, angle float64) {
+	x, y, z := m[2][1]-m[1][2], m[0][2]-m[2][0], m[1][0]-m[0][1]
+	n := math.Sqrt(x*x + y*y + z*z)
+	// n = 2 sin(angle), trace = 1 + 2 cos(angle)
+	angle = math.Atan2(n, m.Trace()-1)
+	if n < 1e-6 {
+		if angle < math.Pi/2 {
+			return vector.New(1, 0, 0), 0
+		}
+		// close to a half turn the axis comes from the diagonal, m = 2aa^T - I
+		d := [3]float64{m[0][0], m[1][1], m[2][2]}
+		k := 0
+		if d[1] > d[k] {
+			k = 1
+		}
+		if d[2] > d[k] {
+			k = 2
+		}
+		var a [3]float64
+		a[k] = math.Sqrt(math.Max((d[k]+1)/2, 0))
+		for i := 0; i < 3; i++ {
+			if i != k {
+				a[i] = (m[i][k] + m[k][i]) / (4 * a[k])
+			}
+		}
+		return vector.New(float32(a[0]), float32(a[1]), float32(a[2])).Normalize(), angle
+	}
+	return vector.New(float32(x/n), float32(y/n), float32(z/n)), angle
+}
+
+// Makes the rotation matrix turning by |v| radians around v (exponential map)
+func RotationVector(v *vector.Vector) Mat3 {
+	return RotationAxisAngle(v, float64(v.Mag()))
+}
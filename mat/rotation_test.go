@@ -0,0 +1,59 @@
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestRotationAxisAngle(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	opt := getComparer(1e-5)
+	for i := 0; i < 100; i++ {
+		axis := vector.New(r.Float32()-0.5, r.Float32()-0.5, r.Float32()-0.5)
+		angle := (r.Float64() - 0.5) * 4 * math.Pi
+		v := vector.New(r.Float32()*4-2, r.Float32()*4-2, r.Float32()*4-2)
+		m := RotationAxisAngle(axis, angle)
+		if got, want := m.MulVec(v), vector.RotateAlongAxis(v, axis, float32(angle)); !cmp.Equal(got, want, opt) {
+			t.Fatalf("RotationAxisAngle(%v, %v).MulVec(%v) = %v, want %v", axis, angle, v, got, want)
+		}
+		if !m.Transpose().Mul(m).Equal(Identity3(), 1e-12) || math.Abs(m.Det()-1) > 1e-12 {
+			t.Fatalf("RotationAxisAngle(%v, %v) = %v is not a rotation", axis, angle, m)
+		}
+	}
+	if got := RotationAxisAngle(vector.New(0, 0, 0), 1); got != Identity3() {
+		t.Errorf("RotationAxisAngle(zero axis) = %v", got)
+	}
+}
+
+func TestAxisAngle(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	opt := getComparer(1e-5)
+	angles := []float64{0, 1e-3, 0.5, 2, math.Pi - 1e-4, math.Pi}
+	for _, angle := range angles {
+		for i := 0; i < 20; i++ {
+			axis := vector.New(r.Float32()-0.5, r.Float32()-0.5, r.Float32()-0.5).Normalize()
+			m := RotationAxisAngle(axis, angle)
+			gotAxis, gotAngle := m.AxisAngle()
+			back := RotationAxisAngle(gotAxis, gotAngle)
+			if !back.Equal(m, 1e-5) {
+				t.Fatalf("AxisAngle() of (%v, %v) = (%v, %v)", axis, angle, gotAxis, gotAngle)
+			}
+			v := vector.New(1, 2, 3)
+			if got, want := v.Copy().RotateAlongAxis(gotAxis, float32(gotAngle)), m.MulVec(v); !cmp.Equal(got, want, opt) {
+				t.Fatalf("RotateAlongAxis(AxisAngle()) = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestRotationVector(t *testing.T) {
+	v := vector.New(0, 0, math.Pi/2)
+	opt := getComparer(1e-6)
+	if got, want := RotationVector(v).MulVec(vector.New(1, 0, 0)), vector.New(0, 1, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("RotationVector(%v).MulVec({1, 0, 0}) = %v, want %v", v, got, want)
+	}
+}
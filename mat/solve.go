@@ -0,0 +1,50 @@
+package mat
+
+import (
+	"math"
+)
+
+// Solves the square system a*x = b of any size, a given as rows, with
+// Gaussian elimination and partial pivoting. ok is false if a is (nearly)
+// singular. a and b are overwritten.
+func Solve(a [][]float64, b []float64) (x []float64, ok bool) {
+	n := len(b)
+	scale := 0.0
+	for i := range a {
+		for _, v := range a[i] {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	if scale == 0 {
+		return nil, false
+	}
+	for c := 0; c < n; c++ {
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(a[r][c]) > math.Abs(a[p][c]) {
+				p = r
+			}
+		}
+		if math.Abs(a[p][c]) <= 1e-12*scale {
+			return nil, false
+		}
+		a[c], a[p] = a[p], a[c]
+		b[c], b[p] = b[p], b[c]
+		for r := c + 1; r < n; r++ {
+			f := a[r][c] / a[c][c]
+			for k := c; k < n; k++ {
+				a[r][k] -= f * a[c][k]
+			}
+			b[r] -= f * b[c]
+		}
+	}
+	x = make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		s := b[r]
+		for k := r + 1; k < n; k++ {
+			s -= a[r][k] * x[k]
+		}
+		x[r] = s / a[r][r]
+	}
+	return x, true
+}
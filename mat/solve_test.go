@@ -0,0 +1,19 @@
+package mat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSolve(t *testing.T) {
+	a := [][]float64{{0, 2, 1}, {1, 1, 1}, {2, 0, -1}}
+	got, ok := Solve(a, []float64{7, 6, -1})
+	if want := []float64{1, 2, 3}; !ok || !cmp.Equal(got, want, cmp.Comparer(func(x, y float64) bool { return math.Abs(x-y) < 1e-12 })) {
+		t.Errorf("Solve() = %v, %v, want %v", got, ok, want)
+	}
+	if _, ok := Solve([][]float64{{1, 2}, {2, 4}}, []float64{1, 2}); ok {
+		t.Errorf("Solve(singular) ok")
+	}
+}
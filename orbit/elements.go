@@ -0,0 +1,184 @@
+// Package orbit converts between position/velocity state vectors and
+// classical orbital elements, propagates Keplerian orbits of any eccentricity
+// with Kepler's equation and integrates two-body and n-body motion with
+// symplectic integrators. Vectors are double precision, from the vector64
+// package, and units are those of the gravitational parameter mu = G*M.
+// https://en.wikipedia.org/wiki/Orbital_elements
+package orbit
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Relative size below which an eccentricity or node vector counts as zero,
+// making the orbit circular or equatorial
+const singularTolerance = 1e-11
+
+type p3 [3]float64
+
+func add3(a, b p3) p3           { return p3{a[0] + b[0], a[1] + b[1], a[2] + b[2]} }
+func sub3(a, b p3) p3           { return p3{a[0] - b[0], a[1] - b[1], a[2] - b[2]} }
+func scale3(a p3, s float64) p3 { return p3{a[0] * s, a[1] * s, a[2] * s} }
+func dot3(a, b p3) float64      { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+func norm3(a p3) float64        { return math.Sqrt(dot3(a, a)) }
+func cross3(a, b p3) p3 {
+	return p3{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func from(v *vector64.Vector) p3      { return p3{v.X, v.Y, v.Z} }
+func (a p3) vector() *vector64.Vector { return vector64.New(a[0], a[1], a[2]) }
+
+// Angle wrapped to [0, 2pi)
+func wrap(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return a
+}
+
+// Angle from a to b turning about the unit axis n
+func angleAbout(a, b, n p3) float64 {
+	return math.Atan2(dot3(n, cross3(a, b)), dot3(a, b))
+}
+
+// Classical orbital elements. The semi-latus rectum p = a(1 - e^2) is used
+// instead of the semi-major axis a so that parabolic orbits are described too.
+// Angles are in radians in [0, 2pi).
+//
+// On an equatorial orbit the node is taken on the X axis, so RAAN is 0 and the
+// argument of periapsis is measured from X. On a circular orbit the periapsis
+// is taken at the node, so the argument of periapsis is 0 and the true anomaly
+// is the argument of latitude.
+type Elements struct {
+	SemiLatusRectum float64
+	Eccentricity    float64
+	Inclination     float64
+	// Right ascension of the ascending node
+	RAAN                float64
+	ArgumentOfPeriapsis float64
+	TrueAnomaly         float64
+}
+
+// Elements from the semi-major axis a, negative for hyperbolic orbits
+func NewElements(a, e, inclination, raan, argumentOfPeriapsis, trueAnomaly float64) Elements {
+	return Elements{
+		SemiLatusRectum:     a * (1 - e*e),
+		Eccentricity:        e,
+		Inclination:         inclination,
+		RAAN:                raan,
+		ArgumentOfPeriapsis: argumentOfPeriapsis,
+		TrueAnomaly:         trueAnomaly,
+	}
+}
+
+// Orbital elements of a body at position r with velocity v relative to a
+// central body of gravitational parameter mu
+func FromState(r, v *vector64.Vector, mu float64) Elements {
+	return fromState(from(r), from(v), mu)
+}
+
+func fromState(r, v p3, mu float64) Elements {
+	h := cross3(r, v)
+	hm := norm3(h)
+	rm := norm3(r)
+	// eccentricity vector, pointing to the periapsis
+	ev := scale3(sub3(scale3(r, dot3(v, v)-mu/rm), scale3(v, dot3(r, v))), 1/mu)
+	el := Elements{
+		SemiLatusRectum: hm * hm / mu,
+		Eccentricity:    norm3(ev),
+	}
+	k := scale3(h, 1/hm)
+	el.Inclination = math.Atan2(math.Hypot(h[0], h[1]), h[2])
+	node := p3{-h[1], h[0], 0}
+	if nm := norm3(node); nm > singularTolerance*hm {
+		node = scale3(node, 1/nm)
+		el.RAAN = wrap(math.Atan2(node[1], node[0]))
+	} else {
+		node = p3{1, 0, 0}
+	}
+	periapsis := node
+	if el.Eccentricity > singularTolerance {
+		periapsis = scale3(ev, 1/el.Eccentricity)
+		el.ArgumentOfPeriapsis = wrap(angleAbout(node, periapsis, k))
+	} else {
+		el.Eccentricity = 0
+	}
+	el.TrueAnomaly = wrap(angleAbout(periapsis, r, k))
+	return el
+}
+
+// Position and velocity relative to the central body of gravitational
+// parameter mu
+func (el Elements) State(mu float64) (r, v *vector64.Vector) {
+	pr, pv := el.state(mu)
+	return pr.vector(), pv.vector()
+}
+
+func (el Elements) state(mu float64) (r, v p3) {
+	sinNu, cosNu := math.Sincos(el.TrueAnomaly)
+	rm := el.SemiLatusRectum / (1 + el.Eccentricity*cosNu)
+	vm := math.Sqrt(mu / el.SemiLatusRectum)
+	// perifocal frame: P to the periapsis, Q a quarter turn ahead
+	P, Q := el.perifocal()
+	r = add3(scale3(P, rm*cosNu), scale3(Q, rm*sinNu))
+	v = add3(scale3(P, -vm*sinNu), scale3(Q, vm*(el.Eccentricity+cosNu)))
+	return r, v
+}
+
+// Unit vectors to the periapsis and a quarter turn ahead of it in the plane
+// of the orbit
+func (el Elements) perifocal() (P, Q p3) {
+	sinO, cosO := math.Sincos(el.RAAN)
+	sinI, cosI := math.Sincos(el.Inclination)
+	sinW, cosW := math.Sincos(el.ArgumentOfPeriapsis)
+	P = p3{
+		cosO*cosW - sinO*sinW*cosI,
+		sinO*cosW + cosO*sinW*cosI,
+		sinW * sinI,
+	}
+	Q = p3{
+		-cosO*sinW - sinO*cosW*cosI,
+		-sinO*sinW + cosO*cosW*cosI,
+		cosW * sinI,
+	}
+	return P, Q
+}
+
+// Semi-major axis, negative for hyperbolic orbits and infinite for parabolic
+// ones
+func (el Elements) SemiMajorAxis() float64 {
+	if el.Eccentricity == 1 {
+		return math.Inf(1)
+	}
+	return el.SemiLatusRectum / (1 - el.Eccentricity*el.Eccentricity)
+}
+
+// Closest distance to the central body
+func (el Elements) Periapsis() float64 {
+	return el.SemiLatusRectum / (1 + el.Eccentricity)
+}
+
+// Farthest distance from the central body, infinite for open orbits
+func (el Elements) Apoapsis() float64 {
+	if el.Eccentricity >= 1 {
+		return math.Inf(1)
+	}
+	return el.SemiLatusRectum / (1 - el.Eccentricity)
+}
+
+// Specific orbital energy, negative for bound orbits
+func (el Elements) Energy(mu float64) float64 {
+	return -mu * (1 - el.Eccentricity*el.Eccentricity) / (2 * el.SemiLatusRectum)
+}
+
+// Orbital period, infinite for open orbits
+func (el Elements) Period(mu float64) float64 {
+	if el.Eccentricity >= 1 {
+		return math.Inf(1)
+	}
+	a := el.SemiMajorAxis()
+	return 2 * math.Pi * math.Sqrt(a*a*a/mu)
+}
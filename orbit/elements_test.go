@@ -0,0 +1,102 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+const muEarth = 398600.4418
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector64.Vector) bool {
+			return a == b || a != nil && b != nil && a.Equal(b, tolerance)
+		}),
+		cmp.Comparer(func(a, b float64) bool { return a == b || math.Abs(a-b) <= tolerance }),
+	}
+}
+
+func deg(d float64) float64 { return d * math.Pi / 180 }
+
+func TestFromState(t *testing.T) {
+	// Vallado, Fundamentals of Astrodynamics and Applications, example 2-5
+	r := vector64.New(6524.834, 6862.875, 6448.296)
+	v := vector64.New(4.901327, 5.533756, -1.976341)
+	got := FromState(r, v, muEarth)
+	want := Elements{
+		SemiLatusRectum:     got.SemiLatusRectum,
+		Eccentricity:        0.832853,
+		Inclination:         deg(87.870),
+		RAAN:                deg(227.898),
+		ArgumentOfPeriapsis: deg(53.38),
+		TrueAnomaly:         deg(92.335),
+	}
+	if diff := cmp.Diff(want, got, getComparer(2e-3)); diff != "" {
+		t.Errorf("FromState() mismatch (-want +got):\n%s", diff)
+	}
+	if p := got.SemiLatusRectum; math.Abs(p-11067.790) > 1e-2 {
+		t.Errorf("SemiLatusRectum = %v, want 11067.790", p)
+	}
+	if a := got.SemiMajorAxis(); math.Abs(a-36127.343) > 1e-2 {
+		t.Errorf("SemiMajorAxis() = %v, want 36127.343", a)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		el   Elements
+	}{
+		{"elliptic", NewElements(8000, 0.3, deg(40), deg(100), deg(250), deg(30))},
+		{"circular", NewElements(7000, 0, deg(51.6), deg(300), 0, deg(120))},
+		{"equatorial", NewElements(9000, 0.5, 0, 0, deg(75), deg(200))},
+		{"retrograde equatorial", NewElements(9000, 0.5, math.Pi, 0, deg(75), deg(200))},
+		{"circular equatorial", NewElements(42164, 0, 0, 0, 0, deg(10))},
+		{"hyperbolic", NewElements(-20000, 1.8, deg(120), deg(20), deg(310), deg(80))},
+		{"hyperbolic inbound", NewElements(-20000, 1.8, deg(120), deg(20), deg(310), deg(300))},
+		{"parabolic", Elements{SemiLatusRectum: 14000, Eccentricity: 1, Inclination: deg(10), RAAN: deg(5), ArgumentOfPeriapsis: deg(15), TrueAnomaly: deg(90)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, v := tt.el.State(muEarth)
+			got := FromState(r, v, muEarth)
+			if diff := cmp.Diff(tt.el, got, getComparer(1e-8)); diff != "" {
+				t.Errorf("FromState(State()) mismatch (-want +got):\n%s", diff)
+			}
+			// energy and angular momentum of the state
+			energy := v.MagSq()/2 - muEarth/r.Mag()
+			if e := tt.el.Energy(muEarth); math.Abs(e-energy) > 1e-9 {
+				t.Errorf("Energy() = %v, want %v", e, energy)
+			}
+			if h := vector64.Cross(r, v).Mag(); math.Abs(h*h/muEarth-tt.el.SemiLatusRectum) > 1e-6 {
+				t.Errorf("angular momentum %v does not match the semi-latus rectum", h)
+			}
+		})
+	}
+}
+
+func TestShape(t *testing.T) {
+	el := NewElements(10000, 0.2, 0, 0, 0, 0)
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"SemiMajorAxis", el.SemiMajorAxis(), 10000},
+		{"Periapsis", el.Periapsis(), 8000},
+		{"Apoapsis", el.Apoapsis(), 12000},
+		{"Period", el.Period(muEarth), 2 * math.Pi * math.Sqrt(1e12/muEarth)},
+		{"Energy", el.Energy(muEarth), -muEarth / 20000},
+		{"parabolic SemiMajorAxis", Elements{SemiLatusRectum: 1, Eccentricity: 1}.SemiMajorAxis(), math.Inf(1)},
+		{"hyperbolic SemiMajorAxis", NewElements(-5000, 2, 0, 0, 0, 0).SemiMajorAxis(), -5000},
+		{"hyperbolic Apoapsis", NewElements(-5000, 2, 0, 0, 0, 0).Apoapsis(), math.Inf(1)},
+		{"hyperbolic Period", NewElements(-5000, 2, 0, 0, 0, 0).Period(muEarth), math.Inf(1)},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.got, getComparer(1e-9)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
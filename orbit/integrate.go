@@ -0,0 +1,183 @@
+package orbit
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Symplectic integration scheme. Symplectic schemes keep the energy error
+// bounded over long runs instead of letting it drift.
+// https://en.wikipedia.org/wiki/Symplectic_integrator
+type Integrator int
+
+const (
+	// Second order drift-kick-drift leapfrog, one force evaluation per step
+	Leapfrog Integrator = iota
+	// Fourth order Yoshida composition of leapfrogs, three force evaluations
+	// per step
+	Yoshida
+)
+
+// Drift (position) and kick (velocity) weights of the schemes
+type scheme struct{ drift, kick []float64 }
+
+var schemes = func() map[Integrator]scheme {
+	w1 := 1 / (2 - math.Cbrt(2))
+	w0 := 1 - 2*w1
+	return map[Integrator]scheme{
+		Leapfrog: {[]float64{0.5, 0.5}, []float64{1}},
+		Yoshida:  {[]float64{w1 / 2, (w0 + w1) / 2, (w0 + w1) / 2, w1 / 2}, []float64{w1, w0, w1}},
+	}
+}()
+
+// Advances positions x and velocities v by dt, acc filling the accelerations
+// a of the positions x
+func (in Integrator) step(x, v, a []p3, dt float64, acc func(x, a []p3)) {
+	s, ok := schemes[in]
+	if !ok {
+		s = schemes[Leapfrog]
+	}
+	for i, c := range s.drift {
+		for j := range x {
+			x[j] = add3(x[j], scale3(v[j], c*dt))
+		}
+		if i < len(s.kick) {
+			acc(x, a)
+			for j := range v {
+				v[j] = add3(v[j], scale3(a[j], s.kick[i]*dt))
+			}
+		}
+	}
+}
+
+// Body orbiting a fixed central body of gravitational parameter Mu
+type TwoBody struct {
+	Position, Velocity *vector64.Vector
+	Mu                 float64
+	Integrator         Integrator
+}
+
+// Advances the body by a time step dt
+func (b *TwoBody) Step(dt float64) {
+	x, v, a := []p3{from(b.Position)}, []p3{from(b.Velocity)}, make([]p3, 1)
+	b.Integrator.step(x, v, a, dt, func(x, a []p3) {
+		r := norm3(x[0])
+		a[0] = scale3(x[0], -b.Mu/(r*r*r))
+	})
+	b.Position.Assign(x[0].vector())
+	b.Velocity.Assign(v[0].vector())
+}
+
+// Specific orbital energy, conserved by the exact motion
+func (b *TwoBody) Energy() float64 {
+	return b.Velocity.MagSq()/2 - b.Mu/b.Position.Mag()
+}
+
+// Orbital elements of the current state
+func (b *TwoBody) Elements() Elements {
+	return FromState(b.Position, b.Velocity, b.Mu)
+}
+
+// Point mass of an n-body system
+type Body struct {
+	Position, Velocity *vector64.Vector
+	Mass               float64
+}
+
+// Bodies attracting each other by gravity
+type System struct {
+	Bodies []*Body
+	// Gravitational constant
+	G float64
+	// Length added in quadrature to the distances, avoiding the singular
+	// forces of close encounters; 0 for exact gravity
+	Softening  float64
+	Integrator Integrator
+}
+
+// Creates an empty system with gravitational constant g
+func NewSystem(g float64) *System {
+	return &System{G: g}
+}
+
+// Adds a body to the system
+func (s *System) AddBody(position, velocity *vector64.Vector, mass float64) *Body {
+	b := &Body{Position: position, Velocity: velocity, Mass: mass}
+	s.Bodies = append(s.Bodies, b)
+	return b
+}
+
+func (s *System) accelerations(x, a []p3) {
+	eps2 := s.Softening * s.Softening
+	for i := range a {
+		a[i] = p3{}
+	}
+	for i := range x {
+		for j := i + 1; j < len(x); j++ {
+			d := sub3(x[j], x[i])
+			r2 := dot3(d, d) + eps2
+			f := s.G / (r2 * math.Sqrt(r2))
+			a[i] = add3(a[i], scale3(d, f*s.Bodies[j].Mass))
+			a[j] = sub3(a[j], scale3(d, f*s.Bodies[i].Mass))
+		}
+	}
+}
+
+// Advances all the bodies by a time step dt
+func (s *System) Step(dt float64) {
+	n := len(s.Bodies)
+	x, v, a := make([]p3, n), make([]p3, n), make([]p3, n)
+	for i, b := range s.Bodies {
+		x[i], v[i] = from(b.Position), from(b.Velocity)
+	}
+	s.Integrator.step(x, v, a, dt, s.accelerations)
+	for i, b := range s.Bodies {
+		b.Position.Assign(x[i].vector())
+		b.Velocity.Assign(v[i].vector())
+	}
+}
+
+// Total kinetic and potential energy, including the softening
+func (s *System) Energy() float64 {
+	eps2 := s.Softening * s.Softening
+	e := 0.0
+	for i, b := range s.Bodies {
+		e += b.Mass * b.Velocity.MagSq() / 2
+		for _, c := range s.Bodies[i+1:] {
+			e -= s.G * b.Mass * c.Mass / math.Sqrt(vector64.Sub(b.Position, c.Position).MagSq()+eps2)
+		}
+	}
+	return e
+}
+
+// Total linear momentum
+func (s *System) Momentum() *vector64.Vector {
+	p := vector64.New(0, 0, 0)
+	for _, b := range s.Bodies {
+		p.Add(b.Velocity.Copy().Mult(b.Mass))
+	}
+	return p
+}
+
+// Total angular momentum about the origin
+func (s *System) AngularMomentum() *vector64.Vector {
+	l := vector64.New(0, 0, 0)
+	for _, b := range s.Bodies {
+		l.Add(vector64.Cross(b.Position, b.Velocity).Mult(b.Mass))
+	}
+	return l
+}
+
+// Center of mass, the origin for a massless system
+func (s *System) CenterOfMass() *vector64.Vector {
+	c, m := vector64.New(0, 0, 0), 0.0
+	for _, b := range s.Bodies {
+		c.Add(b.Position.Copy().Mult(b.Mass))
+		m += b.Mass
+	}
+	if m == 0 {
+		return c
+	}
+	return c.Mult(1 / m)
+}
@@ -0,0 +1,130 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+func TestTwoBody(t *testing.T) {
+	el := NewElements(10000, 0.4, deg(30), deg(60), deg(90), 0)
+	period := el.Period(muEarth)
+	tests := []struct {
+		name       string
+		integrator Integrator
+		steps      int
+		tolerance  float64
+	}{
+		{"leapfrog", Leapfrog, 20000, 1},
+		{"yoshida", Yoshida, 2000, 1e-1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, v := el.State(muEarth)
+			b := &TwoBody{Position: r, Velocity: v, Mu: muEarth, Integrator: tt.integrator}
+			e0 := b.Energy()
+			dt := 0.5 * period / float64(tt.steps)
+			maxDrift := 0.0
+			for i := 0; i < tt.steps; i++ {
+				b.Step(dt)
+				maxDrift = math.Max(maxDrift, math.Abs(b.Energy()-e0))
+			}
+			// half a period later the body is at the apoapsis
+			want, _ := el.Propagate(period/2, muEarth).State(muEarth)
+			if diff := cmp.Diff(want, b.Position, getComparer(tt.tolerance)); diff != "" {
+				t.Errorf("position mismatch (-want +got):\n%s", diff)
+			}
+			if maxDrift > 1e-4*math.Abs(e0) {
+				t.Errorf("energy drifted by %v of %v", maxDrift, e0)
+			}
+			if got := b.Elements(); math.Abs(got.Eccentricity-0.4) > 1e-4 {
+				t.Errorf("Elements() eccentricity = %v, want 0.4", got.Eccentricity)
+			}
+		})
+	}
+}
+
+func TestYoshidaOrder(t *testing.T) {
+	// halving the step divides the error by 16
+	el := NewElements(10000, 0.2, 0, 0, 0, 0)
+	want, _ := el.Propagate(2000, muEarth).State(muEarth)
+	errAt := func(steps int) float64 {
+		r, v := el.State(muEarth)
+		b := &TwoBody{Position: r, Velocity: v, Mu: muEarth, Integrator: Yoshida}
+		for i := 0; i < steps; i++ {
+			b.Step(2000 / float64(steps))
+		}
+		return vector64.Dist(want, b.Position)
+	}
+	if ratio := errAt(100) / errAt(200); ratio < 12 || ratio > 20 {
+		t.Errorf("error ratio %v, want about 16", ratio)
+	}
+}
+
+func TestSystem(t *testing.T) {
+	// a sun, a planet and a moon
+	s := NewSystem(1)
+	s.Integrator = Yoshida
+	s.AddBody(vector64.New(0, 0, 0), vector64.New(0, 0, 0), 1000)
+	s.AddBody(vector64.New(10, 0, 0), vector64.New(0, 10, 0.5), 1)
+	s.AddBody(vector64.New(10.5, 0, 0), vector64.New(0, 11.4, 0), 0.01)
+	s.AddBody(vector64.New(-20, 3, 0), vector64.New(1, -7, 0), 0.5)
+	e0, p0, l0 := s.Energy(), s.Momentum(), s.AngularMomentum()
+	c0 := s.CenterOfMass()
+	const dt, steps = 1e-3, 5000
+	for i := 0; i < steps; i++ {
+		s.Step(dt)
+		if e := s.Energy(); math.Abs(e-e0) > 1e-5*math.Abs(e0) {
+			t.Fatalf("energy %v at step %d, want %v", e, i, e0)
+		}
+	}
+	if diff := cmp.Diff(p0, s.Momentum(), getComparer(1e-9)); diff != "" {
+		t.Errorf("Momentum() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(l0, s.AngularMomentum(), getComparer(1e-8)); diff != "" {
+		t.Errorf("AngularMomentum() mismatch (-want +got):\n%s", diff)
+	}
+	// the center of mass drifts with the total momentum
+	mass := 1000 + 1 + 0.01 + 0.5
+	wantC := vector64.Add(c0, p0.Copy().Mult(dt*steps/mass))
+	if diff := cmp.Diff(wantC, s.CenterOfMass(), getComparer(1e-9)); diff != "" {
+		t.Errorf("CenterOfMass() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSystemTwoBody(t *testing.T) {
+	// two bodies orbit their barycenter on Keplerian orbits of mu = G(m1 + m2)
+	s := NewSystem(2)
+	a := s.AddBody(vector64.New(0, 0, 0), vector64.New(0, 0, 0), 30)
+	b := s.AddBody(vector64.New(5, 0, 0), vector64.New(0, 3, 1), 10)
+	mu := s.G * (a.Mass + b.Mass)
+	r0 := vector64.Sub(b.Position, a.Position)
+	v0 := vector64.Sub(b.Velocity, a.Velocity)
+	s.Integrator = Yoshida
+	for i := 0; i < 4000; i++ {
+		s.Step(1e-3)
+	}
+	want, _ := Propagate(r0, v0, 4, mu)
+	got := vector64.Sub(b.Position, a.Position)
+	if diff := cmp.Diff(want, got, getComparer(1e-6)); diff != "" {
+		t.Errorf("relative position mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSoftening(t *testing.T) {
+	s := NewSystem(1)
+	s.Softening = 0.1
+	s.AddBody(vector64.New(0, 0, 0), vector64.New(0, 0, 0), 1)
+	s.AddBody(vector64.New(0, 0, 0), vector64.New(0, 0, 0), 1)
+	s.Step(0.1)
+	for _, b := range s.Bodies {
+		if diff := cmp.Diff(vector64.New(0, 0, 0), b.Position); diff != "" {
+			t.Errorf("coincident bodies moved (-want +got):\n%s", diff)
+		}
+	}
+	if e := s.Energy(); e != -10 {
+		t.Errorf("Energy() = %v, want -10", e)
+	}
+}
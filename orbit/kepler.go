@@ -0,0 +1,110 @@
+package orbit
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector64"
+)
+
+// Iterations of Newton's method on Kepler's equation
+const maxKeplerIterations = 50
+
+// Distance of the eccentricity from 1 below which an orbit is propagated as
+// a parabola, the elliptic and hyperbolic forms losing precision near it
+const parabolicTolerance = 1e-9
+
+// Eccentric anomaly E of an elliptic orbit solving Kepler's equation
+// M = E - e*sin(E) for the mean anomaly M, in (-pi, pi].
+// https://en.wikipedia.org/wiki/Kepler%27s_equation
+func EccentricAnomaly(m, e float64) float64 {
+	m = math.Remainder(m, 2*math.Pi)
+	E := m
+	if e > 0.8 {
+		E = math.Copysign(math.Pi, m)
+	}
+	for i := 0; i < maxKeplerIterations; i++ {
+		sin, cos := math.Sincos(E)
+		d := (E - e*sin - m) / (1 - e*cos)
+		E -= d
+		if math.Abs(d) <= 1e-15*math.Max(1, math.Abs(E)) {
+			break
+		}
+	}
+	return E
+}
+
+// Hyperbolic anomaly H of a hyperbolic orbit solving Kepler's equation
+// M = e*sinh(H) - H for the mean anomaly M
+func HyperbolicAnomaly(m, e float64) float64 {
+	H := math.Copysign(math.Log(2*math.Abs(m)/e+1.8), m)
+	for i := 0; i < maxKeplerIterations; i++ {
+		d := (e*math.Sinh(H) - H - m) / (e*math.Cosh(H) - 1)
+		H -= d
+		if math.Abs(d) <= 1e-15*math.Max(1, math.Abs(H)) {
+			break
+		}
+	}
+	return H
+}
+
+// Parabolic anomaly D = tan(nu/2) of a parabolic orbit solving Barker's
+// equation M = D + D^3/3 in closed form
+// https://en.wikipedia.org/wiki/Parabolic_trajectory#Barker's_equation
+func ParabolicAnomaly(m float64) float64 {
+	w := 1.5 * math.Abs(m)
+	a := math.Cbrt(w + math.Sqrt(w*w+1))
+	return math.Copysign(a-1/a, m)
+}
+
+// Time elapsed since the periapsis passage, negative before it
+func (el Elements) TimeSincePeriapsis(mu float64) float64 {
+	e, p, nu := el.Eccentricity, el.SemiLatusRectum, el.TrueAnomaly
+	switch {
+	case math.Abs(e-1) < parabolicTolerance:
+		D := math.Tan(nu / 2)
+		return (D + D*D*D/3) / 2 * math.Sqrt(p*p*p/mu)
+	case e < 1:
+		a := p / (1 - e*e)
+		sin, cos := math.Sincos(nu)
+		E := math.Atan2(math.Sqrt(1-e*e)*sin, e+cos)
+		return (E - e*math.Sin(E)) * math.Sqrt(a*a*a/mu)
+	default:
+		a := p / (e*e - 1)
+		H := 2 * math.Atanh(math.Sqrt((e-1)/(e+1))*math.Tan(nu/2))
+		return (e*math.Sinh(H) - H) * math.Sqrt(a*a*a/mu)
+	}
+}
+
+// Elements of the same orbit with the body moved along it to the given time
+// since the periapsis passage
+func (el Elements) AtTime(t, mu float64) Elements {
+	e, p := el.Eccentricity, el.SemiLatusRectum
+	switch {
+	case math.Abs(e-1) < parabolicTolerance:
+		D := ParabolicAnomaly(2 * t * math.Sqrt(mu/(p*p*p)))
+		el.TrueAnomaly = 2 * math.Atan(D)
+	case e < 1:
+		a := p / (1 - e*e)
+		E := EccentricAnomaly(t*math.Sqrt(mu/(a*a*a)), e)
+		sin, cos := math.Sincos(E / 2)
+		el.TrueAnomaly = 2 * math.Atan2(math.Sqrt(1+e)*sin, math.Sqrt(1-e)*cos)
+	default:
+		a := p / (e*e - 1)
+		H := HyperbolicAnomaly(t*math.Sqrt(mu/(a*a*a)), e)
+		el.TrueAnomaly = 2 * math.Atan(math.Sqrt((e+1)/(e-1))*math.Tanh(H/2))
+	}
+	el.TrueAnomaly = wrap(el.TrueAnomaly)
+	return el
+}
+
+// Elements after a time dt along the Keplerian orbit
+func (el Elements) Propagate(dt, mu float64) Elements {
+	return el.AtTime(el.TimeSincePeriapsis(mu)+dt, mu)
+}
+
+// Position and velocity after a time dt on the Keplerian orbit through the
+// state r, v around a central body of gravitational parameter mu
+func Propagate(r, v *vector64.Vector, dt, mu float64) (*vector64.Vector, *vector64.Vector) {
+	pr, pv := fromState(from(r), from(v), mu).Propagate(dt, mu).state(mu)
+	return pr.vector(), pv.vector()
+}
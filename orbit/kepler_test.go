@@ -0,0 +1,106 @@
+package orbit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAnomalies(t *testing.T) {
+	for _, e := range []float64{0, 0.1, 0.5, 0.9, 0.99, 0.999} {
+		for _, m := range []float64{-3, -1, -1e-6, 0, 0.2, 1.5, 3.1, 7} {
+			E := EccentricAnomaly(m, e)
+			if got := E - e*math.Sin(E); math.Abs(math.Remainder(got-m, 2*math.Pi)) > 1e-12 {
+				t.Errorf("EccentricAnomaly(%v, %v) = %v, gives M = %v", m, e, E, got)
+			}
+		}
+	}
+	for _, e := range []float64{1.001, 1.5, 3, 20} {
+		for _, m := range []float64{-50, -1, 0, 1e-6, 0.7, 10, 1000} {
+			H := HyperbolicAnomaly(m, e)
+			if got := e*math.Sinh(H) - H; math.Abs(got-m) > 1e-12*math.Max(1, math.Abs(m)) {
+				t.Errorf("HyperbolicAnomaly(%v, %v) = %v, gives M = %v", m, e, H, got)
+			}
+		}
+	}
+	for _, m := range []float64{-1e4, -2, 0, 1e-8, 0.5, 3, 1e6} {
+		D := ParabolicAnomaly(m)
+		if got := D + D*D*D/3; math.Abs(got-m) > 1e-12*math.Max(1, math.Abs(m)) {
+			t.Errorf("ParabolicAnomaly(%v) = %v, gives M = %v", m, D, got)
+		}
+	}
+}
+
+// Numerical solution of the two-body problem with a fine fourth order
+// Runge-Kutta integration
+func integrateRK4(r, v p3, dt, mu float64, n int) (p3, p3) {
+	acc := func(r p3) p3 { return scale3(r, -mu/math.Pow(dot3(r, r), 1.5)) }
+	h := dt / float64(n)
+	for i := 0; i < n; i++ {
+		k1r, k1v := v, acc(r)
+		k2r, k2v := add3(v, scale3(k1v, h/2)), acc(add3(r, scale3(k1r, h/2)))
+		k3r, k3v := add3(v, scale3(k2v, h/2)), acc(add3(r, scale3(k2r, h/2)))
+		k4r, k4v := add3(v, scale3(k3v, h)), acc(add3(r, scale3(k3r, h)))
+		r = add3(r, scale3(add3(add3(k1r, scale3(k2r, 2)), add3(scale3(k3r, 2), k4r)), h/6))
+		v = add3(v, scale3(add3(add3(k1v, scale3(k2v, 2)), add3(scale3(k3v, 2), k4v)), h/6))
+	}
+	return r, v
+}
+
+func TestPropagate(t *testing.T) {
+	tests := []struct {
+		name string
+		el   Elements
+		dt   float64
+	}{
+		{"elliptic", NewElements(8000, 0.3, deg(40), deg(100), deg(250), deg(30)), 4000},
+		{"eccentric", NewElements(30000, 0.9, deg(10), deg(20), deg(30), deg(340)), 20000},
+		{"backward", NewElements(8000, 0.3, deg(40), deg(100), deg(250), deg(30)), -2500},
+		{"circular", NewElements(7000, 0, deg(51.6), deg(300), 0, deg(120)), 3000},
+		{"hyperbolic", NewElements(-20000, 1.8, deg(120), deg(20), deg(310), deg(300)), 10000},
+		{"parabolic", Elements{SemiLatusRectum: 14000, Eccentricity: 1, Inclination: deg(10), RAAN: deg(5), ArgumentOfPeriapsis: deg(15), TrueAnomaly: deg(-60)}, 8000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r0, v0 := tt.el.state(muEarth)
+			wantR, wantV := integrateRK4(r0, v0, tt.dt, muEarth, 100000)
+			gotR, gotV := Propagate(r0.vector(), v0.vector(), tt.dt, muEarth)
+			if diff := cmp.Diff(wantR.vector(), gotR, getComparer(1e-5)); diff != "" {
+				t.Errorf("Propagate() position mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(wantV.vector(), gotV, getComparer(1e-8)); diff != "" {
+				t.Errorf("Propagate() velocity mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPropagatePeriod(t *testing.T) {
+	el := NewElements(12000, 0.6, deg(63.4), deg(45), deg(270), deg(10))
+	got := el.Propagate(3*el.Period(muEarth), muEarth)
+	if diff := cmp.Diff(el, got, getComparer(1e-9)); diff != "" {
+		t.Errorf("Propagate() by whole periods mismatch (-want +got):\n%s", diff)
+	}
+	// the periapsis is reached after minus the time since it
+	at := el.Propagate(-el.TimeSincePeriapsis(muEarth), muEarth)
+	if nu := math.Remainder(at.TrueAnomaly, 2*math.Pi); math.Abs(nu) > 1e-9 {
+		t.Errorf("true anomaly at the periapsis = %v, want 0", nu)
+	}
+	if got := el.AtTime(el.TimeSincePeriapsis(muEarth), muEarth); math.Abs(got.TrueAnomaly-el.TrueAnomaly) > 1e-12 {
+		t.Errorf("AtTime(TimeSincePeriapsis()) true anomaly = %v, want %v", got.TrueAnomaly, el.TrueAnomaly)
+	}
+}
+
+func TestPropagateNearParabolic(t *testing.T) {
+	// the elliptic and hyperbolic sides meet the parabolic solution
+	r0, v0 := Elements{SemiLatusRectum: 10000, Eccentricity: 1, TrueAnomaly: deg(-90)}.state(muEarth)
+	want, _ := Propagate(r0.vector(), v0.vector(), 5000, muEarth)
+	for _, e := range []float64{1 - 1e-6, 1 + 1e-6} {
+		r, v := Elements{SemiLatusRectum: 10000, Eccentricity: e, TrueAnomaly: deg(-90)}.State(muEarth)
+		got, _ := Propagate(r, v, 5000, muEarth)
+		if diff := cmp.Diff(want, got, getComparer(1e-1)); diff != "" {
+			t.Errorf("eccentricity %v mismatch (-want +got):\n%s", e, diff)
+		}
+	}
+}
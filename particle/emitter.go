@@ -0,0 +1,90 @@
+package particle
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Spawns particles at a steady rate from a point, in a cone of directions
+type Emitter struct {
+	Position *vector.Vector
+	// Axis of the cone, nil emits in all directions
+	Direction *vector.Vector
+	// Half angle of the cone in radians, 0 emits along Direction only
+	Spread float32
+	// Initial speed, picked uniformly in Speed ± SpeedVariation
+	Speed, SpeedVariation float32
+	// Particles per second
+	Rate float32
+	// Lifetime of the emitted particles, 0 lives forever
+	Lifetime float32
+	// Mass of the emitted particles (default 1)
+	Mass float32
+	// Source of randomness (default seeded from time)
+	Rand *rand.Rand
+
+	pending float32
+}
+
+// Gives the particles due over dt at Rate. Fractions of a particle carry
+// over to the next call.
+func (e *Emitter) Emit(dt float32) []*Particle {
+	e.pending += e.Rate * dt
+	n := int(e.pending)
+	e.pending -= float32(n)
+	return e.Burst(n)
+}
+
+// Gives n particles at once
+func (e *Emitter) Burst(n int) []*Particle {
+	if e.Rand == nil {
+		e.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	mass := e.Mass
+	if mass == 0 {
+		mass = 1
+	}
+	ps := make([]*Particle, n)
+	for i := range ps {
+		speed := e.Speed + (2*e.Rand.Float32()-1)*e.SpeedVariation
+		p := NewParticle(e.Position, e.direction().Mult(speed), mass)
+		p.Lifetime = e.Lifetime
+		ps[i] = p
+	}
+	return ps
+}
+
+// Uniformly distributed unit vector in the cone
+func (e *Emitter) direction() *vector.Vector {
+	spread := float64(e.Spread)
+	if e.Direction == nil {
+		spread = math.Pi
+	}
+	// uniform on the spherical cap: cos of the angle to the axis is uniform
+	cosT := 1 - e.Rand.Float64()*(1-math.Cos(spread))
+	sinT := math.Sqrt(math.Max(0, 1-cosT*cosT))
+	phi := e.Rand.Float64() * 2 * math.Pi
+	axis := vector.New(0, 0, 1)
+	if e.Direction != nil {
+		axis = vector.Unit(e.Direction)
+	}
+	u, w := basis(axis)
+	d := axis.Mult(float32(cosT))
+	d.Add(u.Mult(float32(sinT * math.Cos(phi))))
+	d.Add(w.Mult(float32(sinT * math.Sin(phi))))
+	return d
+}
+
+// Two unit vectors perpendicular to the unit vector n and to each other
+func basis(n *vector.Vector) (u, w *vector.Vector) {
+	a := vector.New(1, 0, 0)
+	if math.Abs(float64(n.X)) > 0.9 {
+		a = vector.New(0, 1, 0)
+	}
+	u = vector.Cross(n, a).Normalize()
+	w = vector.Cross(n, u)
+	return u, w
+}
@@ -0,0 +1,71 @@
+package particle
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestEmit(t *testing.T) {
+	e := &Emitter{Position: vector.New(1, 2, 3), Rate: 25, Speed: 2, Lifetime: 1, Rand: rand.New(rand.NewSource(1))}
+	total := 0
+	for i := 0; i < 10; i++ {
+		ps := e.Emit(0.01)
+		total += len(ps)
+		for _, p := range ps {
+			if !cmp.Equal(p.Position, e.Position) || p.Lifetime != 1 || p.Mass != 1 {
+				t.Errorf("emitted %+v", p)
+			}
+			if s := p.Velocity.Mag(); math.Abs(float64(s)-2) > 1e-5 {
+				t.Errorf("speed = %v, want 2", s)
+			}
+		}
+	}
+	if total != 2 {
+		t.Errorf("emitted %d particles in 0.1s at 25/s, want 2", total)
+	}
+}
+
+func TestEmitterCone(t *testing.T) {
+	dir := vector.New(1, 1, 0)
+	e := &Emitter{Position: vector.New(0, 0, 0), Direction: dir, Spread: 0.3, Speed: 1, SpeedVariation: 0.5, Rand: rand.New(rand.NewSource(2))}
+	var mean vector.Vector
+	for _, p := range e.Burst(2000) {
+		if a := p.Velocity.Angle(dir); a > 0.3+1e-4 {
+			t.Fatalf("velocity %v is %v from the axis, want within 0.3", p.Velocity, a)
+		}
+		if s := p.Velocity.Mag(); s < 0.5-1e-5 || s > 1.5+1e-5 {
+			t.Fatalf("speed = %v, want in [0.5, 1.5]", s)
+		}
+		mean.Add(vector.Unit(p.Velocity))
+	}
+	if a := mean.Angle(dir); a > 0.02 {
+		t.Errorf("mean direction is %v off the axis", a)
+	}
+
+	// no direction emits over the whole sphere
+	e = &Emitter{Position: vector.New(0, 0, 0), Speed: 1, Rand: rand.New(rand.NewSource(3))}
+	mean = vector.Vector{}
+	ps := e.Burst(4000)
+	for _, p := range ps {
+		mean.Add(p.Velocity)
+	}
+	if m := mean.Mag() / float32(len(ps)); m > 0.05 {
+		t.Errorf("mean of isotropic directions = %v, want about 0", m)
+	}
+}
+
+func TestSystemEmitter(t *testing.T) {
+	s := NewSystem(SemiImplicitEuler, 0.1)
+	s.AddEmitter(&Emitter{Position: vector.New(0, 0, 0), Rate: 10, Lifetime: 0.35, Rand: rand.New(rand.NewSource(4))})
+	for i := 0; i < 10; i++ {
+		s.Step(0.1)
+	}
+	// one particle per step living 4 steps
+	if len(s.Particles) != 3 {
+		t.Errorf("%d live particles, want 3", len(s.Particles))
+	}
+}
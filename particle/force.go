@@ -0,0 +1,94 @@
+package particle
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Something pushing particles. Apply is called once per force evaluation,
+// possibly several times per step, and adds to the particles' forces with
+// Particle.ApplyForce.
+type Force interface {
+	Apply(ps []*Particle)
+}
+
+// Adapter to use a function as a Force
+type ForceFunc func(ps []*Particle)
+
+// Calls f(ps)
+func (f ForceFunc) Apply(ps []*Particle) {
+	f(ps)
+}
+
+// Uniform gravitational field, gives every particle the same acceleration
+type Gravity struct {
+	Acceleration *vector.Vector
+}
+
+// Applies mass * Acceleration to every particle
+func (g Gravity) Apply(ps []*Particle) {
+	for _, p := range ps {
+		p.force.Add(g.Acceleration.Copy().Mult(p.Mass))
+	}
+}
+
+// Resistance against the velocity, -(Linear + Quadratic*|v|) * v
+type Drag struct {
+	Linear, Quadratic float32
+}
+
+// Applies the drag force to every particle
+func (d Drag) Apply(ps []*Particle) {
+	for _, p := range ps {
+		k := d.Linear + d.Quadratic*p.Velocity.Mag()
+		p.force.Add(p.Velocity.Copy().Mult(-k))
+	}
+}
+
+// Damped spring between two particles
+// https://en.wikipedia.org/wiki/Hooke%27s_law
+type Spring struct {
+	A, B *Particle
+	// Length at rest
+	RestLength float32
+	// Force per unit of stretch
+	Stiffness float32
+	// Force per unit of relative speed along the spring
+	Damping float32
+}
+
+// Pulls A and B towards the rest length. ps is ignored, the spring only
+// acts on its own particles.
+func (s Spring) Apply(ps []*Particle) {
+	d := vector.Sub(s.B.Position, s.A.Position)
+	l := d.Mag()
+	if l == 0 {
+		return
+	}
+	d.Mult(1 / l)
+	relSpeed := vector.Sub(s.B.Velocity, s.A.Velocity).Dot(d)
+	f := d.Mult(s.Stiffness*(l-s.RestLength) + s.Damping*relSpeed)
+	s.A.force.Add(f)
+	s.B.force.Sub(f)
+}
+
+// Inverse square attraction towards a point, repulsion for a negative Strength
+type Attractor struct {
+	Position *vector.Vector
+	// Force on a unit mass at unit distance
+	Strength float32
+	// Added to the squared distance to bound the force near Position
+	Softening float32
+}
+
+// Applies Strength * mass / (d² + Softening²) towards Position to every particle
+func (a Attractor) Apply(ps []*Particle) {
+	for _, p := range ps {
+		d := vector.Sub(a.Position, p.Position)
+		distSq := d.MagSq()
+		if distSq == 0 {
+			continue
+		}
+		f := a.Strength * p.Mass / (distSq + a.Softening*a.Softening)
+		p.force.Add(d.Resize(f))
+	}
+}
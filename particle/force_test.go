@@ -0,0 +1,97 @@
+package particle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func forceOn(f Force, ps ...*Particle) []*vector.Vector {
+	for _, p := range ps {
+		p.force = vector.Vector{}
+	}
+	f.Apply(ps)
+	out := make([]*vector.Vector, len(ps))
+	for i, p := range ps {
+		out[i] = p.force.Copy()
+	}
+	return out
+}
+
+func TestGravity(t *testing.T) {
+	p := NewParticle(vector.New(0, 0, 0), vector.New(0, 0, 0), 3)
+	got := forceOn(Gravity{vector.New(0, -2, 0)}, p)
+	if want := []*vector.Vector{vector.New(0, -6, 0)}; !cmp.Equal(got, want) {
+		t.Errorf("Gravity force = %v, want %v", got, want)
+	}
+}
+
+func TestDrag(t *testing.T) {
+	p := NewParticle(vector.New(0, 0, 0), vector.New(3, 4, 0), 1)
+	got := forceOn(Drag{Linear: 0.5, Quadratic: 0.1}, p)
+	if want := []*vector.Vector{vector.New(-3, -4, 0)}; !cmp.Equal(got, want, getComparer(1e-6)) {
+		t.Errorf("Drag force = %v, want %v", got, want)
+	}
+
+	// falling with linear drag approaches the terminal velocity m*g/k
+	s := NewSystem(RK4, 0.01)
+	p = NewParticle(vector.New(0, 0, 0), vector.New(0, 0, 0), 2)
+	s.Add(p).AddForce(Gravity{vector.New(0, -10, 0)}, Drag{Linear: 4})
+	for i := 0; i < 1000; i++ {
+		s.Step(0.01)
+	}
+	if want := vector.New(0, -5, 0); !cmp.Equal(p.Velocity, want, getComparer(1e-4)) {
+		t.Errorf("terminal velocity = %v, want %v", p.Velocity, want)
+	}
+}
+
+func TestSpring(t *testing.T) {
+	a := NewParticle(vector.New(0, 0, 0), vector.New(0, 0, 0), 1)
+	b := NewParticle(vector.New(3, 0, 0), vector.New(1, 0, 0), 1)
+	got := forceOn(Spring{A: a, B: b, RestLength: 2, Stiffness: 10, Damping: 0.5}, a, b)
+	want := []*vector.Vector{vector.New(10.5, 0, 0), vector.New(-10.5, 0, 0)}
+	if !cmp.Equal(got, want, getComparer(1e-6)) {
+		t.Errorf("Spring forces = %v, want %v", got, want)
+	}
+	b.Position = vector.New(0, 0, 0)
+	if got := forceOn(Spring{A: a, B: b, Stiffness: 1}, a, b); !cmp.Equal(got[0], vector.New(0, 0, 0)) {
+		t.Errorf("Spring force at zero length = %v, want 0", got)
+	}
+}
+
+func TestAttractor(t *testing.T) {
+	p := NewParticle(vector.New(0, 2, 0), vector.New(0, 0, 0), 2)
+	got := forceOn(Attractor{Position: vector.New(0, 0, 0), Strength: 8}, p)
+	if want := []*vector.Vector{vector.New(0, -4, 0)}; !cmp.Equal(got, want, getComparer(1e-6)) {
+		t.Errorf("Attractor force = %v, want %v", got, want)
+	}
+	got = forceOn(Attractor{Position: vector.New(0, 0, 0), Strength: -8, Softening: 2}, p)
+	if want := []*vector.Vector{vector.New(0, 2, 0)}; !cmp.Equal(got, want, getComparer(1e-6)) {
+		t.Errorf("softened repulsion = %v, want %v", got, want)
+	}
+
+	// a circular orbit stays circular: v² = Strength / r
+	s := NewSystem(RK4, 0.01)
+	p = NewParticle(vector.New(1, 0, 0), vector.New(0, 1, 0), 1)
+	s.Add(p).AddForce(Attractor{Position: vector.New(0, 0, 0), Strength: 1})
+	for i := 0; i < 628; i++ {
+		s.Step(0.01)
+	}
+	if r := p.Position.Mag(); math.Abs(float64(r)-1) > 1e-3 {
+		t.Errorf("orbit radius = %v, want 1", r)
+	}
+}
+
+func TestForceFunc(t *testing.T) {
+	p := NewParticle(vector.New(0, 0, 0), vector.New(0, 0, 0), 1)
+	f := ForceFunc(func(ps []*Particle) {
+		for _, p := range ps {
+			p.ApplyForce(vector.New(1, 2, 3))
+		}
+	})
+	if got, want := forceOn(f, p), []*vector.Vector{vector.New(1, 2, 3)}; !cmp.Equal(got, want) {
+		t.Errorf("ForceFunc force = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,286 @@
+// Package particle provides a particle system on vector.Vector with pluggable
+// forces, emitters and a choice of integrators, stepped at a fixed timestep.
+package particle
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Point mass
+type Particle struct {
+	Position, Velocity *vector.Vector
+	// Acceleration from the forces at the end of the last step
+	Acceleration *vector.Vector
+	// Mass of the particle, 0 for an infinite mass that forces don't move
+	Mass float32
+	// Time since the particle was created
+	Age float32
+	// The particle is removed once Age reaches Lifetime, 0 lives forever
+	Lifetime float32
+
+	force vector.Vector
+	prev  vector.Vector
+}
+
+// Makes a particle at position moving with velocity, the vectors are copied
+func NewParticle(position, velocity *vector.Vector, mass float32) *Particle {
+	return &Particle{
+		Position:     position.Copy(),
+		Velocity:     velocity.Copy(),
+		Acceleration: vector.New(0, 0, 0),
+		Mass:         mass,
+		prev:         *position,
+	}
+}
+
+// Adds a force to the ones acting on the particle in the current evaluation.
+// Meant to be called from a Force.
+func (p *Particle) ApplyForce(f *vector.Vector) *Particle {
+	p.force.Add(f)
+	return p
+}
+
+// Inverse of the mass, 0 for infinite mass
+func (p *Particle) InvMass() float32 {
+	if p.Mass <= 0 {
+		return 0
+	}
+	return 1 / p.Mass
+}
+
+// Position between the two last steps for rendering,
+// alpha = 0 gives the previous position and 1 the current one.
+func (p *Particle) Interpolate(alpha float32) *vector.Vector {
+	return vector.Lerp(&p.prev, p.Position, alpha)
+}
+
+// Whether the particle reached the end of its life
+func (p *Particle) Dead() bool {
+	return p.Lifetime > 0 && p.Age >= p.Lifetime
+}
+
+// Numerical integration scheme
+type Integrator int
+
+const (
+	// Semi-implicit (symplectic) Euler: velocity first, then position with the new velocity
+	SemiImplicitEuler Integrator = iota
+	// Explicit Euler: position with the old velocity. Gains energy, mostly for comparison.
+	Euler
+	// Velocity Verlet, second order with two force evaluations per step
+	Verlet
+	// Classic fourth order Runge-Kutta, four force evaluations per step
+	RK4
+)
+
+func (i Integrator) String() string {
+	switch i {
+	case SemiImplicitEuler:
+		return "SemiImplicitEuler"
+	case Euler:
+		return "Euler"
+	case Verlet:
+		return "Verlet"
+	case RK4:
+		return "RK4"
+	}
+	return "Integrator(?)"
+}
+
+// Collection of particles with the forces acting on them and their emitters
+type System struct {
+	Particles []*Particle
+	Forces    []Force
+	Emitters  []*Emitter
+	// Integration scheme
+	Integrator Integrator
+	// Fixed timestep used by Update
+	Dt float32
+	// Most steps one Update takes, the rest of the time is dropped so a slow
+	// frame doesn't make the next one slower (default 8)
+	MaxSteps int
+
+	accumulator float32
+}
+
+// Makes an empty system stepping with the integrator at the fixed timestep dt
+func NewSystem(integrator Integrator, dt float32) *System {
+	return &System{Integrator: integrator, Dt: dt, MaxSteps: 8}
+}
+
+// Adds particles to the system
+func (s *System) Add(ps ...*Particle) *System {
+	s.Particles = append(s.Particles, ps...)
+	return s
+}
+
+// Adds forces to the system
+func (s *System) AddForce(fs ...Force) *System {
+	s.Forces = append(s.Forces, fs...)
+	return s
+}
+
+// Adds emitters to the system
+func (s *System) AddEmitter(es ...*Emitter) *System {
+	s.Emitters = append(s.Emitters, es...)
+	return s
+}
+
+// Advances the simulation by the frame time in fixed steps of Dt.
+// Returns how far the leftover time is into the next step, in [0, 1),
+// to pass to Particle.Interpolate.
+func (s *System) Update(frameTime float32) (alpha float32) {
+	if s.Dt <= 0 {
+		return 0
+	}
+	maxSteps := s.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = 8
+	}
+	s.accumulator += frameTime
+	for steps := 0; s.accumulator >= s.Dt; steps++ {
+		if steps == maxSteps {
+			s.accumulator = float32(math.Mod(float64(s.accumulator), float64(s.Dt)))
+			break
+		}
+		s.Step(s.Dt)
+		s.accumulator -= s.Dt
+	}
+	return s.accumulator / s.Dt
+}
+
+// Advances the simulation by one step of dt: emits, integrates, ages and
+// removes dead particles.
+func (s *System) Step(dt float32) {
+	for _, e := range s.Emitters {
+		s.Add(e.Emit(dt)...)
+	}
+	for _, p := range s.Particles {
+		p.prev = *p.Position
+	}
+	switch s.Integrator {
+	case Euler:
+		s.euler(dt)
+	case Verlet:
+		s.verlet(dt)
+	case RK4:
+		s.rk4(dt)
+	default:
+		s.semiImplicitEuler(dt)
+	}
+	alive := s.Particles[:0]
+	for _, p := range s.Particles {
+		p.Age += dt
+		if !p.Dead() {
+			alive = append(alive, p)
+		}
+	}
+	for i := len(alive); i < len(s.Particles); i++ {
+		s.Particles[i] = nil
+	}
+	s.Particles = alive
+}
+
+// Total kinetic energy of the particles with finite mass
+func (s *System) KineticEnergy() float32 {
+	var e float32
+	for _, p := range s.Particles {
+		e += 0.5 * p.Mass * p.Velocity.MagSq()
+	}
+	return e
+}
+
+// Sets every particle's Acceleration from the forces at the current state
+func (s *System) evaluate() {
+	for _, p := range s.Particles {
+		p.force = vector.Vector{}
+	}
+	for _, f := range s.Forces {
+		f.Apply(s.Particles)
+	}
+	for _, p := range s.Particles {
+		if p.Acceleration == nil {
+			p.Acceleration = vector.New(0, 0, 0)
+		}
+		p.Acceleration.Assign(&p.force).Mult(p.InvMass())
+	}
+}
+
+// a + b*t as a value
+func madd(a, b vector.Vector, t float32) vector.Vector {
+	return vector.Vector{X: a.X + b.X*t, Y: a.Y + b.Y*t, Z: a.Z + b.Z*t}
+}
+
+func (s *System) euler(dt float32) {
+	s.evaluate()
+	for _, p := range s.Particles {
+		*p.Position = madd(*p.Position, *p.Velocity, dt)
+		*p.Velocity = madd(*p.Velocity, *p.Acceleration, dt)
+	}
+}
+
+func (s *System) semiImplicitEuler(dt float32) {
+	s.evaluate()
+	for _, p := range s.Particles {
+		*p.Velocity = madd(*p.Velocity, *p.Acceleration, dt)
+		*p.Position = madd(*p.Position, *p.Velocity, dt)
+	}
+}
+
+// https://en.wikipedia.org/wiki/Verlet_integration#Velocity_Verlet
+func (s *System) verlet(dt float32) {
+	s.evaluate()
+	v0 := make([]vector.Vector, len(s.Particles))
+	a0 := make([]vector.Vector, len(s.Particles))
+	for i, p := range s.Particles {
+		v0[i], a0[i] = *p.Velocity, *p.Acceleration
+		*p.Position = madd(madd(*p.Position, v0[i], dt), a0[i], dt*dt/2)
+		// velocity dependent forces see the predicted velocity
+		*p.Velocity = madd(v0[i], a0[i], dt)
+	}
+	s.evaluate()
+	for i, p := range s.Particles {
+		*p.Velocity = madd(madd(v0[i], a0[i], dt/2), *p.Acceleration, dt/2)
+	}
+}
+
+// https://en.wikipedia.org/wiki/Runge%E2%80%93Kutta_methods
+func (s *System) rk4(dt float32) {
+	n := len(s.Particles)
+	x0 := make([]vector.Vector, n)
+	v0 := make([]vector.Vector, n)
+	// derivatives of position (velocity) and velocity (acceleration) at the 4 stages
+	var dx, dv [4][]vector.Vector
+	for k := range dx {
+		dx[k] = make([]vector.Vector, n)
+		dv[k] = make([]vector.Vector, n)
+	}
+	for i, p := range s.Particles {
+		x0[i], v0[i] = *p.Position, *p.Velocity
+	}
+	weights := [4]float32{0, dt / 2, dt / 2, dt}
+	for k := 0; k < 4; k++ {
+		if k > 0 {
+			for i, p := range s.Particles {
+				*p.Position = madd(x0[i], dx[k-1][i], weights[k])
+				*p.Velocity = madd(v0[i], dv[k-1][i], weights[k])
+			}
+		}
+		s.evaluate()
+		for i, p := range s.Particles {
+			dx[k][i], dv[k][i] = *p.Velocity, *p.Acceleration
+		}
+	}
+	for i, p := range s.Particles {
+		*p.Position = x0[i]
+		*p.Velocity = v0[i]
+		for k, w := range [4]float32{1, 2, 2, 1} {
+			*p.Position = madd(*p.Position, dx[k][i], w*dt/6)
+			*p.Velocity = madd(*p.Velocity, dv[k][i], w*dt/6)
+		}
+		// acceleration at the start of the step, the best estimate without another evaluation
+		*p.Acceleration = dv[0][i]
+	}
+}
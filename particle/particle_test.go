@@ -0,0 +1,144 @@
+package particle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) })
+}
+
+var integrators = []Integrator{SemiImplicitEuler, Euler, Verlet, RK4}
+
+func TestProjectile(t *testing.T) {
+	// exact under constant acceleration for the second and higher order schemes
+	tests := []struct {
+		integrator Integrator
+		tolerance  float32
+	}{
+		{SemiImplicitEuler, 0.2},
+		{Euler, 0.2},
+		{Verlet, 1e-3},
+		{RK4, 1e-3},
+	}
+	for _, tt := range tests {
+		s := NewSystem(tt.integrator, 0.01)
+		p := NewParticle(vector.New(0, 0, 0), vector.New(3, 10, 0), 2)
+		s.Add(p).AddForce(Gravity{vector.New(0, -9.8, 0)})
+		for i := 0; i < 100; i++ {
+			s.Step(0.01)
+		}
+		want := vector.New(3, 10-4.9, 0)
+		if !cmp.Equal(p.Position, want, getComparer(tt.tolerance)) {
+			t.Errorf("%v: position = %v, want %v", tt.integrator, p.Position, want)
+		}
+		if wantV := vector.New(3, 10-9.8, 0); !cmp.Equal(p.Velocity, wantV, getComparer(1e-3)) {
+			t.Errorf("%v: velocity = %v, want %v", tt.integrator, p.Velocity, wantV)
+		}
+		if wantA := vector.New(0, -9.8, 0); !cmp.Equal(p.Acceleration, wantA, getComparer(1e-5)) {
+			t.Errorf("%v: acceleration = %v, want %v", tt.integrator, p.Acceleration, wantA)
+		}
+	}
+}
+
+// Mass on a spring to a fixed anchor, the energy shows each scheme's character
+func TestOscillatorEnergy(t *testing.T) {
+	const k, m = 4, 1
+	energy := func(s *System, p *Particle) float64 {
+		x := float64(p.Position.X)
+		return float64(s.KineticEnergy()) + 0.5*k*x*x
+	}
+	results := map[Integrator]float64{}
+	for _, in := range integrators {
+		s := NewSystem(in, 0.05)
+		anchor := NewParticle(vector.New(0, 0, 0), vector.New(0, 0, 0), 0)
+		p := NewParticle(vector.New(1, 0, 0), vector.New(0, 0, 0), m)
+		s.Add(anchor, p).AddForce(Spring{A: anchor, B: p, Stiffness: k})
+		e0 := energy(s, p)
+		for i := 0; i < 400; i++ {
+			s.Step(0.05)
+		}
+		results[in] = energy(s, p) / e0
+		// exact solution x = cos(2t)
+		if in == RK4 {
+			if want := math.Cos(2 * 20); math.Abs(float64(p.Position.X)-want) > 1e-3 {
+				t.Errorf("RK4: x = %v, want %v", p.Position.X, want)
+			}
+		}
+		if !anchor.Position.Equal(vector.New(0, 0, 0)) {
+			t.Errorf("%v: infinite mass moved to %v", in, anchor.Position)
+		}
+	}
+	if r := results[Euler]; r < 2 {
+		t.Errorf("Euler energy ratio = %v, want growth", r)
+	}
+	for _, in := range []Integrator{SemiImplicitEuler, Verlet} {
+		if r := results[in]; math.Abs(r-1) > 0.15 {
+			t.Errorf("%v energy ratio = %v, want bounded near 1", in, r)
+		}
+	}
+	if r := results[RK4]; math.Abs(r-1) > 1e-3 {
+		t.Errorf("RK4 energy ratio = %v, want 1", r)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s := NewSystem(SemiImplicitEuler, 0.1)
+	p := NewParticle(vector.New(0, 0, 0), vector.New(1, 0, 0), 1)
+	s.Add(p)
+	alpha := s.Update(0.25)
+	if math.Abs(float64(alpha)-0.5) > 1e-5 {
+		t.Errorf("Update(0.25) = %v, want 0.5", alpha)
+	}
+	if want := vector.New(0.2, 0, 0); !cmp.Equal(p.Position, want, getComparer(1e-6)) {
+		t.Errorf("position = %v, want %v", p.Position, want)
+	}
+	if got, want := p.Interpolate(alpha), vector.New(0.15, 0, 0); !cmp.Equal(got, want, getComparer(1e-6)) {
+		t.Errorf("Interpolate(%v) = %v, want %v", alpha, got, want)
+	}
+	alpha = s.Update(0.05)
+	if math.Abs(float64(alpha)) > 1e-5 && math.Abs(float64(alpha)-1) > 1e-5 {
+		t.Errorf("Update(0.05) = %v, want 0", alpha)
+	}
+
+	// a long frame is capped at MaxSteps
+	s = NewSystem(SemiImplicitEuler, 0.1)
+	p = NewParticle(vector.New(0, 0, 0), vector.New(1, 0, 0), 1)
+	s.Add(p)
+	s.MaxSteps = 3
+	if alpha := s.Update(10.05); alpha < 0 || alpha >= 1 {
+		t.Errorf("Update(10.05) = %v, want in [0, 1)", alpha)
+	}
+	if want := vector.New(0.3, 0, 0); !cmp.Equal(p.Position, want, getComparer(1e-6)) {
+		t.Errorf("position = %v, want %v", p.Position, want)
+	}
+}
+
+func TestLifetime(t *testing.T) {
+	s := NewSystem(Verlet, 0.1)
+	a := NewParticle(vector.New(0, 0, 0), vector.New(0, 0, 0), 1)
+	a.Lifetime = 0.25
+	b := NewParticle(vector.New(0, 0, 0), vector.New(0, 0, 0), 1)
+	s.Add(a, b)
+	s.Step(0.1)
+	s.Step(0.1)
+	if len(s.Particles) != 2 {
+		t.Fatalf("%d particles after 0.2s, want 2", len(s.Particles))
+	}
+	s.Step(0.1)
+	if len(s.Particles) != 1 || s.Particles[0] != b {
+		t.Errorf("particles after 0.3s = %v, want only the immortal one", s.Particles)
+	}
+}
+
+func TestIntegratorString(t *testing.T) {
+	for _, in := range integrators {
+		if in.String() == "Integrator(?)" {
+			t.Errorf("Integrator(%d) has no name", int(in))
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package physics2d
+
+import (
+	"github.com/vaibhav11s/gopkgs/rtree"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Rigid body. Static bodies have zero Mass and never move.
+type Body struct {
+	Shape Shape
+	// Position of the center of mass and rotation in radians
+	Position *vector2d.Vector2D
+	Angle    float32
+	// Linear velocity of the center of mass and angular velocity in radians per second
+	Velocity        *vector2d.Vector2D
+	AngularVelocity float32
+	// Mass and rotational inertia about the center of mass, 0 for infinite.
+	// A body with zero Mass is static.
+	Mass, Inertia float32
+	// Bounciness in [0, 1], the larger of the two bodies' is used
+	Restitution float32
+	// Coulomb friction coefficient, the geometric mean of the two bodies' is used
+	Friction float32
+
+	force  vec
+	torque float32
+
+	id        int
+	sleeping  bool
+	sleepTime float32
+}
+
+// Makes a body of the shape at the origin, with mass from the density.
+// Zero density makes a static body. Friction defaults to 0.5.
+func NewBody(shape Shape, density float32) *Body {
+	mass, inertia := shape.MassData(density)
+	return &Body{
+		Shape:    shape,
+		Position: vector2d.New(0, 0),
+		Velocity: vector2d.New(0, 0),
+		Mass:     mass,
+		Inertia:  inertia,
+		Friction: 0.5,
+	}
+}
+
+// Whether the body has infinite mass
+func (b *Body) IsStatic() bool {
+	return b.Mass <= 0
+}
+
+// Whether the body is asleep, see World.AllowSleep
+func (b *Body) IsSleeping() bool {
+	return b.sleeping
+}
+
+// Wakes the body up. Needed after setting the position or velocity of a sleeping body.
+// Modify + Returns self
+func (b *Body) Wake() *Body {
+	b.sleeping = false
+	b.sleepTime = 0
+	return b
+}
+
+func (b *Body) invMass() float32 {
+	if b.Mass <= 0 {
+		return 0
+	}
+	return 1 / b.Mass
+}
+
+func (b *Body) invInertia() float32 {
+	if b.Mass <= 0 || b.Inertia <= 0 {
+		return 0
+	}
+	return 1 / b.Inertia
+}
+
+func (b *Body) transform() transform {
+	return transform{p: *b.Position, q: rotation(b.Angle)}
+}
+
+// Converts a point from body space to world space
+func (b *Body) WorldPoint(local *vector2d.Vector2D) *vector2d.Vector2D {
+	p := b.transform().apply(*local)
+	return &p
+}
+
+// Converts a point from world space to body space
+func (b *Body) LocalPoint(world *vector2d.Vector2D) *vector2d.Vector2D {
+	p := b.transform().applyInv(*world)
+	return &p
+}
+
+// Velocity of the material point of the body at a world position
+func (b *Body) VelocityAt(point *vector2d.Vector2D) *vector2d.Vector2D {
+	v := add(*b.Velocity, crossSV(b.AngularVelocity, sub(*point, *b.Position)))
+	return &v
+}
+
+// World space bounding box of the body
+func (b *Body) Bounds() rtree.Rect {
+	return b.Shape.bounds(b.transform())
+}
+
+// Adds a force for the next step, applied at a world point (nil for the center of mass).
+// Modify + Returns self
+func (b *Body) ApplyForce(f, point *vector2d.Vector2D) *Body {
+	if b.IsStatic() {
+		return b
+	}
+	b.force = add(b.force, *f)
+	if point != nil {
+		b.torque += cross(sub(*point, *b.Position), *f)
+	}
+	return b.Wake()
+}
+
+// Adds a torque for the next step.
+// Modify + Returns self
+func (b *Body) ApplyTorque(torque float32) *Body {
+	if b.IsStatic() {
+		return b
+	}
+	b.torque += torque
+	return b.Wake()
+}
+
+// Changes the velocity at once by an impulse at a world point (nil for the center of mass).
+// Modify + Returns self
+func (b *Body) ApplyImpulse(j, point *vector2d.Vector2D) *Body {
+	if b.IsStatic() {
+		return b
+	}
+	*b.Velocity = madd(*b.Velocity, *j, b.invMass())
+	if point != nil {
+		b.AngularVelocity += b.invInertia() * cross(sub(*point, *b.Position), *j)
+	}
+	return b.Wake()
+}
+
+// Velocity change at world offsets r from the centers of mass, used by the solver
+func applyImpulse(a, b *Body, p vec, ra, rb vec) {
+	*a.Velocity = madd(*a.Velocity, p, -a.invMass())
+	a.AngularVelocity -= a.invInertia() * cross(ra, p)
+	*b.Velocity = madd(*b.Velocity, p, b.invMass())
+	b.AngularVelocity += b.invInertia() * cross(rb, p)
+}
+
+// Velocity of b relative to a at the offsets ra and rb
+func relativeVelocity(a, b *Body, ra, rb vec) vec {
+	va := add(*a.Velocity, crossSV(a.AngularVelocity, ra))
+	vb := add(*b.Velocity, crossSV(b.AngularVelocity, rb))
+	return sub(vb, va)
+}
+
+// Effective mass inverse along the unit direction n at offsets ra and rb
+func effectiveMass(a, b *Body, ra, rb, n vec) float32 {
+	rna, rnb := cross(ra, n), cross(rb, n)
+	k := a.invMass() + b.invMass() + a.invInertia()*rna*rna + b.invInertia()*rnb*rnb
+	if k == 0 {
+		return 0
+	}
+	return 1 / k
+}
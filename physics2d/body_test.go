@@ -0,0 +1,69 @@
+package physics2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, tolerance) })
+}
+
+func TestBodyPoints(t *testing.T) {
+	b := NewBody(NewBox(2, 2), 1)
+	b.Position = vector2d.New(3, 4)
+	b.Angle = math.Pi / 2
+	opt := getComparer(1e-6)
+	if got, want := b.WorldPoint(vector2d.New(1, 0)), vector2d.New(3, 5); !cmp.Equal(got, want, opt) {
+		t.Errorf("WorldPoint() = %v, want %v", got, want)
+	}
+	if got, want := b.LocalPoint(vector2d.New(3, 5)), vector2d.New(1, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("LocalPoint() = %v, want %v", got, want)
+	}
+	b.AngularVelocity = 2
+	if got, want := b.VelocityAt(vector2d.New(4, 4)), vector2d.New(0, 2); !cmp.Equal(got, want, opt) {
+		t.Errorf("VelocityAt() = %v, want %v", got, want)
+	}
+	r := b.Bounds()
+	if !cmp.Equal(&r.Min, vector2d.New(2, 3), opt) || !cmp.Equal(&r.Max, vector2d.New(4, 5), opt) {
+		t.Errorf("Bounds() = %v", r)
+	}
+}
+
+func TestApplyImpulse(t *testing.T) {
+	b := NewBody(NewBox(1, 1), 2) // mass 2, inertia 1/3
+	b.ApplyImpulse(vector2d.New(0, 4), vector2d.New(0.5, 0))
+	if want := vector2d.New(0, 2); !cmp.Equal(b.Velocity, want) {
+		t.Errorf("Velocity = %v, want %v", b.Velocity, want)
+	}
+	if !near(b.AngularVelocity, 6, 1e-5) {
+		t.Errorf("AngularVelocity = %v, want 6", b.AngularVelocity)
+	}
+
+	ground := NewBody(NewBox(1, 1), 0)
+	ground.ApplyImpulse(vector2d.New(0, 4), nil).ApplyForce(vector2d.New(1, 0), nil)
+	if !ground.IsStatic() || !cmp.Equal(ground.Velocity, vector2d.New(0, 0)) || ground.force != (vec{}) {
+		t.Errorf("static body moved: %v", ground.Velocity)
+	}
+}
+
+func TestApplyForce(t *testing.T) {
+	w := NewWorld(vector2d.New(0, 0))
+	b := NewBody(NewCircle(1), 1/math.Pi) // mass 1, inertia 0.5
+	w.Add(b)
+	b.ApplyForce(vector2d.New(0, 10), vector2d.New(1, 0))
+	w.Step(0.1)
+	if want := vector2d.New(0, 1); !cmp.Equal(b.Velocity, want, getComparer(1e-5)) {
+		t.Errorf("Velocity = %v, want %v", b.Velocity, want)
+	}
+	if !near(b.AngularVelocity, 2, 1e-5) {
+		t.Errorf("AngularVelocity = %v, want 2", b.AngularVelocity)
+	}
+	w.Step(0.1)
+	if want := vector2d.New(0, 1); !cmp.Equal(b.Velocity, want, getComparer(1e-5)) {
+		t.Errorf("force not cleared after the step: Velocity = %v", b.Velocity)
+	}
+}
@@ -0,0 +1,218 @@
+package physics2d
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Contact between two bodies
+type Manifold struct {
+	A, B *Body
+	// Unit normal pointing from A to B
+	Normal vector2d.Vector2D
+	// One or two contact points
+	Points []ContactPoint
+}
+
+// Point where two bodies touch
+type ContactPoint struct {
+	// World position, on the surface of one of the bodies
+	Point vector2d.Vector2D
+	// Penetration along the normal
+	Depth float32
+	// Impulses accumulated by the solver in the last step
+	NormalImpulse, TangentImpulse float32
+
+	id featureID
+	// solver data
+	ra, rb       vec
+	massN, massT float32
+	bias         float32
+}
+
+// Identifies the features generating a contact point, so a point of the
+// next step's manifold can pick up its impulses for warm starting
+type featureID struct {
+	ref, inc int8
+	// 0 or 1: incident vertex kept, 2 or 3: clipped by a reference side
+	kind int8
+	flip bool
+}
+
+// Finds the contact between two bodies, ok is false if they don't touch
+func Collide(a, b *Body) (m *Manifold, ok bool) {
+	ta, tb := a.transform(), b.transform()
+	m = &Manifold{A: a, B: b}
+	switch sa := a.Shape.(type) {
+	case *Circle:
+		switch sb := b.Shape.(type) {
+		case *Circle:
+			ok = collideCircles(m, sa, ta, sb, tb)
+		case *Polygon:
+			ok = collidePolygonCircle(m, sb, tb, sa, ta)
+			m.Normal = neg(m.Normal)
+		}
+	case *Polygon:
+		switch sb := b.Shape.(type) {
+		case *Circle:
+			ok = collidePolygonCircle(m, sa, ta, sb, tb)
+		case *Polygon:
+			ok = collidePolygons(m, sa, ta, sb, tb)
+		}
+	}
+	if !ok {
+		return nil, false
+	}
+	return m, true
+}
+
+func collideCircles(m *Manifold, a *Circle, ta transform, b *Circle, tb transform) bool {
+	d := sub(tb.p, ta.p)
+	dist := length(d)
+	r := a.Radius + b.Radius
+	if dist > r {
+		return false
+	}
+	n := vec{X: 0, Y: 1}
+	if dist > 0 {
+		n = scale(d, 1/dist)
+	}
+	m.Normal = n
+	m.Points = []ContactPoint{{Point: madd(ta.p, n, a.Radius), Depth: r - dist}}
+	return true
+}
+
+// Normal from the polygon to the circle
+func collidePolygonCircle(m *Manifold, p *Polygon, tp transform, c *Circle, tc transform) bool {
+	center := tp.applyInv(tc.p)
+	edge, sep := 0, float32(math.Inf(-1))
+	for i, n := range p.Normals {
+		if s := dot(n, sub(center, p.Vertices[i])); s > sep {
+			edge, sep = i, s
+		}
+	}
+	if sep > c.Radius {
+		return false
+	}
+	v1, v2 := p.Vertices[edge], p.Vertices[(edge+1)%len(p.Vertices)]
+	var n, point vec
+	var depth float32
+	switch {
+	case sep <= 0:
+		// center inside the polygon
+		n, point, depth = p.Normals[edge], madd(center, p.Normals[edge], -sep), c.Radius-sep
+	case dot(sub(center, v1), sub(v2, v1)) <= 0:
+		if length(sub(center, v1)) > c.Radius {
+			return false
+		}
+		n, point, depth = normalize(sub(center, v1)), v1, c.Radius-length(sub(center, v1))
+	case dot(sub(center, v2), sub(v1, v2)) <= 0:
+		if length(sub(center, v2)) > c.Radius {
+			return false
+		}
+		n, point, depth = normalize(sub(center, v2)), v2, c.Radius-length(sub(center, v2))
+	default:
+		n, point, depth = p.Normals[edge], madd(center, p.Normals[edge], -sep), c.Radius-sep
+	}
+	m.Normal = tp.q.apply(n)
+	m.Points = []ContactPoint{{Point: tp.apply(point), Depth: depth}}
+	return true
+}
+
+// Largest separation of b from the edges of a, and the edge giving it
+func maxSeparation(a *Polygon, ta transform, b *Polygon, tb transform) (edge int, sep float32) {
+	sep = float32(math.Inf(-1))
+	for i := range a.Vertices {
+		n := ta.q.apply(a.Normals[i])
+		v := ta.apply(a.Vertices[i])
+		s := float32(math.Inf(1))
+		for _, u := range b.Vertices {
+			s = minf(s, dot(n, sub(tb.apply(u), v)))
+		}
+		if s > sep {
+			edge, sep = i, s
+		}
+	}
+	return edge, sep
+}
+
+type clipVertex struct {
+	v  vec
+	id featureID
+}
+
+// Keeps the part of the segment with dot(n, v) <= offset
+func clipSegment(in [2]clipVertex, n vec, offset float32, kind int8) (out [2]clipVertex, count int) {
+	d0, d1 := dot(n, in[0].v)-offset, dot(n, in[1].v)-offset
+	if d0 <= 0 {
+		out[count] = in[0]
+		count++
+	}
+	if d1 <= 0 {
+		out[count] = in[1]
+		count++
+	}
+	if d0*d1 < 0 {
+		t := d0 / (d0 - d1)
+		out[count] = clipVertex{v: add(in[0].v, scale(sub(in[1].v, in[0].v), t)), id: in[0].id}
+		out[count].id.kind = kind
+		count++
+	}
+	return out, count
+}
+
+// Separating axis test and clipping of the incident edge against the reference edge
+// https://box2d.org/files/ErinCatto_ContactManifolds_GDC2007.pdf
+func collidePolygons(m *Manifold, a *Polygon, ta transform, b *Polygon, tb transform) bool {
+	edgeA, sepA := maxSeparation(a, ta, b, tb)
+	if sepA > 0 {
+		return false
+	}
+	edgeB, sepB := maxSeparation(b, tb, a, ta)
+	if sepB > 0 {
+		return false
+	}
+	ref, tRef, inc, tInc, edge, flip := a, ta, b, tb, edgeA, false
+	// prefer A as the reference so the choice doesn't flicker between steps
+	if sepB > sepA+1e-3 {
+		ref, tRef, inc, tInc, edge, flip = b, tb, a, ta, edgeB, true
+	}
+
+	// incident edge: the one most anti-parallel to the reference normal
+	refN := tRef.q.apply(ref.Normals[edge])
+	incEdge, minDot := 0, float32(math.Inf(1))
+	for i, n := range inc.Normals {
+		if d := dot(refN, tInc.q.apply(n)); d < minDot {
+			incEdge, minDot = i, d
+		}
+	}
+	i2 := (incEdge + 1) % len(inc.Vertices)
+	id := featureID{ref: int8(edge), inc: int8(incEdge), flip: flip}
+	in := [2]clipVertex{{v: tInc.apply(inc.Vertices[incEdge]), id: id}, {v: tInc.apply(inc.Vertices[i2]), id: id}}
+	in[1].id.kind = 1
+
+	v1 := tRef.apply(ref.Vertices[edge])
+	v2 := tRef.apply(ref.Vertices[(edge+1)%len(ref.Vertices)])
+	tangent := normalize(sub(v2, v1))
+	clip, n := clipSegment(in, neg(tangent), -dot(tangent, v1), 2)
+	if n < 2 {
+		return false
+	}
+	clip, n = clipSegment(clip, tangent, dot(tangent, v2), 3)
+	if n < 2 {
+		return false
+	}
+
+	front := dot(refN, v1)
+	m.Normal = refN
+	if flip {
+		m.Normal = neg(refN)
+	}
+	for _, c := range clip {
+		if sep := dot(refN, c.v) - front; sep <= 0 {
+			m.Points = append(m.Points, ContactPoint{Point: c.v, Depth: -sep, id: c.id})
+		}
+	}
+	return len(m.Points) > 0
+}
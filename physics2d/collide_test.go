@@ -0,0 +1,75 @@
+package physics2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func bodyAt(s Shape, x, y, angle float32) *Body {
+	b := NewBody(s, 1)
+	b.Position = vector2d.New(x, y)
+	b.Angle = angle
+	return b
+}
+
+func TestCollide(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   *Body
+		normal *vector2d.Vector2D
+		depths []float32
+	}{
+		{"circles", bodyAt(NewCircle(1), 0, 0, 0), bodyAt(NewCircle(1), 1.5, 0, 0), vector2d.New(1, 0), []float32{0.5}},
+		{"circles apart", bodyAt(NewCircle(1), 0, 0, 0), bodyAt(NewCircle(1), 2.5, 0, 0), nil, nil},
+		{"box face circle", bodyAt(NewBox(2, 2), 0, 0, 0), bodyAt(NewCircle(1), 0, 1.8, 0), vector2d.New(0, 1), []float32{0.2}},
+		{"circle box face", bodyAt(NewCircle(1), 0, 1.8, 0), bodyAt(NewBox(2, 2), 0, 0, 0), vector2d.New(0, -1), []float32{0.2}},
+		{"box corner circle", bodyAt(NewBox(2, 2), 0, 0, 0), bodyAt(NewCircle(1), 1.6, 1.6, 0), vector2d.New(math.Sqrt2/2, math.Sqrt2/2), []float32{1 - 0.6*math.Sqrt2}},
+		{"box corner circle apart", bodyAt(NewBox(2, 2), 0, 0, 0), bodyAt(NewCircle(1), 1.8, 1.8, 0), nil, nil},
+		{"circle inside box", bodyAt(NewBox(4, 4), 0, 0, 0), bodyAt(NewCircle(0.5), 1.5, 0, 0), vector2d.New(1, 0), []float32{1}},
+		{"stacked boxes", bodyAt(NewBox(2, 2), 0, 0, 0), bodyAt(NewBox(2, 2), 0.5, 1.9, 0), vector2d.New(0, 1), []float32{0.1, 0.1}},
+		{"boxes apart", bodyAt(NewBox(2, 2), 0, 0, 0), bodyAt(NewBox(2, 2), 0, 2.1, 0), nil, nil},
+		{"box on corner", bodyAt(NewBox(4, 2), 0, 0, 0), bodyAt(NewBox(2, 2), 0, 1+math.Sqrt2-0.1, math.Pi/4), vector2d.New(0, 1), []float32{0.1}},
+		{"corner under box", bodyAt(NewBox(2, 2), 0, 1+math.Sqrt2-0.1, math.Pi/4), bodyAt(NewBox(4, 2), 0, 0, 0), vector2d.New(0, -1), []float32{0.1}},
+	}
+	for _, tt := range tests {
+		m, ok := Collide(tt.a, tt.b)
+		if ok != (tt.normal != nil) {
+			t.Errorf("%s: Collide() ok = %v", tt.name, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !cmp.Equal(&m.Normal, tt.normal, getComparer(1e-5)) {
+			t.Errorf("%s: normal = %v, want %v", tt.name, m.Normal, tt.normal)
+		}
+		var depths []float32
+		for _, p := range m.Points {
+			depths = append(depths, p.Depth)
+		}
+		if !cmp.Equal(depths, tt.depths, cmp.Comparer(func(a, b float32) bool { return near(a, b, 1e-5) })) {
+			t.Errorf("%s: depths = %v, want %v", tt.name, depths, tt.depths)
+		}
+	}
+}
+
+func TestCollideBoxesPoints(t *testing.T) {
+	m, ok := Collide(bodyAt(NewBox(2, 2), 0, 0, 0), bodyAt(NewBox(2, 2), 0.5, 1.9, 0))
+	if !ok {
+		t.Fatal("boxes don't collide")
+	}
+	// overlap along the top face of a is x in [-0.5, 1]
+	var xs []float32
+	for _, p := range m.Points {
+		xs = append(xs, p.Point.X)
+	}
+	if !cmp.Equal(xs, []float32{-0.5, 1}) && !cmp.Equal(xs, []float32{1, -0.5}) {
+		t.Errorf("contact x = %v, want -0.5 and 1", xs)
+	}
+	if m.Points[0].id == m.Points[1].id {
+		t.Errorf("contact points share the feature id %v", m.Points[0].id)
+	}
+}
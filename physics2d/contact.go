@@ -0,0 +1,79 @@
+package physics2d
+
+import "math"
+
+const (
+	// Penetration allowed without correction, keeps resting contacts stable
+	linearSlop = 0.01
+	// Share of the penetration removed per step
+	baumgarte = 0.2
+	// Approach speed under which contacts don't bounce
+	restitutionThreshold = 1
+)
+
+// Copies the impulses of the matching points of the previous manifold
+func (m *Manifold) warmStartFrom(old *Manifold) {
+	for i := range m.Points {
+		for _, o := range old.Points {
+			if o.id == m.Points[i].id {
+				m.Points[i].NormalImpulse = o.NormalImpulse
+				m.Points[i].TangentImpulse = o.TangentImpulse
+				break
+			}
+		}
+	}
+}
+
+func (m *Manifold) friction() float32 {
+	return float32(math.Sqrt(float64(m.A.Friction * m.B.Friction)))
+}
+
+func (m *Manifold) restitution() float32 {
+	return maxf(m.A.Restitution, m.B.Restitution)
+}
+
+func (m *Manifold) preStep(invDt float32, warmStart bool) {
+	a, b := m.A, m.B
+	n := m.Normal
+	t := perpRight(n)
+	e := m.restitution()
+	for i := range m.Points {
+		c := &m.Points[i]
+		c.ra = sub(c.Point, *a.Position)
+		c.rb = sub(c.Point, *b.Position)
+		c.massN = effectiveMass(a, b, c.ra, c.rb, n)
+		c.massT = effectiveMass(a, b, c.ra, c.rb, t)
+		c.bias = baumgarte * invDt * maxf(0, c.Depth-linearSlop)
+		if vn := dot(relativeVelocity(a, b, c.ra, c.rb), n); vn < -restitutionThreshold {
+			c.bias = maxf(c.bias, -e*vn)
+		}
+		if warmStart {
+			p := add(scale(n, c.NormalImpulse), scale(t, c.TangentImpulse))
+			applyImpulse(a, b, p, c.ra, c.rb)
+		} else {
+			c.NormalImpulse, c.TangentImpulse = 0, 0
+		}
+	}
+}
+
+func (m *Manifold) solve() {
+	a, b := m.A, m.B
+	n := m.Normal
+	t := perpRight(n)
+	friction := m.friction()
+	for i := range m.Points {
+		c := &m.Points[i]
+		// non penetration: the accumulated normal impulse only pushes
+		vn := dot(relativeVelocity(a, b, c.ra, c.rb), n)
+		old := c.NormalImpulse
+		c.NormalImpulse = maxf(old+c.massN*(c.bias-vn), 0)
+		applyImpulse(a, b, scale(n, c.NormalImpulse-old), c.ra, c.rb)
+
+		// friction bounded by the normal impulse
+		vt := dot(relativeVelocity(a, b, c.ra, c.rb), t)
+		maxF := friction * c.NormalImpulse
+		old = c.TangentImpulse
+		c.TangentImpulse = clampf(old-c.massT*vt, -maxF, maxF)
+		applyImpulse(a, b, scale(t, c.TangentImpulse-old), c.ra, c.rb)
+	}
+}
@@ -0,0 +1,177 @@
+package physics2d
+
+import (
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Constraint between two bodies. Bodies joined by a joint don't collide.
+// Implemented by *DistanceJoint, *RevoluteJoint and *WeldJoint.
+type Joint interface {
+	Bodies() (a, b *Body)
+	preStep(invDt float32, warmStart bool)
+	solve()
+}
+
+// Keeps the anchors of two bodies at a fixed distance, like a rigid rod
+type DistanceJoint struct {
+	A, B *Body
+	// Anchors in body space
+	LocalAnchorA, LocalAnchorB vector2d.Vector2D
+	Length                     float32
+
+	impulse    float32
+	ra, rb, u  vec
+	mass, bias float32
+}
+
+// Joins two bodies at world anchors, keeping their current distance
+func NewDistanceJoint(a, b *Body, anchorA, anchorB *vector2d.Vector2D) *DistanceJoint {
+	return &DistanceJoint{
+		A: a, B: b,
+		LocalAnchorA: *a.LocalPoint(anchorA),
+		LocalAnchorB: *b.LocalPoint(anchorB),
+		Length:       length(sub(*anchorB, *anchorA)),
+	}
+}
+
+// The joined bodies
+func (j *DistanceJoint) Bodies() (a, b *Body) { return j.A, j.B }
+
+func (j *DistanceJoint) preStep(invDt float32, warmStart bool) {
+	j.ra = rotation(j.A.Angle).apply(j.LocalAnchorA)
+	j.rb = rotation(j.B.Angle).apply(j.LocalAnchorB)
+	d := sub(add(*j.B.Position, j.rb), add(*j.A.Position, j.ra))
+	l := length(d)
+	j.u = vec{X: 1}
+	if l > 0 {
+		j.u = scale(d, 1/l)
+	}
+	j.mass = effectiveMass(j.A, j.B, j.ra, j.rb, j.u)
+	j.bias = -baumgarte * invDt * (l - j.Length)
+	if warmStart {
+		applyImpulse(j.A, j.B, scale(j.u, j.impulse), j.ra, j.rb)
+	} else {
+		j.impulse = 0
+	}
+}
+
+func (j *DistanceJoint) solve() {
+	vn := dot(relativeVelocity(j.A, j.B, j.ra, j.rb), j.u)
+	di := j.mass * (j.bias - vn)
+	j.impulse += di
+	applyImpulse(j.A, j.B, scale(j.u, di), j.ra, j.rb)
+}
+
+// Pins two bodies together at a point they can rotate around
+type RevoluteJoint struct {
+	A, B *Body
+	// Anchors in body space
+	LocalAnchorA, LocalAnchorB vector2d.Vector2D
+
+	point pointConstraint
+}
+
+// Joins two bodies at a world anchor
+func NewRevoluteJoint(a, b *Body, anchor *vector2d.Vector2D) *RevoluteJoint {
+	return &RevoluteJoint{A: a, B: b, LocalAnchorA: *a.LocalPoint(anchor), LocalAnchorB: *b.LocalPoint(anchor)}
+}
+
+// The joined bodies
+func (j *RevoluteJoint) Bodies() (a, b *Body) { return j.A, j.B }
+
+func (j *RevoluteJoint) preStep(invDt float32, warmStart bool) {
+	j.point.preStep(j.A, j.B, j.LocalAnchorA, j.LocalAnchorB, invDt, warmStart)
+}
+
+func (j *RevoluteJoint) solve() {
+	j.point.solve(j.A, j.B)
+}
+
+// Glues two bodies together, keeping their relative position and angle
+type WeldJoint struct {
+	A, B *Body
+	// Anchors in body space
+	LocalAnchorA, LocalAnchorB vector2d.Vector2D
+	// Angle of B minus angle of A to keep
+	ReferenceAngle float32
+
+	point        pointConstraint
+	angleImpulse float32
+	angleMass    float32
+	angleBias    float32
+}
+
+// Joins two bodies at a world anchor in their current relative pose
+func NewWeldJoint(a, b *Body, anchor *vector2d.Vector2D) *WeldJoint {
+	return &WeldJoint{
+		A: a, B: b,
+		LocalAnchorA:   *a.LocalPoint(anchor),
+		LocalAnchorB:   *b.LocalPoint(anchor),
+		ReferenceAngle: b.Angle - a.Angle,
+	}
+}
+
+// The joined bodies
+func (j *WeldJoint) Bodies() (a, b *Body) { return j.A, j.B }
+
+func (j *WeldJoint) preStep(invDt float32, warmStart bool) {
+	j.point.preStep(j.A, j.B, j.LocalAnchorA, j.LocalAnchorB, invDt, warmStart)
+	k := j.A.invInertia() + j.B.invInertia()
+	j.angleMass = 0
+	if k > 0 {
+		j.angleMass = 1 / k
+	}
+	j.angleBias = -baumgarte * invDt * (j.B.Angle - j.A.Angle - j.ReferenceAngle)
+	if warmStart {
+		j.A.AngularVelocity -= j.A.invInertia() * j.angleImpulse
+		j.B.AngularVelocity += j.B.invInertia() * j.angleImpulse
+	} else {
+		j.angleImpulse = 0
+	}
+}
+
+func (j *WeldJoint) solve() {
+	dw := j.B.AngularVelocity - j.A.AngularVelocity
+	di := j.angleMass * (j.angleBias - dw)
+	j.angleImpulse += di
+	j.A.AngularVelocity -= j.A.invInertia() * di
+	j.B.AngularVelocity += j.B.invInertia() * di
+	j.point.solve(j.A, j.B)
+}
+
+// Makes two anchors coincide, shared by the revolute and weld joints
+type pointConstraint struct {
+	impulse vec
+	ra, rb  vec
+	mass    mat.Mat2
+	bias    vec
+}
+
+func (c *pointConstraint) preStep(a, b *Body, localA, localB vec, invDt float32, warmStart bool) {
+	c.ra = rotation(a.Angle).apply(localA)
+	c.rb = rotation(b.Angle).apply(localB)
+	ma, mb := float64(a.invMass()), float64(b.invMass())
+	ia, ib := float64(a.invInertia()), float64(b.invInertia())
+	rax, ray := float64(c.ra.X), float64(c.ra.Y)
+	rbx, rby := float64(c.rb.X), float64(c.rb.Y)
+	k := mat.Mat2{
+		{ma + mb + ia*ray*ray + ib*rby*rby, -ia*rax*ray - ib*rbx*rby},
+		{-ia*rax*ray - ib*rbx*rby, ma + mb + ia*rax*rax + ib*rbx*rbx},
+	}
+	c.mass, _ = k.Inverse()
+	gap := sub(add(*b.Position, c.rb), add(*a.Position, c.ra))
+	c.bias = scale(gap, -baumgarte*invDt)
+	if warmStart {
+		applyImpulse(a, b, c.impulse, c.ra, c.rb)
+	} else {
+		c.impulse = vec{}
+	}
+}
+
+func (c *pointConstraint) solve(a, b *Body) {
+	dv := sub(c.bias, relativeVelocity(a, b, c.ra, c.rb))
+	di := *c.mass.MulVec(&dv)
+	c.impulse = add(c.impulse, di)
+	applyImpulse(a, b, di, c.ra, c.rb)
+}
@@ -0,0 +1,69 @@
+package physics2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestRevoluteJoint(t *testing.T) {
+	w := NewWorld(vector2d.New(0, -10))
+	ground := NewBody(NewBox(1, 1), 0)
+	bob := NewBody(NewCircle(0.25), 1)
+	bob.Position = vector2d.New(2, 0)
+	w.Add(ground, bob)
+	pivot := vector2d.New(0, 0)
+	w.AddJoint(NewRevoluteJoint(ground, bob, pivot))
+	lowest := float32(0)
+	for i := 0; i < 120; i++ {
+		w.Step(1.0 / 60)
+		if d := bob.Position.Dist(pivot); !near(d, 2, 0.05) {
+			t.Fatalf("step %d: bob %v from the pivot, want 2", i, d)
+		}
+		lowest = minf(lowest, bob.Position.Y)
+	}
+	if lowest > -1.9 {
+		t.Errorf("pendulum lowest point = %v, want about -2", lowest)
+	}
+}
+
+func TestDistanceJoint(t *testing.T) {
+	w := NewWorld(vector2d.New(0, -10))
+	a := NewBody(NewCircle(0.5), 1)
+	b := NewBody(NewCircle(0.5), 1)
+	b.Position = vector2d.New(3, 0)
+	w.Add(a, b)
+	a.Velocity = vector2d.New(0, 5)
+	w.AddJoint(NewDistanceJoint(a, b, a.Position, b.Position))
+	for i := 0; i < 60; i++ {
+		w.Step(1.0 / 60)
+		if d := a.Position.Dist(b.Position); !near(d, 3, 0.05) {
+			t.Fatalf("step %d: distance %v, want 3", i, d)
+		}
+	}
+	// the joint is internal, so the center of mass follows the free trajectory
+	cy := (a.Position.Y + b.Position.Y) / 2
+	if want := float32(2.5 - 5); !near(cy, want, 0.1) {
+		t.Errorf("center of mass y = %v, want %v", cy, want)
+	}
+}
+
+func TestWeldJoint(t *testing.T) {
+	w := NewWorld(vector2d.New(0, -10))
+	wall := NewBody(NewBox(1, 4), 0)
+	beam := NewBody(NewBox(2, 0.5), 1)
+	beam.Position = vector2d.New(1.5, 0)
+	w.Add(wall, beam)
+	w.AddJoint(NewWeldJoint(wall, beam, vector2d.New(0.5, 0)))
+	for i := 0; i < 120; i++ {
+		w.Step(1.0 / 60)
+	}
+	if !cmp.Equal(beam.Position, vector2d.New(1.5, 0), getComparer(0.05)) || math.Abs(float64(beam.Angle)) > 0.05 {
+		t.Errorf("welded beam moved to %v, angle %v", beam.Position, beam.Angle)
+	}
+	if len(w.Contacts()) != 0 {
+		t.Errorf("jointed bodies collide: %v", w.Contacts())
+	}
+}
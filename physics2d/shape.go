@@ -0,0 +1,162 @@
+package physics2d
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/vaibhav11s/gopkgs/rtree"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Returned for polygons without area
+var ErrDegenerate = errors.New("physics2d: polygon needs 3 non collinear vertices")
+
+// Collision geometry of a body, in body space around its center of mass.
+// Implemented by *Circle and *Polygon.
+type Shape interface {
+	// Mass and rotational inertia about the center of mass for a density
+	MassData(density float32) (mass, inertia float32)
+	bounds(t transform) rtree.Rect
+}
+
+// Circle centered on the body
+type Circle struct {
+	Radius float32
+}
+
+// Makes a circle shape
+func NewCircle(radius float32) *Circle {
+	return &Circle{Radius: radius}
+}
+
+// Mass and rotational inertia of a disc
+func (c *Circle) MassData(density float32) (mass, inertia float32) {
+	mass = density * math.Pi * c.Radius * c.Radius
+	return mass, mass * c.Radius * c.Radius / 2
+}
+
+func (c *Circle) bounds(t transform) rtree.Rect {
+	r := vec{X: c.Radius, Y: c.Radius}
+	return rtree.Rect{Min: sub(t.p, r), Max: add(t.p, r)}
+}
+
+// Convex polygon with counter clockwise vertices around the center of mass
+type Polygon struct {
+	Vertices []vector2d.Vector2D
+	// Outward unit normal of the edge from Vertices[i] to Vertices[i+1]
+	Normals []vector2d.Vector2D
+}
+
+// Makes a w by h rectangle
+func NewBox(w, h float32) *Polygon {
+	x, y := w/2, h/2
+	p, _ := newPolygon([]vec{{X: -x, Y: -y}, {X: x, Y: -y}, {X: x, Y: y}, {X: -x, Y: y}})
+	return p
+}
+
+// Makes the convex hull of the points, moved so its centroid is the origin.
+// A body using it is placed by its centroid.
+func NewPolygon(points []*vector2d.Vector2D) (*Polygon, error) {
+	vs := make([]vec, len(points))
+	for i, p := range points {
+		vs[i] = *p
+	}
+	return newPolygon(hull(vs))
+}
+
+func newPolygon(vs []vec) (*Polygon, error) {
+	if len(vs) < 3 {
+		return nil, ErrDegenerate
+	}
+	var area float32
+	var c vec
+	for i := range vs {
+		a, b := vs[i], vs[(i+1)%len(vs)]
+		cr := cross(a, b)
+		area += cr / 2
+		c = add(c, scale(add(a, b), cr/6))
+	}
+	if area <= 1e-9 {
+		return nil, ErrDegenerate
+	}
+	c = scale(c, 1/area)
+	p := &Polygon{Vertices: make([]vec, len(vs)), Normals: make([]vec, len(vs))}
+	for i := range vs {
+		p.Vertices[i] = sub(vs[i], c)
+	}
+	for i := range vs {
+		e := sub(p.Vertices[(i+1)%len(vs)], p.Vertices[i])
+		p.Normals[i] = normalize(perpRight(e))
+	}
+	return p, nil
+}
+
+// Convex hull in counter clockwise order with Andrew's monotone chain,
+// collinear points are dropped
+func hull(ps []vec) []vec {
+	ps = append([]vec(nil), ps...)
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].X != ps[j].X {
+			return ps[i].X < ps[j].X
+		}
+		return ps[i].Y < ps[j].Y
+	})
+	if len(ps) < 3 {
+		return ps
+	}
+	h := make([]vec, 0, 2*len(ps))
+	for pass := 0; pass < 2; pass++ {
+		start := len(h)
+		for _, p := range ps {
+			for len(h) >= start+2 && cross(sub(h[len(h)-1], h[len(h)-2]), sub(p, h[len(h)-2])) <= 0 {
+				h = h[:len(h)-1]
+			}
+			h = append(h, p)
+		}
+		// the last point starts the other chain
+		h = h[:len(h)-1]
+		for i, j := 0, len(ps)-1; i < j; i, j = i+1, j-1 {
+			ps[i], ps[j] = ps[j], ps[i]
+		}
+	}
+	return h
+}
+
+// Mass and rotational inertia of the polygon, summed over the triangles
+// between the origin and each edge
+func (p *Polygon) MassData(density float32) (mass, inertia float32) {
+	var area, i float32
+	for k := range p.Vertices {
+		e1, e2 := p.Vertices[k], p.Vertices[(k+1)%len(p.Vertices)]
+		cr := cross(e1, e2)
+		area += cr / 2
+		i += cr / 12 * (dot(e1, e1) + dot(e1, e2) + dot(e2, e2))
+	}
+	return density * area, density * i
+}
+
+func (p *Polygon) bounds(t transform) rtree.Rect {
+	v := t.apply(p.Vertices[0])
+	r := rtree.Rect{Min: v, Max: v}
+	for _, u := range p.Vertices[1:] {
+		v := t.apply(u)
+		r.Min = vec{X: minf(r.Min.X, v.X), Y: minf(r.Min.Y, v.Y)}
+		r.Max = vec{X: maxf(r.Max.X, v.X), Y: maxf(r.Max.Y, v.Y)}
+	}
+	return r
+}
+
+func minf(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxf(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,61 @@
+package physics2d
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func near(a, b, tolerance float32) bool {
+	return math.Abs(float64(a-b)) <= float64(tolerance)
+}
+
+func TestMassData(t *testing.T) {
+	tests := []struct {
+		shape         Shape
+		mass, inertia float32
+	}{
+		{NewCircle(2), 2 * 4 * math.Pi, 2 * 4 * math.Pi * 4 / 2},
+		{NewBox(2, 4), 2 * 8, 2 * 8 * (4 + 16) / 12.0},
+	}
+	for _, tt := range tests {
+		m, i := tt.shape.MassData(2)
+		if !near(m, tt.mass, 1e-4) || !near(i, tt.inertia, 1e-3) {
+			t.Errorf("%T.MassData(2) = %v, %v, want %v, %v", tt.shape, m, i, tt.mass, tt.inertia)
+		}
+	}
+}
+
+func TestNewPolygon(t *testing.T) {
+	// triangle with an interior and a collinear point, centroid (1, 1)
+	ps := []*vector2d.Vector2D{
+		vector2d.New(0, 0), vector2d.New(3, 0), vector2d.New(1, 1),
+		vector2d.New(0, 3), vector2d.New(0, 1.5),
+	}
+	p, err := NewPolygon(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []vector2d.Vector2D{{X: -1, Y: -1}, {X: 2, Y: -1}, {X: -1, Y: 2}}
+	if !cmp.Equal(p.Vertices, want) {
+		t.Errorf("Vertices = %v, want %v", p.Vertices, want)
+	}
+	s := float32(math.Sqrt2 / 2)
+	wantN := []vector2d.Vector2D{{X: 0, Y: -1}, {X: s, Y: s}, {X: -1, Y: 0}}
+	if !cmp.Equal(p.Normals, wantN, cmp.Comparer(func(a, b vector2d.Vector2D) bool { return a.Equal(&b, 1e-6) })) {
+		t.Errorf("Normals = %v, want %v", p.Normals, wantN)
+	}
+	if m, _ := p.MassData(1); !near(m, 4.5, 1e-5) {
+		t.Errorf("area = %v, want 4.5", m)
+	}
+	if _, err := NewPolygon(ps[:2]); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("NewPolygon(2 points) error = %v", err)
+	}
+	line := []*vector2d.Vector2D{vector2d.New(0, 0), vector2d.New(1, 1), vector2d.New(2, 2)}
+	if _, err := NewPolygon(line); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("NewPolygon(collinear) error = %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package physics2d
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// The solver works on vector values to avoid allocating in its inner loops
+type vec = vector2d.Vector2D
+
+func add(a, b vec) vec             { return vec{X: a.X + b.X, Y: a.Y + b.Y} }
+func sub(a, b vec) vec             { return vec{X: a.X - b.X, Y: a.Y - b.Y} }
+func scale(a vec, s float32) vec   { return vec{X: a.X * s, Y: a.Y * s} }
+func dot(a, b vec) float32         { return a.X*b.X + a.Y*b.Y }
+func cross(a, b vec) float32       { return a.X*b.Y - a.Y*b.X }
+func length(a vec) float32         { return float32(math.Sqrt(float64(dot(a, a)))) }
+func neg(a vec) vec                { return vec{X: -a.X, Y: -a.Y} }
+func madd(a, b vec, s float32) vec { return vec{X: a.X + b.X*s, Y: a.Y + b.Y*s} }
+func crossSV(s float32, v vec) vec { return vec{X: -s * v.Y, Y: s * v.X} }
+func perpRight(v vec) vec          { return vec{X: v.Y, Y: -v.X} }
+func absf(x float32) float32       { return float32(math.Abs(float64(x))) }
+func sqrtf(x float32) float32      { return float32(math.Sqrt(float64(x))) }
+func clampf(x, lo, hi float32) float32 {
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
+
+func normalize(a vec) vec {
+	l := length(a)
+	if l == 0 {
+		return a
+	}
+	return scale(a, 1/l)
+}
+
+// Rotation by an angle
+type rot struct{ c, s float32 }
+
+func rotation(angle float32) rot {
+	s, c := math.Sincos(float64(angle))
+	return rot{c: float32(c), s: float32(s)}
+}
+
+func (q rot) apply(v vec) vec    { return vec{X: q.c*v.X - q.s*v.Y, Y: q.s*v.X + q.c*v.Y} }
+func (q rot) applyInv(v vec) vec { return vec{X: q.c*v.X + q.s*v.Y, Y: -q.s*v.X + q.c*v.Y} }
+
+// Rigid transform from body to world space
+type transform struct {
+	p vec
+	q rot
+}
+
+func (t transform) apply(v vec) vec    { return add(t.p, t.q.apply(v)) }
+func (t transform) applyInv(v vec) vec { return t.q.applyInv(sub(v, t.p)) }
@@ -0,0 +1,337 @@
+// Package physics2d provides a small 2D rigid body engine on vector2d: circle,
+// box and convex polygon bodies, sort and sweep broad-phase, SAT contact
+// manifolds, a sequential impulse solver with warm starting and sleeping, and
+// distance, revolute and weld joints.
+// https://box2d.org/publications/
+package physics2d
+
+import (
+	"sort"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+type pairKey struct{ a, b *Body }
+
+// Simulation of bodies and joints
+type World struct {
+	Gravity *vector2d.Vector2D
+	// Solver iterations per step (default 10)
+	Iterations int
+	// Starts the solver from the impulses of the last step
+	WarmStarting bool
+	// Puts groups of touching bodies to sleep once they have been at rest for a while
+	AllowSleep bool
+
+	bodies   []*Body
+	joints   []Joint
+	contacts map[pairKey]*Manifold
+	nextID   int
+}
+
+const (
+	// Speeds under which a body counts as at rest
+	sleepLinear  = 0.01
+	sleepAngular = 2 * 3.14159265 / 180
+	// Time at rest before sleeping
+	timeToSleep = 0.5
+)
+
+// Makes an empty world with warm starting and sleeping on
+func NewWorld(gravity *vector2d.Vector2D) *World {
+	return &World{
+		Gravity:      gravity.Copy(),
+		Iterations:   10,
+		WarmStarting: true,
+		AllowSleep:   true,
+		contacts:     map[pairKey]*Manifold{},
+	}
+}
+
+// Adds bodies to the world
+func (w *World) Add(bodies ...*Body) *World {
+	for _, b := range bodies {
+		b.id = w.nextID
+		w.nextID++
+		w.bodies = append(w.bodies, b)
+	}
+	return w
+}
+
+// Removes a body with its contacts and joints, returns false if it is not in the world
+func (w *World) Remove(b *Body) bool {
+	for i, x := range w.bodies {
+		if x != b {
+			continue
+		}
+		w.bodies = append(w.bodies[:i], w.bodies[i+1:]...)
+		for k, m := range w.contacts {
+			if k.a == b || k.b == b {
+				m.wakeBodies()
+				delete(w.contacts, k)
+			}
+		}
+		joints := w.joints[:0]
+		for _, j := range w.joints {
+			if ja, jb := j.Bodies(); ja != b && jb != b {
+				joints = append(joints, j)
+			}
+		}
+		w.joints = joints
+		return true
+	}
+	return false
+}
+
+// Adds joints to the world
+func (w *World) AddJoint(joints ...Joint) *World {
+	w.joints = append(w.joints, joints...)
+	return w
+}
+
+// Removes a joint, returns false if it is not in the world
+func (w *World) RemoveJoint(j Joint) bool {
+	for i, x := range w.joints {
+		if x == j {
+			w.joints = append(w.joints[:i], w.joints[i+1:]...)
+			a, b := j.Bodies()
+			a.Wake()
+			b.Wake()
+			return true
+		}
+	}
+	return false
+}
+
+// Bodies in the world
+func (w *World) Bodies() []*Body {
+	return w.bodies
+}
+
+// Joints in the world
+func (w *World) Joints() []Joint {
+	return w.joints
+}
+
+// Contacts found in the last step, sorted by body order
+func (w *World) Contacts() []*Manifold {
+	ms := make([]*Manifold, 0, len(w.contacts))
+	for _, m := range w.contacts {
+		ms = append(ms, m)
+	}
+	sort.Slice(ms, func(i, j int) bool {
+		if ms[i].A.id != ms[j].A.id {
+			return ms[i].A.id < ms[j].A.id
+		}
+		return ms[i].B.id < ms[j].B.id
+	})
+	return ms
+}
+
+func (m *Manifold) wakeBodies() {
+	if !m.A.IsStatic() {
+		m.A.Wake()
+	}
+	if !m.B.IsStatic() {
+		m.B.Wake()
+	}
+}
+
+// Whether the body moves in this step
+func awake(b *Body) bool {
+	return !b.IsStatic() && !b.sleeping
+}
+
+// Advances the simulation by dt
+func (w *World) Step(dt float32) {
+	if dt <= 0 {
+		return
+	}
+	invDt := 1 / dt
+	w.updateContacts()
+
+	for _, b := range w.bodies {
+		if !awake(b) {
+			continue
+		}
+		*b.Velocity = madd(madd(*b.Velocity, *w.Gravity, dt), b.force, dt*b.invMass())
+		b.AngularVelocity += dt * b.invInertia() * b.torque
+	}
+
+	contacts := w.Contacts()
+	active := contacts[:0]
+	for _, m := range contacts {
+		if awake(m.A) || awake(m.B) {
+			active = append(active, m)
+		}
+	}
+	var joints []Joint
+	for _, j := range w.joints {
+		if a, b := j.Bodies(); awake(a) || awake(b) {
+			joints = append(joints, j)
+		}
+	}
+	for _, m := range active {
+		m.preStep(invDt, w.WarmStarting)
+	}
+	for _, j := range joints {
+		j.preStep(invDt, w.WarmStarting)
+	}
+	for i := 0; i < w.Iterations; i++ {
+		for _, m := range active {
+			m.solve()
+		}
+		for _, j := range joints {
+			j.solve()
+		}
+	}
+
+	for _, b := range w.bodies {
+		b.force, b.torque = vec{}, 0
+		if !awake(b) {
+			continue
+		}
+		*b.Position = madd(*b.Position, *b.Velocity, dt)
+		b.Angle += dt * b.AngularVelocity
+	}
+	if w.AllowSleep {
+		w.updateSleep(dt)
+	}
+}
+
+// Broad-phase with sort and sweep along x, then narrow-phase on the
+// overlapping pairs. Impulses of persisting contact points are kept.
+func (w *World) updateContacts() {
+	type entry struct {
+		b    *Body
+		minX float32
+		maxX float32
+	}
+	bounds := make(map[*Body][2]vec, len(w.bodies))
+	entries := make([]entry, len(w.bodies))
+	for i, b := range w.bodies {
+		r := b.Bounds()
+		bounds[b] = [2]vec{r.Min, r.Max}
+		entries[i] = entry{b, r.Min.X, r.Max.X}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].minX < entries[j].minX })
+	jointed := map[pairKey]bool{}
+	for _, j := range w.joints {
+		a, b := j.Bodies()
+		jointed[orderedPair(a, b)] = true
+	}
+
+	next := make(map[pairKey]*Manifold, len(w.contacts))
+	for i, ei := range entries {
+		for _, ej := range entries[i+1:] {
+			if ej.minX > ei.maxX {
+				break
+			}
+			a, b := ei.b, ej.b
+			if !awake(a) && !awake(b) {
+				// keep resting contacts of sleeping bodies for when they wake
+				if m, ok := w.contacts[orderedPair(a, b)]; ok {
+					next[orderedPair(a, b)] = m
+				}
+				continue
+			}
+			ra, rb := bounds[a], bounds[b]
+			if ra[0].Y > rb[1].Y || rb[0].Y > ra[1].Y {
+				continue
+			}
+			key := orderedPair(a, b)
+			if jointed[key] {
+				continue
+			}
+			m, ok := Collide(key.a, key.b)
+			if !ok {
+				continue
+			}
+			if old, ok := w.contacts[key]; ok {
+				m.warmStartFrom(old)
+			}
+			next[key] = m
+		}
+	}
+	w.contacts = next
+}
+
+func orderedPair(a, b *Body) pairKey {
+	if b.id < a.id {
+		a, b = b, a
+	}
+	return pairKey{a, b}
+}
+
+// Groups dynamic bodies linked by contacts or joints into islands; an island
+// sleeps when all its bodies have been at rest long enough, and wakes
+// entirely when any of its bodies is awake and moving.
+func (w *World) updateSleep(dt float32) {
+	for _, b := range w.bodies {
+		if !awake(b) {
+			continue
+		}
+		if b.Velocity.MagSq() > sleepLinear*sleepLinear || absf(b.AngularVelocity) > sleepAngular {
+			b.sleepTime = 0
+		} else {
+			b.sleepTime += dt
+		}
+	}
+
+	parent := map[*Body]*Body{}
+	var find func(b *Body) *Body
+	find = func(b *Body) *Body {
+		p, ok := parent[b]
+		if !ok || p == b {
+			return b
+		}
+		r := find(p)
+		parent[b] = r
+		return r
+	}
+	union := func(a, b *Body) {
+		if a.IsStatic() || b.IsStatic() {
+			return
+		}
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			parent[ra] = rb
+		}
+	}
+	for k := range w.contacts {
+		union(k.a, k.b)
+	}
+	for _, j := range w.joints {
+		union(j.Bodies())
+	}
+
+	// time the whole island has been at rest, sleeping bodies count as rested
+	minTime := map[*Body]float32{}
+	for _, b := range w.bodies {
+		if b.IsStatic() {
+			continue
+		}
+		t := b.sleepTime
+		if b.sleeping {
+			t = timeToSleep
+		}
+		r := find(b)
+		if cur, ok := minTime[r]; !ok || t < cur {
+			minTime[r] = t
+		}
+	}
+	for _, b := range w.bodies {
+		if b.IsStatic() {
+			continue
+		}
+		if minTime[find(b)] >= timeToSleep {
+			if !b.sleeping {
+				b.sleeping = true
+				*b.Velocity = vec{}
+				b.AngularVelocity = 0
+			}
+		} else if b.sleeping {
+			b.Wake()
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package physics2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+const dt = 1.0 / 60
+
+func newGround(w *World) *Body {
+	g := NewBody(NewBox(40, 2), 0)
+	g.Position = vector2d.New(0, -1)
+	w.Add(g)
+	return g
+}
+
+func run(w *World, seconds float32) {
+	for i := 0; i < int(math.Round(float64(seconds/dt))); i++ {
+		w.Step(dt)
+	}
+}
+
+func TestFreeFall(t *testing.T) {
+	w := NewWorld(vector2d.New(0, -10))
+	b := NewBody(NewCircle(1), 1)
+	w.Add(b)
+	run(w, 1)
+	if want := vector2d.New(0, -10); !cmp.Equal(b.Velocity, want, getComparer(1e-3)) {
+		t.Errorf("Velocity = %v, want %v", b.Velocity, want)
+	}
+}
+
+func TestStack(t *testing.T) {
+	for _, warm := range []bool{true, false} {
+		w := NewWorld(vector2d.New(0, -10))
+		w.WarmStarting = warm
+		newGround(w)
+		var boxes []*Body
+		for i := 0; i < 5; i++ {
+			b := NewBody(NewBox(1, 1), 1)
+			b.Position = vector2d.New(0, 0.5+float32(i)*1.01)
+			boxes = append(boxes, b)
+			w.Add(b)
+		}
+		run(w, 4)
+		top := boxes[len(boxes)-1]
+		if warm {
+			if !near(top.Position.Y, 4.5, 0.05) || !near(top.Position.X, 0, 0.01) {
+				t.Errorf("top of the stack at %v, want (0, 4.5)", top.Position)
+			}
+			for i, b := range boxes {
+				if !b.IsSleeping() {
+					t.Errorf("box %d awake at %v", i, b.Velocity)
+				}
+			}
+		} else if top.Position.Y < 4 {
+			t.Errorf("stack without warm starting collapsed to %v", top.Position)
+		}
+	}
+}
+
+func TestRestingImpulse(t *testing.T) {
+	w := NewWorld(vector2d.New(0, -10))
+	newGround(w)
+	b := NewBody(NewBox(1, 1), 2)
+	b.Position = vector2d.New(0, 0.5)
+	w.Add(b)
+	w.AllowSleep = false
+	run(w, 1)
+	cs := w.Contacts()
+	if len(cs) != 1 || len(cs[0].Points) != 2 {
+		t.Fatalf("contacts = %v, want one manifold with two points", cs)
+	}
+	var total float32
+	for _, p := range cs[0].Points {
+		total += p.NormalImpulse
+	}
+	// the impulse holds the weight over one step
+	if want := float32(2 * 10 * dt); !near(total, want, want*0.05) {
+		t.Errorf("normal impulse = %v, want %v", total, want)
+	}
+}
+
+func TestRestitution(t *testing.T) {
+	for _, e := range []float32{0, 1} {
+		w := NewWorld(vector2d.New(0, -10))
+		newGround(w)
+		b := NewBody(NewCircle(0.5), 1)
+		b.Position = vector2d.New(0, 5)
+		b.Restitution = e
+		w.Add(b)
+		run(w, 1.2)
+		var peak float32
+		for i := 0; i < 120; i++ {
+			w.Step(dt)
+			peak = maxf(peak, b.Position.Y)
+		}
+		if e == 1 && peak < 4.5 {
+			t.Errorf("elastic ball bounced to %v, want about 5", peak)
+		}
+		if e == 0 && peak > 0.6 {
+			t.Errorf("inelastic ball bounced to %v", peak)
+		}
+	}
+}
+
+func TestFriction(t *testing.T) {
+	for _, f := range []float32{0, 0.5} {
+		w := NewWorld(vector2d.New(0, -10))
+		g := newGround(w)
+		g.Friction = f
+		b := NewBody(NewBox(1, 1), 1)
+		b.Position = vector2d.New(-10, 0.5)
+		b.Velocity = vector2d.New(5, 0)
+		b.Friction = f
+		w.Add(b)
+		run(w, 1)
+		if f == 0 && !near(b.Velocity.X, 5, 1e-3) {
+			t.Errorf("frictionless speed = %v, want 5", b.Velocity.X)
+		}
+		if f != 0 {
+			// decelerates at f*g = 5 until it stops after 1s and 2.5 units
+			if !near(b.Velocity.X, 0, 0.1) || !near(b.Position.X, -7.5, 0.1) {
+				t.Errorf("sliding box at %v with %v, want stopped at -7.5", b.Position, b.Velocity)
+			}
+		}
+	}
+}
+
+func TestSleepAndWake(t *testing.T) {
+	w := NewWorld(vector2d.New(0, -10))
+	newGround(w)
+	b := NewBody(NewBox(1, 1), 1)
+	b.Position = vector2d.New(0, 0.5)
+	w.Add(b)
+	run(w, 1)
+	if !b.IsSleeping() {
+		t.Fatal("resting box awake")
+	}
+	y := b.Position.Y
+	run(w, 1)
+	if b.Position.Y != y {
+		t.Errorf("sleeping box moved from %v to %v", y, b.Position.Y)
+	}
+
+	// a falling body wakes it
+	ball := NewBody(NewCircle(0.5), 1)
+	ball.Position = vector2d.New(0, 3)
+	w.Add(ball)
+	woke := false
+	for i := 0; i < 60 && !woke; i++ {
+		w.Step(dt)
+		woke = !b.IsSleeping()
+	}
+	if !woke {
+		t.Error("hit did not wake the box")
+	}
+
+	run(w, 3)
+	b.ApplyImpulse(vector2d.New(0, 5), nil)
+	if b.IsSleeping() {
+		t.Error("impulse did not wake the box")
+	}
+	w.Step(dt)
+	if b.Velocity.Y <= 0 {
+		t.Errorf("velocity after impulse = %v", b.Velocity)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	w := NewWorld(vector2d.New(0, -10))
+	g := newGround(w)
+	a := NewBody(NewBox(1, 1), 1)
+	a.Position = vector2d.New(0, 0.5)
+	b := NewBody(NewBox(1, 1), 1)
+	b.Position = vector2d.New(0, 1.5)
+	w.Add(a, b)
+	j := NewWeldJoint(a, b, vector2d.New(0, 1))
+	w.AddJoint(j)
+	run(w, 0.5)
+	if !w.Remove(a) || w.Remove(a) {
+		t.Fatal("Remove() result")
+	}
+	if len(w.Bodies()) != 2 || len(w.Joints()) != 0 || w.RemoveJoint(j) {
+		t.Errorf("after Remove: %d bodies, %d joints", len(w.Bodies()), len(w.Joints()))
+	}
+	for _, m := range w.Contacts() {
+		if m.A == a || m.B == a {
+			t.Errorf("contact with a removed body")
+		}
+	}
+	run(w, 1)
+	if !near(b.Position.Y, 0.5, 0.05) {
+		t.Errorf("box fell to %v, want 0.5", b.Position.Y)
+	}
+	_ = g
+}
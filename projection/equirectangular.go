@@ -0,0 +1,41 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/geodesy"
+)
+
+// Equirectangular projection on a sphere: x is proportional to the longitude
+// and y to the latitude. Distances are true along the meridians and along the
+// standard parallels.
+// https://en.wikipedia.org/wiki/Equirectangular_projection
+type Equirectangular struct {
+	// Latitude in degrees where the scale is true, 0 for plate carrée
+	StandardParallel float64
+	// Longitude in degrees of the center of the map
+	CentralMeridian float64
+	// Radius of the sphere, 0 for geodesy.EarthRadius
+	Radius float64
+}
+
+func (e Equirectangular) radius() float64 {
+	if e.Radius == 0 {
+		return geodesy.EarthRadius
+	}
+	return e.Radius
+}
+
+// Distances east of the central meridian and north of the equator
+func (e Equirectangular) Forward(lat, lon float64) (x, y float64) {
+	r := e.radius()
+	dLon := math.Remainder(lon-e.CentralMeridian, 360)
+	return r * radians(dLon) * math.Cos(radians(e.StandardParallel)), r * radians(lat)
+}
+
+// Latitude and longitude of a position
+func (e Equirectangular) Inverse(x, y float64) (lat, lon float64) {
+	r := e.radius()
+	lon = e.CentralMeridian + degrees(x/(r*math.Cos(radians(e.StandardParallel))))
+	return degrees(y / r), math.Remainder(lon, 360)
+}
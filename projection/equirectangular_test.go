@@ -0,0 +1,47 @@
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/geodesy"
+)
+
+func TestEquirectangular(t *testing.T) {
+	deg := geodesy.EarthRadius * math.Pi / 180
+	tests := []struct {
+		name     string
+		p        Equirectangular
+		lat, lon float64
+		x, y     float64
+	}{
+		{"plate carree", Equirectangular{}, 10, 20, 20 * deg, 10 * deg},
+		{"standard parallel", Equirectangular{StandardParallel: 60}, -10, 20, 10 * deg, -10 * deg},
+		{"radius", Equirectangular{Radius: 180 / math.Pi}, 45, -90, -90, 45},
+		{"central meridian", Equirectangular{CentralMeridian: 170, Radius: 180 / math.Pi}, 0, -170, 20, 0},
+	}
+	for _, tt := range tests {
+		x, y := tt.p.Forward(tt.lat, tt.lon)
+		if diff := cmp.Diff([]float64{tt.x, tt.y}, []float64{x, y}, floatComparer(1e-6)); diff != "" {
+			t.Errorf("%s Forward() mismatch (-want +got):\n%s", tt.name, diff)
+		}
+		lat, lon := tt.p.Inverse(x, y)
+		if diff := cmp.Diff([]float64{tt.lat, tt.lon}, []float64{lat, lon}, floatComparer(1e-12)); diff != "" {
+			t.Errorf("%s Inverse() mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
+
+func TestEquirectangularScale(t *testing.T) {
+	// the scale is true along the standard parallel
+	p := Equirectangular{StandardParallel: 40, CentralMeridian: -100}
+	a, b := geodesy.Geodetic{Lat: 40, Lon: -100}, geodesy.Geodetic{Lat: 40, Lon: -99.99}
+	v := ProjectAll(p, []geodesy.Geodetic{a, b})
+	d := v[1].X - v[0].X
+	// along the parallel, not the great circle
+	want := geodesy.EarthRadius * math.Cos(radians(40)) * radians(0.01)
+	if math.Abs(float64(d)-want) > 1e-3 {
+		t.Errorf("distance along the standard parallel = %v, want %v", d, want)
+	}
+}
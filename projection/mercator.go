@@ -0,0 +1,92 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/geodesy"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Latitude in degrees where Web Mercator maps are cut to make them square
+const MaxMercatorLat = 85.05112877980659
+
+// Default width of a map tile in pixels
+const defaultTileSize = 256
+
+// Spherical Mercator of web maps (EPSG:3857), in meters on a sphere of the
+// WGS84 equatorial radius. Latitudes are clamped to MaxMercatorLat. Tiles and
+// pixels follow the XYZ scheme, with the origin at the north west corner and
+// y growing southwards.
+// https://en.wikipedia.org/wiki/Web_Mercator_projection
+type WebMercator struct{}
+
+// Half the width of the map in meters
+var mercatorHalf = math.Pi * geodesy.WGS84.A
+
+// Meters east of the prime meridian and north of the equator
+func (WebMercator) Forward(lat, lon float64) (x, y float64) {
+	lat = math.Max(-MaxMercatorLat, math.Min(MaxMercatorLat, lat))
+	r := geodesy.WGS84.A
+	return r * radians(lon), r * math.Log(math.Tan(math.Pi/4+radians(lat)/2))
+}
+
+// Latitude and longitude of a position in meters
+func (WebMercator) Inverse(x, y float64) (lat, lon float64) {
+	r := geodesy.WGS84.A
+	return degrees(2*math.Atan(math.Exp(y/r)) - math.Pi/2), degrees(x / r)
+}
+
+// Size of the whole map in pixels at a zoom level
+func mapSize(zoom int, tileSize []int) float64 {
+	size := defaultTileSize
+	if len(tileSize) >= 1 {
+		size = tileSize[0]
+	}
+	return float64(size) * math.Exp2(float64(zoom))
+}
+
+// Tile holding a position at a zoom level, 2^zoom tiles across
+func (m WebMercator) Tile(g geodesy.Geodetic, zoom int) (x, y int) {
+	n := math.Exp2(float64(zoom))
+	px, py := m.pixel(g, n)
+	clamp := func(v float64) int {
+		return int(math.Max(0, math.Min(n-1, math.Floor(v))))
+	}
+	return clamp(px), clamp(py)
+}
+
+// North west and south east corners of a tile
+func (m WebMercator) TileBounds(x, y, zoom int) (northWest, southEast geodesy.Geodetic) {
+	n := math.Exp2(float64(zoom))
+	corner := func(px, py float64) geodesy.Geodetic {
+		lat, lon := m.Inverse(px/n*2*mercatorHalf-mercatorHalf, mercatorHalf-py/n*2*mercatorHalf)
+		return geodesy.Geodetic{Lat: lat, Lon: lon}
+	}
+	return corner(float64(x), float64(y)), corner(float64(x+1), float64(y+1))
+}
+
+// Position on a map of size units across
+func (m WebMercator) pixel(g geodesy.Geodetic, size float64) (x, y float64) {
+	mx, my := m.Forward(g.Lat, g.Lon)
+	return (mx + mercatorHalf) / (2 * mercatorHalf) * size, (mercatorHalf - my) / (2 * mercatorHalf) * size
+}
+
+// Pixel of a position on the whole map at a zoom level, with tiles of
+// tileSize pixels (default 256)
+func (m WebMercator) Pixel(g geodesy.Geodetic, zoom int, tileSize ...int) *vector2d.Vector2D {
+	x, y := m.pixel(g, mapSize(zoom, tileSize))
+	return vector2d.New(float32(x), float32(y))
+}
+
+// Geodetic position of a pixel on the whole map at a zoom level, see Pixel
+func (m WebMercator) FromPixel(p *vector2d.Vector2D, zoom int, tileSize ...int) geodesy.Geodetic {
+	size := mapSize(zoom, tileSize)
+	lat, lon := m.Inverse(float64(p.X)/size*2*mercatorHalf-mercatorHalf, mercatorHalf-float64(p.Y)/size*2*mercatorHalf)
+	return geodesy.Geodetic{Lat: lat, Lon: lon}
+}
+
+// Ground meters per pixel at a latitude and zoom level, with tiles of
+// tileSize pixels (default 256)
+func (WebMercator) Resolution(lat float64, zoom int, tileSize ...int) float64 {
+	return 2 * mercatorHalf * math.Cos(radians(lat)) / mapSize(zoom, tileSize)
+}
@@ -0,0 +1,99 @@
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/geodesy"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestWebMercator(t *testing.T) {
+	const half = 20037508.342789244
+	tests := []struct {
+		lat, lon, x, y float64
+	}{
+		{0, 0, 0, 0},
+		{0, 180, half, 0},
+		{0, -90, -half / 2, 0},
+		{MaxMercatorLat, 0, 0, half},
+		{-MaxMercatorLat, 45, half / 4, -half},
+		{90, 0, 0, half},
+	}
+	m := WebMercator{}
+	for _, tt := range tests {
+		x, y := m.Forward(tt.lat, tt.lon)
+		if diff := cmp.Diff([]float64{tt.x, tt.y}, []float64{x, y}, floatComparer(1e-6)); diff != "" {
+			t.Errorf("Forward(%v, %v) mismatch (-want +got):\n%s", tt.lat, tt.lon, diff)
+		}
+	}
+	for _, g := range []geodesy.Geodetic{{Lat: 51.5074, Lon: -0.1278}, {Lat: -33.87, Lon: 151.21}, {Lat: 80, Lon: -179}} {
+		lat, lon := m.Inverse(m.Forward(g.Lat, g.Lon))
+		if diff := cmp.Diff([]float64{g.Lat, g.Lon}, []float64{lat, lon}, floatComparer(1e-12)); diff != "" {
+			t.Errorf("Inverse(Forward(%v)) mismatch (-want +got):\n%s", g, diff)
+		}
+	}
+}
+
+func TestTiles(t *testing.T) {
+	m := WebMercator{}
+	london := geodesy.Geodetic{Lat: 51.5074, Lon: -0.1278}
+	tests := []struct {
+		g          geodesy.Geodetic
+		zoom, x, y int
+	}{
+		{london, 0, 0, 0},
+		{london, 1, 0, 0},
+		{london, 10, 511, 340},
+		{london, 16, 32744, 21792},
+		{geodesy.Geodetic{Lat: -89, Lon: 180}, 3, 7, 7},
+		{geodesy.Geodetic{Lat: 0.001, Lon: 0.001}, 5, 16, 15},
+	}
+	for _, tt := range tests {
+		x, y := m.Tile(tt.g, tt.zoom)
+		if x != tt.x || y != tt.y {
+			t.Errorf("Tile(%v, %v) = %v, %v, want %v, %v", tt.g, tt.zoom, x, y, tt.x, tt.y)
+		}
+		// the tile holds the position
+		nw, se := m.TileBounds(x, y, tt.zoom)
+		if tt.g.Lat > -MaxMercatorLat && (tt.g.Lat > nw.Lat || tt.g.Lat < se.Lat || tt.g.Lon < nw.Lon || tt.g.Lon > se.Lon) {
+			t.Errorf("TileBounds(%v, %v, %v) = %v, %v outside %v", x, y, tt.zoom, nw, se, tt.g)
+		}
+	}
+	nw, se := m.TileBounds(0, 0, 0)
+	want := []geodesy.Geodetic{{Lat: MaxMercatorLat, Lon: -180}, {Lat: -MaxMercatorLat, Lon: 180}}
+	if diff := cmp.Diff(want, []geodesy.Geodetic{nw, se}, floatComparer(1e-9)); diff != "" {
+		t.Errorf("TileBounds() of the world mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPixel(t *testing.T) {
+	m := WebMercator{}
+	tests := []struct {
+		g        geodesy.Geodetic
+		zoom     int
+		tileSize []int
+		want     *vector2d.Vector2D
+	}{
+		{geodesy.Geodetic{}, 0, nil, vector2d.New(128, 128)},
+		{geodesy.Geodetic{Lat: MaxMercatorLat, Lon: -180}, 3, nil, vector2d.New(0, 0)},
+		{geodesy.Geodetic{Lat: -MaxMercatorLat, Lon: 180}, 2, []int{512}, vector2d.New(2048, 2048)},
+		{geodesy.Geodetic{Lat: 0, Lon: 90}, 1, nil, vector2d.New(384, 256)},
+	}
+	for _, tt := range tests {
+		got := m.Pixel(tt.g, tt.zoom, tt.tileSize...)
+		if diff := cmp.Diff(tt.want, got, getComparer(1e-3)); diff != "" {
+			t.Errorf("Pixel(%v) mismatch (-want +got):\n%s", tt.g, diff)
+		}
+		if diff := cmp.Diff(tt.g, m.FromPixel(got, tt.zoom, tt.tileSize...), floatComparer(1e-6)); diff != "" {
+			t.Errorf("FromPixel() mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if r := m.Resolution(0, 0); math.Abs(r-156543.03392804097) > 1e-6 {
+		t.Errorf("Resolution() = %v, want 156543.03392804097", r)
+	}
+	if r := m.Resolution(60, 10, 512); math.Abs(r-156543.03392804097/2/1024/2) > 1e-9 {
+		t.Errorf("Resolution() = %v", r)
+	}
+}
@@ -0,0 +1,53 @@
+// Package projection maps geodetic latitudes and longitudes to planar
+// coordinates and back with the Web Mercator, UTM and equirectangular
+// projections. Projections work in double precision with Forward and Inverse;
+// Project and Unproject give vector2d.Vector2D, whose float32 coordinates
+// round projected meters at the scale of the earth to about a meter.
+// https://en.wikipedia.org/wiki/Map_projection
+package projection
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/geodesy"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Map projection between latitude and longitude in degrees and planar x, y
+type Projection interface {
+	Forward(lat, lon float64) (x, y float64)
+	Inverse(x, y float64) (lat, lon float64)
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// Planar position of a geodetic position, the altitude is ignored
+func Project(p Projection, g geodesy.Geodetic) *vector2d.Vector2D {
+	x, y := p.Forward(g.Lat, g.Lon)
+	return vector2d.New(float32(x), float32(y))
+}
+
+// Geodetic position, on the ellipsoid, of a planar position
+func Unproject(p Projection, v *vector2d.Vector2D) geodesy.Geodetic {
+	lat, lon := p.Inverse(float64(v.X), float64(v.Y))
+	return geodesy.Geodetic{Lat: lat, Lon: lon}
+}
+
+// Planar positions of a slice of geodetic positions
+func ProjectAll(p Projection, gs []geodesy.Geodetic) []*vector2d.Vector2D {
+	vs := make([]*vector2d.Vector2D, len(gs))
+	for i, g := range gs {
+		vs[i] = Project(p, g)
+	}
+	return vs
+}
+
+// Geodetic positions of a slice of planar positions
+func UnprojectAll(p Projection, vs []*vector2d.Vector2D) []geodesy.Geodetic {
+	gs := make([]geodesy.Geodetic, len(vs))
+	for i, v := range vs {
+		gs[i] = Unproject(p, v)
+	}
+	return gs
+}
@@ -0,0 +1,72 @@
+package projection
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/geodesy"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool {
+			return a == b || a != nil && b != nil && a.Equal(b, tolerance)
+		}),
+		floatComparer(float64(tolerance)),
+	}
+}
+
+func floatComparer(tolerance float64) cmp.Option {
+	return cmp.Comparer(func(a, b float64) bool { return a == b || math.Abs(a-b) <= tolerance })
+}
+
+var _ = []Projection{WebMercator{}, UTM{}, Equirectangular{}}
+
+func TestProjectAll(t *testing.T) {
+	// on the unit sphere float32 keeps the precision
+	p := Equirectangular{Radius: 180 / math.Pi}
+	gs := []geodesy.Geodetic{{Lat: 0, Lon: 0}, {Lat: 10, Lon: -20}, {Lat: -45.5, Lon: 179}}
+	want := []*vector2d.Vector2D{vector2d.New(0, 0), vector2d.New(-20, 10), vector2d.New(179, -45.5)}
+	got := ProjectAll(p, gs)
+	if diff := cmp.Diff(want, got, getComparer(1e-5)); diff != "" {
+		t.Errorf("ProjectAll() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(gs, UnprojectAll(p, got), getComparer(1e-5)); diff != "" {
+		t.Errorf("UnprojectAll() mismatch (-want +got):\n%s", diff)
+	}
+	if got := ProjectAll(p, nil); len(got) != 0 {
+		t.Errorf("ProjectAll() of nothing = %v", got)
+	}
+}
+
+func TestProjectRoundTrip(t *testing.T) {
+	gs := []geodesy.Geodetic{{Lat: 48.8584, Lon: 2.2945}, {Lat: 48.86, Lon: 2.35}, {Lat: 48.7, Lon: 2.1}}
+	tests := []struct {
+		name string
+		p    Projection
+	}{
+		{"web mercator", WebMercator{}},
+		{"utm", NewUTM(gs[0])},
+		{"equirectangular", Equirectangular{StandardParallel: 48.8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := ProjectAll(tt.p, gs)
+			// float32 meters round to about a meter, some 1e-5 degrees
+			if diff := cmp.Diff(gs, UnprojectAll(tt.p, vs), getComparer(2e-5)); diff != "" {
+				t.Errorf("UnprojectAll(ProjectAll()) mismatch (-want +got):\n%s", diff)
+			}
+			// relative positions are close to the local distances
+			d := float64(vector2d.Sub(vs[1], vs[0]).Mag())
+			h := geodesy.Haversine(gs[0], gs[1])
+			if tt.name == "web mercator" {
+				d *= math.Cos(radians(48.86))
+			}
+			if math.Abs(d/h-1) > 5e-3 {
+				t.Errorf("projected distance %v, want about %v", d, h)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/geodesy"
+)
+
+const (
+	utmScale         = 0.9996
+	utmFalseEasting  = 500000
+	utmFalseNorthing = 10000000
+)
+
+// Coefficients of the Krüger series of the transverse Mercator projection on
+// the WGS84 ellipsoid, to the third order of the third flattening
+var utmSeries = func() (s struct {
+	a, e             float64
+	alpha, beta, del [3]float64
+}) {
+	f := geodesy.WGS84.F
+	n := f / (2 - f)
+	n2, n3 := n*n, n*n*n
+	s.a = geodesy.WGS84.A / (1 + n) * (1 + n2/4 + n2*n2/64)
+	s.e = 2 * math.Sqrt(n) / (1 + n)
+	s.alpha = [3]float64{n/2 - 2*n2/3 + 5*n3/16, 13*n2/48 - 3*n3/5, 61 * n3 / 240}
+	s.beta = [3]float64{n/2 - 2*n2/3 + 37*n3/96, n2/48 + n3/15, 17 * n3 / 480}
+	s.del = [3]float64{2*n - 2*n2/3 - 2*n3, 7*n2/3 - 8*n3/5, 56 * n3 / 15}
+	return s
+}()
+
+// Universal Transverse Mercator projection of a zone on the WGS84 ellipsoid,
+// giving eastings and northings in meters. It is accurate to about a
+// millimeter within the zone and its neighbours.
+// https://en.wikipedia.org/wiki/Universal_Transverse_Mercator_coordinate_system
+type UTM struct {
+	// Zone from 1 to 60, 6 degrees of longitude wide starting at 180 W
+	Zone int
+	// Southern hemisphere, with northings offset by 10000 km
+	South bool
+}
+
+// Projection of the zone holding a position
+func NewUTM(g geodesy.Geodetic) UTM {
+	zone, south := UTMZone(g)
+	return UTM{Zone: zone, South: south}
+}
+
+// Zone and hemisphere of a position, including the wider zones of southern
+// Norway and Svalbard
+func UTMZone(g geodesy.Geodetic) (zone int, south bool) {
+	lon := math.Remainder(g.Lon, 360)
+	zone = int(math.Floor((lon+180)/6)) + 1
+	if zone > 60 {
+		zone = 60
+	}
+	switch {
+	case g.Lat >= 56 && g.Lat < 64 && lon >= 3 && lon < 12:
+		zone = 32
+	case g.Lat >= 72 && g.Lat <= 84 && lon >= 0 && lon < 42:
+		// zones 31, 33, 35 and 37 are 12 degrees wide
+		zone = 31 + 2*int(math.Floor((lon+3)/12))
+	}
+	return zone, g.Lat < 0
+}
+
+// Latitude band letter from C at 80 S to X at 84 N, 0 outside of UTM
+func LatitudeBand(lat float64) byte {
+	if lat < -80 || lat > 84 {
+		return 0
+	}
+	const bands = "CDEFGHJKLMNPQRSTUVWX"
+	i := int(math.Floor((lat + 80) / 8))
+	if i > len(bands)-1 {
+		i = len(bands) - 1
+	}
+	return bands[i]
+}
+
+// Longitude in degrees of the central meridian of the zone
+func (u UTM) CentralMeridian() float64 {
+	return float64(u.Zone)*6 - 183
+}
+
+// Easting and northing of a position
+func (u UTM) Forward(lat, lon float64) (easting, northing float64) {
+	s := utmSeries
+	phi := radians(lat)
+	lambda := radians(math.Remainder(lon-u.CentralMeridian(), 360))
+	sinPhi := math.Sin(phi)
+	t := math.Sinh(math.Atanh(sinPhi) - s.e*math.Atanh(s.e*sinPhi))
+	xi := math.Atan2(t, math.Cos(lambda))
+	eta := math.Atanh(math.Sin(lambda) / math.Sqrt(1+t*t))
+	x, y := eta, xi
+	for j, a := range s.alpha {
+		k := 2 * float64(j+1)
+		x += a * math.Cos(k*xi) * math.Sinh(k*eta)
+		y += a * math.Sin(k*xi) * math.Cosh(k*eta)
+	}
+	easting = utmFalseEasting + utmScale*s.a*x
+	northing = utmScale * s.a * y
+	if u.South {
+		northing += utmFalseNorthing
+	}
+	return easting, northing
+}
+
+// Latitude and longitude of an easting and northing
+func (u UTM) Inverse(easting, northing float64) (lat, lon float64) {
+	s := utmSeries
+	if u.South {
+		northing -= utmFalseNorthing
+	}
+	xi := northing / (utmScale * s.a)
+	eta := (easting - utmFalseEasting) / (utmScale * s.a)
+	xi1, eta1 := xi, eta
+	for j, b := range s.beta {
+		k := 2 * float64(j+1)
+		xi1 -= b * math.Sin(k*xi) * math.Cosh(k*eta)
+		eta1 -= b * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+	chi := math.Asin(math.Sin(xi1) / math.Cosh(eta1))
+	phi := chi
+	for j, d := range s.del {
+		phi += d * math.Sin(2*float64(j+1)*chi)
+	}
+	lambda := math.Atan2(math.Sinh(eta1), math.Cos(xi1))
+	return degrees(phi), math.Remainder(u.CentralMeridian()+degrees(lambda), 360)
+}
@@ -0,0 +1,106 @@
+package projection
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/geodesy"
+)
+
+func TestUTMZone(t *testing.T) {
+	tests := []struct {
+		name  string
+		g     geodesy.Geodetic
+		zone  int
+		south bool
+		band  byte
+	}{
+		{"greenwich", geodesy.Geodetic{Lat: 51.48, Lon: 0}, 31, false, 'U'},
+		{"west edge", geodesy.Geodetic{Lat: 0, Lon: -180}, 1, false, 'N'},
+		{"east edge", geodesy.Geodetic{Lat: -0.1, Lon: 179.9}, 60, true, 'M'},
+		{"wrapped", geodesy.Geodetic{Lat: 10, Lon: 183}, 1, false, 'P'},
+		{"oslo", geodesy.Geodetic{Lat: 59.91, Lon: 10.75}, 32, false, 'V'},
+		{"bergen", geodesy.Geodetic{Lat: 60.39, Lon: 5.32}, 32, false, 'V'},
+		{"longyearbyen", geodesy.Geodetic{Lat: 78.22, Lon: 15.65}, 33, false, 'X'},
+		{"svalbard west", geodesy.Geodetic{Lat: 79, Lon: 8}, 31, false, 'X'},
+		{"svalbard east", geodesy.Geodetic{Lat: 80, Lon: 35}, 37, false, 'X'},
+		{"sydney", geodesy.Geodetic{Lat: -33.87, Lon: 151.21}, 56, true, 'H'},
+		{"far south", geodesy.Geodetic{Lat: -80, Lon: 0}, 31, true, 'C'},
+	}
+	for _, tt := range tests {
+		zone, south := UTMZone(tt.g)
+		if zone != tt.zone || south != tt.south {
+			t.Errorf("%s UTMZone() = %v, %v, want %v, %v", tt.name, zone, south, tt.zone, tt.south)
+		}
+		if b := LatitudeBand(tt.g.Lat); b != tt.band {
+			t.Errorf("%s LatitudeBand() = %c, want %c", tt.name, b, tt.band)
+		}
+	}
+	if b := LatitudeBand(84.5); b != 0 {
+		t.Errorf("LatitudeBand() beyond 84 N = %c, want none", b)
+	}
+	if u := NewUTM(geodesy.Geodetic{Lat: -33.87, Lon: 151.21}); u != (UTM{Zone: 56, South: true}) {
+		t.Errorf("NewUTM() = %+v", u)
+	}
+}
+
+func TestUTMMeridian(t *testing.T) {
+	// along the central meridian the northing is the scaled meridian arc
+	u := UTM{Zone: 33}
+	lon := u.CentralMeridian()
+	if lon != 15 {
+		t.Fatalf("CentralMeridian() = %v, want 15", lon)
+	}
+	for _, lat := range []float64{0, 10, 45, 70, 84} {
+		arc, _, _, _ := geodesy.Vincenty(geodesy.Geodetic{Lat: 0, Lon: lon}, geodesy.Geodetic{Lat: lat, Lon: lon})
+		e, n := u.Forward(lat, lon)
+		want := []float64{500000, 0.9996 * arc}
+		if diff := cmp.Diff(want, []float64{e, n}, floatComparer(1e-3)); diff != "" {
+			t.Errorf("Forward(%v) mismatch (-want +got):\n%s", lat, diff)
+		}
+	}
+	e, n := UTM{Zone: 33, South: true}.Forward(-45, lon)
+	if math.Abs(e-500000) > 1e-6 || math.Abs(n-(10000000-0.9996*4984944.378)) > 1e-2 {
+		t.Errorf("Forward() in the south = %v, %v", e, n)
+	}
+}
+
+func TestUTMKnown(t *testing.T) {
+	// CN Tower, Toronto, in zone 17T
+	e, n := UTM{Zone: 17}.Forward(43.642567, -79.387139)
+	if math.Abs(e-630084) > 1 || math.Abs(n-4833439) > 1 {
+		t.Errorf("Forward() = %v, %v, want 630084, 4833439", e, n)
+	}
+	// the scale at the central meridian is 0.9996 along the parallel
+	u := UTM{Zone: 31}
+	e1, _ := u.Forward(0, 3)
+	e2, _ := u.Forward(0, 3+1e-6)
+	if k := (e2 - e1) / (geodesy.WGS84.A * radians(1e-6)); math.Abs(k-0.9996) > 1e-6 {
+		t.Errorf("scale at the central meridian = %v, want 0.9996", k)
+	}
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		g := geodesy.Geodetic{Lat: r.Float64()*164 - 80, Lon: r.Float64()*360 - 180}
+		u := NewUTM(g)
+		e, n := u.Forward(g.Lat, g.Lon)
+		lat, lon := u.Inverse(e, n)
+		// 1e-8 degrees is about a millimeter
+		if math.Abs(lat-g.Lat) > 1e-8 || math.Abs(lon-g.Lon) > 1e-8 {
+			t.Fatalf("Inverse(Forward(%v)) = %v, %v in %+v", g, lat, lon, u)
+		}
+		if e < 100000 || e > 900000 || n < 0 || n > 10000000 {
+			t.Errorf("Forward(%v) = %v, %v out of the zone %+v", g, e, n, u)
+		}
+	}
+	// a neighbouring zone stays accurate
+	u := UTM{Zone: 30}
+	lat, lon := u.Inverse(u.Forward(48.85, 2.35))
+	if math.Abs(lat-48.85) > 1e-8 || math.Abs(lon-2.35) > 1e-8 {
+		t.Errorf("Inverse(Forward()) in the next zone = %v, %v", lat, lon)
+	}
+}
@@ -0,0 +1,179 @@
+package quat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Dual quaternion Real + εDual with ε² = 0. Unit dual quaternions represent
+// rigid transforms, a rotation followed by a translation.
+// https://en.wikipedia.org/wiki/Dual_quaternion
+type DualQuat struct {
+	Real, Dual Quat
+}
+
+// Makes the rigid transform rotating by the unit quaternion rotation, then
+// moving by translation
+func NewDualQuat(rotation Quat, translation *vector.Vector) DualQuat {
+	t := Quat{X: float64(translation.X), Y: float64(translation.Y), Z: float64(translation.Z)}
+	return DualQuat{Real: rotation, Dual: t.Mul(rotation).Scale(0.5)}
+}
+
+// Gives the identity transform
+func DualIdentity() DualQuat {
+	return DualQuat{Real: Identity()}
+}
+
+// Rotation of the unit dual quaternion
+func (d DualQuat) Rotation() Quat {
+	return d.Real
+}
+
+// Translation of the unit dual quaternion, 2 Dual Real*
+func (d DualQuat) Translation() *vector.Vector {
+	x, y, z := d.translation()
+	return vector.New(float32(x), float32(y), float32(z))
+}
+
+func (d DualQuat) translation() (x, y, z float64) {
+	t := d.Dual.Mul(d.Real.Conjugate())
+	return 2 * t.X, 2 * t.Y, 2 * t.Z
+}
+
+// String representation of the dual quaternion
+func (d DualQuat) String() string {
+	return fmt.Sprintf("DualQuat(%v, %v)", d.Real, d.Dual)
+}
+
+// Checks whether two dual quaternions are equal.
+// optional tolerence value can be passed as a parameter to check for equality within a tolerance.
+func (d DualQuat) Equal(d2 DualQuat, tolerance ...float64) bool {
+	return d.Real.Equal(d2.Real, tolerance...) && d.Dual.Equal(d2.Dual, tolerance...)
+}
+
+// Checks whether two unit dual quaternions are the same transform, d and -d are.
+// optional tolerence value can be passed as a parameter to check for equality within a tolerance.
+func (d DualQuat) SameTransform(d2 DualQuat, tolerance ...float64) bool {
+	return d.Equal(d2, tolerance...) || d.Equal(d2.Scale(-1), tolerance...)
+}
+
+// Sum of two dual quaternions
+func (d DualQuat) Add(d2 DualQuat) DualQuat {
+	return DualQuat{Real: d.Real.Add(d2.Real), Dual: d.Dual.Add(d2.Dual)}
+}
+
+// Multiplies every component by a scalar
+func (d DualQuat) Scale(s float64) DualQuat {
+	return DualQuat{Real: d.Real.Scale(s), Dual: d.Dual.Scale(s)}
+}
+
+// Product d*d2, the transform d2 followed by d
+func (d DualQuat) Mul(d2 DualQuat) DualQuat {
+	return DualQuat{Real: d.Real.Mul(d2.Real), Dual: d.Real.Mul(d2.Dual).Add(d.Dual.Mul(d2.Real))}
+}
+
+// Quaternion conjugate of both parts, the inverse transform for unit dual quaternions
+func (d DualQuat) Conjugate() DualQuat {
+	return DualQuat{Real: d.Real.Conjugate(), Dual: d.Dual.Conjugate()}
+}
+
+// Multiplicative inverse, ok is false when the real part is zero
+func (d DualQuat) Inverse() (inv DualQuat, ok bool) {
+	r, ok := d.Real.Inverse()
+	if !ok {
+		return DualQuat{}, false
+	}
+	return DualQuat{Real: r, Dual: r.Mul(d.Dual).Mul(r).Neg()}, true
+}
+
+// Dual quaternion scaled to a unit real part with the dual part orthogonal to
+// it, the identity if the real part is zero
+func (d DualQuat) Normalize() DualQuat {
+	n := d.Real.Norm()
+	if n == 0 {
+		return DualIdentity()
+	}
+	r, du := d.Real.Scale(1/n), d.Dual.Scale(1/n)
+	return DualQuat{Real: r, Dual: du.Add(r.Scale(-r.Dot(du)))}
+}
+
+// Transforms a point by the unit dual quaternion, giving a new vector
+func (d DualQuat) TransformPoint(v *vector.Vector) *vector.Vector {
+	x, y, z := d.Real.apply(float64(v.X), float64(v.Y), float64(v.Z))
+	tx, ty, tz := d.translation()
+	return vector.New(float32(x+tx), float32(y+ty), float32(z+tz))
+}
+
+// Rotates a direction by the unit dual quaternion, ignoring the translation
+func (d DualQuat) TransformDirection(v *vector.Vector) *vector.Vector {
+	return d.Real.Rotate(v)
+}
+
+// Raises the unit dual quaternion to the power t, the screw motion by t times
+// its angle and translation. The rotation takes the shorter arc.
+func (d DualQuat) Pow(t float64) DualQuat {
+	if d.Real.W < 0 {
+		d = d.Scale(-1)
+	}
+	r, du := d.Real, d.Dual
+	sinHalf := math.Sqrt(r.X*r.X + r.Y*r.Y + r.Z*r.Z)
+	if sinHalf < 1e-9 {
+		// pure translation, the screw axis is undefined
+		x, y, z := d.translation()
+		rot := Nlerp(Identity(), r, t)
+		return DualQuat{Real: rot, Dual: Quat{X: t * x, Y: t * y, Z: t * z}.Mul(rot).Scale(0.5)}
+	}
+	// screw parameters: half angle, unit axis l, slide s along it and moment m
+	half := math.Atan2(sinHalf, r.W)
+	lx, ly, lz := r.X/sinHalf, r.Y/sinHalf, r.Z/sinHalf
+	s := -2 * du.W / sinHalf
+	k := s / 2 * r.W
+	mx, my, mz := (du.X-lx*k)/sinHalf, (du.Y-ly*k)/sinHalf, (du.Z-lz*k)/sinHalf
+
+	half, s = t*half, t*s
+	sn, cs := math.Sincos(half)
+	k = s / 2 * cs
+	return DualQuat{
+		Real: Quat{W: cs, X: lx * sn, Y: ly * sn, Z: lz * sn},
+		Dual: Quat{W: -s / 2 * sn, X: mx*sn + lx*k, Y: my*sn + ly*k, Z: mz*sn + lz*k},
+	}
+}
+
+// Screw linear interpolation between unit dual quaternions, moving at
+// constant speed along the screw motion taking a to b. t = 0 gives a and 1 gives b.
+func ScLerp(a, b DualQuat, t float64) DualQuat {
+	if a.Real.Dot(b.Real) < 0 {
+		b = b.Scale(-1)
+	}
+	return a.Mul(a.Conjugate().Mul(b).Pow(t))
+}
+
+// A bone transform acting on a skinned vertex, with its weight
+type Influence struct {
+	Bone   int
+	Weight float64
+}
+
+// Dual quaternion linear blending of the bone transforms with the weights of
+// the influences. Unlike blending matrices, the result stays a rigid transform
+// so twisting joints keep their volume. Bones are flipped to the hemisphere of
+// the first influence so that d and -d blend alike. No weight gives the identity.
+// See Kavan et al., Skinning with Dual Quaternions, 2007.
+func Blend(bones []DualQuat, influences []Influence) DualQuat {
+	var sum DualQuat
+	var pivot Quat
+	for i, in := range influences {
+		b := bones[in.Bone]
+		if i == 0 {
+			pivot = b.Real
+		}
+		w := in.Weight
+		if b.Real.Dot(pivot) < 0 {
+			w = -w
+		}
+		sum = sum.Add(b.Scale(w))
+	}
+	return sum.Normalize()
+}
@@ -0,0 +1,141 @@
+package quat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func randomDualQuat(r *rand.Rand) (DualQuat, Quat, *vector.Vector) {
+	q := randomQuat(r)
+	t := vector.New(r.Float32()*4-2, r.Float32()*4-2, r.Float32()*4-2)
+	return NewDualQuat(q, t), q, t
+}
+
+func TestDualQuatTransform(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	opt := getComparer(1e-5)
+	for i := 0; i < 100; i++ {
+		d, q, tr := randomDualQuat(r)
+		v := vector.New(r.Float32()*2-1, r.Float32()*2-1, r.Float32()*2-1)
+		if got, want := d.TransformPoint(v), vector.Add(q.Rotate(v), tr); !cmp.Equal(got, want, opt) {
+			t.Fatalf("%v.TransformPoint(%v) = %v, want %v", d, v, got, want)
+		}
+		if got, want := d.TransformDirection(v), q.Rotate(v); !cmp.Equal(got, want, opt) {
+			t.Fatalf("%v.TransformDirection(%v) = %v, want %v", d, v, got, want)
+		}
+		if !d.Rotation().Equal(q) || !cmp.Equal(d.Translation(), tr, opt) {
+			t.Fatalf("%v.Rotation(), Translation() = %v, %v, want %v, %v", d, d.Rotation(), d.Translation(), q, tr)
+		}
+		// d2 then d
+		d2, _, _ := randomDualQuat(r)
+		if got, want := d.Mul(d2).TransformPoint(v), d.TransformPoint(d2.TransformPoint(v)); !cmp.Equal(got, want, opt) {
+			t.Fatalf("Mul().TransformPoint(%v) = %v, want %v", v, got, want)
+		}
+		inv, ok := d.Inverse()
+		if !ok || !inv.Equal(d.Conjugate(), 1e-12) {
+			t.Fatalf("%v.Inverse() = %v, %v, want %v", d, inv, ok, d.Conjugate())
+		}
+		if got := inv.TransformPoint(d.TransformPoint(v)); !cmp.Equal(got, v, opt) {
+			t.Fatalf("inverse transform of %v = %v", v, got)
+		}
+		if got := d.Mul(inv); !got.Equal(DualIdentity(), 1e-12) {
+			t.Fatalf("d * d^-1 = %v", got)
+		}
+	}
+	if _, ok := (DualQuat{}).Inverse(); ok {
+		t.Error("zero dual quaternion has an inverse")
+	}
+}
+
+func TestDualQuatNormalize(t *testing.T) {
+	d := NewDualQuat(FromAxisAngle(vector.New(1, 2, 3), 0.7), vector.New(1, -2, 0.5))
+	// scaled and with a dual part off the real one
+	skewed := d.Scale(3).Add(DualQuat{Dual: d.Real.Scale(0.1)})
+	if got := skewed.Normalize(); !got.Equal(d, 1e-12) {
+		t.Errorf("Normalize() = %v, want %v", got, d)
+	}
+	if got := (DualQuat{}).Normalize(); got != DualIdentity() {
+		t.Errorf("Normalize() of zero = %v", got)
+	}
+}
+
+func TestScLerp(t *testing.T) {
+	opt := getComparer(1e-5)
+	// a screw around the vertical axis through (1, 0, 0), turning by 2 and
+	// climbing by 3
+	axis := vector.New(0, 0, 1)
+	pivot := vector.New(1, 0, 0)
+	screw := func(angle, climb float64) DualQuat {
+		q := FromAxisAngle(axis, angle)
+		tr := vector.Sub(pivot, q.Rotate(pivot)).Add(vector.New(0, 0, float32(climb)))
+		return NewDualQuat(q, tr)
+	}
+	a := DualIdentity()
+	b := screw(2, 3)
+	p := vector.New(3, 0, 0)
+	for _, tt := range []float64{0, 0.25, 0.5, 1} {
+		got := ScLerp(a, b, tt)
+		// the translations of screw are float32
+		if want := screw(2*tt, 3*tt); !got.SameTransform(want, 1e-6) {
+			t.Errorf("ScLerp(%v) = %v, want %v", tt, got, want)
+		}
+		// the point moves on a helix, 2 away from the axis
+		want := vector.New(1+2*float32(math.Cos(2*tt)), 2*float32(math.Sin(2*tt)), 3*float32(tt))
+		if q := got.TransformPoint(p); !cmp.Equal(q, want, opt) {
+			t.Errorf("ScLerp(%v).TransformPoint(%v) = %v, want %v", tt, p, q, want)
+		}
+	}
+	// from a non identity start, and with b negated
+	start := NewDualQuat(FromAxisAngle(vector.New(1, 0, 0), 0.4), vector.New(0, 1, 2))
+	end := b.Mul(start)
+	if got, want := ScLerp(start, end.Scale(-1), 0.5), screw(1, 1.5).Mul(start); !got.SameTransform(want, 1e-6) {
+		t.Errorf("ScLerp(start, -end) = %v, want %v", got, want)
+	}
+	// pure translations interpolate linearly
+	c := NewDualQuat(Identity(), vector.New(2, 4, -6))
+	if got, want := ScLerp(a, c, 0.25), NewDualQuat(Identity(), vector.New(0.5, 1, -1.5)); !got.Equal(want, 1e-12) {
+		t.Errorf("ScLerp() of a translation = %v, want %v", got, want)
+	}
+	if got := b.Pow(1); !got.Equal(b, 1e-12) {
+		t.Errorf("Pow(1) = %v, want %v", got, b)
+	}
+	if got := b.Pow(0); !got.Equal(DualIdentity(), 1e-12) {
+		t.Errorf("Pow(0) = %v", got)
+	}
+}
+
+func TestBlend(t *testing.T) {
+	opt := getComparer(1e-5)
+	x := vector.New(1, 0, 0)
+	bones := []DualQuat{
+		DualIdentity(),
+		NewDualQuat(FromAxisAngle(x, math.Pi), vector.New(0, 0, 0)),
+		NewDualQuat(FromAxisAngle(x, 1), vector.New(0, 2, 0)),
+	}
+	// twisting by half a turn: the vertex turns a quarter turn and keeps its
+	// distance to the bone, where blending matrices collapses it on the axis
+	v := vector.New(0.5, 1, 0)
+	got := Blend(bones, []Influence{{0, 0.5}, {1, 0.5}}).TransformPoint(v)
+	if want := vector.New(0.5, 0, 1); !cmp.Equal(got, want, opt) {
+		t.Errorf("Blend() of a twist moved %v to %v, want %v", v, got, want)
+	}
+	// a single bone, and the sign of a bone
+	if got := Blend(bones, []Influence{{2, 0.3}}); !got.Equal(bones[2], 1e-12) {
+		t.Errorf("Blend() of one bone = %v, want %v", got, bones[2])
+	}
+	flipped := []DualQuat{bones[2], bones[2].Scale(-1)}
+	if got := Blend(flipped, []Influence{{0, 0.5}, {1, 0.5}}); !got.Equal(bones[2], 1e-12) {
+		t.Errorf("Blend() of d and -d = %v, want %v", got, bones[2])
+	}
+	if got := Blend(bones, nil); got != DualIdentity() {
+		t.Errorf("Blend() without influences = %v", got)
+	}
+	// blending the same screw matches ScLerp
+	if got, want := Blend(bones, []Influence{{0, 0.5}, {2, 0.5}}), ScLerp(bones[0], bones[2], 0.5); !got.Rotation().Equal(want.Rotation(), 1e-12) {
+		t.Errorf("Blend() rotation = %v, want %v", got.Rotation(), want.Rotation())
+	}
+}
@@ -0,0 +1,24 @@
+package quat
+
+import "github.com/vaibhav11s/gopkgs/mat"
+
+// Makes the rotation of Euler angles (radians) in the given order, turning
+// by a around the first axis, then b and c around the turned second and
+// third axes. See mat.EulerOrder.
+func FromEuler(order mat.EulerOrder, a, b, c float64) Quat {
+	i, j, k := order.Axes()
+	return axisQuat(i, a).Mul(axisQuat(j, b)).Mul(axisQuat(k, c))
+}
+
+// Rotation around a coordinate axis, 0 for X to 2 for Z
+func axisQuat(axis int, angle float64) Quat {
+	var v [3]float64
+	v[axis] = 1
+	return fromAxisAngle(v[0], v[1], v[2], angle)
+}
+
+// Gives the Euler angles (radians) of the rotation in the given order. At
+// gimbal lock the first angle is 0, see mat.Mat3.Euler.
+func (q Quat) Euler(order mat.EulerOrder) (a, b, c float64) {
+	return q.Normalize().Mat3().Euler(order)
+}
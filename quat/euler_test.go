@@ -0,0 +1,87 @@
+package quat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+var eulerOrders = []mat.EulerOrder{
+	mat.XYZ, mat.XZY, mat.YXZ, mat.YZX, mat.ZXY, mat.ZYX,
+	mat.XYX, mat.XZX, mat.YXY, mat.YZY, mat.ZXZ, mat.ZYZ,
+}
+
+func TestFromEuler(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	opt := getComparer(1e-5)
+	basis := [3]*vector.Vector{vector.New(1, 0, 0), vector.New(0, 1, 0), vector.New(0, 0, 1)}
+	for _, order := range eulerOrders {
+		i, j, k := order.Axes()
+		for n := 0; n < 50; n++ {
+			a, b, c := (r.Float64()*2-1)*math.Pi, (r.Float64()*2-1)*math.Pi, (r.Float64()*2-1)*math.Pi
+			v := vector.New(r.Float32()*2-1, r.Float32()*2-1, r.Float32()*2-1)
+			q := FromEuler(order, a, b, c)
+			want := vector.RotateAlongAxis(v, basis[k], float32(c))
+			want = vector.RotateAlongAxis(want, basis[j], float32(b))
+			want = vector.RotateAlongAxis(want, basis[i], float32(a))
+			if got := q.Rotate(v); !cmp.Equal(got, want, opt) {
+				t.Fatalf("FromEuler(%v, %v, %v, %v).Rotate(%v) = %v, want %v", order, a, b, c, v, got, want)
+			}
+			if got, want := q.Mat3(), mat.RotationEuler(order, a, b, c); !got.Equal(want, 1e-12) {
+				t.Fatalf("FromEuler(%v, %v, %v, %v).Mat3() = %v, want %v", order, a, b, c, got, want)
+			}
+		}
+	}
+}
+
+func TestEulerRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	qs := []Quat{Identity(), New(0, 1, 0, 0), New(0, 0, 1, 0), New(0, 0, 0, 1), FromAxisAngle(vector.New(1, 1, 0), math.Pi)}
+	for i := 0; i < 100; i++ {
+		qs = append(qs, randomQuat(r))
+	}
+	for _, q := range qs {
+		for _, order := range eulerOrders {
+			a, b, c := q.Euler(order)
+			if got := FromEuler(order, a, b, c); !got.SameRotation(q, 1e-12) {
+				t.Fatalf("FromEuler(%v, %v.Euler()) = %v, want %v", order, q, got, q)
+			}
+			ma, mb, mc := q.Mat3().Euler(order)
+			if math.Abs(ma-a) > 1e-12 || math.Abs(mb-b) > 1e-12 || math.Abs(mc-c) > 1e-12 {
+				t.Fatalf("%v.Euler(%v) = %v, %v, %v, Mat3().Euler() = %v, %v, %v", q, order, a, b, c, ma, mb, mc)
+			}
+		}
+	}
+}
+
+func TestConversions(t *testing.T) {
+	// Euler angles -> quaternion -> matrix -> axis-angle -> rotation vector
+	// -> matrix -> quaternion -> Euler angles
+	// the float32 vectors lose too much to get a and c back close to gimbal lock
+	r := rand.New(rand.NewSource(7))
+	opt := getComparer(1e-5)
+	for _, order := range eulerOrders {
+		for n := 0; n < 20; n++ {
+			a, b, c := (r.Float64()*2-1)*math.Pi, 0.2+r.Float64(), (r.Float64()*2-1)*math.Pi
+			q := FromEuler(order, a, b, c)
+			axis, angle := q.Mat3().AxisAngle()
+			rv := axis.Copy().Mult(float32(angle))
+			v := vector.New(r.Float32()*2-1, r.Float32()*2-1, r.Float32()*2-1)
+			if got, want := vector.RotateAlongAxis(v, axis, float32(angle)), q.Rotate(v); !cmp.Equal(got, want, opt) {
+				t.Fatalf("RotateAlongAxis(AxisAngle()) = %v, want %v", got, want)
+			}
+			back := FromMat3(mat.RotationVector(rv))
+			if !back.SameRotation(q, 1e-6) {
+				t.Fatalf("conversions of %v gave %v", q, back)
+			}
+			ga, gb, gc := back.Euler(order)
+			if math.Abs(ga-a) > 1e-5 || math.Abs(gb-b) > 1e-5 || math.Abs(gc-c) > 1e-5 {
+				t.Fatalf("Euler(%v) = %v, %v, %v, want %v, %v, %v", order, ga, gb, gc, a, b, c)
+			}
+		}
+	}
+}
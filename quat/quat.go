@@ -0,0 +1,236 @@
+// Package quat provides quaternions for representing 3D rotations of
+// vector.Vector, and dual quaternions for rigid transforms and skinning.
+// https://en.wikipedia.org/wiki/Quaternions_and_spatial_rotation
+package quat
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Quaternion W + Xi + Yj + Zk. Unit quaternions represent rotations.
+type Quat struct {
+	W, X, Y, Z float64
+}
+
+// Makes a quaternion from its components
+func New(w, x, y, z float64) Quat {
+	return Quat{W: w, X: x, Y: y, Z: z}
+}
+
+// Gives the identity rotation
+func Identity() Quat {
+	return Quat{W: 1}
+}
+
+// Makes the rotation by angle (radians) around axis, following the right
+// hand rule like vector.RotateAlongAxis. A zero axis gives the identity.
+func FromAxisAngle(axis *vector.Vector, angle float64) Quat {
+	return fromAxisAngle(float64(axis.X), float64(axis.Y), float64(axis.Z), angle)
+}
+
+func fromAxisAngle(x, y, z, angle float64) Quat {
+	m := math.Sqrt(x*x + y*y + z*z)
+	if m == 0 {
+		return Identity()
+	}
+	s, c := math.Sincos(angle / 2)
+	s /= m
+	return Quat{W: c, X: x * s, Y: y * s, Z: z * s}
+}
+
+// Makes the rotation turning by |v| radians around v (exponential map)
+func FromRotationVector(v *vector.Vector) Quat {
+	x, y, z := float64(v.X), float64(v.Y), float64(v.Z)
+	return fromAxisAngle(x, y, z, math.Sqrt(x*x+y*y+z*z))
+}
+
+// Makes the quaternion of a rotation matrix
+// https://en.wikipedia.org/wiki/Rotation_matrix#Quaternion
+func FromMat3(m mat.Mat3) Quat {
+	// Shepperd's method: divide by the largest of the four candidates
+	tr := m.Trace()
+	var q Quat
+	switch {
+	case tr > m[0][0] && tr > m[1][1] && tr > m[2][2]:
+		s := 2 * math.Sqrt(1+tr)
+		q = Quat{W: s / 4, X: (m[2][1] - m[1][2]) / s, Y: (m[0][2] - m[2][0]) / s, Z: (m[1][0] - m[0][1]) / s}
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[0][0]-m[1][1]-m[2][2])
+		q = Quat{W: (m[2][1] - m[1][2]) / s, X: s / 4, Y: (m[0][1] + m[1][0]) / s, Z: (m[0][2] + m[2][0]) / s}
+	case m[1][1] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[1][1]-m[0][0]-m[2][2])
+		q = Quat{W: (m[0][2] - m[2][0]) / s, X: (m[0][1] + m[1][0]) / s, Y: s / 4, Z: (m[1][2] + m[2][1]) / s}
+	default:
+		s := 2 * math.Sqrt(1+m[2][2]-m[0][0]-m[1][1])
+		q = Quat{W: (m[1][0] - m[0][1]) / s, X: (m[0][2] + m[2][0]) / s, Y: (m[1][2] + m[2][1]) / s, Z: s / 4}
+	}
+	if q.W < 0 {
+		q = q.Neg()
+	}
+	return q.Normalize()
+}
+
+// String representation of the quaternion
+func (q Quat) String() string {
+	return fmt.Sprintf("Quat{W: %v, X: %v, Y: %v, Z: %v}", q.W, q.X, q.Y, q.Z)
+}
+
+// Checks whether two quaternions are equal.
+// optional tolerence value can be passed as a parameter to check for equality within a tolerance.
+func (q Quat) Equal(q2 Quat, tolerance ...float64) bool {
+	t := 1e-15
+	if len(tolerance) >= 1 {
+		t += tolerance[0]
+	}
+	return math.Abs(q.W-q2.W) <= t && math.Abs(q.X-q2.X) <= t &&
+		math.Abs(q.Y-q2.Y) <= t && math.Abs(q.Z-q2.Z) <= t
+}
+
+// Checks whether two unit quaternions are the same rotation, q and -q are.
+// optional tolerence value can be passed as a parameter to check for equality within a tolerance.
+func (q Quat) SameRotation(q2 Quat, tolerance ...float64) bool {
+	return q.Equal(q2, tolerance...) || q.Equal(q2.Neg(), tolerance...)
+}
+
+// Sum of two quaternions
+func (q Quat) Add(q2 Quat) Quat {
+	return Quat{W: q.W + q2.W, X: q.X + q2.X, Y: q.Y + q2.Y, Z: q.Z + q2.Z}
+}
+
+// Multiplies every component by a scalar
+func (q Quat) Scale(s float64) Quat {
+	return Quat{W: q.W * s, X: q.X * s, Y: q.Y * s, Z: q.Z * s}
+}
+
+// Negated quaternion, the same rotation
+func (q Quat) Neg() Quat {
+	return q.Scale(-1)
+}
+
+// Hamilton product q*q2, the rotation q2 followed by q
+func (q Quat) Mul(q2 Quat) Quat {
+	return Quat{
+		W: q.W*q2.W - q.X*q2.X - q.Y*q2.Y - q.Z*q2.Z,
+		X: q.W*q2.X + q.X*q2.W + q.Y*q2.Z - q.Z*q2.Y,
+		Y: q.W*q2.Y - q.X*q2.Z + q.Y*q2.W + q.Z*q2.X,
+		Z: q.W*q2.Z + q.X*q2.Y - q.Y*q2.X + q.Z*q2.W,
+	}
+}
+
+// Dot product of the components
+func (q Quat) Dot(q2 Quat) float64 {
+	return q.W*q2.W + q.X*q2.X + q.Y*q2.Y + q.Z*q2.Z
+}
+
+// Length of the quaternion
+func (q Quat) Norm() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Quaternion scaled to unit length, the identity if q is zero
+func (q Quat) Normalize() Quat {
+	n := q.Norm()
+	if n == 0 {
+		return Identity()
+	}
+	return q.Scale(1 / n)
+}
+
+// Conjugate, the inverse rotation for unit quaternions
+func (q Quat) Conjugate() Quat {
+	return Quat{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
+
+// Multiplicative inverse, ok is false for the zero quaternion
+func (q Quat) Inverse() (inv Quat, ok bool) {
+	n := q.Dot(q)
+	if n == 0 {
+		return Quat{}, false
+	}
+	return q.Conjugate().Scale(1 / n), true
+}
+
+// Rotates a vector by the unit quaternion, giving a new vector
+func (q Quat) Rotate(v *vector.Vector) *vector.Vector {
+	x, y, z := q.apply(float64(v.X), float64(v.Y), float64(v.Z))
+	return vector.New(float32(x), float32(y), float32(z))
+}
+
+// v + 2w(u x v) + 2u x (u x v) with u the vector part
+func (q Quat) apply(x, y, z float64) (float64, float64, float64) {
+	tx := 2 * (q.Y*z - q.Z*y)
+	ty := 2 * (q.Z*x - q.X*z)
+	tz := 2 * (q.X*y - q.Y*x)
+	return x + q.W*tx + q.Y*tz - q.Z*ty,
+		y + q.W*ty + q.Z*tx - q.X*tz,
+		z + q.W*tz + q.X*ty - q.Y*tx
+}
+
+// Rotation matrix of the unit quaternion
+func (q Quat) Mat3() mat.Mat3 {
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+	return mat.Mat3{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
+
+// Gives the unit axis and the angle in [0, π] of the unit quaternion.
+// The identity gives the x axis and a zero angle.
+func (q Quat) AxisAngle() (axis *vector.Vector, angle float64) {
+	if q.W < 0 {
+		q = q.Neg()
+	}
+	s := math.Sqrt(q.X*q.X + q.Y*q.Y + q.Z*q.Z)
+	if s == 0 {
+		return vector.New(1, 0, 0), 0
+	}
+	return vector.New(float32(q.X/s), float32(q.Y/s), float32(q.Z/s)), 2 * math.Atan2(s, q.W)
+}
+
+// Rotation vector (axis scaled by angle) of the unit quaternion, the inverse of FromRotationVector
+func (q Quat) RotationVector() *vector.Vector {
+	axis, angle := q.AxisAngle()
+	return axis.Mult(float32(angle))
+}
+
+// Turns the orientation q by the angular velocity omega (world space, radians
+// per second) over dt, with the exact exponential map
+func (q Quat) Integrate(omega *vector.Vector, dt float64) Quat {
+	x, y, z := float64(omega.X), float64(omega.Y), float64(omega.Z)
+	w := math.Sqrt(x*x + y*y + z*z)
+	return fromAxisAngle(x, y, z, w*dt).Mul(q).Normalize()
+}
+
+// Spherical linear interpolation between unit quaternions along the shorter arc,
+// t = 0 gives a and 1 gives b
+// https://en.wikipedia.org/wiki/Slerp
+func Slerp(a, b Quat, t float64) Quat {
+	d := a.Dot(b)
+	if d < 0 {
+		b, d = b.Neg(), -d
+	}
+	if d > 0.9995 {
+		// nearly parallel, lerp avoids dividing by sin(theta) ~ 0
+		return Nlerp(a, b, t)
+	}
+	theta := math.Acos(d)
+	s := math.Sin(theta)
+	wa := math.Sin((1-t)*theta) / s
+	wb := math.Sin(t*theta) / s
+	return a.Scale(wa).Add(b.Scale(wb))
+}
+
+// Normalized linear interpolation between unit quaternions along the shorter arc.
+// Faster than Slerp but not constant speed.
+func Nlerp(a, b Quat, t float64) Quat {
+	if a.Dot(b) < 0 {
+		b = b.Neg()
+	}
+	return a.Scale(1 - t).Add(b.Scale(t)).Normalize()
+}
@@ -0,0 +1,141 @@
+package quat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) })
+}
+
+func randomQuat(r *rand.Rand) Quat {
+	return New(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()).Normalize()
+}
+
+func TestRotate(t *testing.T) {
+	opt := getComparer(1e-5)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		axis := vector.New(r.Float32()*2-1, r.Float32()*2-1, r.Float32()*2-1)
+		angle := r.Float32()*4 - 2
+		v := vector.New(r.Float32()*2-1, r.Float32()*2-1, r.Float32()*2-1)
+		q := FromAxisAngle(axis, float64(angle))
+		want := vector.RotateAlongAxis(v, axis, angle)
+		if got := q.Rotate(v); !cmp.Equal(got, want, opt) {
+			t.Errorf("Rotate(%v) = %v, want %v", v, got, want)
+		}
+		if got := q.Mat3().MulVec(v); !cmp.Equal(got, want, opt) {
+			t.Errorf("Mat3().MulVec(%v) = %v, want %v", v, got, want)
+		}
+		if got := mat.RotationAxisAngle(axis, float64(angle)); !got.Equal(q.Mat3(), 1e-6) {
+			t.Errorf("Mat3() = %v, want %v", q.Mat3(), got)
+		}
+	}
+	if got := FromAxisAngle(vector.New(0, 0, 0), 1); got != Identity() {
+		t.Errorf("FromAxisAngle(zero axis) = %v", got)
+	}
+}
+
+func TestMul(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	v := vector.New(1, 2, 3)
+	for i := 0; i < 50; i++ {
+		a, b := randomQuat(r), randomQuat(r)
+		want := a.Rotate(b.Rotate(v))
+		if got := a.Mul(b).Rotate(v); !cmp.Equal(got, want, getComparer(1e-5)) {
+			t.Errorf("%v.Mul(%v).Rotate() = %v, want %v", a, b, got, want)
+		}
+		inv, ok := a.Inverse()
+		if !ok || !a.Mul(inv).Equal(Identity(), 1e-12) || !inv.Equal(a.Conjugate(), 1e-12) {
+			t.Errorf("%v.Inverse() = %v", a, inv)
+		}
+	}
+	if _, ok := (Quat{}).Inverse(); ok {
+		t.Error("zero quaternion has an inverse")
+	}
+	// ij = k
+	if got := New(0, 1, 0, 0).Mul(New(0, 0, 1, 0)); got != New(0, 0, 0, 1) {
+		t.Errorf("i*j = %v, want k", got)
+	}
+	// 1e-15 like vector64, plus the optional tolerance
+	q := New(0.5, 0.5, 0.5, 0.5)
+	if !q.Equal(New(0.5, 0.5, 0.5, 0.5+5e-16)) || q.Equal(New(0.5, 0.5, 0.5, 0.501)) || !q.Equal(New(0.5, 0.5, 0.5, 0.501), 0.01) {
+		t.Errorf("Equal() tolerances")
+	}
+}
+
+func TestFromMat3(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	qs := []Quat{Identity(), New(0, 1, 0, 0), New(0, 0, 1, 0), New(0, 0, 0, 1), FromAxisAngle(vector.New(1, 1, 0), math.Pi)}
+	for i := 0; i < 100; i++ {
+		qs = append(qs, randomQuat(r))
+	}
+	for _, q := range qs {
+		if got := FromMat3(q.Mat3()); !got.SameRotation(q, 1e-9) {
+			t.Errorf("FromMat3(%v.Mat3()) = %v", q, got)
+		}
+	}
+}
+
+func TestAxisAngle(t *testing.T) {
+	tests := []struct {
+		q     Quat
+		axis  *vector.Vector
+		angle float64
+	}{
+		{Identity(), vector.New(1, 0, 0), 0},
+		{FromAxisAngle(vector.New(0, 0, 2), 1), vector.New(0, 0, 1), 1},
+		{FromAxisAngle(vector.New(0, 1, 0), -1), vector.New(0, -1, 0), 1},
+		{FromAxisAngle(vector.New(1, 0, 0), 3*math.Pi/2), vector.New(-1, 0, 0), math.Pi / 2},
+	}
+	for _, tt := range tests {
+		axis, angle := tt.q.AxisAngle()
+		if !cmp.Equal(axis, tt.axis, getComparer(1e-6)) || math.Abs(angle-tt.angle) > 1e-9 {
+			t.Errorf("%v.AxisAngle() = %v, %v, want %v, %v", tt.q, axis, angle, tt.axis, tt.angle)
+		}
+	}
+	v := vector.New(0.3, -0.2, 0.5)
+	if got := FromRotationVector(v).RotationVector(); !cmp.Equal(got, v, getComparer(1e-6)) {
+		t.Errorf("RotationVector() = %v, want %v", got, v)
+	}
+}
+
+func TestIntegrate(t *testing.T) {
+	// a quarter turn around z in 100 steps
+	omega := vector.New(0, 0, math.Pi/2)
+	q := Identity()
+	for i := 0; i < 100; i++ {
+		q = q.Integrate(omega, 0.01)
+	}
+	if want := FromAxisAngle(vector.New(0, 0, 1), math.Pi/2); !q.Equal(want, 1e-7) {
+		t.Errorf("Integrate() = %v, want %v", q, want)
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	axis := vector.New(0, 1, 0)
+	a := FromAxisAngle(axis, 0.2)
+	b := FromAxisAngle(axis, 1.4)
+	for _, tt := range []float64{0, 0.25, 0.5, 1} {
+		want := FromAxisAngle(axis, 0.2+1.2*tt)
+		if got := Slerp(a, b, tt); !got.Equal(want, 1e-12) {
+			t.Errorf("Slerp(%v) = %v, want %v", tt, got, want)
+		}
+	}
+	// takes the shorter arc when b is negated
+	if got, want := Slerp(a, b.Neg(), 0.5), FromAxisAngle(axis, 0.8); !got.SameRotation(want, 1e-12) {
+		t.Errorf("Slerp(-b) = %v, want %v", got, want)
+	}
+	if got := Slerp(a, a, 0.3); !got.Equal(a, 1e-12) {
+		t.Errorf("Slerp(a, a) = %v, want %v", got, a)
+	}
+	if got, want := Nlerp(a, b, 0.5), FromAxisAngle(axis, 0.8); !got.Equal(want, 1e-12) {
+		t.Errorf("Nlerp(0.5) = %v, want %v", got, want)
+	}
+}
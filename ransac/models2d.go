@@ -0,0 +1,103 @@
+package ransac
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/stats"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Infinite 2D line through Point along the unit vector Direction
+type Line2D struct {
+	Point, Direction *vector2d.Vector2D
+}
+
+// Estimator of 2D lines
+type Line2DModel struct{}
+
+// Robustly fits a 2D line to the points, see Fit
+func FitLine2D(points []*vector2d.Vector2D, cfg Config) (Result[Line2D], error) {
+	return Fit[*vector2d.Vector2D, Line2D](points, Line2DModel{}, cfg)
+}
+
+// A line needs 2 points
+func (Line2DModel) MinSamples() int { return 2 }
+
+// Line through two distinct points, or the total least squares line through more
+func (Line2DModel) Fit(ps []*vector2d.Vector2D) (Line2D, bool) {
+	if len(ps) == 2 {
+		d := vector2d.Sub(ps[1], ps[0])
+		if d.Mag() == 0 {
+			return Line2D{}, false
+		}
+		return Line2D{Point: ps[0].Copy(), Direction: d.Normalize()}, true
+	}
+	point, dir, err := stats.FitLine2D(ps)
+	if err != nil {
+		return Line2D{}, false
+	}
+	return Line2D{Point: point, Direction: dir}, true
+}
+
+// Perpendicular distance from p to the line
+func (Line2DModel) Distance(l Line2D, p *vector2d.Vector2D) float32 {
+	return float32(math.Abs(float64(vector2d.Cross(vector2d.Sub(p, l.Point), l.Direction))))
+}
+
+// 2D circle
+type Circle struct {
+	Center *vector2d.Vector2D
+	Radius float32
+}
+
+// Estimator of 2D circles
+type CircleModel struct{}
+
+// Robustly fits a circle to the points, see Fit
+func FitCircle(points []*vector2d.Vector2D, cfg Config) (Result[Circle], error) {
+	return Fit[*vector2d.Vector2D, Circle](points, CircleModel{}, cfg)
+}
+
+// A circle needs 3 points
+func (CircleModel) MinSamples() int { return 3 }
+
+// Circle through three non collinear points, or the algebraic (Kåsa) least
+// squares circle through more
+func (CircleModel) Fit(ps []*vector2d.Vector2D) (Circle, bool) {
+	// centered on the mean for conditioning; solves
+	// x²+y² + a*x + b*y + c = 0 in the least squares sense
+	mx, my := stats.Mean2D(ps)
+	var ata [3][3]float64
+	var atb [3]float64
+	for _, p := range ps {
+		x, y := float64(p.X)-mx, float64(p.Y)-my
+		row := [3]float64{x, y, 1}
+		rhs := -(x*x + y*y)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				ata[i][j] += row[i] * row[j]
+			}
+			atb[i] += row[i] * rhs
+		}
+	}
+	a := make([][]float64, 3)
+	for i := range a {
+		a[i] = ata[i][:]
+	}
+	sol, ok := mat.Solve(a, atb[:])
+	if !ok {
+		return Circle{}, false
+	}
+	cx, cy := -sol[0]/2, -sol[1]/2
+	r2 := cx*cx + cy*cy - sol[2]
+	if r2 <= 0 || math.IsNaN(r2) || math.IsInf(r2, 0) {
+		return Circle{}, false
+	}
+	return Circle{Center: vector2d.New(float32(cx+mx), float32(cy+my)), Radius: float32(math.Sqrt(r2))}, true
+}
+
+// Distance from p to the circle line
+func (CircleModel) Distance(c Circle, p *vector2d.Vector2D) float32 {
+	return float32(math.Abs(float64(p.Dist(c.Center) - c.Radius)))
+}
@@ -0,0 +1,122 @@
+package ransac
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer2D(tolerance float32) cmp.Option {
+	return cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, tolerance) })
+}
+
+func TestLine2DModel(t *testing.T) {
+	m := Line2DModel{}
+	l, ok := m.Fit([]*vector2d.Vector2D{vector2d.New(1, 1), vector2d.New(4, 5)})
+	if !ok {
+		t.Fatal("Fit() not ok")
+	}
+	if want := vector2d.New(0.6, 0.8); !l.Direction.Equal(want, 1e-6) {
+		t.Errorf("Fit().Direction = %v, want %v", l.Direction, want)
+	}
+	if got := m.Distance(l, vector2d.New(1+4, 1-3)); math.Abs(float64(got)-5) > 1e-5 {
+		t.Errorf("Distance() = %v, want 5", got)
+	}
+	if _, ok := m.Fit([]*vector2d.Vector2D{vector2d.New(1, 1), vector2d.New(1, 1)}); ok {
+		t.Errorf("Fit(same points) ok")
+	}
+}
+
+func TestRANSACLine2D(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var ps []*vector2d.Vector2D
+	var want []int
+	for i := 0; i < 300; i++ {
+		if i%3 == 0 {
+			ps = append(ps, vector2d.New(r.Float32()*20-10, r.Float32()*20-10))
+			continue
+		}
+		x := r.Float32()*20 - 10
+		ps = append(ps, vector2d.New(x, 0.5*x+2+(r.Float32()-0.5)*0.1))
+	}
+	m := Line2DModel{}
+	res, err := Fit[*vector2d.Vector2D, Line2D](ps, m, Config{Threshold: 0.1, Rand: rand.New(rand.NewSource(2))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := res.Model.Direction.Cross(vector2d.Unit(vector2d.New(2, 1))); math.Abs(float64(d)) > 1e-2 {
+		t.Errorf("line direction = %v", res.Model.Direction)
+	}
+	if d := m.Distance(res.Model, vector2d.New(0, 2)); d > 0.02 {
+		t.Errorf("line misses (0, 2) by %v", d)
+	}
+	for i := range ps {
+		if i%3 != 0 {
+			want = append(want, i)
+		}
+	}
+	if missing := len(difference(want, res.Inliers)); missing > 0 {
+		t.Errorf("%d line points not inliers", missing)
+	}
+	if len(res.Inliers) > len(want)+10 {
+		t.Errorf("%d inliers, want about %d", len(res.Inliers), len(want))
+	}
+}
+
+func TestCircleModel(t *testing.T) {
+	m := CircleModel{}
+	c, ok := m.Fit([]*vector2d.Vector2D{vector2d.New(3, 1), vector2d.New(1, 3), vector2d.New(-1, 1)})
+	if !ok {
+		t.Fatal("Fit() not ok")
+	}
+	if !cmp.Equal(c.Center, vector2d.New(1, 1), getComparer2D(1e-5)) || math.Abs(float64(c.Radius)-2) > 1e-5 {
+		t.Errorf("Fit() = %v, %v, want (1, 1), 2", c.Center, c.Radius)
+	}
+	if got := m.Distance(c, vector2d.New(1, 4)); math.Abs(float64(got)-1) > 1e-5 {
+		t.Errorf("Distance() = %v, want 1", got)
+	}
+	if _, ok := m.Fit([]*vector2d.Vector2D{vector2d.New(0, 0), vector2d.New(1, 1), vector2d.New(2, 2)}); ok {
+		t.Errorf("Fit(collinear) ok")
+	}
+}
+
+func TestRANSACCircle(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	var ps []*vector2d.Vector2D
+	for i := 0; i < 200; i++ {
+		if i%4 == 0 {
+			ps = append(ps, vector2d.New(r.Float32()*20-10, r.Float32()*20-10))
+			continue
+		}
+		p := vector2d.FromAngle(r.Float32() * 2 * math.Pi).Mult(4 + (r.Float32()-0.5)*0.05)
+		ps = append(ps, p.Add(vector2d.New(-2, 3)))
+	}
+	res, err := FitCircle(ps, Config{Threshold: 0.05, Rand: rand.New(rand.NewSource(6))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(res.Model.Center, vector2d.New(-2, 3), getComparer2D(0.02)) || math.Abs(float64(res.Model.Radius)-4) > 0.02 {
+		t.Errorf("circle = %v, %v, want (-2, 3), 4", res.Model.Center, res.Model.Radius)
+	}
+	if len(res.Inliers) < 150 {
+		t.Errorf("%d inliers, want at least 150", len(res.Inliers))
+	}
+}
+
+// Elements of a not in b, both sorted
+func difference(a, b []int) []int {
+	var out []int
+	j := 0
+	for _, x := range a {
+		for j < len(b) && b[j] < x {
+			j++
+		}
+		if j == len(b) || b[j] != x {
+			out = append(out, x)
+		}
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package ransac
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/stats"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Plane through Point with the unit Normal
+type Plane struct {
+	Point, Normal *vector.Vector
+}
+
+// Estimator of planes
+type PlaneModel struct{}
+
+// Robustly fits a plane to the points, see Fit
+func FitPlane(points []*vector.Vector, cfg Config) (Result[Plane], error) {
+	return Fit[*vector.Vector, Plane](points, PlaneModel{}, cfg)
+}
+
+// A plane needs 3 points
+func (PlaneModel) MinSamples() int { return 3 }
+
+// Plane through three non collinear points, or the total least squares plane through more
+func (PlaneModel) Fit(ps []*vector.Vector) (Plane, bool) {
+	if len(ps) == 3 {
+		n := vector.Cross(vector.Sub(ps[1], ps[0]), vector.Sub(ps[2], ps[0]))
+		if n.Mag() == 0 {
+			return Plane{}, false
+		}
+		return Plane{Point: ps[0].Copy(), Normal: n.Normalize()}, true
+	}
+	point, normal, err := stats.FitPlane(ps)
+	if err != nil {
+		return Plane{}, false
+	}
+	return Plane{Point: point, Normal: normal}, true
+}
+
+// Perpendicular distance from p to the plane
+func (PlaneModel) Distance(pl Plane, p *vector.Vector) float32 {
+	return float32(math.Abs(float64(vector.Dot(vector.Sub(p, pl.Point), pl.Normal))))
+}
+
+// Sphere
+type Sphere struct {
+	Center *vector.Vector
+	Radius float32
+}
+
+// Estimator of spheres
+type SphereModel struct{}
+
+// Robustly fits a sphere to the points, see Fit
+func FitSphere(points []*vector.Vector, cfg Config) (Result[Sphere], error) {
+	return Fit[*vector.Vector, Sphere](points, SphereModel{}, cfg)
+}
+
+// A sphere needs 4 points
+func (SphereModel) MinSamples() int { return 4 }
+
+// Sphere through four non coplanar points, or the algebraic least squares
+// sphere through more
+func (SphereModel) Fit(ps []*vector.Vector) (Sphere, bool) {
+	// centered on the mean for conditioning; solves
+	// x²+y²+z² + a*x + b*y + c*z + d = 0 in the least squares sense
+	mx, my, mz := stats.Mean(ps)
+	var ata [4][4]float64
+	var atb [4]float64
+	for _, p := range ps {
+		x, y, z := float64(p.X)-mx, float64(p.Y)-my, float64(p.Z)-mz
+		row := [4]float64{x, y, z, 1}
+		rhs := -(x*x + y*y + z*z)
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				ata[i][j] += row[i] * row[j]
+			}
+			atb[i] += row[i] * rhs
+		}
+	}
+	a := make([][]float64, 4)
+	for i := range a {
+		a[i] = ata[i][:]
+	}
+	sol, ok := mat.Solve(a, atb[:])
+	if !ok {
+		return Sphere{}, false
+	}
+	cx, cy, cz := -sol[0]/2, -sol[1]/2, -sol[2]/2
+	r2 := cx*cx + cy*cy + cz*cz - sol[3]
+	if r2 <= 0 || math.IsNaN(r2) || math.IsInf(r2, 0) {
+		return Sphere{}, false
+	}
+	center := vector.New(float32(cx+mx), float32(cy+my), float32(cz+mz))
+	return Sphere{Center: center, Radius: float32(math.Sqrt(r2))}, true
+}
+
+// Distance from p to the sphere surface
+func (SphereModel) Distance(s Sphere, p *vector.Vector) float32 {
+	return float32(math.Abs(float64(vector.Dist(p, s.Center) - s.Radius)))
+}
@@ -0,0 +1,110 @@
+package ransac
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) })
+}
+
+func TestPlaneModel(t *testing.T) {
+	m := PlaneModel{}
+	pl, ok := m.Fit([]*vector.Vector{vector.New(0, 0, 2), vector.New(1, 0, 2), vector.New(0, 1, 2)})
+	if !ok {
+		t.Fatal("Fit() not ok")
+	}
+	if !cmp.Equal(pl.Normal, vector.New(0, 0, 1), getComparer(1e-6)) {
+		t.Errorf("Fit().Normal = %v, want (0, 0, 1)", pl.Normal)
+	}
+	if got := m.Distance(pl, vector.New(5, -3, -1)); got != 3 {
+		t.Errorf("Distance() = %v, want 3", got)
+	}
+	if _, ok := m.Fit([]*vector.Vector{vector.New(0, 0, 0), vector.New(1, 1, 1), vector.New(2, 2, 2)}); ok {
+		t.Errorf("Fit(collinear) ok")
+	}
+}
+
+func TestRANSACPlane(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	normal := vector.Unit(vector.New(1, -2, 3))
+	u := vector.Unit(vector.Cross(normal, vector.New(1, 0, 0)))
+	v := vector.Cross(normal, u)
+	origin := vector.New(1, 2, 3)
+	var ps []*vector.Vector
+	for i := 0; i < 400; i++ {
+		if i%2 == 0 {
+			ps = append(ps, vector.New(r.Float32()*20-10, r.Float32()*20-10, r.Float32()*20-10))
+			continue
+		}
+		p := vector.Add(origin, u.Copy().Mult(r.Float32()*10-5))
+		p.Add(v.Copy().Mult(r.Float32()*10 - 5)).Add(normal.Copy().Mult((r.Float32() - 0.5) * 0.02))
+		ps = append(ps, p)
+	}
+	m := PlaneModel{}
+	res, err := Fit[*vector.Vector, Plane](ps, m, Config{Threshold: 0.05, Rand: rand.New(rand.NewSource(2))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := math.Abs(float64(vector.Dot(res.Model.Normal, normal))); d < 1-1e-4 {
+		t.Errorf("plane normal = %v, want ±%v", res.Model.Normal, normal)
+	}
+	if d := m.Distance(res.Model, origin); d > 0.01 {
+		t.Errorf("plane misses %v by %v", origin, d)
+	}
+	if len(res.Inliers) < 200 || len(res.Inliers) > 210 {
+		t.Errorf("%d inliers, want about 200", len(res.Inliers))
+	}
+}
+
+func TestSphereModel(t *testing.T) {
+	m := SphereModel{}
+	c := vector.New(1, -1, 2)
+	var ps []*vector.Vector
+	for _, d := range []*vector.Vector{vector.New(3, 0, 0), vector.New(0, 3, 0), vector.New(0, 0, 3), vector.New(-3, 0, 0)} {
+		ps = append(ps, vector.Add(c, d))
+	}
+	s, ok := m.Fit(ps)
+	if !ok {
+		t.Fatal("Fit() not ok")
+	}
+	if !cmp.Equal(s.Center, c, getComparer(1e-5)) || math.Abs(float64(s.Radius)-3) > 1e-5 {
+		t.Errorf("Fit() = %v, %v, want %v, 3", s.Center, s.Radius, c)
+	}
+	if got := m.Distance(s, vector.New(1, -1, 0)); math.Abs(float64(got)-1) > 1e-5 {
+		t.Errorf("Distance() = %v, want 1", got)
+	}
+	coplanar := []*vector.Vector{vector.New(0, 0, 0), vector.New(1, 0, 0), vector.New(0, 1, 0), vector.New(1, 1, 0)}
+	if _, ok := m.Fit(coplanar); ok {
+		t.Errorf("Fit(coplanar) ok")
+	}
+}
+
+func TestRANSACSphere(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	c := vector.New(-2, 0, 5)
+	var ps []*vector.Vector
+	for i := 0; i < 300; i++ {
+		if i%3 == 0 {
+			ps = append(ps, vector.New(r.Float32()*20-10, r.Float32()*20-10, r.Float32()*20))
+			continue
+		}
+		d := vector.Unit(vector.New(float32(r.NormFloat64()), float32(r.NormFloat64()), float32(r.NormFloat64())))
+		ps = append(ps, vector.Add(c, d.Mult(2.5+(r.Float32()-0.5)*0.02)))
+	}
+	res, err := FitSphere(ps, Config{Threshold: 0.03, Rand: rand.New(rand.NewSource(4))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(res.Model.Center, c, getComparer(0.01)) || math.Abs(float64(res.Model.Radius)-2.5) > 0.01 {
+		t.Errorf("sphere = %v, %v, want %v, 2.5", res.Model.Center, res.Model.Radius, c)
+	}
+	if len(res.Inliers) < 200 {
+		t.Errorf("%d inliers, want at least 200", len(res.Inliers))
+	}
+}
@@ -0,0 +1,165 @@
+// Package ransac provides a generic RANSAC driver for robust model fitting,
+// with line, circle, plane and sphere models.
+// https://en.wikipedia.org/wiki/Random_sample_consensus
+package ransac
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var (
+	// Returned when there are fewer points than a model needs
+	ErrTooFewPoints = errors.New("ransac: not enough points")
+	// Returned when no sample gave a valid model
+	ErrNoModel = errors.New("ransac: no model found")
+)
+
+// Fits models of type M to points of type P
+type Estimator[P, M any] interface {
+	// Number of points needed to fit a model
+	MinSamples() int
+	// Fits a model through the points. Gets exactly MinSamples points while
+	// sampling and all inliers (a least squares fit) when refining.
+	// ok is false for degenerate samples, e.g. collinear points for a plane.
+	Fit(points []P) (model M, ok bool)
+	// Distance from a point to the model
+	Distance(model M, p P) float32
+}
+
+// Parameters of a run. Zero fields take their default value.
+type Config struct {
+	// Points closer than this to a model are its inliers
+	Threshold float32
+	// Maximum number of samples tried (default 1000)
+	MaxIterations int
+	// Stop once a model with at least this probability of being outlier free
+	// has been sampled (default 0.99)
+	Confidence float64
+	// Source of randomness (default seeded from time), set it for repeatable runs
+	Rand *rand.Rand
+	// Skips the final least squares fit on the inliers
+	NoRefine bool
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxIterations <= 0 {
+		c.MaxIterations = 1000
+	}
+	if c.Confidence <= 0 || c.Confidence >= 1 {
+		c.Confidence = 0.99
+	}
+	if c.Rand == nil {
+		c.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return c
+}
+
+// Outcome of a run
+type Result[M any] struct {
+	Model M
+	// Indexes of the points within Threshold of Model, in increasing order
+	Inliers []int
+	// Number of samples tried
+	Iterations int
+}
+
+// Finds the model with the most inliers among models fitted to random
+// minimal samples, then refits it on its inliers.
+func Fit[P, M any](points []P, est Estimator[P, M], cfg Config) (Result[M], error) {
+	cfg = cfg.withDefaults()
+	s := est.MinSamples()
+	if len(points) < s {
+		return Result[M]{}, ErrTooFewPoints
+	}
+
+	var best Result[M]
+	found := false
+	sample := make([]P, s)
+	idx := make([]int, s)
+	needed := cfg.MaxIterations
+	for best.Iterations < needed {
+		best.Iterations++
+		pick(cfg.Rand, len(points), idx)
+		for i, j := range idx {
+			sample[i] = points[j]
+		}
+		model, ok := est.Fit(sample)
+		if !ok {
+			continue
+		}
+		in := inliers(points, est, model, cfg.Threshold)
+		if !found || len(in) > len(best.Inliers) {
+			found = true
+			best.Model, best.Inliers = model, in
+			w := float64(len(in)) / float64(len(points))
+			needed = min(cfg.MaxIterations, iterationsFor(cfg.Confidence, w, s))
+		}
+	}
+	if !found {
+		return best, ErrNoModel
+	}
+	if !cfg.NoRefine && len(best.Inliers) > s {
+		in := make([]P, len(best.Inliers))
+		for i, j := range best.Inliers {
+			in[i] = points[j]
+		}
+		if model, ok := est.Fit(in); ok {
+			if refined := inliers(points, est, model, cfg.Threshold); len(refined) >= len(best.Inliers) {
+				best.Model, best.Inliers = model, refined
+			}
+		}
+	}
+	return best, nil
+}
+
+func inliers[P, M any](points []P, est Estimator[P, M], model M, threshold float32) []int {
+	var out []int
+	for i, p := range points {
+		if est.Distance(model, p) <= threshold {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// Number of samples needed to draw an outlier free one with the given
+// confidence when a share w of the points are inliers
+func iterationsFor(confidence, w float64, s int) int {
+	p := math.Pow(w, float64(s))
+	if p >= 1 {
+		return 1
+	}
+	if p <= 0 {
+		return math.MaxInt32
+	}
+	n := math.Log(1-confidence) / math.Log(1-p)
+	if n > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(math.Ceil(n))
+}
+
+// Fills idx with distinct random indexes below n with Floyd's algorithm
+func pick(r *rand.Rand, n int, idx []int) {
+	seen := make(map[int]bool, len(idx))
+	k := 0
+	for j := n - len(idx); j < n; j++ {
+		t := r.Intn(j + 1)
+		if seen[t] {
+			t = j
+		}
+		seen[t] = true
+		idx[k] = t
+		k++
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,111 @@
+package ransac
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Estimator of a constant over floats, to test the driver on its own
+type constModel struct{}
+
+func (constModel) MinSamples() int { return 1 }
+func (constModel) Fit(ps []float32) (float32, bool) {
+	var s float32
+	for _, p := range ps {
+		s += p
+	}
+	return s / float32(len(ps)), true
+}
+func (constModel) Distance(m, p float32) float32 {
+	if p > m {
+		return p - m
+	}
+	return m - p
+}
+
+type failModel struct{ constModel }
+
+func (failModel) Fit([]float32) (float32, bool) { return 0, false }
+
+func TestFit(t *testing.T) {
+	ps := []float32{5, 5.1, 4.9, 5, 100, -20, 5.05, 37}
+	got, err := Fit[float32, float32](ps, constModel{}, Config{Threshold: 0.2, Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{0, 1, 2, 3, 6}; !cmp.Equal(got.Inliers, want) {
+		t.Errorf("Fit().Inliers = %v, want %v", got.Inliers, want)
+	}
+	if got.Model < 4.99 || got.Model > 5.02 {
+		t.Errorf("Fit().Model = %v, want refined mean 5.01", got.Model)
+	}
+	if got.Iterations < 1 || got.Iterations >= 1000 {
+		t.Errorf("Fit().Iterations = %v, want early stop", got.Iterations)
+	}
+
+	cfg := Config{Threshold: 0.2, NoRefine: true, MaxIterations: 3, Rand: rand.New(rand.NewSource(1))}
+	if got, _ := Fit[float32, float32](ps, constModel{}, cfg); got.Iterations > 3 {
+		t.Errorf("Fit().Iterations = %v, want <= 3", got.Iterations)
+	}
+	if _, err := Fit[float32, float32](nil, constModel{}, Config{}); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("Fit(nil) error = %v, want %v", err, ErrTooFewPoints)
+	}
+	if _, err := Fit[float32, float32](ps, failModel{}, Config{MaxIterations: 10}); !errors.Is(err, ErrNoModel) {
+		t.Errorf("Fit(degenerate) error = %v, want %v", err, ErrNoModel)
+	}
+}
+
+func TestFitDeterministic(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	var ps []*vector2d.Vector2D
+	for i := 0; i < 200; i++ {
+		ps = append(ps, vector2d.New(r.Float32()*10, r.Float32()*10))
+	}
+	run := func() Result[Line2D] {
+		res, err := Fit[*vector2d.Vector2D, Line2D](ps, Line2DModel{}, Config{Threshold: 0.5, Rand: rand.New(rand.NewSource(7))})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	if a, b := run(), run(); !cmp.Equal(a, b) {
+		t.Errorf("Fit() with the same seed differs: %v, %v", a, b)
+	}
+}
+
+func TestIterationsFor(t *testing.T) {
+	tests := []struct {
+		confidence, w float64
+		s             int
+		want          int
+	}{
+		{0.99, 1, 3, 1},
+		{0.99, 0.5, 2, 17},
+		{0.99, 0.5, 4, 72},
+		{0.99, 0, 2, 1<<31 - 1},
+	}
+	for _, tt := range tests {
+		if got := iterationsFor(tt.confidence, tt.w, tt.s); got != tt.want {
+			t.Errorf("iterationsFor(%v, %v, %v) = %v, want %v", tt.confidence, tt.w, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestPick(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	idx := make([]int, 4)
+	for i := 0; i < 1000; i++ {
+		pick(r, 6, idx)
+		seen := map[int]bool{}
+		for _, j := range idx {
+			if j < 0 || j >= 6 || seen[j] {
+				t.Fatalf("pick() = %v, want distinct indexes below 6", idx)
+			}
+			seen[j] = true
+		}
+	}
+}
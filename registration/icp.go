@@ -0,0 +1,178 @@
+package registration
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/stats"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Error metric minimized by ICP
+type Method int
+
+const (
+	// Minimizes the distance between each point and its closest target point
+	PointToPoint Method = iota
+	// Minimizes the distance between each point and the tangent plane at its
+	// closest target point. Converges faster on smooth surfaces.
+	PointToPlane
+)
+
+// Number of neighbors used to estimate target normals
+const normalNeighbors = 8
+
+// Parameters of ICP. Zero fields take their default value.
+type ICPConfig struct {
+	Method Method
+	// Maximum number of iterations (default 50)
+	MaxIterations int
+	// Stop once the RMS error improves by less than this (default 1e-6)
+	Tolerance float64
+	// Ignore pairs farther apart than this, 0 keeps all pairs
+	MaxDistance float32
+	// Initial guess of the transform (default identity)
+	Initial *Transform
+	// Unit normals of the target points for PointToPlane.
+	// Estimated from the closest target points when nil.
+	Normals []*vector.Vector
+}
+
+// Outcome of ICP
+type ICPResult struct {
+	// Transform mapping the source cloud onto the target
+	Transform Transform
+	// Root mean square distance of the matched pairs after the last iteration
+	RMS float64
+	// Number of iterations run
+	Iterations int
+	// Whether the tolerance was reached before MaxIterations
+	Converged bool
+}
+
+// Aligns the src cloud to the dst cloud with Iterative Closest Point.
+// The clouds do not need to correspond or have the same size.
+// https://en.wikipedia.org/wiki/Iterative_closest_point
+func ICP(src, dst []*vector.Vector, cfg ICPConfig) (ICPResult, error) {
+	if cfg.MaxIterations <= 0 {
+		cfg.MaxIterations = 50
+	}
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = 1e-6
+	}
+	if len(src) < 3 || len(dst) < 3 {
+		return ICPResult{}, ErrTooFewPoints
+	}
+	tree := newKDTree(dst)
+	normals := cfg.Normals
+	if cfg.Method == PointToPlane && normals == nil {
+		normals = estimateNormals(dst, tree)
+	}
+	if cfg.Method == PointToPlane && len(normals) != len(dst) {
+		return ICPResult{}, ErrLengthMismatch
+	}
+
+	res := ICPResult{Transform: Identity(), RMS: math.Inf(1)}
+	if cfg.Initial != nil {
+		res.Transform = *cfg.Initial
+	}
+	var pairSrc, pairDst, pairNormals []*vector.Vector
+	for res.Iterations < cfg.MaxIterations {
+		// match every moved source point to its closest target point
+		pairSrc, pairDst, pairNormals = pairSrc[:0], pairDst[:0], pairNormals[:0]
+		for _, p := range src {
+			q := res.Transform.Apply(p)
+			j, d := tree.closest(q)
+			if cfg.MaxDistance > 0 && d > cfg.MaxDistance {
+				continue
+			}
+			if cfg.Method == PointToPlane {
+				pairSrc = append(pairSrc, q)
+				pairNormals = append(pairNormals, normals[j])
+			} else {
+				pairSrc = append(pairSrc, p)
+			}
+			pairDst = append(pairDst, dst[j])
+		}
+		if len(pairSrc) < 3 {
+			return res, ErrTooFewPoints
+		}
+
+		var rms float64
+		if cfg.Method == PointToPlane {
+			step, ok := pointToPlaneStep(pairSrc, pairDst, pairNormals)
+			if !ok {
+				break
+			}
+			res.Transform = step.Compose(res.Transform)
+			rms = RMS(step, pairSrc, pairDst)
+		} else {
+			t, err := Kabsch(pairSrc, pairDst)
+			if err != nil {
+				return res, err
+			}
+			res.Transform = t
+			rms = RMS(t, pairSrc, pairDst)
+		}
+		res.Iterations++
+
+		improved := res.RMS - rms
+		res.RMS = rms
+		if math.Abs(improved) < cfg.Tolerance {
+			res.Converged = true
+			break
+		}
+	}
+	return res, nil
+}
+
+// Solves the linearized point to plane problem for a small rotation w and
+// translation t minimizing sum(((p + w x p + t - q) . n)^2)
+func pointToPlaneStep(src, dst, normals []*vector.Vector) (Transform, bool) {
+	var ata [6][6]float64
+	var atb [6]float64
+	for i, p := range src {
+		n := normals[i]
+		c := vector.Cross(p, n)
+		row := [6]float64{float64(c.X), float64(c.Y), float64(c.Z), float64(n.X), float64(n.Y), float64(n.Z)}
+		b := float64(vector.Dot(vector.Sub(dst[i], p), n))
+		for r := 0; r < 6; r++ {
+			for k := 0; k < 6; k++ {
+				ata[r][k] += row[r] * row[k]
+			}
+			atb[r] += row[r] * b
+		}
+	}
+	a := make([][]float64, 6)
+	for i := range a {
+		a[i] = ata[i][:]
+	}
+	x, ok := mat.Solve(a, atb[:])
+	if !ok {
+		return Transform{}, false
+	}
+	w := vector.New(float32(x[0]), float32(x[1]), float32(x[2]))
+	return Transform{
+		Rotation:    mat.RotationVector(w),
+		Translation: vector.New(float32(x[3]), float32(x[4]), float32(x[5])),
+		Scale:       1,
+	}, true
+}
+
+// Estimates a unit normal at every point from the plane fitted through its neighbors
+func estimateNormals(ps []*vector.Vector, tree *kdTree) []*vector.Vector {
+	normals := make([]*vector.Vector, len(ps))
+	nb := make([]*vector.Vector, 0, normalNeighbors)
+	for i, p := range ps {
+		nb = nb[:0]
+		for _, n := range tree.nearest(p, normalNeighbors) {
+			nb = append(nb, ps[n.index])
+		}
+		_, normal, err := stats.FitPlane(nb)
+		if err != nil {
+			normal = vector.New(0, 0, 1)
+		}
+		normals[i] = normal
+	}
+	return normals
+}
@@ -0,0 +1,77 @@
+package registration
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Points on a smooth bumpy surface z = f(x, y)
+func surface(r *rand.Rand, n int) []*vector.Vector {
+	ps := make([]*vector.Vector, n)
+	for i := range ps {
+		x, y := r.Float64()*4-2, r.Float64()*4-2
+		z := 0.5*math.Sin(2*x)*math.Cos(1.5*y) + 0.2*x*y
+		ps[i] = vector.New(float32(x), float32(y), float32(z))
+	}
+	return ps
+}
+
+func TestICP(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	dst := surface(r, 3000)
+	want := Transform{mat.RotationAxisAngle(vector.New(0.2, 1, 0.4), 0.15), vector.New(0.1, -0.15, 0.05), 1}
+	inv := Transform{want.Rotation.Transpose(), want.Rotation.Transpose().MulVec(want.Translation).Mult(-1), 1}
+	// source is a random subset of the target moved away by the inverse transform
+	var src []*vector.Vector
+	for i := 0; i < len(dst); i += 3 {
+		src = append(src, inv.Apply(dst[i]))
+	}
+	for _, method := range []Method{PointToPoint, PointToPlane} {
+		res, err := ICP(src, dst, ICPConfig{Method: method, MaxIterations: 100, Tolerance: 1e-9})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Transform.Rotation.Equal(want.Rotation, 1e-3) || !res.Transform.Translation.Equal(want.Translation, 1e-3) {
+			t.Errorf("ICP(method %d) = %v, want %v", method, res.Transform, want)
+		}
+		if res.RMS > 1e-3 || res.Iterations == 0 {
+			t.Errorf("ICP(method %d) RMS = %v after %d iterations", method, res.RMS, res.Iterations)
+		}
+	}
+}
+
+func TestICPPointToPlaneFaster(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	dst := surface(r, 2000)
+	move := Transform{mat.RotationAxisAngle(vector.New(1, 0, 0), 0.1), vector.New(0.2, 0, 0.1), 1}
+	src := move.ApplyAll(dst)
+	p2p, _ := ICP(src, dst, ICPConfig{Method: PointToPoint, MaxIterations: 200, Tolerance: 1e-7})
+	p2l, _ := ICP(src, dst, ICPConfig{Method: PointToPlane, MaxIterations: 200, Tolerance: 1e-7})
+	if !p2l.Converged || p2l.Iterations > p2p.Iterations {
+		t.Errorf("point to plane took %d iterations (converged %v), point to point %d", p2l.Iterations, p2l.Converged, p2p.Iterations)
+	}
+}
+
+func TestICPOptions(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	dst := surface(r, 500)
+	initial := Transform{mat.Identity3(), vector.New(0.05, 0, 0), 1}
+	res, err := ICP(dst, dst, ICPConfig{Initial: &initial, MaxIterations: 1})
+	if err != nil || res.Iterations != 1 || res.Converged {
+		t.Errorf("ICP(MaxIterations 1) = %v, %v", res, err)
+	}
+	if _, err := ICP(dst, dst, ICPConfig{MaxDistance: 1e-9, Initial: &initial}); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("ICP(no pairs within MaxDistance) error = %v", err)
+	}
+	if _, err := ICP(dst, dst, ICPConfig{Method: PointToPlane, Normals: dst[:3]}); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("ICP(wrong number of normals) error = %v", err)
+	}
+	if _, err := ICP(dst[:2], dst, ICPConfig{}); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("ICP(2 points) error = %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Package registration aligns point sets: best fit rigid (and similarity)
+// transforms for corresponding points, and Iterative Closest Point for clouds.
+package registration
+
+import (
+	"errors"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/stats"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+var (
+	// Returned when the point sets are too small to define a transform
+	ErrTooFewPoints = errors.New("registration: not enough points")
+	// Returned when corresponding point sets have different lengths
+	ErrLengthMismatch = errors.New("registration: point sets differ in length")
+)
+
+// Similarity transform p -> Scale * Rotation * p + Translation
+type Transform struct {
+	Rotation    mat.Mat3
+	Translation *vector.Vector
+	Scale       float64
+}
+
+// Gives the identity transform
+func Identity() Transform {
+	return Transform{mat.Identity3(), vector.New(0, 0, 0), 1}
+}
+
+// Applies the transform to a point
+func (t Transform) Apply(p *vector.Vector) *vector.Vector {
+	return t.Rotation.MulVec(p).Mult(float32(t.Scale)).Add(t.Translation)
+}
+
+// Applies the transform to all points
+func (t Transform) ApplyAll(ps []*vector.Vector) []*vector.Vector {
+	out := make([]*vector.Vector, len(ps))
+	for i, p := range ps {
+		out[i] = t.Apply(p)
+	}
+	return out
+}
+
+// Gives the rotation as an axis and angle for vector.RotateAlongAxis,
+// so that Apply(p) == p.RotateAlongAxis(axis, angle).Mult(Scale).Add(Translation)
+func (t Transform) AxisAngle() (axis *vector.Vector, angle float32) {
+	a, ang := t.Rotation.AxisAngle()
+	return a, float32(ang)
+}
+
+// Gives the transform applying u first and then t
+func (t Transform) Compose(u Transform) Transform {
+	return Transform{
+		Rotation:    t.Rotation.Mul(u.Rotation),
+		Translation: t.Apply(u.Translation),
+		Scale:       t.Scale * u.Scale,
+	}
+}
+
+// Root mean square distance between the transformed src points and dst
+func RMS(t Transform, src, dst []*vector.Vector) float64 {
+	if len(src) == 0 {
+		return 0
+	}
+	var sum float64
+	for i, p := range src {
+		sum += float64(vector.Sub(t.Apply(p), dst[i]).MagSq())
+	}
+	return math.Sqrt(sum / float64(len(src)))
+}
+
+// Best rigid transform (rotation and translation) mapping src[i] onto dst[i]
+// in the least squares sense. Never returns a reflection.
+// https://en.wikipedia.org/wiki/Kabsch_algorithm
+func Kabsch(src, dst []*vector.Vector) (Transform, error) {
+	return fit(src, dst, false)
+}
+
+// Best similarity transform (rotation, translation and uniform scale)
+// mapping src[i] onto dst[i] in the least squares sense.
+// https://doi.org/10.1109/34.88573
+func Umeyama(src, dst []*vector.Vector) (Transform, error) {
+	return fit(src, dst, true)
+}
+
+func fit(src, dst []*vector.Vector, scaled bool) (Transform, error) {
+	if len(src) != len(dst) {
+		return Transform{}, ErrLengthMismatch
+	}
+	if len(src) < 3 {
+		return Transform{}, ErrTooFewPoints
+	}
+	var ms, md [3]float64
+	ms[0], ms[1], ms[2] = stats.Mean(src)
+	md[0], md[1], md[2] = stats.Mean(dst)
+	// cross-covariance H = sum (s - ms)(d - md)^T and variance of src
+	var h mat.Mat3
+	var variance float64
+	for i := range src {
+		s := [3]float64{float64(src[i].X) - ms[0], float64(src[i].Y) - ms[1], float64(src[i].Z) - ms[2]}
+		d := [3]float64{float64(dst[i].X) - md[0], float64(dst[i].Y) - md[1], float64(dst[i].Z) - md[2]}
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				h[r][c] += s[r] * d[c]
+			}
+		}
+		variance += s[0]*s[0] + s[1]*s[1] + s[2]*s[2]
+	}
+	u, sv, v := h.SVD()
+	// flip the weakest axis if the best orthogonal fit is a reflection
+	d := 1.0
+	if v.Mul(u.Transpose()).Det() < 0 {
+		d = -1
+	}
+	rot := v.Mul(mat.Diag3(1, 1, d)).Mul(u.Transpose())
+	scale := 1.0
+	if scaled {
+		if variance == 0 {
+			return Transform{}, ErrTooFewPoints
+		}
+		scale = (sv[0] + sv[1] + d*sv[2]) / variance
+	}
+	// translation computed in float64 to keep precision
+	rm := [3]float64{
+		rot[0][0]*ms[0] + rot[0][1]*ms[1] + rot[0][2]*ms[2],
+		rot[1][0]*ms[0] + rot[1][1]*ms[1] + rot[1][2]*ms[2],
+		rot[2][0]*ms[0] + rot[2][1]*ms[1] + rot[2][2]*ms[2],
+	}
+	t := vector.New(
+		float32(md[0]-scale*rm[0]),
+		float32(md[1]-scale*rm[1]),
+		float32(md[2]-scale*rm[2]),
+	)
+	return Transform{rot, t, scale}, nil
+}
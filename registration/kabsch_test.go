@@ -0,0 +1,117 @@
+package registration
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Comparer(func(x, y float32) bool {
+		diff := math.Abs(float64(x - y))
+		return diff <= tolerance
+	})
+}
+
+func randomCloud(r *rand.Rand, n int, scale float32) []*vector.Vector {
+	ps := make([]*vector.Vector, n)
+	for i := range ps {
+		ps[i] = vector.New((r.Float32()-0.5)*scale, (r.Float32()-0.5)*scale, (r.Float32()-0.5)*scale)
+	}
+	return ps
+}
+
+func TestKabsch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		axis := vector.New(r.Float32()-0.5, r.Float32()-0.5, r.Float32()-0.5)
+		angle := r.Float64() * math.Pi
+		want := Transform{mat.RotationAxisAngle(axis, angle), vector.New(r.Float32()*10, r.Float32()*10, -5), 1}
+		src := randomCloud(r, 30, 4)
+		dst := want.ApplyAll(src)
+		got, err := Kabsch(src, dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Rotation.Equal(want.Rotation, 1e-4) || !got.Translation.Equal(want.Translation, 1e-3) || got.Scale != 1 {
+			t.Fatalf("Kabsch() = %v, want %v", got, want)
+		}
+		if rms := RMS(got, src, dst); rms > 1e-4 {
+			t.Errorf("RMS() = %v, want about 0", rms)
+		}
+	}
+}
+
+func TestKabschPlanarAndReflection(t *testing.T) {
+	// planar points leave one singular value at zero
+	src := []*vector.Vector{vector.New(0, 0, 0), vector.New(1, 0, 0), vector.New(0, 1, 0), vector.New(1, 1, 0)}
+	want := Transform{mat.RotationAxisAngle(vector.New(1, 1, 0), 2), vector.New(1, 2, 3), 1}
+	got, err := Kabsch(src, want.ApplyAll(src))
+	if err != nil || !got.Rotation.Equal(want.Rotation, 1e-5) {
+		t.Errorf("Kabsch(planar) = %v, %v, want %v", got, err, want)
+	}
+	// a mirrored target must still give a proper rotation
+	src = []*vector.Vector{vector.New(1, 0, 0), vector.New(0, 2, 0), vector.New(0, 0, 3), vector.New(1, 1, 1)}
+	dst := make([]*vector.Vector, len(src))
+	for i, p := range src {
+		dst[i] = vector.New(-p.X, p.Y, p.Z)
+	}
+	got, _ = Kabsch(src, dst)
+	if d := got.Rotation.Det(); math.Abs(d-1) > 1e-9 {
+		t.Errorf("Kabsch(mirrored) det = %v, want 1", d)
+	}
+}
+
+func TestUmeyama(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	want := Transform{mat.RotationAxisAngle(vector.New(0, 1, 1), 0.7), vector.New(-3, 4, 1), 2.5}
+	src := randomCloud(r, 50, 2)
+	got, err := Umeyama(src, want.ApplyAll(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Rotation.Equal(want.Rotation, 1e-4) || !got.Translation.Equal(want.Translation, 1e-3) || math.Abs(got.Scale-2.5) > 1e-4 {
+		t.Errorf("Umeyama() = %v, want %v", got, want)
+	}
+}
+
+func TestFitErrors(t *testing.T) {
+	ps := randomCloud(rand.New(rand.NewSource(3)), 4, 1)
+	if _, err := Kabsch(ps, ps[:3]); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("Kabsch(mismatched) error = %v", err)
+	}
+	if _, err := Kabsch(ps[:2], ps[:2]); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("Kabsch(2 points) error = %v", err)
+	}
+	same := []*vector.Vector{vector.New(1, 1, 1), vector.New(1, 1, 1), vector.New(1, 1, 1)}
+	if _, err := Umeyama(same, same); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("Umeyama(coincident points) error = %v", err)
+	}
+}
+
+func TestTransformAxisAngle(t *testing.T) {
+	opt := getComparer(1e-4)
+	tr := Transform{mat.RotationAxisAngle(vector.New(1, 2, 3), 1.2), vector.New(1, 0, -1), 2}
+	axis, angle := tr.AxisAngle()
+	p := vector.New(0.5, -2, 4)
+	want := tr.Apply(p)
+	got := p.Copy().RotateAlongAxis(axis, angle).Mult(2).Add(tr.Translation)
+	if !cmp.Equal(got, want, opt) {
+		t.Errorf("RotateAlongAxis(AxisAngle()) = %v, want %v", got, want)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	opt := getComparer(1e-4)
+	a := Transform{mat.RotationAxisAngle(vector.New(1, 0, 0), 0.3), vector.New(1, 2, 3), 2}
+	b := Transform{mat.RotationAxisAngle(vector.New(0, 1, 0), -1), vector.New(-1, 0, 5), 0.5}
+	p := vector.New(3, -1, 2)
+	if got, want := a.Compose(b).Apply(p), a.Apply(b.Apply(p)); !cmp.Equal(got, want, opt) {
+		t.Errorf("Compose().Apply() = %v, want %v", got, want)
+	}
+}
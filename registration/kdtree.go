@@ -0,0 +1,99 @@
+package registration
+
+import (
+	"math"
+	"sort"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Static 3D k-d tree over the target points, used for closest point queries
+type kdTree struct {
+	points []*vector.Vector
+	// nodes store indexes into points, laid out as an implicit balanced tree
+	// over idx[lo:hi] with the median at (lo+hi)/2
+	idx []int
+}
+
+func coord(v *vector.Vector, axis int) float32 {
+	switch axis {
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	}
+	return v.Z
+}
+
+func newKDTree(points []*vector.Vector) *kdTree {
+	t := &kdTree{points: points, idx: make([]int, len(points))}
+	for i := range t.idx {
+		t.idx[i] = i
+	}
+	t.build(0, len(points), 0)
+	return t
+}
+
+func (t *kdTree) build(lo, hi, depth int) {
+	if hi-lo <= 1 {
+		return
+	}
+	axis := depth % 3
+	s := t.idx[lo:hi]
+	sort.Slice(s, func(i, j int) bool {
+		return coord(t.points[s[i]], axis) < coord(t.points[s[j]], axis)
+	})
+	mid := (lo + hi) / 2
+	t.build(lo, mid, depth+1)
+	t.build(mid+1, hi, depth+1)
+}
+
+type neighbor struct {
+	index  int
+	distSq float32
+}
+
+// Gives the k closest points to q, closest first
+func (t *kdTree) nearest(q *vector.Vector, k int) []neighbor {
+	best := make([]neighbor, 0, k+1)
+	var search func(lo, hi, depth int)
+	search = func(lo, hi, depth int) {
+		if lo >= hi {
+			return
+		}
+		mid := (lo + hi) / 2
+		p := t.points[t.idx[mid]]
+		d := vector.Sub(p, q).MagSq()
+		if len(best) < k || d < best[len(best)-1].distSq {
+			// insertion keeps best sorted, k is small
+			i := sort.Search(len(best), func(i int) bool { return best[i].distSq > d })
+			best = append(best, neighbor{})
+			copy(best[i+1:], best[i:])
+			best[i] = neighbor{t.idx[mid], d}
+			if len(best) > k {
+				best = best[:k]
+			}
+		}
+		axis := depth % 3
+		diff := coord(q, axis) - coord(p, axis)
+		near, far := [2]int{lo, mid}, [2]int{mid + 1, hi}
+		if diff > 0 {
+			near, far = far, near
+		}
+		search(near[0], near[1], depth+1)
+		if len(best) < k || diff*diff < best[len(best)-1].distSq {
+			search(far[0], far[1], depth+1)
+		}
+	}
+	search(0, len(t.idx), 0)
+	return best
+}
+
+// Gives the closest point to q
+func (t *kdTree) closest(q *vector.Vector) (index int, dist float32) {
+	n := t.nearest(q, 1)
+	if len(n) == 0 {
+		return -1, float32(math.Inf(1))
+	}
+	return n[0].index, float32(math.Sqrt(float64(n[0].distSq)))
+}
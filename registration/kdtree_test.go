@@ -0,0 +1,37 @@
+package registration
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestKDTreeNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	ps := randomCloud(r, 1000, 10)
+	tree := newKDTree(ps)
+	for i := 0; i < 100; i++ {
+		q := vector.New(r.Float32()*12-6, r.Float32()*12-6, r.Float32()*12-6)
+		order := make([]int, len(ps))
+		for j := range order {
+			order[j] = j
+		}
+		sort.Slice(order, func(a, b int) bool {
+			return vector.Sub(ps[order[a]], q).MagSq() < vector.Sub(ps[order[b]], q).MagSq()
+		})
+		got := tree.nearest(q, 5)
+		for j, n := range got {
+			if want := vector.Sub(ps[order[j]], q).MagSq(); n.distSq != want {
+				t.Fatalf("nearest(%v)[%d] distance %v, want %v", q, j, n.distSq, want)
+			}
+		}
+		if j, _ := tree.closest(q); j != order[0] {
+			t.Fatalf("closest(%v) = %d, want %d", q, j, order[0])
+		}
+	}
+	if j, _ := newKDTree(nil).closest(vector.New(0, 0, 0)); j != -1 {
+		t.Errorf("closest() on empty tree = %d, want -1", j)
+	}
+}
@@ -0,0 +1,187 @@
+// Package rigidbody provides 3D rigid body dynamics: mass properties of
+// boxes, spheres, capsules and convex hulls, world space inertia tensors,
+// forces and torques at points, and quaternion orientation integration with
+// gyroscopic effects.
+package rigidbody
+
+import (
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Rigid body. A body with zero Mass has infinite mass and inertia.
+type Body struct {
+	Shape Shape
+	// World position of the center of mass
+	Position *vector.Vector
+	// Rotation from body to world space
+	Orientation quat.Quat
+	// World space velocity of the center of mass and angular velocity (radians per second)
+	Velocity, AngularVelocity *vector.Vector
+	Mass                      float64
+	// Inertia tensor about the center of mass in body space
+	Inertia mat.Mat3
+	// Center of mass in shape space, body space is shape space moved to it
+	LocalCenter *vector.Vector
+	// Share of the velocities lost per second
+	LinearDamping, AngularDamping float64
+	// Whether the angular velocity precesses as the rotating inertia requires
+	// (the ω × Iω term of Euler's equations)
+	Gyroscopic bool
+
+	force, torque vector.Vector
+}
+
+// Makes a body of the shape with uniform density, resting at the origin
+// with the identity orientation and gyroscopic effects on.
+func NewBody(shape Shape, density float64) *Body {
+	mp := shape.MassProperties(density)
+	return &Body{
+		Shape:           shape,
+		Position:        vector.New(0, 0, 0),
+		Orientation:     quat.Identity(),
+		Velocity:        vector.New(0, 0, 0),
+		AngularVelocity: vector.New(0, 0, 0),
+		Mass:            mp.Mass,
+		Inertia:         mp.Inertia,
+		LocalCenter:     mp.Center,
+		Gyroscopic:      true,
+	}
+}
+
+// Inverse of the mass, 0 for infinite mass
+func (b *Body) InvMass() float64 {
+	if b.Mass <= 0 {
+		return 0
+	}
+	return 1 / b.Mass
+}
+
+// Rotation matrix from body to world space
+func (b *Body) Rotation() mat.Mat3 {
+	return b.Orientation.Mat3()
+}
+
+// Inertia tensor about the center of mass in world space axes, R*I*R^T
+func (b *Body) WorldInertia() mat.Mat3 {
+	r := b.Rotation()
+	return r.Mul(b.Inertia).Mul(r.Transpose())
+}
+
+// Inverse of the world inertia tensor, zero for infinite mass
+func (b *Body) InvWorldInertia() mat.Mat3 {
+	inv, ok := b.Inertia.Inverse()
+	if b.Mass <= 0 || !ok {
+		return mat.Mat3{}
+	}
+	r := b.Rotation()
+	return r.Mul(inv).Mul(r.Transpose())
+}
+
+// Converts a point from shape space to world space
+func (b *Body) WorldPoint(local *vector.Vector) *vector.Vector {
+	return b.Orientation.Rotate(vector.Sub(local, b.LocalCenter)).Add(b.Position)
+}
+
+// Converts a point from world space to shape space
+func (b *Body) LocalPoint(world *vector.Vector) *vector.Vector {
+	return b.Orientation.Conjugate().Rotate(vector.Sub(world, b.Position)).Add(b.LocalCenter)
+}
+
+// Velocity of the material point of the body at a world position, v + ω × r
+func (b *Body) VelocityAt(point *vector.Vector) *vector.Vector {
+	return vector.Cross(b.AngularVelocity, vector.Sub(point, b.Position)).Add(b.Velocity)
+}
+
+// Adds a force for the next step, applied at a world point (nil for the
+// center of mass) so that it also gives the torque r × f.
+// Modify + Returns self
+func (b *Body) ApplyForce(f, point *vector.Vector) *Body {
+	b.force.Add(f)
+	if point != nil {
+		b.torque.Add(vector.Cross(vector.Sub(point, b.Position), f))
+	}
+	return b
+}
+
+// Adds a world space torque for the next step.
+// Modify + Returns self
+func (b *Body) ApplyTorque(torque *vector.Vector) *Body {
+	b.torque.Add(torque)
+	return b
+}
+
+// Changes the velocities at once by an impulse at a world point (nil for the center of mass).
+// Modify + Returns self
+func (b *Body) ApplyImpulse(j, point *vector.Vector) *Body {
+	b.Velocity.Add(j.Copy().Mult(float32(b.InvMass())))
+	if point != nil {
+		b.ApplyAngularImpulse(vector.Cross(vector.Sub(point, b.Position), j))
+	}
+	return b
+}
+
+// Changes the angular velocity at once by a world space angular impulse.
+// Modify + Returns self
+func (b *Body) ApplyAngularImpulse(l *vector.Vector) *Body {
+	b.AngularVelocity.Add(b.InvWorldInertia().MulVec(l))
+	return b
+}
+
+// Linear momentum m*v
+func (b *Body) LinearMomentum() *vector.Vector {
+	return b.Velocity.Copy().Mult(float32(b.Mass))
+}
+
+// Angular momentum about the center of mass, I*ω in world space
+func (b *Body) AngularMomentum() *vector.Vector {
+	return b.WorldInertia().MulVec(b.AngularVelocity)
+}
+
+// Translational plus rotational kinetic energy
+func (b *Body) KineticEnergy() float64 {
+	v := float64(b.Velocity.MagSq())
+	rot := float64(vector.Dot(b.AngularVelocity, b.AngularMomentum()))
+	return 0.5*b.Mass*v + 0.5*rot
+}
+
+// Advances the body by dt with semi-implicit Euler: the accumulated force and
+// torque update the velocities, which then move the body. Clears the
+// accumulated force and torque.
+func (b *Body) Integrate(dt float64) {
+	if b.Mass > 0 {
+		b.Velocity.Add(b.force.Copy().Mult(float32(dt * b.InvMass())))
+		b.AngularVelocity.Add(b.InvWorldInertia().MulVec(b.torque.Copy().Mult(float32(dt))))
+		if b.Gyroscopic {
+			b.gyroscopic(dt)
+		}
+	}
+	if b.LinearDamping > 0 {
+		b.Velocity.Mult(float32(1 / (1 + dt*b.LinearDamping)))
+	}
+	if b.AngularDamping > 0 {
+		b.AngularVelocity.Mult(float32(1 / (1 + dt*b.AngularDamping)))
+	}
+	b.Position.Add(b.Velocity.Copy().Mult(float32(dt)))
+	b.Orientation = b.Orientation.Integrate(b.AngularVelocity, dt)
+	b.force, b.torque = vector.Vector{}, vector.Vector{}
+}
+
+// Applies the gyroscopic term with one Newton step on the implicit equation
+// I(ω2 - ω1) + dt ω2 × Iω2 = 0 in body space, which stays stable where the
+// explicit term gains energy.
+// https://box2d.org/files/ErinCatto_NumericalMethods_GDC2015.pdf
+func (b *Body) gyroscopic(dt float64) {
+	r := b.Rotation()
+	w := r.Transpose().MulVec(b.AngularVelocity)
+	iw := b.Inertia.MulVec(w)
+	f := vector.Cross(w, iw).Mult(float32(dt))
+	j := b.Inertia.Add(mat.Skew3(w).Mul(b.Inertia).Sub(mat.Skew3(iw)).Scale(dt))
+	inv, ok := j.Inverse()
+	if !ok {
+		return
+	}
+	w.Sub(inv.MulVec(f))
+	b.AngularVelocity.Assign(r.MulVec(w))
+}
@@ -0,0 +1,143 @@
+package rigidbody
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestBodyPoints(t *testing.T) {
+	b := NewBody(NewBox(1, 1, 1), 1)
+	b.Position = vector.New(1, 2, 3)
+	b.Orientation = quat.FromAxisAngle(vector.New(0, 0, 1), math.Pi/2)
+	opt := getComparer(1e-6)
+	if got, want := b.WorldPoint(vector.New(1, 0, 0)), vector.New(1, 3, 3); !cmp.Equal(got, want, opt) {
+		t.Errorf("WorldPoint() = %v, want %v", got, want)
+	}
+	if got, want := b.LocalPoint(vector.New(1, 3, 3)), vector.New(1, 0, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("LocalPoint() = %v, want %v", got, want)
+	}
+	b.AngularVelocity = vector.New(0, 0, 2)
+	if got, want := b.VelocityAt(vector.New(2, 2, 3)), vector.New(0, 2, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("VelocityAt() = %v, want %v", got, want)
+	}
+}
+
+func TestWorldInertia(t *testing.T) {
+	b := NewBody(NewBox(1, 2, 3), 1)
+	b.Orientation = quat.FromAxisAngle(vector.New(0, 0, 1), math.Pi/2)
+	// x and y swap
+	want := mat.Diag3(b.Inertia[1][1], b.Inertia[0][0], b.Inertia[2][2])
+	if got := b.WorldInertia(); !got.Equal(want, 1e-9) {
+		t.Errorf("WorldInertia() = %v, want %v", got, want)
+	}
+	if got := b.WorldInertia().Mul(b.InvWorldInertia()); !got.Equal(mat.Identity3(), 1e-12) {
+		t.Errorf("WorldInertia * InvWorldInertia = %v", got)
+	}
+	static := NewBody(NewBox(1, 1, 1), 0)
+	if got := static.InvWorldInertia(); got != (mat.Mat3{}) || static.InvMass() != 0 {
+		t.Errorf("static InvWorldInertia() = %v", got)
+	}
+}
+
+func TestApplyForce(t *testing.T) {
+	b := NewBody(NewSphere(1), 3/(4*math.Pi)) // mass 1, inertia 0.4
+	b.Gyroscopic = false
+	b.ApplyForce(vector.New(0, 2, 0), vector.New(1, 0, 0)).ApplyTorque(vector.New(0.4, 0, 0))
+	b.Integrate(0.5)
+	opt := getComparer(1e-5)
+	if want := vector.New(0, 1, 0); !cmp.Equal(b.Velocity, want, opt) {
+		t.Errorf("Velocity = %v, want %v", b.Velocity, want)
+	}
+	if want := vector.New(0.5, 0, 2.5); !cmp.Equal(b.AngularVelocity, want, opt) {
+		t.Errorf("AngularVelocity = %v, want %v", b.AngularVelocity, want)
+	}
+	if want := vector.New(0, 0.5, 0); !cmp.Equal(b.Position, want, opt) {
+		t.Errorf("Position = %v, want %v", b.Position, want)
+	}
+	b.Integrate(0.5)
+	if want := vector.New(0, 1, 0); !cmp.Equal(b.Velocity, want, opt) {
+		t.Errorf("force not cleared: Velocity = %v", b.Velocity)
+	}
+}
+
+func TestApplyImpulse(t *testing.T) {
+	b := NewBody(NewBox(1, 1, 1), 1.0/8) // mass 1, inertia 2/3
+	b.ApplyImpulse(vector.New(0, 0, 1), vector.New(1, 0, 0))
+	opt := getComparer(1e-5)
+	if want := vector.New(0, 0, 1); !cmp.Equal(b.Velocity, want, opt) {
+		t.Errorf("Velocity = %v, want %v", b.Velocity, want)
+	}
+	if want := vector.New(0, -1.5, 0); !cmp.Equal(b.AngularVelocity, want, opt) {
+		t.Errorf("AngularVelocity = %v, want %v", b.AngularVelocity, want)
+	}
+	if want := vector.New(0, 0, 1); !cmp.Equal(b.LinearMomentum(), want, opt) {
+		t.Errorf("LinearMomentum() = %v, want %v", b.LinearMomentum(), want)
+	}
+}
+
+func TestSpin(t *testing.T) {
+	// spinning about a principal axis turns at the expected rate
+	b := NewBody(NewBox(1, 2, 3), 1)
+	b.AngularVelocity = vector.New(0, math.Pi, 0)
+	for i := 0; i < 100; i++ {
+		b.Integrate(0.005)
+	}
+	want := quat.FromAxisAngle(vector.New(0, 1, 0), math.Pi/2)
+	if !b.Orientation.SameRotation(want, 1e-5) {
+		t.Errorf("Orientation = %v, want %v", b.Orientation, want)
+	}
+}
+
+// Torque free tumbling conserves the angular momentum, and with gyroscopic
+// effects the intermediate axis is unstable (Dzhanibekov effect)
+func TestGyroscopic(t *testing.T) {
+	b := NewBody(NewBox(0.2, 1, 2), 1)
+	b.AngularVelocity = vector.New(0.01, 4, 0.01)
+	l0 := b.AngularMomentum()
+	e0 := b.KineticEnergy()
+	flipped := false
+	for i := 0; i < 8000; i++ {
+		b.Integrate(0.001)
+		// the body y axis turns over
+		if b.Orientation.Rotate(vector.New(0, 1, 0)).Y < -0.9 {
+			flipped = true
+		}
+	}
+	if l := b.AngularMomentum(); !cmp.Equal(l, l0, getComparer(0.01*l0.Mag())) {
+		t.Errorf("AngularMomentum() = %v, want %v", l, l0)
+	}
+	if e := b.KineticEnergy(); math.Abs(e-e0) > 0.05*e0 {
+		t.Errorf("KineticEnergy() = %v, want %v", e, e0)
+	}
+	if !flipped {
+		t.Error("spin about the intermediate axis did not flip")
+	}
+
+	// without gyroscopic effects the angular velocity never changes
+	b = NewBody(NewBox(0.2, 1, 2), 1)
+	b.Gyroscopic = false
+	b.AngularVelocity = vector.New(0.01, 4, 0.01)
+	for i := 0; i < 100; i++ {
+		b.Integrate(0.002)
+	}
+	if want := vector.New(0.01, 4, 0.01); !cmp.Equal(b.AngularVelocity, want) {
+		t.Errorf("AngularVelocity = %v, want %v", b.AngularVelocity, want)
+	}
+}
+
+func TestDamping(t *testing.T) {
+	b := NewBody(NewSphere(1), 1)
+	b.Velocity = vector.New(1, 0, 0)
+	b.AngularVelocity = vector.New(0, 1, 0)
+	b.LinearDamping, b.AngularDamping = 1, 1
+	b.Integrate(1)
+	opt := getComparer(1e-6)
+	if !cmp.Equal(b.Velocity, vector.New(0.5, 0, 0), opt) || !cmp.Equal(b.AngularVelocity, vector.New(0, 0.5, 0), opt) {
+		t.Errorf("damped velocities = %v, %v", b.Velocity, b.AngularVelocity)
+	}
+}
@@ -0,0 +1,163 @@
+package rigidbody
+
+import (
+	"errors"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Returned when the points of a hull lie on a plane
+var ErrDegenerate = errors.New("rigidbody: hull needs 4 non coplanar points")
+
+// Convex polyhedron
+type Hull struct {
+	Vertices []*vector.Vector
+	// Triangles indexing Vertices, counter clockwise seen from outside
+	Faces [][3]int
+}
+
+type hullFace struct {
+	v    [3]int
+	n    [3]float64
+	d    float64
+	dead bool
+}
+
+type point3 [3]float64
+
+func sub3(a, b point3) point3  { return point3{a[0] - b[0], a[1] - b[1], a[2] - b[2]} }
+func dot3(a, b point3) float64 { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+func cross3(a, b point3) point3 {
+	return point3{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+// Builds the convex hull of the points incrementally, adding each point
+// outside the current hull and replacing the faces it sees.
+// https://en.wikipedia.org/wiki/Convex_hull_algorithms#Incremental_convex_hull_algorithm
+func NewHull(points []*vector.Vector) (*Hull, error) {
+	ps := make([]point3, len(points))
+	var lo, hi point3
+	for i, p := range points {
+		ps[i] = point3{float64(p.X), float64(p.Y), float64(p.Z)}
+		for k := 0; k < 3; k++ {
+			if i == 0 || ps[i][k] < lo[k] {
+				lo[k] = ps[i][k]
+			}
+			if i == 0 || ps[i][k] > hi[k] {
+				hi[k] = ps[i][k]
+			}
+		}
+	}
+	d := sub3(hi, lo)
+	eps := 1e-7 * math.Sqrt(dot3(d, d))
+	t, ok := initialTetrahedron(ps, eps)
+	if !ok {
+		return nil, ErrDegenerate
+	}
+
+	var faces []*hullFace
+	addFace := func(a, b, c int) {
+		f := &hullFace{v: [3]int{a, b, c}}
+		f.n = cross3(sub3(ps[b], ps[a]), sub3(ps[c], ps[a]))
+		l := math.Sqrt(dot3(f.n, f.n))
+		f.n = point3{f.n[0] / l, f.n[1] / l, f.n[2] / l}
+		f.d = dot3(f.n, ps[a])
+		faces = append(faces, f)
+	}
+	// orient the tetrahedron outward
+	if dot3(cross3(sub3(ps[t[1]], ps[t[0]]), sub3(ps[t[2]], ps[t[0]])), sub3(ps[t[3]], ps[t[0]])) > 0 {
+		t[1], t[2] = t[2], t[1]
+	}
+	addFace(t[0], t[1], t[2])
+	addFace(t[0], t[3], t[1])
+	addFace(t[1], t[3], t[2])
+	addFace(t[2], t[3], t[0])
+
+	for i, p := range ps {
+		if i == t[0] || i == t[1] || i == t[2] || i == t[3] {
+			continue
+		}
+		// directed edges of the faces seeing p
+		var edges [][2]int
+		visible := map[[2]int]bool{}
+		for _, f := range faces {
+			if !f.dead && dot3(f.n, p)-f.d > eps {
+				f.dead = true
+				for k := 0; k < 3; k++ {
+					e := [2]int{f.v[k], f.v[(k+1)%3]}
+					edges = append(edges, e)
+					visible[e] = true
+				}
+			}
+		}
+		if len(edges) == 0 {
+			continue
+		}
+		// the horizon is made of the visible edges whose twin is not visible
+		for _, e := range edges {
+			if !visible[[2]int{e[1], e[0]}] {
+				addFace(e[0], e[1], i)
+			}
+		}
+		alive := faces[:0]
+		for _, f := range faces {
+			if !f.dead {
+				alive = append(alive, f)
+			}
+		}
+		faces = alive
+	}
+
+	h := &Hull{}
+	index := map[int]int{}
+	for _, f := range faces {
+		var tri [3]int
+		for k, v := range f.v {
+			j, ok := index[v]
+			if !ok {
+				j = len(h.Vertices)
+				index[v] = j
+				h.Vertices = append(h.Vertices, points[v].Copy())
+			}
+			tri[k] = j
+		}
+		h.Faces = append(h.Faces, tri)
+	}
+	return h, nil
+}
+
+// Four affinely independent points spanning the set, as far apart as possible
+func initialTetrahedron(ps []point3, eps float64) (t [4]int, ok bool) {
+	if len(ps) < 4 {
+		return t, false
+	}
+	farthest := func(dist func(p point3) float64) (int, float64) {
+		best, bestD := 0, -1.0
+		for i, p := range ps {
+			if d := dist(p); d > bestD {
+				best, bestD = i, d
+			}
+		}
+		return best, bestD
+	}
+	t[1], _ = farthest(func(p point3) float64 { d := sub3(p, ps[0]); return dot3(d, d) })
+	t[0], _ = farthest(func(p point3) float64 { d := sub3(p, ps[t[1]]); return dot3(d, d) })
+	a, b := ps[t[0]], ps[t[1]]
+	ab := sub3(b, a)
+	var dist float64
+	t[2], dist = farthest(func(p point3) float64 {
+		c := cross3(ab, sub3(p, a))
+		return math.Sqrt(dot3(c, c) / dot3(ab, ab))
+	})
+	if dist <= eps {
+		return t, false
+	}
+	n := cross3(ab, sub3(ps[t[2]], a))
+	nl := math.Sqrt(dot3(n, n))
+	t[3], dist = farthest(func(p point3) float64 { return math.Abs(dot3(n, sub3(p, a))) / nl })
+	if dist <= eps {
+		return t, false
+	}
+	return t, true
+}
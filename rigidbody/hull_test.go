@@ -0,0 +1,72 @@
+package rigidbody
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Checks every face is oriented outward with all points behind it
+func checkHull(t *testing.T, h *Hull, ps []*vector.Vector) {
+	t.Helper()
+	edges := map[[2]int]int{}
+	for _, f := range h.Faces {
+		a, b, c := h.Vertices[f[0]], h.Vertices[f[1]], h.Vertices[f[2]]
+		n := vector.Cross(vector.Sub(b, a), vector.Sub(c, a)).Normalize()
+		for _, p := range ps {
+			if d := vector.Dot(n, vector.Sub(p, a)); d > 1e-4 {
+				t.Fatalf("point %v is %v outside face %v", p, d, f)
+			}
+		}
+		for k := 0; k < 3; k++ {
+			edges[[2]int{f[k], f[(k+1)%3]}]++
+		}
+	}
+	// closed and consistently oriented: each edge once in each direction
+	for e, n := range edges {
+		if n != 1 || edges[[2]int{e[1], e[0]}] != 1 {
+			t.Fatalf("edge %v used %d times, twin %d", e, n, edges[[2]int{e[1], e[0]}])
+		}
+	}
+	if v, e, f := len(h.Vertices), len(edges)/2, len(h.Faces); v-e+f != 2 {
+		t.Errorf("Euler characteristic V-E+F = %d-%d+%d, want 2", v, e, f)
+	}
+}
+
+func TestNewHull(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var ps []*vector.Vector
+	for i := 0; i < 300; i++ {
+		ps = append(ps, vector.New(r.Float32()*2-1, r.Float32()*2-1, r.Float32()*2-1))
+	}
+	h, err := NewHull(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHull(t, h, ps)
+
+	// points on a sphere are all vertices
+	ps = ps[:0]
+	for i := 0; i < 100; i++ {
+		ps = append(ps, vector.New(float32(r.NormFloat64()), float32(r.NormFloat64()), float32(r.NormFloat64())).Normalize())
+	}
+	if h, err = NewHull(ps); err != nil {
+		t.Fatal(err)
+	}
+	checkHull(t, h, ps)
+	if len(h.Vertices) != 100 {
+		t.Errorf("sphere hull has %d vertices, want 100", len(h.Vertices))
+	}
+}
+
+func TestNewHullDegenerate(t *testing.T) {
+	planar := []*vector.Vector{vector.New(0, 0, 0), vector.New(1, 0, 0), vector.New(0, 1, 0), vector.New(1, 1, 0)}
+	if _, err := NewHull(planar); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("NewHull(planar) error = %v", err)
+	}
+	if _, err := NewHull(planar[:3]); !errors.Is(err, ErrDegenerate) {
+		t.Errorf("NewHull(3 points) error = %v", err)
+	}
+}
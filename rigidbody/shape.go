@@ -0,0 +1,123 @@
+package rigidbody
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Mass distribution of a shape
+type MassProperties struct {
+	Mass float64
+	// Center of mass in shape space
+	Center *vector.Vector
+	// Inertia tensor about the center of mass, in shape space axes
+	Inertia mat.Mat3
+}
+
+// Solid shape of uniform density, in its own space.
+// Implemented by *Box, *Sphere, *Capsule and *Hull.
+type Shape interface {
+	MassProperties(density float64) MassProperties
+}
+
+// Box centered on the origin
+type Box struct {
+	HalfExtents *vector.Vector
+}
+
+// Makes a box with the given half sizes along x, y and z
+func NewBox(hx, hy, hz float32) *Box {
+	return &Box{HalfExtents: vector.New(hx, hy, hz)}
+}
+
+// Mass properties of the solid box
+func (b *Box) MassProperties(density float64) MassProperties {
+	x, y, z := float64(b.HalfExtents.X), float64(b.HalfExtents.Y), float64(b.HalfExtents.Z)
+	m := density * 8 * x * y * z
+	return MassProperties{
+		Mass:    m,
+		Center:  vector.New(0, 0, 0),
+		Inertia: mat.Diag3(m*(y*y+z*z)/3, m*(x*x+z*z)/3, m*(x*x+y*y)/3),
+	}
+}
+
+// Sphere centered on the origin
+type Sphere struct {
+	Radius float32
+}
+
+// Makes a sphere shape
+func NewSphere(radius float32) *Sphere {
+	return &Sphere{Radius: radius}
+}
+
+// Mass properties of the solid ball
+func (s *Sphere) MassProperties(density float64) MassProperties {
+	r := float64(s.Radius)
+	m := density * 4 / 3 * math.Pi * r * r * r
+	i := 2 * m * r * r / 5
+	return MassProperties{Mass: m, Center: vector.New(0, 0, 0), Inertia: mat.Diag3(i, i, i)}
+}
+
+// Cylinder capped by two hemispheres, centered on the origin along the y axis
+type Capsule struct {
+	Radius float32
+	// Half the length of the cylinder part
+	HalfHeight float32
+}
+
+// Makes a capsule shape
+func NewCapsule(radius, halfHeight float32) *Capsule {
+	return &Capsule{Radius: radius, HalfHeight: halfHeight}
+}
+
+// Mass properties of the solid capsule
+func (c *Capsule) MassProperties(density float64) MassProperties {
+	r, h := float64(c.Radius), 2*float64(c.HalfHeight)
+	cyl := density * math.Pi * r * r * h
+	caps := density * 4 / 3 * math.Pi * r * r * r
+	// each hemisphere's inertia about the center, by the parallel axis theorem
+	// from its own center of mass at 3r/8 from the flat face
+	iy := cyl*r*r/2 + caps*2*r*r/5
+	ix := cyl*(h*h/12+r*r/4) + caps*(2*r*r/5+h*h/4+3*h*r/8)
+	return MassProperties{Mass: cyl + caps, Center: vector.New(0, 0, 0), Inertia: mat.Diag3(ix, iy, ix)}
+}
+
+// Mass properties of the solid hull, summed over the tetrahedra between the
+// origin and each face
+// https://en.wikipedia.org/wiki/Tetrahedron#Volume
+func (h *Hull) MassProperties(density float64) MassProperties {
+	// second moment of the canonical tetrahedron (0, e1, e2, e3)
+	canonical := mat.Mat3{{2, 1, 1}, {1, 2, 1}, {1, 1, 2}}.Scale(1.0 / 120)
+	var volume float64
+	var c [3]float64
+	var cov mat.Mat3
+	for _, f := range h.Faces {
+		a := mat.FromColumns3(h.Vertices[f[0]], h.Vertices[f[1]], h.Vertices[f[2]])
+		det := a.Det()
+		volume += det / 6
+		for i := 0; i < 3; i++ {
+			c[i] += det / 24 * (a[i][0] + a[i][1] + a[i][2])
+		}
+		cov = cov.Add(a.Mul(canonical).Mul(a.Transpose()).Scale(det))
+	}
+	if volume == 0 {
+		return MassProperties{Center: vector.New(0, 0, 0)}
+	}
+	for i := range c {
+		c[i] /= volume
+	}
+	m := density * volume
+	// move the second moment to the center of mass, then I = tr(C)*Id - C
+	cv := vector.New(float32(c[0]), float32(c[1]), float32(c[2]))
+	cov = cov.Scale(density)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			cov[i][j] -= m * c[i] * c[j]
+		}
+	}
+	inertia := mat.Identity3().Scale(cov.Trace()).Sub(cov)
+	return MassProperties{Mass: m, Center: cv, Inertia: inertia}
+}
@@ -0,0 +1,80 @@
+package rigidbody
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) })
+}
+
+func checkMass(t *testing.T, name string, got, want MassProperties, tolerance float64) {
+	t.Helper()
+	if math.Abs(got.Mass-want.Mass) > tolerance*want.Mass ||
+		!got.Center.Equal(want.Center, float32(tolerance)) ||
+		!got.Inertia.Equal(want.Inertia, tolerance*want.Mass) {
+		t.Errorf("%s: MassProperties() = %v, %v, %v, want %v, %v, %v",
+			name, got.Mass, got.Center, got.Inertia, want.Mass, want.Center, want.Inertia)
+	}
+}
+
+func TestMassProperties(t *testing.T) {
+	origin := vector.New(0, 0, 0)
+	checkMass(t, "box", NewBox(1, 2, 3).MassProperties(2), MassProperties{
+		Mass: 96, Center: origin, Inertia: mat.Diag3(96*(4+9)/3.0, 96*(1+9)/3.0, 96*(1+4)/3.0),
+	}, 1e-12)
+	m := 4 / 3.0 * math.Pi * 8
+	checkMass(t, "sphere", NewSphere(2).MassProperties(1), MassProperties{
+		Mass: m, Center: origin, Inertia: mat.Diag3(0.4*m*4, 0.4*m*4, 0.4*m*4),
+	}, 1e-12)
+	// a capsule without cylinder is a sphere
+	checkMass(t, "flat capsule", NewCapsule(2, 0).MassProperties(1), NewSphere(2).MassProperties(1), 1e-12)
+}
+
+// Capsule against a dense point hull, which approximates it from the inside
+func TestCapsuleMassProperties(t *testing.T) {
+	const r, hh = 0.5, 1.5
+	var ps []*vector.Vector
+	for i := 0; i <= 40; i++ {
+		lat := math.Pi * (float64(i)/40 - 0.5)
+		for j := 0; j < 80; j++ {
+			lon := 2 * math.Pi * float64(j) / 80
+			x, z := r*math.Cos(lat)*math.Cos(lon), r*math.Cos(lat)*math.Sin(lon)
+			y := r * math.Sin(lat)
+			for _, c := range []float64{-hh, hh} {
+				if (c > 0) == (y >= 0) {
+					ps = append(ps, vector.New(float32(x), float32(y+c), float32(z)))
+				}
+			}
+		}
+	}
+	h, err := NewHull(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMass(t, "capsule", NewCapsule(r, hh).MassProperties(3), h.MassProperties(3), 0.01)
+}
+
+func TestHullMassProperties(t *testing.T) {
+	// box corners off the origin
+	var ps []*vector.Vector
+	for _, x := range []float32{1, 3} {
+		for _, y := range []float32{-1, 3} {
+			for _, z := range []float32{0, 6} {
+				ps = append(ps, vector.New(x, y, z))
+			}
+		}
+	}
+	h, err := NewHull(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := NewBox(1, 2, 3).MassProperties(2)
+	want.Center = vector.New(2, 1, 3)
+	checkMass(t, "box hull", h.MassProperties(2), want, 1e-6)
+}
@@ -0,0 +1,76 @@
+package rtree
+
+import (
+	"math"
+	"sort"
+)
+
+// Builds a tree from all items at once with Sort-Tile-Recursive packing.
+// Much faster than repeated Insert and gives nodes with very little overlap.
+// optional value sets the maximum number of entries per node (default 16, at least 4).
+// https://apps.dtic.mil/sti/pdfs/ADA324493.pdf
+func BulkLoad(items []Item, maxEntries ...int) *RTree {
+	t := New(maxEntries...)
+	if len(items) == 0 {
+		return t
+	}
+	entries := make([]entry, len(items))
+	for i := range items {
+		it := items[i]
+		entries[i] = entry{rect: it.Rect, item: &it}
+	}
+	level := 0
+	for {
+		nodes := t.pack(entries, level)
+		if len(nodes) == 1 {
+			t.root = nodes[0]
+			break
+		}
+		entries = make([]entry, len(nodes))
+		for i, n := range nodes {
+			entries[i] = entry{rect: n.bounds(), child: n}
+		}
+		level++
+	}
+	t.size = len(items)
+	return t
+}
+
+// Packs entries into nodes of the given level, tiling first by x then by y
+func (t *RTree) pack(entries []entry, level int) []*node {
+	if len(entries) <= t.maxEntries {
+		return []*node{{level: level, entries: entries}}
+	}
+	pages := int(math.Ceil(float64(len(entries)) / float64(t.maxEntries)))
+	slices := int(math.Ceil(math.Sqrt(float64(pages))))
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].rect.Min.X+entries[i].rect.Max.X < entries[j].rect.Min.X+entries[j].rect.Max.X
+	})
+	var nodes []*node
+	for _, slab := range evenChunks(entries, slices) {
+		sort.SliceStable(slab, func(i, j int) bool {
+			return slab[i].rect.Min.Y+slab[i].rect.Max.Y < slab[j].rect.Min.Y+slab[j].rect.Max.Y
+		})
+		groups := int(math.Ceil(float64(len(slab)) / float64(t.maxEntries)))
+		for _, g := range evenChunks(slab, groups) {
+			nodes = append(nodes, &node{level: level, entries: append([]entry(nil), g...)})
+		}
+	}
+	return nodes
+}
+
+// Splits es into n consecutive chunks whose sizes differ by at most one
+func evenChunks(es []entry, n int) [][]entry {
+	if n > len(es) {
+		n = len(es)
+	}
+	chunks := make([][]entry, 0, n)
+	start := 0
+	for i := 0; i < n; i++ {
+		end := start + (len(es)-start)/(n-i)
+		chunks = append(chunks, es[start:end])
+		start = end
+	}
+	return chunks
+}
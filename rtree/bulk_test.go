@@ -0,0 +1,61 @@
+package rtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBulkLoad(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for _, n := range []int{0, 1, 15, 16, 17, 100, 5000} {
+		items := randomItems(r, n)
+		tr := BulkLoad(items, 16)
+		checkTree(t, tr)
+		if tr.Len() != n {
+			t.Fatalf("BulkLoad(%d items).Len() = %v", n, tr.Len())
+		}
+		q := rect(100, 100, 400, 700)
+		if got, want := ids(tr.Search(q)), bruteSearch(items, q); len(got) != len(want) {
+			t.Fatalf("Search(%v) on bulk loaded tree found %v items, want %v", q, len(got), len(want))
+		}
+		// the tree stays usable afterwards
+		extra := randomItems(r, 50)
+		for i, it := range extra {
+			tr.Insert(it.Rect, n+i)
+		}
+		for _, it := range items[:n/2] {
+			if !tr.Delete(it.Rect, it.Data) {
+				t.Fatalf("Delete(%v, %v) = false after bulk load", it.Rect, it.Data)
+			}
+		}
+		checkTree(t, tr)
+	}
+}
+
+func TestEvenChunks(t *testing.T) {
+	es := make([]entry, 10)
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{1, []int{10}},
+		{3, []int{3, 3, 4}},
+		{4, []int{2, 2, 3, 3}},
+		{12, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+	}
+	for _, test := range tests {
+		chunks := evenChunks(es, test.n)
+		got := make([]int, len(chunks))
+		for i, c := range chunks {
+			got[i] = len(c)
+		}
+		if len(got) != len(test.want) {
+			t.Fatalf("evenChunks(10, %d) sizes = %v, want %v", test.n, got, test.want)
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Fatalf("evenChunks(10, %d) sizes = %v, want %v", test.n, got, test.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package rtree
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Axis aligned rectangle given by its min and max corners
+type Rect struct {
+	Min, Max vector2d.Vector2D
+}
+
+// Makes a rectangle spanning the two corners, in any order
+func NewRect(a, b *vector2d.Vector2D) Rect {
+	return Rect{
+		vector2d.Vector2D{X: float32(math.Min(float64(a.X), float64(b.X))), Y: float32(math.Min(float64(a.Y), float64(b.Y)))},
+		vector2d.Vector2D{X: float32(math.Max(float64(a.X), float64(b.X))), Y: float32(math.Max(float64(a.Y), float64(b.Y)))},
+	}
+}
+
+// Makes a degenerate rectangle covering a single point
+func PointRect(p *vector2d.Vector2D) Rect {
+	return Rect{*p, *p}
+}
+
+// String representation of the rectangle
+func (r Rect) String() string {
+	return fmt.Sprintf("{Min: %v, Max: %v}", &r.Min, &r.Max)
+}
+
+// Area of the rectangle
+func (r Rect) Area() float32 {
+	return (r.Max.X - r.Min.X) * (r.Max.Y - r.Min.Y)
+}
+
+// Half perimeter of the rectangle
+func (r Rect) Margin() float32 {
+	return (r.Max.X - r.Min.X) + (r.Max.Y - r.Min.Y)
+}
+
+// Center point of the rectangle
+func (r Rect) Center() *vector2d.Vector2D {
+	return vector2d.New((r.Min.X+r.Max.X)/2, (r.Min.Y+r.Max.Y)/2)
+}
+
+// Checks whether the two rectangles overlap (touching counts)
+func (r Rect) Intersects(o Rect) bool {
+	return r.Min.X <= o.Max.X && o.Min.X <= r.Max.X && r.Min.Y <= o.Max.Y && o.Min.Y <= r.Max.Y
+}
+
+// Checks whether o lies completely inside r
+func (r Rect) Contains(o Rect) bool {
+	return r.Min.X <= o.Min.X && o.Max.X <= r.Max.X && r.Min.Y <= o.Min.Y && o.Max.Y <= r.Max.Y
+}
+
+// Smallest rectangle containing both rectangles
+func (r Rect) Union(o Rect) Rect {
+	return Rect{
+		vector2d.Vector2D{X: minf(r.Min.X, o.Min.X), Y: minf(r.Min.Y, o.Min.Y)},
+		vector2d.Vector2D{X: maxf(r.Max.X, o.Max.X), Y: maxf(r.Max.Y, o.Max.Y)},
+	}
+}
+
+// Area of the overlap of the two rectangles, 0 if they are disjoint
+func (r Rect) OverlapArea(o Rect) float32 {
+	w := minf(r.Max.X, o.Max.X) - maxf(r.Min.X, o.Min.X)
+	h := minf(r.Max.Y, o.Max.Y) - maxf(r.Min.Y, o.Min.Y)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// Euclidean distance from the point to the closest point of the rectangle,
+// 0 if the point is inside
+func (r Rect) Dist(p *vector2d.Vector2D) float32 {
+	closest := vector2d.New(clamp(p.X, r.Min.X, r.Max.X), clamp(p.Y, r.Min.Y, r.Max.Y))
+	return p.Dist(closest)
+}
+
+func minf(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxf(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func clamp(v, lo, hi float32) float32 {
+	return minf(maxf(v, lo), hi)
+}
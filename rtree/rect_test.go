@@ -0,0 +1,92 @@
+package rtree
+
+import (
+	"testing"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func rect(x1, y1, x2, y2 float32) Rect {
+	return NewRect(vector2d.New(x1, y1), vector2d.New(x2, y2))
+}
+
+func TestNewRect(t *testing.T) {
+	want := Rect{vector2d.Vector2D{X: 1, Y: 2}, vector2d.Vector2D{X: 3, Y: 4}}
+	for _, got := range []Rect{rect(1, 2, 3, 4), rect(3, 4, 1, 2), rect(1, 4, 3, 2), rect(3, 2, 1, 4)} {
+		if got != want {
+			t.Errorf("NewRect() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRectMeasures(t *testing.T) {
+	tests := []struct {
+		r            Rect
+		area, margin float32
+	}{
+		{rect(0, 0, 1, 1), 1, 2},
+		{rect(-1, 2, 3, 5), 12, 7},
+		{rect(2, 2, 2, 2), 0, 0},
+	}
+	for _, test := range tests {
+		if got := test.r.Area(); got != test.area {
+			t.Errorf("%v.Area() = %v, want %v", test.r, got, test.area)
+		}
+		if got := test.r.Margin(); got != test.margin {
+			t.Errorf("%v.Margin() = %v, want %v", test.r, got, test.margin)
+		}
+	}
+}
+
+func TestIntersectsContains(t *testing.T) {
+	tests := []struct {
+		a, b                 Rect
+		intersects, contains bool
+	}{
+		{rect(0, 0, 4, 4), rect(1, 1, 2, 2), true, true},
+		{rect(0, 0, 4, 4), rect(3, 3, 5, 5), true, false},
+		{rect(0, 0, 4, 4), rect(4, 4, 5, 5), true, false},
+		{rect(0, 0, 4, 4), rect(5, 0, 6, 4), false, false},
+		{rect(1, 1, 2, 2), rect(0, 0, 4, 4), true, false},
+	}
+	for _, test := range tests {
+		if got := test.a.Intersects(test.b); got != test.intersects {
+			t.Errorf("%v.Intersects(%v) = %v, want %v", test.a, test.b, got, test.intersects)
+		}
+		if got := test.a.Contains(test.b); got != test.contains {
+			t.Errorf("%v.Contains(%v) = %v, want %v", test.a, test.b, got, test.contains)
+		}
+	}
+}
+
+func TestUnionOverlap(t *testing.T) {
+	a, b := rect(0, 0, 2, 2), rect(1, 1, 4, 3)
+	if got, want := a.Union(b), rect(0, 0, 4, 3); got != want {
+		t.Errorf("%v.Union(%v) = %v, want %v", a, b, got, want)
+	}
+	if got := a.OverlapArea(b); got != 1 {
+		t.Errorf("%v.OverlapArea(%v) = %v, want 1", a, b, got)
+	}
+	if got := a.OverlapArea(rect(5, 5, 6, 6)); got != 0 {
+		t.Errorf("%v.OverlapArea(disjoint) = %v, want 0", a, got)
+	}
+}
+
+func TestRectDist(t *testing.T) {
+	r := rect(0, 0, 2, 2)
+	tests := []struct {
+		p    *vector2d.Vector2D
+		want float32
+	}{
+		{vector2d.New(1, 1), 0},
+		{vector2d.New(2, 1), 0},
+		{vector2d.New(5, 1), 3},
+		{vector2d.New(5, 6), 5},
+		{vector2d.New(-3, -4), 5},
+	}
+	for _, test := range tests {
+		if got := r.Dist(test.p); got != test.want {
+			t.Errorf("%v.Dist(%v) = %v, want %v", r, test.p, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Package rtree provides an R*-tree spatial index for 2D rectangles.
+package rtree
+
+import (
+	"math"
+	"sort"
+)
+
+// Default maximum number of entries per node
+const DefaultMaxEntries = 16
+
+// Share of the entries of an overflowing node that get reinserted (R* forced reinsert)
+const reinsertFactor = 0.3
+
+// Indexed rectangle and the value attached to it
+type Item struct {
+	Rect Rect
+	Data interface{}
+}
+
+type entry struct {
+	rect  Rect
+	child *node
+	item  *Item
+}
+
+type node struct {
+	// 0 for leaves, increasing towards the root
+	level   int
+	entries []entry
+}
+
+// R*-tree of rectangles.
+// All operations are deterministic: the same sequence of calls always
+// produces the same tree and the same query results.
+type RTree struct {
+	root       *node
+	size       int
+	minEntries int
+	maxEntries int
+}
+
+// Creates an empty tree.
+// optional value sets the maximum number of entries per node (default 16, at least 4).
+func New(maxEntries ...int) *RTree {
+	max := DefaultMaxEntries
+	if len(maxEntries) >= 1 && maxEntries[0] >= 4 {
+		max = maxEntries[0]
+	}
+	min := int(math.Ceil(float64(max) * 0.4))
+	return &RTree{root: &node{}, minEntries: min, maxEntries: max}
+}
+
+// Number of items in the tree
+func (t *RTree) Len() int {
+	return t.size
+}
+
+// Bounding rectangle of all items, ok is false for an empty tree
+func (t *RTree) Bounds() (r Rect, ok bool) {
+	if t.size == 0 {
+		return Rect{}, false
+	}
+	return t.root.bounds(), true
+}
+
+func (n *node) bounds() Rect {
+	r := n.entries[0].rect
+	for _, e := range n.entries[1:] {
+		r = r.Union(e.rect)
+	}
+	return r
+}
+
+// Adds a rectangle with its value to the tree
+func (t *RTree) Insert(r Rect, data interface{}) {
+	t.insertEntry(entry{rect: r, item: &Item{r, data}}, 0, map[int]bool{})
+	t.size++
+}
+
+// Inserts e into a node of the given level
+func (t *RTree) insertEntry(e entry, level int, reinserted map[int]bool) {
+	path := []*node{t.root}
+	n := t.root
+	for n.level > level {
+		n = n.entries[chooseSubtree(n, e.rect)].child
+		path = append(path, n)
+	}
+	n.entries = append(n.entries, e)
+
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+		if len(n.entries) <= t.maxEntries {
+			if i > 0 {
+				updateChild(path[i-1], n)
+			}
+			continue
+		}
+		if n != t.root && !reinserted[n.level] {
+			reinserted[n.level] = true
+			removed := t.takeFarthest(n)
+			for j := i; j > 0; j-- {
+				updateChild(path[j-1], path[j])
+			}
+			for _, r := range removed {
+				t.insertEntry(r, n.level, reinserted)
+			}
+			return
+		}
+		sibling := t.split(n)
+		if n == t.root {
+			t.root = &node{level: n.level + 1, entries: []entry{
+				{rect: n.bounds(), child: n},
+				{rect: sibling.bounds(), child: sibling},
+			}}
+			return
+		}
+		parent := path[i-1]
+		updateChild(parent, n)
+		parent.entries = append(parent.entries, entry{rect: sibling.bounds(), child: sibling})
+	}
+}
+
+// Refreshes the rectangle of the parent entry pointing at child
+func updateChild(parent, child *node) {
+	for i := range parent.entries {
+		if parent.entries[i].child == child {
+			parent.entries[i].rect = child.bounds()
+			return
+		}
+	}
+}
+
+// Picks the child of n whose rectangle should hold r.
+// Minimizes overlap enlargement when the children are leaves, area enlargement otherwise.
+func chooseSubtree(n *node, r Rect) int {
+	best := 0
+	bestOverlap, bestEnlarge, bestArea := float32(math.Inf(1)), float32(math.Inf(1)), float32(math.Inf(1))
+	for i, e := range n.entries {
+		grown := e.rect.Union(r)
+		area := e.rect.Area()
+		enlarge := grown.Area() - area
+		var overlap float32
+		if n.level == 1 {
+			for j, o := range n.entries {
+				if j != i {
+					overlap += grown.OverlapArea(o.rect) - e.rect.OverlapArea(o.rect)
+				}
+			}
+		}
+		if overlap < bestOverlap ||
+			(overlap == bestOverlap && enlarge < bestEnlarge) ||
+			(overlap == bestOverlap && enlarge == bestEnlarge && area < bestArea) {
+			best, bestOverlap, bestEnlarge, bestArea = i, overlap, enlarge, area
+		}
+	}
+	return best
+}
+
+// Removes the entries farthest from the center of n and returns them,
+// closest first, for reinsertion
+func (t *RTree) takeFarthest(n *node) []entry {
+	center := n.bounds().Center()
+	sort.SliceStable(n.entries, func(i, j int) bool {
+		return n.entries[i].rect.Center().Dist(center) < n.entries[j].rect.Center().Dist(center)
+	})
+	p := int(math.Ceil(float64(t.maxEntries) * reinsertFactor))
+	keep := len(n.entries) - p
+	removed := make([]entry, p)
+	copy(removed, n.entries[keep:])
+	n.entries = n.entries[:keep]
+	return removed
+}
+
+// Splits an overflowing node with the R* topological split.
+// n keeps the first group, the second group is returned as a new node.
+func (t *RTree) split(n *node) *node {
+	type sorting struct {
+		axis int
+		less func(a, b Rect) bool
+	}
+	sortings := []sorting{
+		{0, func(a, b Rect) bool { return a.Min.X < b.Min.X || (a.Min.X == b.Min.X && a.Max.X < b.Max.X) }},
+		{0, func(a, b Rect) bool { return a.Max.X < b.Max.X || (a.Max.X == b.Max.X && a.Min.X < b.Min.X) }},
+		{1, func(a, b Rect) bool { return a.Min.Y < b.Min.Y || (a.Min.Y == b.Min.Y && a.Max.Y < b.Max.Y) }},
+		{1, func(a, b Rect) bool { return a.Max.Y < b.Max.Y || (a.Max.Y == b.Max.Y && a.Min.Y < b.Min.Y) }},
+	}
+	sorted := func(s sorting) []entry {
+		es := make([]entry, len(n.entries))
+		copy(es, n.entries)
+		sort.SliceStable(es, func(i, j int) bool { return s.less(es[i].rect, es[j].rect) })
+		return es
+	}
+
+	// choose the axis with the smallest sum of margins
+	var margins [2]float32
+	for _, s := range sortings {
+		es := sorted(s)
+		for k := t.minEntries; k <= len(es)-t.minEntries; k++ {
+			margins[s.axis] += groupBounds(es[:k]).Margin() + groupBounds(es[k:]).Margin()
+		}
+	}
+	axis := 0
+	if margins[1] < margins[0] {
+		axis = 1
+	}
+
+	// along that axis, choose the distribution with the least overlap, then least area
+	var best []entry
+	bestK := 0
+	bestOverlap, bestArea := float32(math.Inf(1)), float32(math.Inf(1))
+	for _, s := range sortings {
+		if s.axis != axis {
+			continue
+		}
+		es := sorted(s)
+		for k := t.minEntries; k <= len(es)-t.minEntries; k++ {
+			a, b := groupBounds(es[:k]), groupBounds(es[k:])
+			overlap, area := a.OverlapArea(b), a.Area()+b.Area()
+			if overlap < bestOverlap || (overlap == bestOverlap && area < bestArea) {
+				best, bestK, bestOverlap, bestArea = es, k, overlap, area
+			}
+		}
+	}
+	n.entries = append(n.entries[:0:0], best[:bestK]...)
+	return &node{level: n.level, entries: append([]entry(nil), best[bestK:]...)}
+}
+
+func groupBounds(es []entry) Rect {
+	r := es[0].rect
+	for _, e := range es[1:] {
+		r = r.Union(e.rect)
+	}
+	return r
+}
+
+// Removes an item with the given rectangle and value.
+// Values are compared with ==, so they must be comparable.
+// Returns false if no such item is in the tree.
+func (t *RTree) Delete(r Rect, data interface{}) bool {
+	path, idx := t.findLeaf(t.root, r, data, nil)
+	if path == nil {
+		return false
+	}
+	leaf := path[len(path)-1]
+	leaf.entries = append(leaf.entries[:idx], leaf.entries[idx+1:]...)
+	t.size--
+
+	// condense the tree, collecting entries of underfull nodes
+	var orphans []*node
+	for i := len(path) - 1; i > 0; i-- {
+		n, parent := path[i], path[i-1]
+		if len(n.entries) < t.minEntries {
+			for j := range parent.entries {
+				if parent.entries[j].child == n {
+					parent.entries = append(parent.entries[:j], parent.entries[j+1:]...)
+					break
+				}
+			}
+			orphans = append(orphans, n)
+		} else {
+			updateChild(parent, n)
+		}
+	}
+	for _, o := range orphans {
+		for _, e := range o.entries {
+			t.insertEntry(e, o.level, map[int]bool{})
+		}
+	}
+	for t.root.level > 0 && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	if t.root.level > 0 && len(t.root.entries) == 0 {
+		t.root = &node{}
+	}
+	return true
+}
+
+func (t *RTree) findLeaf(n *node, r Rect, data interface{}, path []*node) ([]*node, int) {
+	path = append(path, n)
+	for i, e := range n.entries {
+		if !e.rect.Contains(r) {
+			continue
+		}
+		if n.level == 0 {
+			if e.rect == r && e.item.Data == data {
+				return path, i
+			}
+			continue
+		}
+		if p, idx := t.findLeaf(e.child, r, data, path); p != nil {
+			return p, idx
+		}
+	}
+	return nil, 0
+}
@@ -0,0 +1,139 @@
+package rtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func randomItems(r *rand.Rand, n int) []Item {
+	items := make([]Item, n)
+	for i := range items {
+		x, y := r.Float32()*1000, r.Float32()*1000
+		w, h := r.Float32()*20, r.Float32()*20
+		items[i] = Item{rect(x, y, x+w, y+h), i}
+	}
+	return items
+}
+
+// Checks the structural invariants of the tree
+func checkTree(t *testing.T, tr *RTree) {
+	t.Helper()
+	count := 0
+	var check func(n *node, isRoot bool)
+	check = func(n *node, isRoot bool) {
+		if !isRoot && (len(n.entries) < tr.minEntries || len(n.entries) > tr.maxEntries) {
+			t.Fatalf("node at level %d has %d entries, want %d..%d", n.level, len(n.entries), tr.minEntries, tr.maxEntries)
+		}
+		for _, e := range n.entries {
+			if n.level == 0 {
+				if e.child != nil || e.item == nil || e.item.Rect != e.rect {
+					t.Fatalf("invalid leaf entry %v", e.rect)
+				}
+				count++
+				continue
+			}
+			if e.child.level != n.level-1 {
+				t.Fatalf("child level %d under level %d", e.child.level, n.level)
+			}
+			if b := e.child.bounds(); b != e.rect {
+				t.Fatalf("entry rect %v, child bounds %v", e.rect, b)
+			}
+			check(e.child, false)
+		}
+	}
+	check(tr.root, true)
+	if count != tr.Len() {
+		t.Fatalf("tree holds %d items, Len() = %d", count, tr.Len())
+	}
+}
+
+func ids(items []Item) []int {
+	out := make([]int, len(items))
+	for i, it := range items {
+		out[i] = it.Data.(int)
+	}
+	sort.Ints(out)
+	return out
+}
+
+func bruteSearch(items []Item, q Rect) []int {
+	var out []int
+	for _, it := range items {
+		if it.Rect.Intersects(q) {
+			out = append(out, it.Data.(int))
+		}
+	}
+	sort.Ints(out)
+	return out
+}
+
+func TestInsert(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, max := range []int{4, 9, 16} {
+		items := randomItems(r, 1000)
+		tr := New(max)
+		for _, it := range items {
+			tr.Insert(it.Rect, it.Data)
+		}
+		checkTree(t, tr)
+		for i := 0; i < 100; i++ {
+			q := randomItems(r, 1)[0].Rect
+			q.Max.X += 50
+			q.Max.Y += 50
+			if got, want := ids(tr.Search(q)), bruteSearch(items, q); !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Fatalf("Search(%v) = %v, want %v", q, got, want)
+			}
+		}
+	}
+}
+
+func TestDelete(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	items := randomItems(r, 800)
+	tr := New(8)
+	for _, it := range items {
+		tr.Insert(it.Rect, it.Data)
+	}
+	r.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+	for i, it := range items {
+		if !tr.Delete(it.Rect, it.Data) {
+			t.Fatalf("Delete(%v, %v) = false, want true", it.Rect, it.Data)
+		}
+		if tr.Delete(it.Rect, it.Data) {
+			t.Fatalf("second Delete(%v, %v) = true, want false", it.Rect, it.Data)
+		}
+		if i%50 == 0 {
+			checkTree(t, tr)
+			q := rect(200, 200, 600, 600)
+			if got, want := ids(tr.Search(q)), bruteSearch(items[i+1:], q); !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Fatalf("Search(%v) after deletes = %v, want %v", q, got, want)
+			}
+		}
+	}
+	if tr.Len() != 0 {
+		t.Errorf("Len() = %v after deleting everything, want 0", tr.Len())
+	}
+	if _, ok := tr.Bounds(); ok {
+		t.Errorf("Bounds() ok = true for empty tree")
+	}
+	tr.Insert(rect(0, 0, 1, 1), "again")
+	checkTree(t, tr)
+}
+
+func TestDeterministic(t *testing.T) {
+	items := randomItems(rand.New(rand.NewSource(3)), 500)
+	build := func() []Item {
+		tr := New()
+		for _, it := range items {
+			tr.Insert(it.Rect, it.Data)
+		}
+		return tr.Search(rect(0, 0, 500, 500))
+	}
+	if a, b := build(), build(); !cmp.Equal(a, b) {
+		t.Errorf("two identical builds returned results in different order")
+	}
+}
@@ -0,0 +1,122 @@
+package rtree
+
+import (
+	"container/heap"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Gives all items whose rectangle intersects r
+func (t *RTree) Search(r Rect) []Item {
+	var found []Item
+	t.walk(t.root, r.Intersects, func(e *entry) bool {
+		if e.rect.Intersects(r) {
+			found = append(found, *e.item)
+		}
+		return true
+	})
+	return found
+}
+
+// Gives all items whose rectangle lies completely inside r
+func (t *RTree) Contained(r Rect) []Item {
+	var found []Item
+	t.walk(t.root, r.Intersects, func(e *entry) bool {
+		if r.Contains(e.rect) {
+			found = append(found, *e.item)
+		}
+		return true
+	})
+	return found
+}
+
+// Gives all items whose rectangle contains the point
+func (t *RTree) Containing(p *vector2d.Vector2D) []Item {
+	pr := PointRect(p)
+	var found []Item
+	t.walk(t.root, func(o Rect) bool { return o.Contains(pr) }, func(e *entry) bool {
+		if e.rect.Contains(pr) {
+			found = append(found, *e.item)
+		}
+		return true
+	})
+	return found
+}
+
+// Calls fn for every item in the tree until fn returns false
+func (t *RTree) Each(fn func(Item) bool) {
+	all := func(Rect) bool { return true }
+	t.walk(t.root, all, func(e *entry) bool { return fn(*e.item) })
+}
+
+// Visits the leaf entries of the subtrees whose rectangle passes descend.
+// Returns false if visit stopped the walk.
+func (t *RTree) walk(n *node, descend func(Rect) bool, visit func(*entry) bool) bool {
+	for i := range n.entries {
+		e := &n.entries[i]
+		if n.level == 0 {
+			if !visit(e) {
+				return false
+			}
+			continue
+		}
+		if descend(e.rect) && !t.walk(e.child, descend, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+type candidate struct {
+	dist  float32
+	entry *entry
+	// insertion counter, breaks distance ties deterministically
+	seq int
+}
+
+type candidateQueue []candidate
+
+func (q candidateQueue) Len() int { return len(q) }
+func (q candidateQueue) Less(i, j int) bool {
+	if q[i].dist != q[j].dist {
+		return q[i].dist < q[j].dist
+	}
+	return q[i].seq < q[j].seq
+}
+func (q candidateQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *candidateQueue) Push(x interface{}) { *q = append(*q, x.(candidate)) }
+func (q *candidateQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// Gives the k items closest to the point, nearest first.
+// Distance is measured from the point to the closest point of each rectangle,
+// so for point items it is the same as Vector2D.Dist.
+func (t *RTree) Nearest(p *vector2d.Vector2D, k int) []Item {
+	if k <= 0 || t.size == 0 {
+		return nil
+	}
+	found := make([]Item, 0, k)
+	q := &candidateQueue{}
+	seq := 0
+	push := func(n *node) {
+		for i := range n.entries {
+			e := &n.entries[i]
+			heap.Push(q, candidate{e.rect.Dist(p), e, seq})
+			seq++
+		}
+	}
+	push(t.root)
+	for q.Len() > 0 && len(found) < k {
+		c := heap.Pop(q).(candidate)
+		if c.entry.child != nil {
+			push(c.entry.child)
+			continue
+		}
+		found = append(found, *c.entry.item)
+	}
+	return found
+}
@@ -0,0 +1,81 @@
+package rtree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestContained(t *testing.T) {
+	tr := New()
+	tr.Insert(rect(1, 1, 2, 2), "inside")
+	tr.Insert(rect(3, 3, 6, 6), "crossing")
+	tr.Insert(rect(7, 7, 8, 8), "outside")
+	q := rect(0, 0, 4, 4)
+	if got := tr.Contained(q); len(got) != 1 || got[0].Data != "inside" {
+		t.Errorf("Contained(%v) = %v, want only inside", q, got)
+	}
+	if got := tr.Search(q); len(got) != 2 {
+		t.Errorf("Search(%v) = %v, want inside and crossing", q, got)
+	}
+	if got := tr.Containing(vector2d.New(5, 5)); len(got) != 1 || got[0].Data != "crossing" {
+		t.Errorf("Containing({5, 5}) = %v, want only crossing", got)
+	}
+}
+
+func TestEach(t *testing.T) {
+	items := randomItems(rand.New(rand.NewSource(5)), 300)
+	tr := BulkLoad(items)
+	var seen []Item
+	tr.Each(func(it Item) bool {
+		seen = append(seen, it)
+		return true
+	})
+	if got := ids(seen); len(got) != 300 || got[0] != 0 || got[299] != 299 {
+		t.Errorf("Each() visited %d items", len(got))
+	}
+	count := 0
+	tr.Each(func(Item) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("Each() kept going after false, visited %d", count)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	items := make([]Item, 2000)
+	points := make([]*vector2d.Vector2D, len(items))
+	tr := New()
+	for i := range items {
+		points[i] = vector2d.New(r.Float32()*100, r.Float32()*100)
+		items[i] = Item{PointRect(points[i]), i}
+		tr.Insert(items[i].Rect, i)
+	}
+	for i := 0; i < 50; i++ {
+		q := vector2d.New(r.Float32()*120-10, r.Float32()*120-10)
+		want := make([]int, len(points))
+		for j := range want {
+			want[j] = j
+		}
+		sort.SliceStable(want, func(a, b int) bool { return points[want[a]].Dist(q) < points[want[b]].Dist(q) })
+		got := tr.Nearest(q, 7)
+		gotDists := make([]float32, len(got))
+		wantDists := make([]float32, len(got))
+		for j := range got {
+			gotDists[j] = points[got[j].Data.(int)].Dist(q)
+			wantDists[j] = points[want[j]].Dist(q)
+		}
+		if len(got) != 7 || !cmp.Equal(gotDists, wantDists) {
+			t.Fatalf("Nearest(%v, 7) distances = %v, want %v", q, gotDists, wantDists)
+		}
+	}
+	if got := New().Nearest(vector2d.New(0, 0), 3); got != nil {
+		t.Errorf("Nearest() on empty tree = %v, want nil", got)
+	}
+}
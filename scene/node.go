@@ -0,0 +1,318 @@
+// Package scene provides transform hierarchies of 2D and 3D nodes. Each node
+// has a translation, rotation and scale relative to its parent, and caches
+// its local and world matrices, which are only recomputed after a node or one
+// of its ancestors changed.
+// https://en.wikipedia.org/wiki/Scene_graph
+package scene
+
+import (
+	"errors"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+var (
+	// A node can not become its own ancestor
+	ErrCycle = errors.New("scene: node would become its own ancestor")
+	// A parent with a zero scale has no local space to keep a world transform in
+	ErrSingular = errors.New("scene: parent transform is not invertible")
+)
+
+// Node of a 3D hierarchy. Its local transform scales, then rotates, then
+// translates in the space of its parent. Make nodes with NewNode.
+type Node struct {
+	translation vector.Vector
+	rotation    quat.Quat
+	scale       vector.Vector
+
+	parent   *Node
+	children []*Node
+
+	local, world, worldInv           mat.Mat4
+	localDirty, worldDirty, invDirty bool
+	invOk                            bool
+}
+
+// Makes a node without parent and with the identity transform
+func NewNode() *Node {
+	return &Node{
+		rotation:   quat.Identity(),
+		scale:      vector.Vector{X: 1, Y: 1, Z: 1},
+		localDirty: true,
+		worldDirty: true,
+		invDirty:   true,
+	}
+}
+
+// Translation relative to the parent
+func (n *Node) Translation() *vector.Vector {
+	return n.translation.Copy()
+}
+
+// Rotation relative to the parent
+func (n *Node) Rotation() quat.Quat {
+	return n.rotation
+}
+
+// Scale along the local axes
+func (n *Node) Scale() *vector.Vector {
+	return n.scale.Copy()
+}
+
+// Sets the translation relative to the parent
+func (n *Node) SetTranslation(t *vector.Vector) *Node {
+	n.translation = *t
+	n.changed()
+	return n
+}
+
+// Sets the rotation relative to the parent, normalizing q
+func (n *Node) SetRotation(q quat.Quat) *Node {
+	n.rotation = q.Normalize()
+	n.changed()
+	return n
+}
+
+// Sets the scale along the local axes
+func (n *Node) SetScale(s *vector.Vector) *Node {
+	n.scale = *s
+	n.changed()
+	return n
+}
+
+// Moves the node by d in the space of its parent
+func (n *Node) Translate(d *vector.Vector) *Node {
+	return n.SetTranslation(vector.Add(&n.translation, d))
+}
+
+// Turns the node by q around its origin, in the space of its parent
+func (n *Node) Rotate(q quat.Quat) *Node {
+	return n.SetRotation(q.Mul(n.rotation))
+}
+
+// Marks the local matrix and the world matrices of the subtree dirty
+func (n *Node) changed() {
+	n.localDirty = true
+	n.invalidate()
+}
+
+// Marks the world matrices of the subtree dirty. A dirty node only has dirty
+// descendants, as computing a world matrix first computes the parent's.
+func (n *Node) invalidate() {
+	if n.worldDirty {
+		return
+	}
+	n.worldDirty, n.invDirty = true, true
+	for _, c := range n.children {
+		c.invalidate()
+	}
+}
+
+// Local transform, T * R * S
+func (n *Node) Local() mat.Mat4 {
+	if n.localDirty {
+		s := n.scale
+		m := n.rotation.Mat3().Mul(mat.Diag3(float64(s.X), float64(s.Y), float64(s.Z)))
+		n.local = mat.Affine4(m, &n.translation)
+		n.localDirty = false
+	}
+	return n.local
+}
+
+// Transform from the space of the node to the world
+func (n *Node) World() mat.Mat4 {
+	if n.worldDirty {
+		if n.parent == nil {
+			n.world = n.Local()
+		} else {
+			n.world = n.parent.World().Mul(n.Local())
+		}
+		n.worldDirty = false
+	}
+	return n.world
+}
+
+// Transform from the world to the space of the node, ok is false if a scale
+// on the way is zero
+func (n *Node) WorldInverse() (inv mat.Mat4, ok bool) {
+	w := n.World()
+	if n.invDirty {
+		n.worldInv, n.invOk = w.Inverse()
+		n.invDirty = false
+	}
+	return n.worldInv, n.invOk
+}
+
+// Origin of the node in the world
+func (n *Node) WorldPosition() *vector.Vector {
+	return n.World().Translation()
+}
+
+// Transforms a point from the space of the node to the world
+func (n *Node) PointToWorld(p *vector.Vector) *vector.Vector {
+	return n.World().MulPoint(p)
+}
+
+// Transforms a world point to the space of the node. Nodes with a zero scale
+// map every point to the origin.
+func (n *Node) PointToLocal(p *vector.Vector) *vector.Vector {
+	inv, ok := n.WorldInverse()
+	if !ok {
+		return vector.New(0, 0, 0)
+	}
+	return inv.MulPoint(p)
+}
+
+// Turns a direction from the space of the node to the world. Directions
+// follow the rotations and the shear of scales but keep their length.
+func (n *Node) DirectionToWorld(d *vector.Vector) *vector.Vector {
+	return n.World().MulDirection(d).Resize(d.Mag())
+}
+
+// Turns a world direction to the space of the node, keeping its length. Nodes
+// with a zero scale map every direction to the zero vector.
+func (n *Node) DirectionToLocal(d *vector.Vector) *vector.Vector {
+	inv, ok := n.WorldInverse()
+	if !ok {
+		return vector.New(0, 0, 0)
+	}
+	return inv.MulDirection(d).Resize(d.Mag())
+}
+
+// Parent of the node, nil for a root
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children of the node, in the order they were added
+func (n *Node) Children() []*Node {
+	return append([]*Node(nil), n.children...)
+}
+
+// Root of the hierarchy of the node
+func (n *Node) Root() *Node {
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n
+}
+
+// Adds a child to the node, keeping the local transform of the child
+func (n *Node) AddChild(c *Node) error {
+	return c.SetParent(n)
+}
+
+// Moves the node under parent, nil to detach it, keeping its local transform
+// so that it moves with the new parent
+func (n *Node) SetParent(parent *Node) error {
+	if parent == n.parent {
+		return nil
+	}
+	for p := parent; p != nil; p = p.parent {
+		if p == n {
+			return ErrCycle
+		}
+	}
+	if n.parent != nil {
+		siblings := n.parent.children
+		for i, c := range siblings {
+			if c == n {
+				n.parent.children = append(siblings[:i:i], siblings[i+1:]...)
+				break
+			}
+		}
+	}
+	n.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, n)
+	}
+	n.invalidate()
+	return nil
+}
+
+// Moves the node under parent, nil to detach it, keeping its world transform.
+// The new local transform is exact unless the node was sheared by rotated
+// non uniform scales, which translation, rotation and scale can not represent.
+func (n *Node) Reparent(parent *Node) error {
+	local := n.World()
+	if parent != nil {
+		inv, ok := parent.WorldInverse()
+		if !ok {
+			return ErrSingular
+		}
+		local = inv.Mul(local)
+	}
+	if err := n.SetParent(parent); err != nil {
+		return err
+	}
+	n.setTransform(local)
+	return nil
+}
+
+// Sets translation, rotation and scale from an affine matrix
+func (n *Node) setTransform(m mat.Mat4) {
+	l := m.Mat3()
+	var s [3]float64
+	for i := range s {
+		s[i] = math.Sqrt(l[0][i]*l[0][i] + l[1][i]*l[1][i] + l[2][i]*l[2][i])
+	}
+	if l.Det() < 0 {
+		s[0] = -s[0]
+	}
+	for i := range s {
+		if s[i] != 0 {
+			for r := 0; r < 3; r++ {
+				l[r][i] /= s[i]
+			}
+		}
+	}
+	n.translation = *m.Translation()
+	n.rotation = quat.FromMat3(l)
+	n.scale = vector.Vector{X: float32(s[0]), Y: float32(s[1]), Z: float32(s[2])}
+	n.changed()
+}
+
+// Calls fn for the node and its descendants, depth first with parents before
+// their children, until fn returns false
+func (n *Node) Walk(fn func(*Node) bool) {
+	n.walk(fn)
+}
+
+func (n *Node) walk(fn func(*Node) bool) bool {
+	if !fn(n) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Calls fn for the node and its descendants level by level until fn returns
+// false
+func (n *Node) WalkBreadthFirst(fn func(*Node) bool) {
+	queue := []*Node{n}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if !fn(c) {
+			return
+		}
+		queue = append(queue, c.children...)
+	}
+}
+
+// Calls fn for the parent of the node, then its parent, up to the root,
+// until fn returns false
+func (n *Node) Ancestors(fn func(*Node) bool) {
+	for p := n.parent; p != nil; p = p.parent {
+		if !fn(p) {
+			return
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package scene
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Node of a 2D hierarchy. Its local transform scales, then rotates
+// counter-clockwise, then translates in the space of its parent. World
+// matrices are 3x3 homogeneous transforms. Make nodes with NewNode2D.
+type Node2D struct {
+	translation vector2d.Vector2D
+	rotation    float64
+	scale       vector2d.Vector2D
+
+	parent   *Node2D
+	children []*Node2D
+
+	local, world, worldInv           mat.Mat3
+	localDirty, worldDirty, invDirty bool
+	invOk                            bool
+}
+
+// Makes a node without parent and with the identity transform
+func NewNode2D() *Node2D {
+	return &Node2D{
+		scale:      vector2d.Vector2D{X: 1, Y: 1},
+		localDirty: true,
+		worldDirty: true,
+		invDirty:   true,
+	}
+}
+
+// Translation relative to the parent
+func (n *Node2D) Translation() *vector2d.Vector2D {
+	return n.translation.Copy()
+}
+
+// Rotation (radians, counter-clockwise) relative to the parent
+func (n *Node2D) Rotation() float64 {
+	return n.rotation
+}
+
+// Scale along the local axes
+func (n *Node2D) Scale() *vector2d.Vector2D {
+	return n.scale.Copy()
+}
+
+// Sets the translation relative to the parent
+func (n *Node2D) SetTranslation(t *vector2d.Vector2D) *Node2D {
+	n.translation = *t
+	n.changed()
+	return n
+}
+
+// Sets the rotation (radians, counter-clockwise) relative to the parent
+func (n *Node2D) SetRotation(angle float64) *Node2D {
+	n.rotation = angle
+	n.changed()
+	return n
+}
+
+// Sets the scale along the local axes
+func (n *Node2D) SetScale(s *vector2d.Vector2D) *Node2D {
+	n.scale = *s
+	n.changed()
+	return n
+}
+
+// Moves the node by d in the space of its parent
+func (n *Node2D) Translate(d *vector2d.Vector2D) *Node2D {
+	return n.SetTranslation(vector2d.Add(&n.translation, d))
+}
+
+// Turns the node by angle (radians, counter-clockwise) around its origin
+func (n *Node2D) Rotate(angle float64) *Node2D {
+	return n.SetRotation(n.rotation + angle)
+}
+
+// Marks the local matrix and the world matrices of the subtree dirty
+func (n *Node2D) changed() {
+	n.localDirty = true
+	n.invalidate()
+}
+
+// Marks the world matrices of the subtree dirty, see Node.invalidate
+func (n *Node2D) invalidate() {
+	if n.worldDirty {
+		return
+	}
+	n.worldDirty, n.invDirty = true, true
+	for _, c := range n.children {
+		c.invalidate()
+	}
+}
+
+// Local transform, T * R * S
+func (n *Node2D) Local() mat.Mat3 {
+	if n.localDirty {
+		s := n.scale
+		m := mat.Rotation2(n.rotation).Mul(mat.Mat2{{float64(s.X), 0}, {0, float64(s.Y)}})
+		n.local = mat.Affine2(m, &n.translation)
+		n.localDirty = false
+	}
+	return n.local
+}
+
+// Transform from the space of the node to the world
+func (n *Node2D) World() mat.Mat3 {
+	if n.worldDirty {
+		if n.parent == nil {
+			n.world = n.Local()
+		} else {
+			n.world = n.parent.World().Mul(n.Local())
+		}
+		n.worldDirty = false
+	}
+	return n.world
+}
+
+// Transform from the world to the space of the node, ok is false if a scale
+// on the way is zero
+func (n *Node2D) WorldInverse() (inv mat.Mat3, ok bool) {
+	w := n.World()
+	if n.invDirty {
+		n.worldInv, n.invOk = w.Inverse()
+		n.invDirty = false
+	}
+	return n.worldInv, n.invOk
+}
+
+// Origin of the node in the world
+func (n *Node2D) WorldPosition() *vector2d.Vector2D {
+	return n.World().Translation2()
+}
+
+// Transforms a point from the space of the node to the world
+func (n *Node2D) PointToWorld(p *vector2d.Vector2D) *vector2d.Vector2D {
+	return n.World().MulPoint2(p)
+}
+
+// Transforms a world point to the space of the node. Nodes with a zero scale
+// map every point to the origin.
+func (n *Node2D) PointToLocal(p *vector2d.Vector2D) *vector2d.Vector2D {
+	inv, ok := n.WorldInverse()
+	if !ok {
+		return vector2d.New(0, 0)
+	}
+	return inv.MulPoint2(p)
+}
+
+// Turns a direction from the space of the node to the world. Directions
+// follow the rotations and the shear of scales but keep their length.
+func (n *Node2D) DirectionToWorld(d *vector2d.Vector2D) *vector2d.Vector2D {
+	return n.World().MulDirection2(d).Resize(d.Mag())
+}
+
+// Turns a world direction to the space of the node, keeping its length. Nodes
+// with a zero scale map every direction to the zero vector.
+func (n *Node2D) DirectionToLocal(d *vector2d.Vector2D) *vector2d.Vector2D {
+	inv, ok := n.WorldInverse()
+	if !ok {
+		return vector2d.New(0, 0)
+	}
+	return inv.MulDirection2(d).Resize(d.Mag())
+}
+
+// Parent of the node, nil for a root
+func (n *Node2D) Parent() *Node2D {
+	return n.parent
+}
+
+// Children of the node, in the order they were added
+func (n *Node2D) Children() []*Node2D {
+	return append([]*Node2D(nil), n.children...)
+}
+
+// Root of the hierarchy of the node
+func (n *Node2D) Root() *Node2D {
+	for n.parent != nil {
+		n = n.parent
+	}
+	return n
+}
+
+// Adds a child to the node, keeping the local transform of the child
+func (n *Node2D) AddChild(c *Node2D) error {
+	return c.SetParent(n)
+}
+
+// Moves the node under parent, nil to detach it, keeping its local transform
+// so that it moves with the new parent
+func (n *Node2D) SetParent(parent *Node2D) error {
+	if parent == n.parent {
+		return nil
+	}
+	for p := parent; p != nil; p = p.parent {
+		if p == n {
+			return ErrCycle
+		}
+	}
+	if n.parent != nil {
+		siblings := n.parent.children
+		for i, c := range siblings {
+			if c == n {
+				n.parent.children = append(siblings[:i:i], siblings[i+1:]...)
+				break
+			}
+		}
+	}
+	n.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, n)
+	}
+	n.invalidate()
+	return nil
+}
+
+// Moves the node under parent, nil to detach it, keeping its world transform.
+// The new local transform is exact unless the node was sheared by rotated
+// non uniform scales, in which case the area and the x axis are kept.
+func (n *Node2D) Reparent(parent *Node2D) error {
+	local := n.World()
+	if parent != nil {
+		inv, ok := parent.WorldInverse()
+		if !ok {
+			return ErrSingular
+		}
+		local = inv.Mul(local)
+	}
+	if err := n.SetParent(parent); err != nil {
+		return err
+	}
+	n.setTransform(local)
+	return nil
+}
+
+// Sets translation, rotation and scale from an affine matrix
+func (n *Node2D) setTransform(m mat.Mat3) {
+	l := m.Mat2()
+	sx := math.Hypot(l[0][0], l[1][0])
+	sy := 0.0
+	if sx != 0 {
+		sy = l.Det() / sx
+	}
+	n.translation = *m.Translation2()
+	n.rotation = math.Atan2(l[1][0], l[0][0])
+	n.scale = vector2d.Vector2D{X: float32(sx), Y: float32(sy)}
+	n.changed()
+}
+
+// Calls fn for the node and its descendants, depth first with parents before
+// their children, until fn returns false
+func (n *Node2D) Walk(fn func(*Node2D) bool) {
+	n.walk(fn)
+}
+
+func (n *Node2D) walk(fn func(*Node2D) bool) bool {
+	if !fn(n) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Calls fn for the node and its descendants level by level until fn returns
+// false
+func (n *Node2D) WalkBreadthFirst(fn func(*Node2D) bool) {
+	queue := []*Node2D{n}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if !fn(c) {
+			return
+		}
+		queue = append(queue, c.children...)
+	}
+}
+
+// Calls fn for the parent of the node, then its parent, up to the root,
+// until fn returns false
+func (n *Node2D) Ancestors(fn func(*Node2D) bool) {
+	for p := n.parent; p != nil; p = p.parent {
+		if !fn(p) {
+			return
+		}
+	}
+}
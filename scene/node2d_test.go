@@ -0,0 +1,110 @@
+package scene
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestNode2DTransform(t *testing.T) {
+	opt := getComparer(1e-5)
+	base := NewNode2D().SetTranslation(vector2d.New(10, 0))
+	arm := NewNode2D().SetRotation(math.Pi / 2).SetScale(vector2d.New(2, 2))
+	tip := NewNode2D().SetTranslation(vector2d.New(1, 0))
+	if err := base.AddChild(arm); err != nil {
+		t.Fatal(err)
+	}
+	if err := arm.AddChild(tip); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tip.WorldPosition(), vector2d.New(10, 2); !cmp.Equal(got, want, opt) {
+		t.Errorf("WorldPosition() = %v, want %v", got, want)
+	}
+	if got, want := tip.PointToWorld(vector2d.New(1, 0)), vector2d.New(10, 4); !cmp.Equal(got, want, opt) {
+		t.Errorf("PointToWorld() = %v, want %v", got, want)
+	}
+	if got, want := tip.PointToLocal(vector2d.New(10, 4)), vector2d.New(1, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("PointToLocal() = %v, want %v", got, want)
+	}
+	if got, want := tip.DirectionToWorld(vector2d.New(3, 0)), vector2d.New(0, 3); !cmp.Equal(got, want, opt) {
+		t.Errorf("DirectionToWorld() = %v, want %v", got, want)
+	}
+	if got, want := tip.DirectionToLocal(vector2d.New(0, 3)), vector2d.New(3, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("DirectionToLocal() = %v, want %v", got, want)
+	}
+	arm.Rotate(math.Pi / 2)
+	base.Translate(vector2d.New(0, 1))
+	if got, want := tip.WorldPosition(), vector2d.New(8, 1); !cmp.Equal(got, want, opt) {
+		t.Errorf("WorldPosition() after moving = %v, want %v", got, want)
+	}
+	// a zero scale flattens the local space
+	flat := NewNode2D().SetScale(vector2d.New(0, 1))
+	if got, want := flat.PointToLocal(vector2d.New(1, 2)), vector2d.New(0, 0); !cmp.Equal(got, want) {
+		t.Errorf("PointToLocal() with a zero scale = %v, want %v", got, want)
+	}
+	if got, want := flat.DirectionToLocal(vector2d.New(1, 2)), vector2d.New(0, 0); !cmp.Equal(got, want) {
+		t.Errorf("DirectionToLocal() with a zero scale = %v, want %v", got, want)
+	}
+}
+
+func TestNode2DReparent(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	opt := getComparer(1e-4)
+	random := func() *Node2D {
+		s := 0.5 + r.Float32()
+		return NewNode2D().SetTranslation(vector2d.New(r.Float32()*4-2, r.Float32()*4-2)).
+			SetRotation(r.Float64() * 2 * math.Pi).SetScale(vector2d.New(s, s))
+	}
+	for i := 0; i < 50; i++ {
+		a, b, n := random(), random(), random()
+		n.SetScale(vector2d.New(1, 3))
+		if err := a.AddChild(n); err != nil {
+			t.Fatal(err)
+		}
+		p := vector2d.New(0.5, -1)
+		world := n.PointToWorld(p)
+		if err := n.Reparent(b); err != nil {
+			t.Fatal(err)
+		}
+		if got := n.PointToWorld(p); !cmp.Equal(got, world, opt) {
+			t.Fatalf("PointToWorld() after Reparent() = %v, want %v", got, world)
+		}
+		if err := b.Reparent(n); err != ErrCycle {
+			t.Fatalf("Reparent(child) = %v, want %v", err, ErrCycle)
+		}
+	}
+	// a mirror keeps its handedness in the scale
+	m := NewNode2D().SetScale(vector2d.New(1, -1))
+	n := NewNode2D().SetRotation(0.5)
+	if err := n.Reparent(m); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := n.DirectionToWorld(vector2d.New(1, 0)), vector2d.New(1, 0).Rotate(0.5); !cmp.Equal(got, want, opt) {
+		t.Errorf("DirectionToWorld() under a mirror = %v, want %v", got, want)
+	}
+	var got []*Node2D
+	m.Walk(func(c *Node2D) bool {
+		got = append(got, c)
+		return true
+	})
+	m.WalkBreadthFirst(func(c *Node2D) bool {
+		got = append(got, c)
+		return true
+	})
+	n.Ancestors(func(c *Node2D) bool {
+		got = append(got, c)
+		return true
+	})
+	want := []*Node2D{m, n, m, n, m}
+	if len(got) != len(want) {
+		t.Fatalf("traversals visited %d nodes, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("traversal visit %d = %p, want %p", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package scene
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/quat"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, tolerance) }),
+	}
+}
+
+func randomNode(r *rand.Rand) *Node {
+	return NewNode().
+		SetTranslation(vector.New(r.Float32()*4-2, r.Float32()*4-2, r.Float32()*4-2)).
+		SetRotation(quat.New(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64())).
+		SetScale(vector.New(0.5+r.Float32(), 0.5+r.Float32(), 0.5+r.Float32()))
+}
+
+func TestNodeTransform(t *testing.T) {
+	opt := getComparer(1e-5)
+	// a wheel on an arm on a base
+	base := NewNode().SetTranslation(vector.New(10, 0, 0))
+	arm := NewNode().SetRotation(quat.FromAxisAngle(vector.New(0, 0, 1), math.Pi/2)).SetScale(vector.New(2, 2, 2))
+	wheel := NewNode().SetTranslation(vector.New(1, 0, 0))
+	if err := base.AddChild(arm); err != nil {
+		t.Fatal(err)
+	}
+	if err := arm.AddChild(wheel); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := wheel.WorldPosition(), vector.New(10, 2, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("WorldPosition() = %v, want %v", got, want)
+	}
+	if got, want := wheel.PointToWorld(vector.New(1, 0, 0)), vector.New(10, 4, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("PointToWorld() = %v, want %v", got, want)
+	}
+	if got, want := wheel.PointToLocal(vector.New(10, 4, 0)), vector.New(1, 0, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("PointToLocal() = %v, want %v", got, want)
+	}
+	if got, want := wheel.DirectionToWorld(vector.New(3, 0, 0)), vector.New(0, 3, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("DirectionToWorld() = %v, want %v", got, want)
+	}
+	if got, want := wheel.DirectionToLocal(vector.New(0, 3, 0)), vector.New(3, 0, 0); !cmp.Equal(got, want, opt) {
+		t.Errorf("DirectionToLocal() = %v, want %v", got, want)
+	}
+	// moving an ancestor moves the subtree
+	base.Translate(vector.New(0, 0, 5))
+	if got, want := wheel.WorldPosition(), vector.New(10, 2, 5); !cmp.Equal(got, want, opt) {
+		t.Errorf("WorldPosition() after moving the base = %v, want %v", got, want)
+	}
+	arm.Rotate(quat.FromAxisAngle(vector.New(0, 0, 1), math.Pi/2))
+	if got, want := wheel.WorldPosition(), vector.New(8, 0, 5); !cmp.Equal(got, want, opt) {
+		t.Errorf("WorldPosition() after turning the arm = %v, want %v", got, want)
+	}
+	// a zero scale flattens the local space
+	flat := NewNode().SetScale(vector.New(0, 1, 1))
+	if got, want := flat.PointToLocal(vector.New(1, 2, 3)), vector.New(0, 0, 0); !cmp.Equal(got, want) {
+		t.Errorf("PointToLocal() with a zero scale = %v, want %v", got, want)
+	}
+	if got, want := flat.DirectionToLocal(vector.New(1, 2, 3)), vector.New(0, 0, 0); !cmp.Equal(got, want) {
+		t.Errorf("DirectionToLocal() with a zero scale = %v, want %v", got, want)
+	}
+}
+
+func TestNodeWorldCache(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	nodes := []*Node{randomNode(r)}
+	for i := 1; i < 50; i++ {
+		n := randomNode(r)
+		if err := nodes[r.Intn(i)].AddChild(n); err != nil {
+			t.Fatal(err)
+		}
+		nodes = append(nodes, n)
+	}
+	for step := 0; step < 200; step++ {
+		n := nodes[r.Intn(len(nodes))]
+		switch r.Intn(3) {
+		case 0:
+			n.Translate(vector.New(r.Float32(), r.Float32(), r.Float32()))
+		case 1:
+			n.Rotate(quat.FromAxisAngle(vector.New(r.Float32(), r.Float32(), 1), r.Float64()))
+		default:
+			// read a few world matrices to cache them
+			for i := 0; i < 5; i++ {
+				nodes[r.Intn(len(nodes))].World()
+			}
+		}
+		for _, n := range nodes {
+			// the product of the local transforms up to the root
+			want := n.Local()
+			n.Ancestors(func(p *Node) bool {
+				want = p.Local().Mul(want)
+				return true
+			})
+			if got := n.World(); !got.Equal(want, 1e-9) {
+				t.Fatalf("step %d: World() = %v, want %v", step, got, want)
+			}
+			if !n.worldDirty && n.parent != nil && n.parent.worldDirty {
+				t.Fatalf("step %d: clean node under a dirty parent", step)
+			}
+		}
+	}
+}
+
+func TestNodeReparent(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	opt := getComparer(1e-4)
+	for i := 0; i < 50; i++ {
+		a, b, n := randomNode(r), randomNode(r), NewNode()
+		// uniform scales keep the reparented transform exact
+		s, s2 := 0.5+r.Float32(), 0.5+r.Float32()
+		a.SetScale(vector.New(s, s, s))
+		b.SetScale(vector.New(s2, s2, s2))
+		n.SetTranslation(vector.New(1, 2, 3)).SetRotation(quat.FromAxisAngle(vector.New(1, 1, 0), 0.3)).SetScale(vector.New(1, 2, 3))
+		if err := a.AddChild(n); err != nil {
+			t.Fatal(err)
+		}
+		p := vector.New(0.5, -1, 2)
+		world := n.PointToWorld(p)
+		if err := n.Reparent(b); err != nil {
+			t.Fatal(err)
+		}
+		if n.Parent() != b || len(a.Children()) != 0 || b.Children()[0] != n {
+			t.Fatalf("Reparent() did not move the node")
+		}
+		if got := n.PointToWorld(p); !cmp.Equal(got, world, opt) {
+			t.Fatalf("PointToWorld() after Reparent() = %v, want %v", got, world)
+		}
+		if err := n.Reparent(nil); err != nil || n.Parent() != nil {
+			t.Fatalf("Reparent(nil) = %v", err)
+		}
+		if got := n.PointToWorld(p); !cmp.Equal(got, world, opt) {
+			t.Fatalf("PointToWorld() after Reparent(nil) = %v, want %v", got, world)
+		}
+	}
+	// a mirrored parent
+	a := NewNode().SetScale(vector.New(-1, 2, 2))
+	n := NewNode().SetTranslation(vector.New(1, 0, 0))
+	if err := n.Reparent(a); err != nil {
+		t.Fatal(err)
+	}
+	if got := n.WorldPosition(); !cmp.Equal(got, vector.New(1, 0, 0), opt) {
+		t.Errorf("WorldPosition() under a mirror = %v", got)
+	}
+	if err := n.Reparent(NewNode().SetScale(vector.New(0, 1, 1))); err != ErrSingular {
+		t.Errorf("Reparent() under a zero scale = %v, want %v", err, ErrSingular)
+	}
+	// setting the parent keeps the local transform instead
+	if err := n.SetParent(NewNode().SetTranslation(vector.New(0, 5, 0))); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := n.WorldPosition(), vector.Add(n.Translation(), vector.New(0, 5, 0)); !cmp.Equal(got, want, opt) {
+		t.Errorf("WorldPosition() after SetParent() = %v, want %v", got, want)
+	}
+}
+
+func TestNodeTraversal(t *testing.T) {
+	// root
+	//  - a
+	//    - c
+	//    - d
+	//  - b
+	//    - e
+	nodes := map[string]*Node{}
+	names := map[*Node]string{}
+	for _, name := range []string{"root", "a", "b", "c", "d", "e"} {
+		nodes[name] = NewNode()
+		names[nodes[name]] = name
+	}
+	for _, e := range [][2]string{{"root", "a"}, {"root", "b"}, {"a", "c"}, {"a", "d"}, {"b", "e"}} {
+		if err := nodes[e[0]].AddChild(nodes[e[1]]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	collect := func(walk func(func(*Node) bool), limit int) []string {
+		var got []string
+		walk(func(n *Node) bool {
+			got = append(got, names[n])
+			return len(got) < limit
+		})
+		return got
+	}
+	root := nodes["root"]
+	if diff := cmp.Diff([]string{"root", "a", "c", "d", "b", "e"}, collect(root.Walk, 10)); diff != "" {
+		t.Errorf("Walk() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"root", "a", "c"}, collect(root.Walk, 3)); diff != "" {
+		t.Errorf("stopped Walk() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"root", "a", "b", "c", "d", "e"}, collect(root.WalkBreadthFirst, 10)); diff != "" {
+		t.Errorf("WalkBreadthFirst() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"a", "root"}, collect(nodes["d"].Ancestors, 10)); diff != "" {
+		t.Errorf("Ancestors() mismatch (-want +got):\n%s", diff)
+	}
+	if nodes["e"].Root() != root {
+		t.Errorf("Root() = %v", names[nodes["e"].Root()])
+	}
+	if err := nodes["a"].SetParent(nodes["d"]); err != ErrCycle {
+		t.Errorf("SetParent(descendant) = %v, want %v", err, ErrCycle)
+	}
+	if err := root.AddChild(root); err != ErrCycle {
+		t.Errorf("AddChild(self) = %v, want %v", err, ErrCycle)
+	}
+	if err := nodes["c"].SetParent(nil); err != nil || nodes["c"].Parent() != nil {
+		t.Errorf("SetParent(nil) = %v", err)
+	}
+	if diff := cmp.Diff([]string{"root", "a", "d", "b", "e"}, collect(root.Walk, 10)); diff != "" {
+		t.Errorf("Walk() after detaching mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,172 @@
+// Package stats provides aggregate operations over sets of vectors:
+// centroid, covariance, principal components and least squares fits.
+package stats
+
+import (
+	"errors"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Returned when a fit is asked for fewer points than it needs
+var ErrTooFewPoints = errors.New("stats: not enough points")
+
+// Kahan compensated running sum
+// https://en.wikipedia.org/wiki/Kahan_summation_algorithm
+type kahan struct {
+	sum, c float64
+}
+
+func (k *kahan) add(x float64) {
+	y := x - k.c
+	t := k.sum + y
+	k.c = (t - k.sum) - y
+	k.sum = t
+}
+
+// Gives the mean of the points, using compensated summation to limit
+// rounding errors on large sets. Returns a zero vector for no points.
+func Centroid(ps []*vector.Vector) *vector.Vector {
+	x, y, z := Mean(ps)
+	return vector.New(float32(x), float32(y), float32(z))
+}
+
+// Gives the mean of the points in double precision, see Centroid
+func Mean(ps []*vector.Vector) (x, y, z float64) {
+	if len(ps) == 0 {
+		return 0, 0, 0
+	}
+	var sx, sy, sz kahan
+	for _, p := range ps {
+		sx.add(float64(p.X))
+		sy.add(float64(p.Y))
+		sz.add(float64(p.Z))
+	}
+	n := float64(len(ps))
+	return sx.sum / n, sy.sum / n, sz.sum / n
+}
+
+// Gives the 3x3 population covariance matrix of the points (divided by n).
+// Returns a zero matrix for no points.
+func Covariance(ps []*vector.Vector) mat.Mat3 {
+	var cov mat.Mat3
+	if len(ps) == 0 {
+		return cov
+	}
+	mx, my, mz := Mean(ps)
+	var s [3][3]kahan
+	for _, p := range ps {
+		d := [3]float64{float64(p.X) - mx, float64(p.Y) - my, float64(p.Z) - mz}
+		for i := 0; i < 3; i++ {
+			for j := i; j < 3; j++ {
+				s[i][j].add(d[i] * d[j])
+			}
+		}
+	}
+	n := float64(len(ps))
+	for i := 0; i < 3; i++ {
+		for j := i; j < 3; j++ {
+			cov[i][j] = s[i][j].sum / n
+			cov[j][i] = cov[i][j]
+		}
+	}
+	return cov
+}
+
+// Principal component analysis of a point set
+type PCA struct {
+	Mean *vector.Vector
+	// Unit principal axes, by decreasing variance. They form a right handed frame.
+	Axes [3]*vector.Vector
+	// Variance of the points along each axis
+	Variances [3]float64
+}
+
+// Computes the principal components of the points from the eigen-decomposition
+// of their covariance matrix
+func PrincipalComponents(ps []*vector.Vector) (PCA, error) {
+	if len(ps) == 0 {
+		return PCA{}, ErrTooFewPoints
+	}
+	values, vectors := Covariance(ps).SymEigen()
+	pca := PCA{Mean: Centroid(ps), Variances: values}
+	for i := range pca.Axes {
+		pca.Axes[i] = vectors.Col(i)
+	}
+	return pca, nil
+}
+
+// Least squares line through the points, minimizing the squared
+// perpendicular distances. Needs at least 2 points.
+func FitLine(ps []*vector.Vector) (point, direction *vector.Vector, err error) {
+	if len(ps) < 2 {
+		return nil, nil, ErrTooFewPoints
+	}
+	pca, _ := PrincipalComponents(ps)
+	return pca.Mean, pca.Axes[0], nil
+}
+
+// Least squares plane through the points, minimizing the squared
+// perpendicular distances. Needs at least 3 points.
+func FitPlane(ps []*vector.Vector) (point, normal *vector.Vector, err error) {
+	if len(ps) < 3 {
+		return nil, nil, ErrTooFewPoints
+	}
+	pca, _ := PrincipalComponents(ps)
+	return pca.Mean, pca.Axes[2], nil
+}
+
+// Oriented bounding box
+type OBB struct {
+	Center *vector.Vector
+	// Unit axes of the box
+	Axes [3]*vector.Vector
+	// Half of the size of the box along each axis
+	HalfExtents *vector.Vector
+}
+
+// Gives a box aligned with the principal axes of the points that contains them all
+func OrientedBoundingBox(ps []*vector.Vector) (OBB, error) {
+	pca, err := PrincipalComponents(ps)
+	if err != nil {
+		return OBB{}, err
+	}
+	lo := [3]float64{math.Inf(1), math.Inf(1), math.Inf(1)}
+	hi := [3]float64{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+	for _, p := range ps {
+		d := vector.Sub(p, pca.Mean)
+		for i, a := range pca.Axes {
+			x := float64(vector.Dot(d, a))
+			lo[i] = math.Min(lo[i], x)
+			hi[i] = math.Max(hi[i], x)
+		}
+	}
+	center := pca.Mean.Copy()
+	for i, a := range pca.Axes {
+		center.Add(a.Copy().Mult(float32((lo[i] + hi[i]) / 2)))
+	}
+	return OBB{
+		Center:      center,
+		Axes:        pca.Axes,
+		HalfExtents: vector.New(float32((hi[0]-lo[0])/2), float32((hi[1]-lo[1])/2), float32((hi[2]-lo[2])/2)),
+	}, nil
+}
+
+// Checks whether the point is inside the box.
+// optional tolerance value grows the box on every side.
+func (b OBB) Contains(p *vector.Vector, tolerance ...float32) bool {
+	var t float32 = 0
+	if len(tolerance) >= 1 {
+		t = tolerance[0]
+	}
+	d := vector.Sub(p, b.Center)
+	ext := [3]float32{b.HalfExtents.X, b.HalfExtents.Y, b.HalfExtents.Z}
+	for i, a := range b.Axes {
+		if math.Abs(float64(vector.Dot(d, a))) > float64(ext[i]+t) {
+			return false
+		}
+	}
+	return true
+}
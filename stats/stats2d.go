@@ -0,0 +1,130 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Gives the mean of the 2D points, see Centroid
+func Centroid2D(ps []*vector2d.Vector2D) *vector2d.Vector2D {
+	x, y := Mean2D(ps)
+	return vector2d.New(float32(x), float32(y))
+}
+
+// Gives the mean of the points in double precision, see Centroid2D
+func Mean2D(ps []*vector2d.Vector2D) (x, y float64) {
+	if len(ps) == 0 {
+		return 0, 0
+	}
+	var sx, sy kahan
+	for _, p := range ps {
+		sx.add(float64(p.X))
+		sy.add(float64(p.Y))
+	}
+	n := float64(len(ps))
+	return sx.sum / n, sy.sum / n
+}
+
+// Gives the 2x2 population covariance matrix of the points, see Covariance
+func Covariance2D(ps []*vector2d.Vector2D) mat.Mat2 {
+	var cov mat.Mat2
+	if len(ps) == 0 {
+		return cov
+	}
+	mx, my := Mean2D(ps)
+	var sxx, sxy, syy kahan
+	for _, p := range ps {
+		dx, dy := float64(p.X)-mx, float64(p.Y)-my
+		sxx.add(dx * dx)
+		sxy.add(dx * dy)
+		syy.add(dy * dy)
+	}
+	n := float64(len(ps))
+	return mat.Mat2{{sxx.sum / n, sxy.sum / n}, {sxy.sum / n, syy.sum / n}}
+}
+
+// Principal component analysis of a 2D point set
+type PCA2D struct {
+	Mean *vector2d.Vector2D
+	// Unit principal axes, by decreasing variance. The second is the first rotated by +90°.
+	Axes [2]*vector2d.Vector2D
+	// Variance of the points along each axis
+	Variances [2]float64
+}
+
+// Computes the principal components of the 2D points, see PrincipalComponents
+func PrincipalComponents2D(ps []*vector2d.Vector2D) (PCA2D, error) {
+	if len(ps) == 0 {
+		return PCA2D{}, ErrTooFewPoints
+	}
+	values, vectors := Covariance2D(ps).SymEigen()
+	return PCA2D{
+		Mean:      Centroid2D(ps),
+		Axes:      [2]*vector2d.Vector2D{vectors.Col(0), vectors.Col(1)},
+		Variances: values,
+	}, nil
+}
+
+// Least squares line through the 2D points, see FitLine
+func FitLine2D(ps []*vector2d.Vector2D) (point, direction *vector2d.Vector2D, err error) {
+	if len(ps) < 2 {
+		return nil, nil, ErrTooFewPoints
+	}
+	pca, _ := PrincipalComponents2D(ps)
+	return pca.Mean, pca.Axes[0], nil
+}
+
+// Oriented bounding rectangle
+type OBB2D struct {
+	Center *vector2d.Vector2D
+	// Unit axes of the box
+	Axes [2]*vector2d.Vector2D
+	// Half of the size of the box along each axis
+	HalfExtents *vector2d.Vector2D
+}
+
+// Gives a rectangle aligned with the principal axes of the points that contains them all
+func OrientedBoundingBox2D(ps []*vector2d.Vector2D) (OBB2D, error) {
+	pca, err := PrincipalComponents2D(ps)
+	if err != nil {
+		return OBB2D{}, err
+	}
+	lo := [2]float64{math.Inf(1), math.Inf(1)}
+	hi := [2]float64{math.Inf(-1), math.Inf(-1)}
+	for _, p := range ps {
+		d := vector2d.Sub(p, pca.Mean)
+		for i, a := range pca.Axes {
+			x := float64(d.Dot(a))
+			lo[i] = math.Min(lo[i], x)
+			hi[i] = math.Max(hi[i], x)
+		}
+	}
+	center := pca.Mean.Copy()
+	for i, a := range pca.Axes {
+		center.Add(a.Copy().Mult(float32((lo[i] + hi[i]) / 2)))
+	}
+	return OBB2D{
+		Center:      center,
+		Axes:        pca.Axes,
+		HalfExtents: vector2d.New(float32((hi[0]-lo[0])/2), float32((hi[1]-lo[1])/2)),
+	}, nil
+}
+
+// Checks whether the point is inside the rectangle.
+// optional tolerance value grows the rectangle on every side.
+func (b OBB2D) Contains(p *vector2d.Vector2D, tolerance ...float32) bool {
+	var t float32 = 0
+	if len(tolerance) >= 1 {
+		t = tolerance[0]
+	}
+	d := vector2d.Sub(p, b.Center)
+	ext := [2]float32{b.HalfExtents.X, b.HalfExtents.Y}
+	for i, a := range b.Axes {
+		if math.Abs(float64(d.Dot(a))) > float64(ext[i]+t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func sameLine2D(a, b *vector2d.Vector2D) bool {
+	return math.Abs(math.Abs(float64(a.Dot(b)))-1) < 1e-4
+}
+
+func TestCentroid2D(t *testing.T) {
+	opt := getComparer(1e-6)
+	ps := []*vector2d.Vector2D{vector2d.New(0, 0), vector2d.New(4, 2), vector2d.New(2, 1)}
+	if got, want := Centroid2D(ps), vector2d.New(2, 1); !cmp.Equal(got, want, opt) {
+		t.Errorf("Centroid2D() = %v, want %v", got, want)
+	}
+	if got := Centroid2D(nil); !cmp.Equal(got, vector2d.New(0, 0)) {
+		t.Errorf("Centroid2D(nil) = %v", got)
+	}
+}
+
+func TestCovariance2D(t *testing.T) {
+	ps := []*vector2d.Vector2D{vector2d.New(1, 1), vector2d.New(-1, -1), vector2d.New(1, -1), vector2d.New(-1, 1)}
+	if got := Covariance2D(ps); !got.Equal(mat.Identity2(), 1e-12) {
+		t.Errorf("Covariance2D() = %v, want identity", got)
+	}
+	ps = []*vector2d.Vector2D{vector2d.New(0, 0), vector2d.New(2, 4)}
+	if got, want := Covariance2D(ps), (mat.Mat2{{1, 2}, {2, 4}}); !got.Equal(want, 1e-12) {
+		t.Errorf("Covariance2D() = %v, want %v", got, want)
+	}
+}
+
+func TestFitLine2D(t *testing.T) {
+	ps := []*vector2d.Vector2D{vector2d.New(0, 1), vector2d.New(1, 3), vector2d.New(2, 5)}
+	point, dir, err := FitLine2D(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameLine2D(dir, vector2d.Unit(vector2d.New(1, 2))) || !point.Equal(vector2d.New(1, 3), 1e-5) {
+		t.Errorf("FitLine2D() = %v, %v", point, dir)
+	}
+	if _, _, err := FitLine2D(ps[:1]); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("FitLine2D(1 point) error = %v", err)
+	}
+}
+
+func TestOrientedBoundingBox2D(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	a0 := vector2d.FromAngle(0.5)
+	a1 := vector2d.FromAngle(0.5 + math.Pi/2)
+	var ps []*vector2d.Vector2D
+	for i := 0; i < 5000; i++ {
+		p := vector2d.New(-3, 8)
+		p.Add(a0.Copy().Mult(r.Float32()*10 - 5)).Add(a1.Copy().Mult(r.Float32()*2 - 1))
+		ps = append(ps, p)
+	}
+	box, err := OrientedBoundingBox2D(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sampling noise tilts the axes slightly
+	if math.Abs(float64(box.Axes[0].Dot(a0))) < 0.999 || math.Abs(float64(box.Axes[1].Dot(a1))) < 0.999 {
+		t.Errorf("axes = %v, want along %v and %v", box.Axes, a0, a1)
+	}
+	if !box.HalfExtents.Equal(vector2d.New(5, 1), 0.1) {
+		t.Errorf("HalfExtents = %v, want about {5, 1}", box.HalfExtents)
+	}
+	for _, p := range ps {
+		if !box.Contains(p, 1e-4) {
+			t.Fatalf("box does not contain %v", p)
+		}
+	}
+	if _, err := OrientedBoundingBox2D(nil); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("OrientedBoundingBox2D(nil) error = %v", err)
+	}
+}
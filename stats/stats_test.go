@@ -0,0 +1,172 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/mat"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Comparer(func(x, y float32) bool {
+		diff := math.Abs(float64(x - y))
+		return diff <= tolerance
+	})
+}
+
+// Checks that two unit vectors point along the same line
+func sameLine(a, b *vector.Vector) bool {
+	return math.Abs(math.Abs(float64(vector.Dot(a, b)))-1) < 1e-4
+}
+
+func TestCentroid(t *testing.T) {
+	opt := getComparer(1e-6)
+	tests := []struct {
+		ps   []*vector.Vector
+		want *vector.Vector
+	}{
+		{nil, vector.New(0, 0, 0)},
+		{[]*vector.Vector{vector.New(1, 2, 3)}, vector.New(1, 2, 3)},
+		{[]*vector.Vector{vector.New(0, 0, 0), vector.New(2, 4, 6), vector.New(1, -1, 0)}, vector.New(1, 1, 2)},
+	}
+	for _, test := range tests {
+		if got := Centroid(test.ps); !cmp.Equal(got, test.want, opt) {
+			t.Errorf("Centroid(%v) = %v, want %v", test.ps, got, test.want)
+		}
+	}
+}
+
+func TestCentroidCompensated(t *testing.T) {
+	// a naive float32 running sum drifts badly on this set
+	ps := make([]*vector.Vector, 1000000)
+	for i := range ps {
+		ps[i] = vector.New(10000.1, 0.1, -3)
+	}
+	opt := getComparer(1e-3)
+	if got, want := Centroid(ps), vector.New(10000.1, 0.1, -3); !cmp.Equal(got, want, opt) {
+		t.Errorf("Centroid() = %v, want %v", got, want)
+	}
+}
+
+func TestCovariance(t *testing.T) {
+	ps := []*vector.Vector{vector.New(1, 0, 0), vector.New(-1, 0, 0), vector.New(0, 2, 0), vector.New(0, -2, 0)}
+	want := mat.Diag3(0.5, 2, 0)
+	if got := Covariance(ps); !got.Equal(want, 1e-12) {
+		t.Errorf("Covariance() = %v, want %v", got, want)
+	}
+	ps = []*vector.Vector{vector.New(0, 0, 0), vector.New(1, 1, 1), vector.New(2, 2, 2)}
+	w := 2.0 / 3
+	want = mat.Mat3{{w, w, w}, {w, w, w}, {w, w, w}}
+	if got := Covariance(ps); !got.Equal(want, 1e-12) {
+		t.Errorf("Covariance() = %v, want %v", got, want)
+	}
+}
+
+func TestPrincipalComponents(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	axis := vector.New(1, 2, 2).Normalize()
+	var ps []*vector.Vector
+	for i := 0; i < 500; i++ {
+		p := axis.Copy().Mult(float32(r.NormFloat64() * 10))
+		p.Add(vector.New(float32(r.NormFloat64()*0.1), float32(r.NormFloat64()*0.1), float32(r.NormFloat64()*0.1)))
+		ps = append(ps, p.Add(vector.New(5, 5, 5)))
+	}
+	pca, err := PrincipalComponents(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameLine(pca.Axes[0], axis) {
+		t.Errorf("first axis = %v, want along %v", pca.Axes[0], axis)
+	}
+	if pca.Variances[0] < 50 || pca.Variances[1] > 0.1 {
+		t.Errorf("variances = %v", pca.Variances)
+	}
+	if d := vector.Dot(vector.Cross(pca.Axes[0], pca.Axes[1]), pca.Axes[2]); math.Abs(float64(d)-1) > 1e-5 {
+		t.Errorf("axes are not a right handed frame")
+	}
+	if _, err := PrincipalComponents(nil); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("PrincipalComponents(nil) error = %v", err)
+	}
+}
+
+func TestFitLine(t *testing.T) {
+	ps := []*vector.Vector{vector.New(0, 1, 2), vector.New(1, 2, 3), vector.New(2, 3, 4), vector.New(3, 4, 5)}
+	point, dir, err := FitLine(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameLine(dir, vector.New(1, 1, 1).Normalize()) {
+		t.Errorf("FitLine() direction = %v", dir)
+	}
+	if !point.Equal(vector.New(1.5, 2.5, 3.5), 1e-5) {
+		t.Errorf("FitLine() point = %v", point)
+	}
+	if _, _, err := FitLine(ps[:1]); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("FitLine(1 point) error = %v", err)
+	}
+}
+
+func TestFitPlane(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	normal := vector.New(1, -1, 0.5).Normalize()
+	u := vector.Cross(normal, vector.New(0, 0, 1)).Normalize()
+	v := vector.Cross(normal, u)
+	origin := vector.New(3, -2, 7)
+	var ps []*vector.Vector
+	for i := 0; i < 200; i++ {
+		p := origin.Copy()
+		p.Add(u.Copy().Mult(r.Float32()*10 - 5)).Add(v.Copy().Mult(r.Float32()*10 - 5))
+		ps = append(ps, p)
+	}
+	point, n, err := FitPlane(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sameLine(n, normal) {
+		t.Errorf("FitPlane() normal = %v, want along %v", n, normal)
+	}
+	if d := vector.Dot(vector.Sub(point, origin), normal); math.Abs(float64(d)) > 1e-4 {
+		t.Errorf("FitPlane() point %v is %v off the plane", point, d)
+	}
+	if _, _, err := FitPlane(ps[:2]); !errors.Is(err, ErrTooFewPoints) {
+		t.Errorf("FitPlane(2 points) error = %v", err)
+	}
+}
+
+func TestOrientedBoundingBox(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	// a rotated 10x4x1 box
+	axes := [3]*vector.Vector{vector.New(1, 1, 0).Normalize(), vector.New(-1, 1, 0).Normalize(), vector.New(0, 0, 1)}
+	var ps []*vector.Vector
+	for i := 0; i < 2000; i++ {
+		p := vector.New(1, 2, 3)
+		p.Add(axes[0].Copy().Mult(r.Float32()*10 - 5))
+		p.Add(axes[1].Copy().Mult(r.Float32()*4 - 2))
+		p.Add(axes[2].Copy().Mult(r.Float32()*1 - 0.5))
+		ps = append(ps, p)
+	}
+	box, err := OrientedBoundingBox(ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range axes {
+		if !sameLine(box.Axes[i], axes[i]) {
+			t.Errorf("axis %d = %v, want along %v", i, box.Axes[i], axes[i])
+		}
+	}
+	if !box.HalfExtents.Equal(vector.New(5, 2, 0.5), 0.05) {
+		t.Errorf("HalfExtents = %v, want about {5, 2, 0.5}", box.HalfExtents)
+	}
+	for _, p := range ps {
+		if !box.Contains(p, 1e-4) {
+			t.Fatalf("box does not contain %v", p)
+		}
+	}
+	if box.Contains(vector.New(1, 2, 4)) {
+		t.Errorf("box contains a point above it")
+	}
+}
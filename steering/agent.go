@@ -0,0 +1,173 @@
+// Package steering provides Reynolds steering behaviors and boids flocking for
+// agents moving in 2D (vector2d) and 3D (vector).
+// Each behavior gives a steering force limited to the agent's MaxForce; forces
+// are combined with Blend and applied with Update.
+// https://www.red3d.com/cwr/steer/
+package steering
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Steering force with its weight in a blend
+type Weighted struct {
+	Force  *vector.Vector
+	Weight float32
+}
+
+// Spherical obstacle
+type Obstacle struct {
+	Center *vector.Vector
+	Radius float32
+}
+
+// Polyline to follow, with the distance from it the agent may drift
+type Path struct {
+	Points []*vector.Vector
+	Radius float32
+}
+
+// Vehicle moving in 3D
+type Agent struct {
+	Position, Velocity *vector.Vector
+	// Largest speed and largest steering force
+	MaxSpeed, MaxForce float32
+	// Mass dividing the steering force, 0 is taken as 1
+	Mass float32
+	// Radius of the agent for obstacle avoidance
+	Radius float32
+	// Wander target on a sphere of WanderRadius at WanderDistance ahead,
+	// moved by up to WanderJitter each call
+	WanderDistance, WanderRadius, WanderJitter float32
+	// Source of randomness for Wander (default seeded from time)
+	Rand *rand.Rand
+
+	wander p3
+}
+
+// Makes an agent, the vectors are copied.
+// Wandering defaults to a sphere of radius 1 at distance 2 ahead with a jitter of 0.2.
+func NewAgent(position, velocity *vector.Vector, maxSpeed, maxForce float32) *Agent {
+	return &Agent{
+		Position:       position.Copy(),
+		Velocity:       velocity.Copy(),
+		MaxSpeed:       maxSpeed,
+		MaxForce:       maxForce,
+		WanderDistance: 2,
+		WanderRadius:   1,
+		WanderJitter:   0.2,
+	}
+}
+
+func from3(v *vector.Vector) p3 { return p3{float64(v.X), float64(v.Y), float64(v.Z)} }
+func (a p3) vector() *vector.Vector {
+	return vector.New(float32(a[0]), float32(a[1]), float32(a[2]))
+}
+
+func (a *Agent) body() body {
+	return body{pos: from3(a.Position), vel: from3(a.Velocity), maxSpeed: float64(a.MaxSpeed), maxForce: float64(a.MaxForce)}
+}
+
+// Bodies of the neighbors, without the agent itself
+func (a *Agent) others(neighbors []*Agent) []body {
+	bs := make([]body, 0, len(neighbors))
+	for _, n := range neighbors {
+		if n != a {
+			bs = append(bs, n.body())
+		}
+	}
+	return bs
+}
+
+// Applies the steering force for dt: the velocity is limited to MaxSpeed
+// and the position moved with the new velocity.
+// Modify + Returns self
+func (a *Agent) Update(force *vector.Vector, dt float32) *Agent {
+	m := a.Mass
+	if m <= 0 {
+		m = 1
+	}
+	a.Velocity.Add(force.Copy().Mult(dt / m)).Limit(a.MaxSpeed)
+	a.Position.Add(a.Velocity.Copy().Mult(dt))
+	return a
+}
+
+// Weighted sum of steering forces, limited to MaxForce
+func (a *Agent) Blend(forces ...Weighted) *vector.Vector {
+	sum := vector.New(0, 0, 0)
+	for _, f := range forces {
+		sum.Add(f.Force.Copy().Mult(f.Weight))
+	}
+	return sum.Limit(a.MaxForce)
+}
+
+// Steers straight towards the target at full speed
+func (a *Agent) Seek(target *vector.Vector) *vector.Vector {
+	return a.body().seek(from3(target)).vector()
+}
+
+// Steers straight away from the target at full speed
+func (a *Agent) Flee(target *vector.Vector) *vector.Vector {
+	return a.body().flee(from3(target)).vector()
+}
+
+// Seeks the target, slowing down linearly within slowRadius to stop on it
+func (a *Agent) Arrive(target *vector.Vector, slowRadius float32) *vector.Vector {
+	return a.body().arrive(from3(target), float64(slowRadius)).vector()
+}
+
+// Seeks where the target will be, predicted from its velocity
+func (a *Agent) Pursue(target *Agent) *vector.Vector {
+	return a.body().pursue(target.body()).vector()
+}
+
+// Flees from where the target will be, predicted from its velocity
+func (a *Agent) Evade(target *Agent) *vector.Vector {
+	return a.body().evade(target.body()).vector()
+}
+
+// Seeks a target that drifts randomly on a sphere ahead of the agent
+func (a *Agent) Wander() *vector.Vector {
+	if a.Rand == nil {
+		a.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return a.body().wander(&a.wander, a.Rand, float64(a.WanderRadius), float64(a.WanderDistance), float64(a.WanderJitter), false).vector()
+}
+
+// Keeps the agent within the path radius: when the position predicted lookahead
+// ahead is out of the path, seeks the closest path point moved lookahead along it
+func (a *Agent) FollowPath(path Path, lookahead float32) *vector.Vector {
+	points := make([]p3, len(path.Points))
+	for i, p := range path.Points {
+		points[i] = from3(p)
+	}
+	return a.body().followPath(points, float64(path.Radius), float64(lookahead)).vector()
+}
+
+// Full sideways force away from the nearest obstacle the agent would hit
+// within lookahead along its heading
+func (a *Agent) AvoidObstacles(obstacles []Obstacle, lookahead float32) *vector.Vector {
+	spheres := make([]sphere, len(obstacles))
+	for i, o := range obstacles {
+		spheres[i] = sphere{from3(o.Center), float64(o.Radius)}
+	}
+	return a.body().avoid(spheres, float64(a.Radius), float64(lookahead)).vector()
+}
+
+// Steers away from the neighbors within radius, the closest ones the most
+func (a *Agent) Separation(neighbors []*Agent, radius float32) *vector.Vector {
+	return a.body().separation(a.others(neighbors), float64(radius)).vector()
+}
+
+// Steers towards the average heading of the neighbors within radius
+func (a *Agent) Alignment(neighbors []*Agent, radius float32) *vector.Vector {
+	return a.body().alignment(a.others(neighbors), float64(radius)).vector()
+}
+
+// Seeks the center of the neighbors within radius
+func (a *Agent) Cohesion(neighbors []*Agent, radius float32) *vector.Vector {
+	return a.body().cohesion(a.others(neighbors), float64(radius)).vector()
+}
@@ -0,0 +1,160 @@
+package steering
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Steering force with its weight in a 2D blend
+type Weighted2D struct {
+	Force  *vector2d.Vector2D
+	Weight float32
+}
+
+// Circular obstacle
+type Obstacle2D struct {
+	Center *vector2d.Vector2D
+	Radius float32
+}
+
+// 2D polyline to follow, with the distance from it the agent may drift
+type Path2D struct {
+	Points []*vector2d.Vector2D
+	Radius float32
+}
+
+// Vehicle moving in 2D, see Agent
+type Agent2D struct {
+	Position, Velocity *vector2d.Vector2D
+	MaxSpeed, MaxForce float32
+	Mass               float32
+	Radius             float32
+	// Wander target on a circle of WanderRadius at WanderDistance ahead,
+	// moved by up to WanderJitter each call
+	WanderDistance, WanderRadius, WanderJitter float32
+	Rand                                       *rand.Rand
+
+	wander p3
+}
+
+// Makes a 2D agent, see NewAgent
+func NewAgent2D(position, velocity *vector2d.Vector2D, maxSpeed, maxForce float32) *Agent2D {
+	return &Agent2D{
+		Position:       position.Copy(),
+		Velocity:       velocity.Copy(),
+		MaxSpeed:       maxSpeed,
+		MaxForce:       maxForce,
+		WanderDistance: 2,
+		WanderRadius:   1,
+		WanderJitter:   0.2,
+	}
+}
+
+func from2(v *vector2d.Vector2D) p3 { return p3{float64(v.X), float64(v.Y), 0} }
+func (a p3) vector2D() *vector2d.Vector2D {
+	return vector2d.New(float32(a[0]), float32(a[1]))
+}
+
+func (a *Agent2D) body() body {
+	return body{pos: from2(a.Position), vel: from2(a.Velocity), maxSpeed: float64(a.MaxSpeed), maxForce: float64(a.MaxForce)}
+}
+
+func (a *Agent2D) others(neighbors []*Agent2D) []body {
+	bs := make([]body, 0, len(neighbors))
+	for _, n := range neighbors {
+		if n != a {
+			bs = append(bs, n.body())
+		}
+	}
+	return bs
+}
+
+// Applies the steering force for dt, see Agent.Update.
+// Modify + Returns self
+func (a *Agent2D) Update(force *vector2d.Vector2D, dt float32) *Agent2D {
+	m := a.Mass
+	if m <= 0 {
+		m = 1
+	}
+	a.Velocity.Add(force.Copy().Mult(dt / m)).Limit(a.MaxSpeed)
+	a.Position.Add(a.Velocity.Copy().Mult(dt))
+	return a
+}
+
+// Weighted sum of steering forces, limited to MaxForce
+func (a *Agent2D) Blend(forces ...Weighted2D) *vector2d.Vector2D {
+	sum := vector2d.New(0, 0)
+	for _, f := range forces {
+		sum.Add(f.Force.Copy().Mult(f.Weight))
+	}
+	return sum.Limit(a.MaxForce)
+}
+
+// Steers straight towards the target at full speed
+func (a *Agent2D) Seek(target *vector2d.Vector2D) *vector2d.Vector2D {
+	return a.body().seek(from2(target)).vector2D()
+}
+
+// Steers straight away from the target at full speed
+func (a *Agent2D) Flee(target *vector2d.Vector2D) *vector2d.Vector2D {
+	return a.body().flee(from2(target)).vector2D()
+}
+
+// Seeks the target, slowing down linearly within slowRadius to stop on it
+func (a *Agent2D) Arrive(target *vector2d.Vector2D, slowRadius float32) *vector2d.Vector2D {
+	return a.body().arrive(from2(target), float64(slowRadius)).vector2D()
+}
+
+// Seeks where the target will be, predicted from its velocity
+func (a *Agent2D) Pursue(target *Agent2D) *vector2d.Vector2D {
+	return a.body().pursue(target.body()).vector2D()
+}
+
+// Flees from where the target will be, predicted from its velocity
+func (a *Agent2D) Evade(target *Agent2D) *vector2d.Vector2D {
+	return a.body().evade(target.body()).vector2D()
+}
+
+// Seeks a target that drifts randomly on a circle ahead of the agent
+func (a *Agent2D) Wander() *vector2d.Vector2D {
+	if a.Rand == nil {
+		a.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return a.body().wander(&a.wander, a.Rand, float64(a.WanderRadius), float64(a.WanderDistance), float64(a.WanderJitter), true).vector2D()
+}
+
+// Keeps the agent within the path radius, see Agent.FollowPath
+func (a *Agent2D) FollowPath(path Path2D, lookahead float32) *vector2d.Vector2D {
+	points := make([]p3, len(path.Points))
+	for i, p := range path.Points {
+		points[i] = from2(p)
+	}
+	return a.body().followPath(points, float64(path.Radius), float64(lookahead)).vector2D()
+}
+
+// Full sideways force away from the nearest obstacle the agent would hit
+// within lookahead along its heading
+func (a *Agent2D) AvoidObstacles(obstacles []Obstacle2D, lookahead float32) *vector2d.Vector2D {
+	circles := make([]sphere, len(obstacles))
+	for i, o := range obstacles {
+		circles[i] = sphere{from2(o.Center), float64(o.Radius)}
+	}
+	return a.body().avoid(circles, float64(a.Radius), float64(lookahead)).vector2D()
+}
+
+// Steers away from the neighbors within radius, the closest ones the most
+func (a *Agent2D) Separation(neighbors []*Agent2D, radius float32) *vector2d.Vector2D {
+	return a.body().separation(a.others(neighbors), float64(radius)).vector2D()
+}
+
+// Steers towards the average heading of the neighbors within radius
+func (a *Agent2D) Alignment(neighbors []*Agent2D, radius float32) *vector2d.Vector2D {
+	return a.body().alignment(a.others(neighbors), float64(radius)).vector2D()
+}
+
+// Seeks the center of the neighbors within radius
+func (a *Agent2D) Cohesion(neighbors []*Agent2D, radius float32) *vector2d.Vector2D {
+	return a.body().cohesion(a.others(neighbors), float64(radius)).vector2D()
+}
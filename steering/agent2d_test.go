@@ -0,0 +1,80 @@
+package steering
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestBehaviors2D(t *testing.T) {
+	agent := func() *Agent2D { return NewAgent2D(vector2d.New(0, 0), vector2d.New(1, 0), 2, 10) }
+	target := NewAgent2D(vector2d.New(0, 4), vector2d.New(2, 0), 2, 10)
+	tests := []struct {
+		name  string
+		force func(a *Agent2D) *vector2d.Vector2D
+		want  *vector2d.Vector2D
+	}{
+		{"seek", func(a *Agent2D) *vector2d.Vector2D { return a.Seek(vector2d.New(0, 5)) }, vector2d.New(-1, 2)},
+		{"flee", func(a *Agent2D) *vector2d.Vector2D { return a.Flee(vector2d.New(0, 5)) }, vector2d.New(-1, -2)},
+		{"arrive near", func(a *Agent2D) *vector2d.Vector2D { return a.Arrive(vector2d.New(0, 1), 2) }, vector2d.New(-1, 1)},
+		{"pursue", func(a *Agent2D) *vector2d.Vector2D { return a.Pursue(target) }, vector2d.New(-0.10557281, 1.7888544)},
+		{"evade", func(a *Agent2D) *vector2d.Vector2D { return a.Evade(target) }, vector2d.New(-1.8944272, -1.7888544)},
+		{"avoid", func(a *Agent2D) *vector2d.Vector2D {
+			return a.AvoidObstacles([]Obstacle2D{{vector2d.New(3, -0.5), 1}}, 5)
+		}, vector2d.New(0, 10)},
+		{"avoid dead ahead", func(a *Agent2D) *vector2d.Vector2D {
+			return a.AvoidObstacles([]Obstacle2D{{vector2d.New(3, 0), 1}}, 5)
+		}, vector2d.New(0, -10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.force(agent()), getComparer(1e-5)); diff != "" {
+				t.Errorf("force mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateAndBlend2D(t *testing.T) {
+	a := NewAgent2D(vector2d.New(0, 0), vector2d.New(0, 3), 2, 1)
+	// the initial speed is over MaxSpeed and gets limited
+	a.Update(vector2d.New(0, 0), 1)
+	if diff := cmp.Diff(vector2d.New(0, 2), a.Position, getComparer(1e-6)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+	got := a.Blend(Weighted2D{vector2d.New(1, 0), 0.5}, Weighted2D{vector2d.New(0, -1), 0.5})
+	if diff := cmp.Diff(vector2d.New(0.5, -0.5), got, getComparer(1e-6)); diff != "" {
+		t.Errorf("Blend() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWander2D(t *testing.T) {
+	a := NewAgent2D(vector2d.New(0, 0), vector2d.New(1, 0), 2, 1)
+	a.Rand = rand.New(rand.NewSource(1))
+	start := a.Velocity.Copy()
+	for i := 0; i < 100; i++ {
+		a.Update(a.Wander(), 0.1)
+	}
+	if a.wander[2] != 0 {
+		t.Errorf("wander target %v left the plane", a.wander)
+	}
+	if a.Velocity.Equal(start, 1e-3) {
+		t.Error("wandering agent kept its heading")
+	}
+}
+
+func TestFollowPath2D(t *testing.T) {
+	path := Path2D{Points: []*vector2d.Vector2D{vector2d.New(0, 0), vector2d.New(20, 0)}, Radius: 0.5}
+	a := NewAgent2D(vector2d.New(0, -4), vector2d.New(1, 0), 2, 5)
+	for i := 0; i < 300; i++ {
+		a.Update(a.FollowPath(path, 1), 1.0/60)
+	}
+	if a.Position.Y < -1 || a.Position.Y > 1 {
+		t.Errorf("Position = %v, want near the path", a.Position)
+	}
+	if a.Velocity.X < 1.5 {
+		t.Errorf("Velocity = %v, want along the path", a.Velocity)
+	}
+}
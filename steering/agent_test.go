@@ -0,0 +1,180 @@
+package steering
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b *vector2d.Vector2D) bool { return a.Equal(b, tolerance) }),
+	}
+}
+
+func TestBehaviors(t *testing.T) {
+	// agent at the origin moving along +x at speed 1
+	agent := func() *Agent { return NewAgent(vector.New(0, 0, 0), vector.New(1, 0, 0), 2, 10) }
+	target := NewAgent(vector.New(0, 4, 0), vector.New(2, 0, 0), 2, 10)
+	tests := []struct {
+		name  string
+		force func(a *Agent) *vector.Vector
+		want  *vector.Vector
+	}{
+		{"seek", func(a *Agent) *vector.Vector { return a.Seek(vector.New(0, 5, 0)) }, vector.New(-1, 2, 0)},
+		{"flee", func(a *Agent) *vector.Vector { return a.Flee(vector.New(0, 5, 0)) }, vector.New(-1, -2, 0)},
+		{"arrive far", func(a *Agent) *vector.Vector { return a.Arrive(vector.New(0, 0, 5), 2) }, vector.New(-1, 0, 2)},
+		{"arrive near", func(a *Agent) *vector.Vector { return a.Arrive(vector.New(0, 0, 1), 2) }, vector.New(-1, 0, 1)},
+		{"arrive on target", func(a *Agent) *vector.Vector { return a.Arrive(vector.New(0, 0, 0), 2) }, vector.New(-1, 0, 0)},
+		// the target is 4 away and the speeds add to 4: seek where it is in 1s, (2, 4, 0)
+		{"pursue", func(a *Agent) *vector.Vector { return a.Pursue(target) }, vector.New(-0.10557281, 1.7888544, 0)},
+		{"evade", func(a *Agent) *vector.Vector { return a.Evade(target) }, vector.New(-1.8944272, -1.7888544, 0)},
+		{"limited", func(a *Agent) *vector.Vector {
+			a.MaxForce = 1
+			return a.Flee(vector.New(5, 0, 0))
+		}, vector.New(-1, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.force(agent()), getComparer(1e-5)); diff != "" {
+				t.Errorf("force mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateAndBlend(t *testing.T) {
+	a := NewAgent(vector.New(0, 0, 0), vector.New(1, 0, 0), 2, 3)
+	a.Mass = 2
+	a.Update(vector.New(0, 2, 0), 0.5)
+	// v = (1, 0.5), then position moved with the new velocity
+	if diff := cmp.Diff(vector.New(1, 0.5, 0), a.Velocity, getComparer(1e-6)); diff != "" {
+		t.Errorf("Velocity mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(vector.New(0.5, 0.25, 0), a.Position, getComparer(1e-6)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+	// speed is limited to MaxSpeed
+	a.Update(vector.New(100, 0, 0), 1)
+	if s := a.Velocity.Mag(); s > 2+1e-6 {
+		t.Errorf("speed = %v, want at most 2", s)
+	}
+	got := a.Blend(Weighted{vector.New(1, 0, 0), 2}, Weighted{vector.New(0, 1, 0), 0.5})
+	if diff := cmp.Diff(vector.New(2, 0.5, 0), got, getComparer(1e-6)); diff != "" {
+		t.Errorf("Blend() mismatch (-want +got):\n%s", diff)
+	}
+	got = a.Blend(Weighted{vector.New(3, 4, 0), 1})
+	if diff := cmp.Diff(vector.New(1.8, 2.4, 0), got, getComparer(1e-6)); diff != "" {
+		t.Errorf("limited Blend() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestArriveStops(t *testing.T) {
+	a := NewAgent(vector.New(0, 0, 0), vector.New(0, 0, 0), 5, 10)
+	target := vector.New(10, 5, -3)
+	for i := 0; i < 1200; i++ {
+		a.Update(a.Arrive(target, 4), 1.0/60)
+	}
+	if diff := cmp.Diff(target, a.Position, getComparer(0.05)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+	if s := a.Velocity.Mag(); s > 0.05 {
+		t.Errorf("speed = %v, want about 0", s)
+	}
+}
+
+func TestWander(t *testing.T) {
+	a := NewAgent(vector.New(0, 0, 0), vector.New(1, 0, 0), 2, 1)
+	a.Rand = rand.New(rand.NewSource(1))
+	moved := false
+	for i := 0; i < 200; i++ {
+		f := a.Wander()
+		if f.Mag() > a.MaxForce+1e-5 {
+			t.Fatalf("force %v over MaxForce", f)
+		}
+		a.Update(f, 0.1)
+		if a.Position.Z != 0 {
+			moved = true
+		}
+	}
+	if !moved {
+		t.Error("3D wander stayed in the z = 0 plane")
+	}
+	// the wander target stays on its sphere
+	if r := norm3(a.wander); r < 1-1e-9 || r > 1+1e-9 {
+		t.Errorf("wander target at distance %v, want 1", r)
+	}
+}
+
+func TestFollowPath(t *testing.T) {
+	path := Path{Points: []*vector.Vector{vector.New(0, 0, 0), vector.New(10, 0, 0), vector.New(10, 10, 0)}, Radius: 0.5}
+	// inside the path: no force
+	a := NewAgent(vector.New(1, 0.2, 0), vector.New(1, 0, 0), 2, 5)
+	if diff := cmp.Diff(vector.New(0, 0, 0), a.FollowPath(path, 1), getComparer(0)); diff != "" {
+		t.Errorf("force inside the path mismatch (-want +got):\n%s", diff)
+	}
+	// an agent off the path joins it and follows it around the corner
+	a = NewAgent(vector.New(0, 3, 0), vector.New(1, 0, 0), 2, 5)
+	for i := 0; i < 900; i++ {
+		a.Update(a.FollowPath(path, 1), 1.0/60)
+	}
+	if a.Position.X < 9 || a.Position.X > 11 || a.Position.Y < 3 {
+		t.Errorf("Position = %v, want on the second segment", a.Position)
+	}
+}
+
+func TestAvoidObstacles(t *testing.T) {
+	obstacles := []Obstacle{{vector.New(5, 0.5, 0), 1}, {vector.New(5, 0, 20), 1}}
+	a := NewAgent(vector.New(0, 0, 0), vector.New(2, 0, 0), 2, 4)
+	a.Radius = 0.5
+	// the obstacle ahead is slightly to the left: steer right
+	if diff := cmp.Diff(vector.New(0, -4, 0), a.AvoidObstacles(obstacles, 6), getComparer(1e-5)); diff != "" {
+		t.Errorf("AvoidObstacles() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(vector.New(0, 0, 0), a.AvoidObstacles(obstacles, 2), getComparer(0)); diff != "" {
+		t.Errorf("AvoidObstacles() out of reach mismatch (-want +got):\n%s", diff)
+	}
+	// seeking through the obstacle while avoiding it never touches it
+	goal := vector.New(10, 0, 0)
+	for i := 0; i < 600; i++ {
+		f := a.Blend(Weighted{a.AvoidObstacles(obstacles, 3), 2}, Weighted{a.Arrive(goal, 2), 1})
+		a.Update(f, 1.0/60)
+		if d := a.Position.Dist(obstacles[0].Center); d < obstacles[0].Radius {
+			t.Fatalf("agent inside the obstacle at %v", a.Position)
+		}
+	}
+	if diff := cmp.Diff(goal, a.Position, getComparer(0.1)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFlockingRules(t *testing.T) {
+	a := NewAgent(vector.New(0, 0, 0), vector.New(0, 0, 0), 1, 10)
+	neighbors := []*Agent{
+		a,
+		NewAgent(vector.New(1, 0, 0), vector.New(0, 1, 0), 1, 10),
+		NewAgent(vector.New(-2, 0, 0), vector.New(0, 1, 0), 1, 10),
+		NewAgent(vector.New(0, 0, 50), vector.New(0, 0, -1), 1, 10),
+	}
+	tests := []struct {
+		name string
+		got  *vector.Vector
+		want *vector.Vector
+	}{
+		// 1/1 from +x beats 1/2 from -x
+		{"separation", a.Separation(neighbors, 5), vector.New(-1, 0, 0)},
+		{"alignment", a.Alignment(neighbors, 5), vector.New(0, 1, 0)},
+		// center at (-0.5, 0, 0)
+		{"cohesion", a.Cohesion(neighbors, 5), vector.New(-1, 0, 0)},
+		{"nobody around", a.Cohesion(neighbors, 0.5), vector.New(0, 0, 0)},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.got, getComparer(1e-6)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package steering
+
+import (
+	"math"
+	"math/rand"
+)
+
+// The behaviors are computed once in 3D, 2D agents live in the z = 0 plane.
+type p3 [3]float64
+
+func add3(a, b p3) p3           { return p3{a[0] + b[0], a[1] + b[1], a[2] + b[2]} }
+func sub3(a, b p3) p3           { return p3{a[0] - b[0], a[1] - b[1], a[2] - b[2]} }
+func scale3(a p3, s float64) p3 { return p3{a[0] * s, a[1] * s, a[2] * s} }
+func dot3(a, b p3) float64      { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+func cross3(a, b p3) p3 {
+	return p3{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+func norm3(a p3) float64 { return math.Sqrt(dot3(a, a)) }
+
+// Vector of length l along a, zero for a zero vector
+func resize3(a p3, l float64) p3 {
+	n := norm3(a)
+	if n == 0 {
+		return p3{}
+	}
+	return scale3(a, l/n)
+}
+
+// Shortens a to length l if it is longer
+func limit3(a p3, l float64) p3 {
+	if dot3(a, a) > l*l {
+		return resize3(a, l)
+	}
+	return a
+}
+
+// Unit vector perpendicular to the unit vector a, in the z = 0 plane when a is
+func perpendicular(a p3) p3 {
+	e := p3{0, 0, 1}
+	if math.Abs(a[2]) > 0.9 {
+		e = p3{1, 0, 0}
+	}
+	return resize3(cross3(a, e), 1)
+}
+
+// Moving point with the limits of an agent
+type body struct {
+	pos, vel           p3
+	maxSpeed, maxForce float64
+}
+
+// Direction of motion, +x when at rest
+func (b body) heading() p3 {
+	if h := resize3(b.vel, 1); h != (p3{}) {
+		return h
+	}
+	return p3{1, 0, 0}
+}
+
+// Force turning the velocity into the desired one
+func (b body) steer(desired p3) p3 {
+	return limit3(sub3(desired, b.vel), b.maxForce)
+}
+
+func (b body) seek(target p3) p3 {
+	return b.steer(resize3(sub3(target, b.pos), b.maxSpeed))
+}
+
+func (b body) flee(target p3) p3 {
+	return b.steer(resize3(sub3(b.pos, target), b.maxSpeed))
+}
+
+func (b body) arrive(target p3, slowRadius float64) p3 {
+	offset := sub3(target, b.pos)
+	speed := b.maxSpeed
+	if d := norm3(offset); d < slowRadius {
+		speed *= d / slowRadius
+	}
+	return b.steer(resize3(offset, speed))
+}
+
+// Where the target will be by the time the agent reaches it
+func (b body) predict(target body) p3 {
+	speed := b.maxSpeed + norm3(target.vel)
+	if speed == 0 {
+		return target.pos
+	}
+	t := norm3(sub3(target.pos, b.pos)) / speed
+	return add3(target.pos, scale3(target.vel, t))
+}
+
+func (b body) pursue(target body) p3 {
+	return b.seek(b.predict(target))
+}
+
+func (b body) evade(target body) p3 {
+	return b.flee(b.predict(target))
+}
+
+// Moves the wander target by a random jitter, keeps it on the sphere (circle
+// when planar) of the given radius and seeks it from distance ahead
+func (b body) wander(w *p3, r *rand.Rand, radius, distance, jitter float64, planar bool) p3 {
+	j := p3{r.Float64()*2 - 1, r.Float64()*2 - 1, r.Float64()*2 - 1}
+	if planar {
+		j[2] = 0
+	}
+	next := add3(*w, scale3(j, jitter))
+	if next == (p3{}) {
+		next = p3{1, 0, 0}
+	}
+	*w = resize3(next, radius)
+	target := add3(add3(b.pos, scale3(b.heading(), distance)), *w)
+	return b.seek(target)
+}
+
+// Seeks ahead along the path when the predicted position drifts out of it
+func (b body) followPath(points []p3, radius, lookahead float64) p3 {
+	if len(points) == 0 {
+		return p3{}
+	}
+	future := add3(b.pos, scale3(resize3(b.vel, 1), lookahead))
+	best, bestD := points[0], math.Inf(1)
+	dir := p3{}
+	for i := 1; i < len(points); i++ {
+		a, e := points[i-1], sub3(points[i], points[i-1])
+		t := 0.0
+		if ee := dot3(e, e); ee > 0 {
+			t = math.Max(0, math.Min(1, dot3(sub3(future, a), e)/ee))
+		}
+		q := add3(a, scale3(e, t))
+		if d := norm3(sub3(future, q)); d < bestD {
+			best, bestD, dir = q, d, resize3(e, 1)
+		}
+	}
+	if len(points) == 1 {
+		bestD = norm3(sub3(future, best))
+	}
+	if bestD <= radius {
+		return p3{}
+	}
+	return b.seek(add3(best, scale3(dir, lookahead)))
+}
+
+type sphere struct {
+	center p3
+	radius float64
+}
+
+// Sideways force away from the nearest obstacle in the corridor ahead
+func (b body) avoid(obstacles []sphere, radius, lookahead float64) p3 {
+	h := b.heading()
+	nearest := math.Inf(1)
+	var lateral p3
+	found := false
+	for _, o := range obstacles {
+		local := sub3(o.center, b.pos)
+		ahead := dot3(local, h)
+		if ahead <= 0 || ahead-o.radius > lookahead || ahead >= nearest {
+			continue
+		}
+		side := sub3(local, scale3(h, ahead))
+		if norm3(side) >= o.radius+radius {
+			continue
+		}
+		nearest, lateral, found = ahead, side, true
+	}
+	if !found {
+		return p3{}
+	}
+	away := resize3(scale3(lateral, -1), 1)
+	if away == (p3{}) {
+		away = perpendicular(h)
+	}
+	return scale3(away, b.maxForce)
+}
+
+func (b body) separation(neighbors []body, radius float64) p3 {
+	var sum p3
+	n := 0
+	for _, o := range neighbors {
+		off := sub3(b.pos, o.pos)
+		if d := norm3(off); d > 0 && d < radius {
+			// closer neighbors push harder
+			sum = add3(sum, scale3(off, 1/(d*d)))
+			n++
+		}
+	}
+	if n == 0 {
+		return p3{}
+	}
+	return b.steer(resize3(sum, b.maxSpeed))
+}
+
+func (b body) alignment(neighbors []body, radius float64) p3 {
+	var sum p3
+	n := 0
+	for _, o := range neighbors {
+		if norm3(sub3(o.pos, b.pos)) < radius {
+			sum = add3(sum, o.vel)
+			n++
+		}
+	}
+	if n == 0 {
+		return p3{}
+	}
+	return b.steer(resize3(sum, b.maxSpeed))
+}
+
+func (b body) cohesion(neighbors []body, radius float64) p3 {
+	var sum p3
+	n := 0
+	for _, o := range neighbors {
+		if norm3(sub3(o.pos, b.pos)) < radius {
+			sum = add3(sum, o.pos)
+			n++
+		}
+	}
+	if n == 0 {
+		return p3{}
+	}
+	return b.seek(scale3(sum, 1/float64(n)))
+}
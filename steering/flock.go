@@ -0,0 +1,75 @@
+package steering
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// Boids: every agent blends alignment and cohesion with the agents within
+// Radius and separation from the closer agents within SeparationRadius.
+// Neighbors are found by checking every pair.
+// https://en.wikipedia.org/wiki/Boids
+type Flock struct {
+	Agents                   []*Agent
+	Radius, SeparationRadius float32
+	// Weights of the three rules
+	Separation, Alignment, Cohesion float32
+}
+
+// Makes an empty flock with a separation radius of a third of radius
+// and weights 1.5, 1 and 1
+func NewFlock(radius float32) *Flock {
+	return &Flock{Radius: radius, SeparationRadius: radius / 3, Separation: 1.5, Alignment: 1, Cohesion: 1}
+}
+
+// Steering force of the flocking rules for one agent of the flock
+func (f *Flock) Force(a *Agent) *vector.Vector {
+	return a.Blend(
+		Weighted{a.Separation(f.Agents, f.SeparationRadius), f.Separation},
+		Weighted{a.Alignment(f.Agents, f.Radius), f.Alignment},
+		Weighted{a.Cohesion(f.Agents, f.Radius), f.Cohesion},
+	)
+}
+
+// Moves every agent by dt, the forces are all computed before any agent moves
+func (f *Flock) Update(dt float32) {
+	forces := make([]*vector.Vector, len(f.Agents))
+	for i, a := range f.Agents {
+		forces[i] = f.Force(a)
+	}
+	for i, a := range f.Agents {
+		a.Update(forces[i], dt)
+	}
+}
+
+// Boids in 2D, see Flock
+type Flock2D struct {
+	Agents                          []*Agent2D
+	Radius, SeparationRadius        float32
+	Separation, Alignment, Cohesion float32
+}
+
+// Makes an empty 2D flock, see NewFlock
+func NewFlock2D(radius float32) *Flock2D {
+	return &Flock2D{Radius: radius, SeparationRadius: radius / 3, Separation: 1.5, Alignment: 1, Cohesion: 1}
+}
+
+// Steering force of the flocking rules for one agent of the flock
+func (f *Flock2D) Force(a *Agent2D) *vector2d.Vector2D {
+	return a.Blend(
+		Weighted2D{a.Separation(f.Agents, f.SeparationRadius), f.Separation},
+		Weighted2D{a.Alignment(f.Agents, f.Radius), f.Alignment},
+		Weighted2D{a.Cohesion(f.Agents, f.Radius), f.Cohesion},
+	)
+}
+
+// Moves every agent by dt, the forces are all computed before any agent moves
+func (f *Flock2D) Update(dt float32) {
+	forces := make([]*vector2d.Vector2D, len(f.Agents))
+	for i, a := range f.Agents {
+		forces[i] = f.Force(a)
+	}
+	for i, a := range f.Agents {
+		a.Update(forces[i], dt)
+	}
+}
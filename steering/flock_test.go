@@ -0,0 +1,58 @@
+package steering
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func TestFlock(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	f := NewFlock(3)
+	for i := 0; i < 30; i++ {
+		p := vector.New(r.Float32()*4, r.Float32()*4, r.Float32()*4)
+		v := vector.New(1+r.Float32(), r.Float32()-0.5, r.Float32()-0.5)
+		f.Agents = append(f.Agents, NewAgent(p, v, 2, 1))
+	}
+	for i := 0; i < 300; i++ {
+		f.Update(1.0 / 30)
+	}
+	// the flock ends up heading the same way and spread out
+	sum := vector.New(0, 0, 0)
+	for _, a := range f.Agents {
+		sum.Add(vector.Unit(a.Velocity))
+	}
+	if order := sum.Mag() / float32(len(f.Agents)); order < 0.95 {
+		t.Errorf("order parameter %v, want aligned agents", order)
+	}
+	for i, a := range f.Agents {
+		for _, b := range f.Agents[i+1:] {
+			if d := a.Position.Dist(b.Position); d < 0.2 {
+				t.Errorf("agents %v apart, want separated", d)
+			}
+		}
+	}
+}
+
+func TestFlock2D(t *testing.T) {
+	f := NewFlock2D(5)
+	// two agents side by side heading apart
+	f.Agents = []*Agent2D{
+		NewAgent2D(vector2d.New(0, 0), vector2d.New(1, 1), 2, 1),
+		NewAgent2D(vector2d.New(1, 0), vector2d.New(1, -1), 2, 1),
+	}
+	force := f.Force(f.Agents[0])
+	// separation pushes -x, alignment turns towards +x, cohesion pulls +x
+	if force.Y >= 0 {
+		t.Errorf("Force() = %v, want turning towards the other heading", force)
+	}
+	for i := 0; i < 300; i++ {
+		f.Update(1.0 / 30)
+	}
+	a, b := f.Agents[0].Velocity, f.Agents[1].Velocity
+	if angle := vector2d.AngleBetween(a, b); angle > 0.05 {
+		t.Errorf("velocities %v and %v, want aligned", a, b)
+	}
+}
@@ -0,0 +1,55 @@
+package vector
+
+import "math"
+
+// Intersects a ray with a triangle (Möller–Trumbore algorithm).
+// Returns the distance t along the ray (in units of dir) and the barycentric
+// coordinates u, v of the hit point, hit = (1-u-v)*a + u*b + v*c.
+// ok is false if the ray misses or is parallel to the triangle.
+// https://en.wikipedia.org/wiki/M%C3%B6ller%E2%80%93Trumbore_intersection_algorithm
+func RayTriangle(origin, dir, a, b, c *Vector) (t, u, v float32, ok bool) {
+	const eps = 1e-7
+	e1 := Sub(b, a)
+	e2 := Sub(c, a)
+	p := Cross(dir, e2)
+	det := Dot(e1, p)
+	if det > -eps && det < eps {
+		return 0, 0, 0, false
+	}
+	inv := 1 / det
+	s := Sub(origin, a)
+	u = Dot(s, p) * inv
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+	q := Cross(s, e1)
+	v = Dot(dir, q) * inv
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+	t = Dot(e2, q) * inv
+	if t < 0 {
+		return 0, 0, 0, false
+	}
+	return t, u, v, true
+}
+
+// Intersects a ray with a plane given by a point on it and its normal.
+// Returns the distance t along the ray (in units of dir).
+// ok is false if the ray is parallel to the plane or the plane is behind the ray.
+func RayPlane(origin, dir, point, normal *Vector) (t float32, ok bool) {
+	denom := Dot(dir, normal)
+	if math.Abs(float64(denom)) < 1e-7 {
+		return 0, false
+	}
+	t = Dot(Sub(point, origin), normal) / denom
+	if t < 0 {
+		return 0, false
+	}
+	return t, true
+}
+
+// Gives the point at distance t along the ray, origin + t*dir
+func RayAt(origin, dir *Vector, t float32) *Vector {
+	return Add(origin, dir.Copy().Mult(t))
+}
@@ -0,0 +1,61 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRayTriangle(t *testing.T) {
+	a, b, c := New(0, 0, 0), New(1, 0, 0), New(0, 1, 0)
+	tests := []struct {
+		origin, dir *Vector
+		wantT       float32
+		wantU       float32
+		wantV       float32
+		wantOk      bool
+	}{
+		{New(0.25, 0.25, 1), New(0, 0, -1), 1, 0.25, 0.25, true},
+		{New(0.25, 0.25, -2), New(0, 0, 1), 2, 0.25, 0.25, true},
+		{New(0.5, 0, 3), New(0, 0, -2), 1.5, 0.5, 0, true},
+		{New(1, 1, 1), New(0, 0, -1), 0, 0, 0, false},
+		{New(0.25, 0.25, 1), New(0, 0, 1), 0, 0, 0, false},
+		{New(0.25, 0.25, 1), New(1, 0, 0), 0, 0, 0, false},
+	}
+	opt := getComparer(.00001)
+	for _, test := range tests {
+		gotT, gotU, gotV, gotOk := RayTriangle(test.origin, test.dir, a, b, c)
+		got := []interface{}{gotT, gotU, gotV, gotOk}
+		want := []interface{}{test.wantT, test.wantU, test.wantV, test.wantOk}
+		if !cmp.Equal(got, want, opt) {
+			t.Errorf("RayTriangle(%v, %v, ...) = %v, want %v", test.origin, test.dir, got, want)
+		}
+	}
+}
+
+func TestRayPlane(t *testing.T) {
+	tests := []struct {
+		origin, dir, point, normal *Vector
+		want                       float32
+		wantOk                     bool
+	}{
+		{New(0, 0, 5), New(0, 0, -1), zero(), z(), 5, true},
+		{New(3, 2, 5), New(0, 0, -2), New(1, 1, 1), z(), 2, true},
+		{New(0, 0, 5), New(0, 0, 1), zero(), z(), 0, false},
+		{New(0, 0, 5), New(1, 0, 0), zero(), z(), 0, false},
+	}
+	opt := getComparer(.00001)
+	for _, test := range tests {
+		got, ok := RayPlane(test.origin, test.dir, test.point, test.normal)
+		if ok != test.wantOk || !cmp.Equal(got, test.want, opt) {
+			t.Errorf("RayPlane(%v, %v, %v, %v) = %v, %v, want %v, %v", test.origin, test.dir, test.point, test.normal, got, ok, test.want, test.wantOk)
+		}
+	}
+}
+
+func TestRayAt(t *testing.T) {
+	opt := getComparer(.00001)
+	if got, want := RayAt(New(1, 2, 3), New(0, 1, 0), 2), New(1, 4, 3); !cmp.Equal(got, want, opt) {
+		t.Errorf("RayAt() = %v, want %v", got, want)
+	}
+}
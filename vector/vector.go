@@ -157,6 +157,19 @@ func (v *Vector) Resize(mag float32) *Vector {
 	return v
 }
 
+// Limit the magnitude of the vector to the given value.
+// A negative max is taken as 0.
+// Modify + Returns self
+func (v *Vector) Limit(max float32) *Vector {
+	if max < 0 {
+		max = 0
+	}
+	if v.MagSq() > max*max {
+		v.Resize(max)
+	}
+	return v
+}
+
 // add a vector to the current vector.
 // Modify + Returns self
 func (v *Vector) Add(v2 *Vector) *Vector {
@@ -291,6 +291,25 @@ func TestResize(t *testing.T) {
 	}
 }
 
+func TestLimit(t *testing.T) {
+	tests := []struct {
+		v    *Vector
+		fl   float32
+		want *Vector
+	}{
+		{New(3, 4, 12), 26, New(3, 4, 12)},
+		{New(3, 4, 12), 6.5, New(1.5, 2, 6)},
+		{zero(), 1, zero()},
+		{New(3, 4, 12), -1, zero()},
+	}
+	opt := getComparer(.00001)
+	for _, test := range tests {
+		if test.v.Limit(test.fl); !cmp.Equal(test.v, test.want, opt) {
+			t.Errorf("%v.Limit(%v) = %v, want %v", test.v, test.fl, test.v, test.want)
+		}
+	}
+}
+
 func TestAdd(t *testing.T) {
 	tests := []struct {
 		v1, v2 *Vector
@@ -159,6 +159,22 @@ func (v *Vector2D) Resize(len interface{}) error {
 	return nil
 }
 
+// Limit the length of this vector to the value used for the max parameter.
+// A negative max is taken as 0.
+func (v *Vector2D) Limit(max interface{}) error {
+	M, err := getFloat(max)
+	if err != nil {
+		return err
+	}
+	if M < 0 {
+		M = 0
+	}
+	if v.MagSq() > M*M {
+		return v.Resize(M)
+	}
+	return nil
+}
+
 // add a vector to the current vector
 func (v *Vector2D) Add(v2 Vector2D) {
 	v.X += v2.X
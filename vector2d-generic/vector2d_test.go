@@ -385,6 +385,37 @@ func TestResize(t *testing.T) {
 	}
 }
 
+func TestLimit(t *testing.T) {
+	opt := getComparer(.00001)
+	tests := []struct {
+		v   Vector2D
+		m   interface{}
+		s   Vector2D
+		err bool
+	}{
+		{Vector2D{0, 0}, 2, Vector2D{0, 0}, false},
+		{Vector2D{1, 0}, 2, Vector2D{1, 0}, false},
+		{Vector2D{3, 4}, 5, Vector2D{3, 4}, false},
+		{Vector2D{-3, 4}, 2.5, Vector2D{-1.5, 2}, false},
+		{Vector2D{-3, 4}, -1, Vector2D{0, 0}, false},
+		{Vector2D{3, 4}, "1.2", Vector2D{3, 4}, true},
+	}
+	for _, test := range tests {
+		err := test.v.Limit(test.m)
+		if err != nil && !test.err {
+			t.Errorf("Limit(%v) returned error %v", test.m, err)
+			continue
+		}
+		if err == nil && test.err {
+			t.Errorf("Limit(%v) returned no error", test.m)
+			continue
+		}
+		if !cmp.Equal(test.s, test.v, opt) {
+			t.Errorf("Limit(%v) returned %v, want %v", test.m, test.v, test.s)
+		}
+	}
+}
+
 func TestVecAdd(t *testing.T) {
 	opt := getComparer(.00001)
 	tests := []struct {
@@ -109,6 +109,19 @@ func (v *Vector2D) Resize(mag float32) *Vector2D {
 	return v
 }
 
+// Limit the magnitude of this vector to the value used for the max parameter.
+// A negative max is taken as 0.
+// Modify + Returns self
+func (v *Vector2D) Limit(max float32) *Vector2D {
+	if max < 0 {
+		max = 0
+	}
+	if v.MagSq() > max*max {
+		v.Resize(max)
+	}
+	return v
+}
+
 // add a vector to the current vector.
 // Modify + Returns self
 func (v *Vector2D) Add(v2 *Vector2D) *Vector2D {
@@ -333,6 +333,26 @@ func TestResize(t *testing.T) {
 	}
 }
 
+func TestLimit(t *testing.T) {
+	tests := []struct {
+		v *Vector2D
+		m float32
+		s *Vector2D
+	}{
+		{&Vector2D{0, 0}, 2, &Vector2D{0, 0}},
+		{&Vector2D{1, 0}, 2, &Vector2D{1, 0}},
+		{&Vector2D{3, 4}, 5, &Vector2D{3, 4}},
+		{&Vector2D{-3, 4}, 2.5, &Vector2D{-1.5, 2}},
+		{&Vector2D{-3, 4}, -1, &Vector2D{0, 0}},
+	}
+	for _, test := range tests {
+		test.v.Limit(test.m)
+		if !test.s.Equal(test.v, .00001) {
+			t.Errorf("Limit(%v, %v) returned %v, want %v", test.v, test.m, test.v, test.s)
+		}
+	}
+}
+
 func TestVecAdd(t *testing.T) {
 	tests := []struct {
 		v1 *Vector2D
@@ -0,0 +1,206 @@
+// Package vector64 provides a 3D vector class in double precision, for
+// computations such as orbits or geodesy where float32 is not enough.
+// It mirrors the API of the vector package and converts to and from it.
+package vector64
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+type Vector struct {
+	X, Y, Z float64
+}
+
+// Creates a new 3D vector.
+func New(x, y, z float64) *Vector {
+	return &Vector{x, y, z}
+}
+
+// Makes a double precision copy of a vector.Vector
+func FromVector(v *vector.Vector) *Vector {
+	return &Vector{float64(v.X), float64(v.Y), float64(v.Z)}
+}
+
+// Converts the vector to a single precision vector.Vector
+func (v *Vector) Vector() *vector.Vector {
+	return vector.New(float32(v.X), float32(v.Y), float32(v.Z))
+}
+
+// Make a new 3D vector from a pair of azimuth and zenith angles,
+// see vector.FromAngles
+// https://en.wikipedia.org/wiki/Spherical_coordinate_system
+func FromAngles(theta, phi float64, length ...float64) *Vector {
+	l := 1.0
+	if len(length) >= 1 {
+		l = length[0]
+	}
+	sinTheta, cosTheta := math.Sincos(theta)
+	sinPhi, cosPhi := math.Sincos(phi)
+	return &Vector{l * cosTheta * sinPhi, l * sinTheta * sinPhi, l * cosPhi}
+}
+
+// String representation of vector
+func (v *Vector) String() string {
+	return fmt.Sprintf("{X: %v, Y: %v, Z: %v}", v.X, v.Y, v.Z)
+}
+
+// Checks whether two vectors are equal.
+// optional tolerence value can be passed as a parameter to check for equality
+// within a tolerance.
+func (v *Vector) Equal(v2 *Vector, tolerance ...float64) bool {
+	t := 1e-15
+	if len(tolerance) >= 1 {
+		t += tolerance[0]
+	}
+	return math.Abs(v.X-v2.X) <= t && math.Abs(v.Y-v2.Y) <= t && math.Abs(v.Z-v2.Z) <= t
+}
+
+// Gets a copy of the vector
+func (v *Vector) Copy() *Vector {
+	return &Vector{v.X, v.Y, v.Z}
+}
+
+// Gets a copy of the vector
+func Copy(v *Vector) *Vector {
+	return &Vector{v.X, v.Y, v.Z}
+}
+
+// Assigns the values of given vector to the vector.
+// Modify + Returns self
+func (v *Vector) Assign(v2 *Vector) *Vector {
+	v.X, v.Y, v.Z = v2.X, v2.Y, v2.Z
+	return v
+}
+
+// Calculates the magnitude (length) of the vector
+func (v *Vector) Mag() float64 {
+	return math.Sqrt(v.MagSq())
+}
+
+// Calculates the squared magnitude of the vector
+func (v *Vector) MagSq() float64 {
+	return v.X*v.X + v.Y*v.Y + v.Z*v.Z
+}
+
+// Normalize the vector to length 1 (make it a unit vector).
+// Modify + Returns self
+func (v *Vector) Normalize() *Vector {
+	if m := v.Mag(); m != 0 {
+		v.X /= m
+		v.Y /= m
+		v.Z /= m
+	}
+	return v
+}
+
+// Gives a unit vector in dirction of the vector
+func Unit(v *Vector) *Vector {
+	return v.Copy().Normalize()
+}
+
+// Set the magnitude of the vector to the given value.
+// Modify + Returns self
+func (v *Vector) Resize(mag float64) *Vector {
+	return v.Normalize().Mult(mag)
+}
+
+// add a vector to the current vector.
+// Modify + Returns self
+func (v *Vector) Add(v2 *Vector) *Vector {
+	v.X += v2.X
+	v.Y += v2.Y
+	v.Z += v2.Z
+	return v
+}
+
+// returns the sum of two vectors
+func Add(v1, v2 *Vector) *Vector {
+	return &Vector{v1.X + v2.X, v1.Y + v2.Y, v1.Z + v2.Z}
+}
+
+// subtract a vector from the current vector.
+// Modify + Returns self
+func (v *Vector) Sub(v2 *Vector) *Vector {
+	v.X -= v2.X
+	v.Y -= v2.Y
+	v.Z -= v2.Z
+	return v
+}
+
+// returns the difference of two vectors
+func Sub(v1, v2 *Vector) *Vector {
+	return &Vector{v1.X - v2.X, v1.Y - v2.Y, v1.Z - v2.Z}
+}
+
+// Multiplies the vector by a scalar.
+// Modify + Returns self
+func (v *Vector) Mult(scalar float64) *Vector {
+	v.X *= scalar
+	v.Y *= scalar
+	v.Z *= scalar
+	return v
+}
+
+// Calculates the Euclidean distance to another point
+func (v *Vector) Dist(v2 *Vector) float64 {
+	return Dist(v, v2)
+}
+
+// Calculates the Euclidean distance between two points
+func Dist(v1, v2 *Vector) float64 {
+	return Sub(v1, v2).Mag()
+}
+
+// Calculates the dot product with another vector
+func (v *Vector) Dot(v2 *Vector) float64 {
+	return Dot(v, v2)
+}
+
+// Calculates the dot product of two vectors
+func Dot(v1, v2 *Vector) float64 {
+	return v1.X*v2.X + v1.Y*v2.Y + v1.Z*v2.Z
+}
+
+// Calculates the cross product with another vector
+func (v *Vector) Cross(v2 *Vector) *Vector {
+	return Cross(v, v2)
+}
+
+// Calculates the cross product of two vectors
+func Cross(v1, v2 *Vector) *Vector {
+	return &Vector{v1.Y*v2.Z - v1.Z*v2.Y, v1.Z*v2.X - v1.X*v2.Z, v1.X*v2.Y - v1.Y*v2.X}
+}
+
+// Calculates and returns the angle with another vector
+// Returns NaN if any vector is a zero vector
+func (v *Vector) Angle(v2 *Vector) float64 {
+	return Angle(v, v2)
+}
+
+// Calculates and returns the angle between two vectors, accurate for small
+// and nearly opposite angles.
+// Returns NaN if any vector is a zero vector
+func Angle(v1, v2 *Vector) float64 {
+	if v1.MagSq() == 0 || v2.MagSq() == 0 {
+		return math.NaN()
+	}
+	return math.Atan2(Cross(v1, v2).Mag(), Dot(v1, v2))
+}
+
+// Calculate the azimuth and zenith angles, see FromAngles
+func (v *Vector) Heading() (theta, phi float64) {
+	theta = math.Atan2(v.Y, v.X)
+	m := v.Mag()
+	if m == 0 {
+		return theta, math.NaN()
+	}
+	return theta, math.Acos(v.Z / m)
+}
+
+// Linear interpolate to another vector, t = 0 gives v1 and t = 1 gives v2
+func Lerp(v1, v2 *Vector, t float64) *Vector {
+	return &Vector{v1.X + (v2.X-v1.X)*t, v1.Y + (v2.Y-v1.Y)*t, v1.Z + (v2.Z-v1.Z)*t}
+}
@@ -0,0 +1,125 @@
+package vector64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b *Vector) bool { return a == b || a != nil && b != nil && a.Equal(b, tolerance) }),
+		cmp.Comparer(func(a, b float64) bool {
+			return a == b || math.IsNaN(a) && math.IsNaN(b) || math.Abs(a-b) <= tolerance
+		}),
+	}
+}
+
+func TestConvert(t *testing.T) {
+	v := New(1.5, -2, 1e-9)
+	if diff := cmp.Diff(vector.New(1.5, -2, 1e-9), v.Vector()); diff != "" {
+		t.Errorf("Vector() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(New(1.5, -2, 0.25), FromVector(vector.New(1.5, -2, 0.25))); diff != "" {
+		t.Errorf("FromVector() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFromAnglesHeading(t *testing.T) {
+	tests := []struct {
+		theta, phi float64
+		length     []float64
+		want       *Vector
+	}{
+		{0, math.Pi / 2, nil, New(1, 0, 0)},
+		{math.Pi / 2, math.Pi / 2, []float64{2}, New(0, 2, 0)},
+		{math.Pi / 4, math.Acos(1 / math.Sqrt(3)), []float64{math.Sqrt(3)}, New(1, 1, 1)},
+		{-3 * math.Pi / 4, 3 * math.Pi / 4, []float64{2}, New(-1, -1, -math.Sqrt2)},
+	}
+	for _, tt := range tests {
+		got := FromAngles(tt.theta, tt.phi, tt.length...)
+		if diff := cmp.Diff(tt.want, got, getComparer(1e-12)); diff != "" {
+			t.Errorf("FromAngles(%v, %v) mismatch (-want +got):\n%s", tt.theta, tt.phi, diff)
+		}
+		theta, phi := got.Heading()
+		if diff := cmp.Diff([]float64{tt.theta, tt.phi}, []float64{theta, phi}, getComparer(1e-12)); diff != "" {
+			t.Errorf("Heading() mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if _, phi := New(0, 0, 0).Heading(); !math.IsNaN(phi) {
+		t.Errorf("Heading() of the zero vector gave phi %v, want NaN", phi)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := New(1, 2, 3), New(-4, 0.5, 2)
+	tests := []struct {
+		name string
+		got  *Vector
+		want *Vector
+	}{
+		{"Add", Add(a, b), New(-3, 2.5, 5)},
+		{"Sub", Sub(a, b), New(5, 1.5, 1)},
+		{"Add method", a.Copy().Add(b), New(-3, 2.5, 5)},
+		{"Sub method", a.Copy().Sub(b), New(5, 1.5, 1)},
+		{"Mult", a.Copy().Mult(-2), New(-2, -4, -6)},
+		{"Cross", Cross(a, b), New(2.5, -14, 8.5)},
+		{"Cross method", a.Cross(b), New(2.5, -14, 8.5)},
+		{"Lerp", Lerp(a, b, 0.5), New(-1.5, 1.25, 2.5)},
+		{"Assign", New(0, 0, 0).Assign(b), b},
+		{"Resize", New(3, 0, 4).Resize(10), New(6, 0, 8)},
+		{"Normalize", New(3, 0, 4).Normalize(), New(0.6, 0, 0.8)},
+		{"Normalize zero", New(0, 0, 0).Normalize(), New(0, 0, 0)},
+		{"Unit", Unit(New(0, -2, 0)), New(0, -1, 0)},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.got, getComparer(1e-15)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+	if diff := cmp.Diff(New(1, 2, 3), a); diff != "" {
+		t.Errorf("functions modified their argument (-want +got):\n%s", diff)
+	}
+}
+
+func TestScalars(t *testing.T) {
+	a, b := New(1, 2, 2), New(4, 6, 2)
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"Mag", a.Mag(), 3},
+		{"MagSq", a.MagSq(), 9},
+		{"Dot", Dot(a, b), 20},
+		{"Dot method", a.Dot(b), 20},
+		{"Dist", Dist(a, b), 5},
+		{"Dist method", a.Dist(b), 5},
+		{"Angle", Angle(New(1, 0, 0), New(1, 1, 0)), math.Pi / 4},
+		{"Angle tiny", New(1, 0, 0).Angle(New(1, 1e-12, 0)), 1e-12},
+		{"Angle opposite", Angle(New(1, 0, 0), New(-1, 0, 0)), math.Pi},
+		{"Angle zero", Angle(New(0, 0, 0), a), math.NaN()},
+	}
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, tt.got, getComparer(1e-15)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := New(1, 2, 3)
+	if !a.Equal(New(1, 2, 3)) {
+		t.Error("Equal() of the same values is false")
+	}
+	if a.Equal(New(1, 2, 3.001)) {
+		t.Error("Equal() of different values is true")
+	}
+	if !a.Equal(New(1, 2, 3.001), 0.01) {
+		t.Error("Equal() within the tolerance is false")
+	}
+	if s := a.String(); s != "{X: 1, Y: 2, Z: 3}" {
+		t.Errorf("String() = %q", s)
+	}
+}
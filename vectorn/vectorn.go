@@ -0,0 +1,237 @@
+// Package vectorn provides an n-dimensional vector type, e.g. for embeddings.
+package vectorn
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+// n-dimensional Euclidean vector.
+// Operations between two vectors panic if their dimensions differ.
+type VecN []float32
+
+// Creates a new vector from its components
+func New(components ...float32) VecN {
+	v := make(VecN, len(components))
+	copy(v, components)
+	return v
+}
+
+// Creates a zero vector of the given dimension
+func Zero(dim int) VecN {
+	return make(VecN, dim)
+}
+
+// Makes a 3 dimensional VecN from a 3D vector
+func FromVector(v *vector.Vector) VecN {
+	return VecN{v.X, v.Y, v.Z}
+}
+
+// Makes a 2 dimensional VecN from a 2D vector
+func FromVector2D(v *vector2d.Vector2D) VecN {
+	return VecN{v.X, v.Y}
+}
+
+func checkDim(v1, v2 VecN) {
+	if len(v1) != len(v2) {
+		panic(fmt.Sprintf("vectorn: dimension mismatch %d != %d", len(v1), len(v2)))
+	}
+}
+
+// Number of components of the vector
+func (v VecN) Dim() int {
+	return len(v)
+}
+
+// String representation of vector
+func (v VecN) String() string {
+	parts := make([]string, len(v))
+	for i, c := range v {
+		parts[i] = fmt.Sprint(c)
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// Checks whether two vectors are equal.
+// optional tolerence value can be passed as a parameter to check for equality
+// within a tolerance, added to a base tolerance of 1e-7 like vector.Vector.
+// abs(v[i] - v2[i]) <= 1e-7 + tolerance for every component.
+// Vectors of different dimensions are never equal.
+func (v VecN) Equal(v2 VecN, tolerance ...float32) bool {
+	if len(v) != len(v2) {
+		return false
+	}
+	var t float32 = 1e-7
+	if len(tolerance) >= 1 {
+		t += tolerance[0]
+	}
+	for i := range v {
+		if math.Abs(float64(v[i]-v2[i])) > float64(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// Gets a copy of the vector
+func (v VecN) Copy() VecN {
+	return New(v...)
+}
+
+// Gets a copy of the vector
+func Copy(v VecN) VecN {
+	return New(v...)
+}
+
+// Assigns the values of given vector to the vector.
+// Modify + Returns self
+func (v VecN) Assign(v2 VecN) VecN {
+	checkDim(v, v2)
+	copy(v, v2)
+	return v
+}
+
+// Calculates the magnitude (length) of the vector
+func (v VecN) Mag() float32 {
+	return float32(math.Sqrt(float64(v.MagSq())))
+}
+
+// Calculates the squared magnitude of the vector
+func (v VecN) MagSq() float32 {
+	return Dot(v, v)
+}
+
+// Normalize the vector to length 1 (make it a unit vector).
+// Modify + Returns self
+func (v VecN) Normalize() VecN {
+	m := v.Mag()
+	if m != 0 {
+		for i := range v {
+			v[i] /= m
+		}
+	}
+	return v
+}
+
+// Gives a unit vector in dirction of the vector
+func Unit(v VecN) VecN {
+	return v.Copy().Normalize()
+}
+
+// Set the magnitude of the vector to the given value.
+// Modify + Returns self
+func (v VecN) Resize(mag float32) VecN {
+	return v.Normalize().Mult(mag)
+}
+
+// add a vector to the current vector.
+// Modify + Returns self
+func (v VecN) Add(v2 VecN) VecN {
+	checkDim(v, v2)
+	for i := range v {
+		v[i] += v2[i]
+	}
+	return v
+}
+
+// returns the sum of two vectors
+func Add(v1, v2 VecN) VecN {
+	return v1.Copy().Add(v2)
+}
+
+// subtract a vector from the current vector.
+// Modify + Returns self
+func (v VecN) Sub(v2 VecN) VecN {
+	checkDim(v, v2)
+	for i := range v {
+		v[i] -= v2[i]
+	}
+	return v
+}
+
+// returns the difference of two vectors
+func Sub(v1, v2 VecN) VecN {
+	return v1.Copy().Sub(v2)
+}
+
+// Multiplies the vector by a scalar.
+// Modify + Returns self
+func (v VecN) Mult(scalar float32) VecN {
+	for i := range v {
+		v[i] *= scalar
+	}
+	return v
+}
+
+// Calculates the dot product with another vector
+func (v VecN) Dot(v2 VecN) float32 {
+	return Dot(v, v2)
+}
+
+// Calculates the dot product of two vectors
+func Dot(v1, v2 VecN) float32 {
+	checkDim(v1, v2)
+	var sum float32
+	for i := range v1 {
+		sum += v1[i] * v2[i]
+	}
+	return sum
+}
+
+// Calculates the Euclidean distance between two points
+// (considering a point as a vector object)
+func (v VecN) Dist(v2 VecN) float32 {
+	return Dist(v, v2)
+}
+
+// Calculates the Euclidean distance between two points
+// (considering a point as a vector object)
+func Dist(v1, v2 VecN) float32 {
+	return float32(math.Sqrt(float64(DistSq(v1, v2))))
+}
+
+// Calculates the squared Euclidean distance between two points
+func DistSq(v1, v2 VecN) float32 {
+	checkDim(v1, v2)
+	var sum float32
+	for i := range v1 {
+		d := v1[i] - v2[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// Calculates and returns the angle between two vectors.
+// Returns NaN if any vector is a zero vector
+func Angle(v1, v2 VecN) float32 {
+	c := CosineSimilarity(v1, v2)
+	if math.IsNaN(float64(c)) {
+		return c
+	}
+	return float32(math.Acos(math.Min(1, math.Max(-1, float64(c)))))
+}
+
+// Calculates the cosine of the angle between two vectors.
+// Returns NaN if any vector is a zero vector
+func CosineSimilarity(v1, v2 VecN) float32 {
+	m1 := v1.Mag()
+	m2 := v2.Mag()
+	if m1 == 0 || m2 == 0 {
+		return float32(math.NaN())
+	}
+	return Dot(v1, v2) / (m1 * m2)
+}
+
+// Linear interpolate the vector to another vector
+func Lerp(v1, v2 VecN, t float32) VecN {
+	checkDim(v1, v2)
+	out := make(VecN, len(v1))
+	for i := range v1 {
+		out[i] = v1[i] + (v2[i]-v1[i])*t
+	}
+	return out
+}
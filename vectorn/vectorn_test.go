@@ -0,0 +1,149 @@
+package vectorn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+	"github.com/vaibhav11s/gopkgs/vector2d"
+)
+
+func getComparer(tolerance float64) cmp.Option {
+	return cmp.Comparer(func(x, y float32) bool {
+		if math.IsNaN(float64(x)) && math.IsNaN(float64(y)) {
+			return true
+		}
+		diff := math.Abs(float64(x - y))
+		return diff <= tolerance
+	})
+}
+
+func TestNew(t *testing.T) {
+	c := []float32{1, 2, 3, 4}
+	v := New(c...)
+	c[0] = 9
+	if !cmp.Equal(v, VecN{1, 2, 3, 4}) {
+		t.Errorf("New(...) = %v, want a copy of its arguments", v)
+	}
+	if got := Zero(5); !cmp.Equal(got, VecN{0, 0, 0, 0, 0}) {
+		t.Errorf("Zero(5) = %v", got)
+	}
+	if got := FromVector(vector.New(1, 2, 3)); !cmp.Equal(got, VecN{1, 2, 3}) {
+		t.Errorf("FromVector() = %v", got)
+	}
+	if got := FromVector2D(vector2d.New(1, 2)); !cmp.Equal(got, VecN{1, 2}) {
+		t.Errorf("FromVector2D() = %v", got)
+	}
+}
+
+func TestString(t *testing.T) {
+	if got := New(1.5, 0, -2).String(); got != "{1.5, 0, -2}" {
+		t.Errorf("String() = %v", got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b      VecN
+		tolerance []float32
+		want      bool
+	}{
+		{New(1, 2, 3), New(1, 2, 3), nil, true},
+		{New(1, 2, 3), New(1, 2, 3.1), nil, false},
+		{New(1, 2, 3), New(1, 2, 3.05), []float32{0.1}, true},
+		{New(0, 0, 0), New(0, 0, 5e-8), nil, true},
+		{New(0, 0, 0), New(0, 0, 2e-7), nil, false},
+		{New(1, 2, 3), New(1, 2), nil, false},
+	}
+	for _, test := range tests {
+		if got := test.a.Equal(test.b, test.tolerance...); got != test.want {
+			t.Errorf("%v.Equal(%v, %v...) = %v, want %v", test.a, test.b, test.tolerance, got, test.want)
+		}
+	}
+}
+
+// VecN must agree with vector.Vector for three dimensions
+func TestMirrorsVector(t *testing.T) {
+	opt := getComparer(.00001)
+	pairs := [][2]*vector.Vector{
+		{vector.New(1, 2, 3), vector.New(-4, 5, 0.5)},
+		{vector.New(0, 0, 0), vector.New(3, 4, 12)},
+		{vector.New(312.15, 22.34, -32.98), vector.New(1, 1, 1)},
+	}
+	for _, p := range pairs {
+		a, b := FromVector(p[0]), FromVector(p[1])
+		if got, want := a.Mag(), p[0].Mag(); !cmp.Equal(got, want, opt) {
+			t.Errorf("%v.Mag() = %v, want %v", a, got, want)
+		}
+		if got, want := a.Dot(b), p[0].Dot(p[1]); !cmp.Equal(got, want, opt) {
+			t.Errorf("%v.Dot(%v) = %v, want %v", a, b, got, want)
+		}
+		if got, want := a.Dist(b), p[0].Dist(p[1]); !cmp.Equal(got, want, opt) {
+			t.Errorf("%v.Dist(%v) = %v, want %v", a, b, got, want)
+		}
+		if got, want := Angle(a, b), vector.Angle(p[0], p[1]); !cmp.Equal(got, want, opt) {
+			t.Errorf("Angle(%v, %v) = %v, want %v", a, b, got, want)
+		}
+		if got, want := Unit(a), FromVector(vector.Unit(p[0])); !cmp.Equal(got, want, opt) {
+			t.Errorf("Unit(%v) = %v, want %v", a, got, want)
+		}
+		if got, want := Add(a, b), FromVector(vector.Add(p[0], p[1])); !cmp.Equal(got, want, opt) {
+			t.Errorf("Add(%v, %v) = %v, want %v", a, b, got, want)
+		}
+		if got, want := Sub(a, b), FromVector(vector.Sub(p[0], p[1])); !cmp.Equal(got, want, opt) {
+			t.Errorf("Sub(%v, %v) = %v, want %v", a, b, got, want)
+		}
+		if got, want := Lerp(a, b, 0.3), FromVector(vector.Lerp(p[0], p[1], 0.3)); !cmp.Equal(got, want, opt) {
+			t.Errorf("Lerp(%v, %v, 0.3) = %v, want %v", a, b, got, want)
+		}
+	}
+}
+
+func TestModifySelf(t *testing.T) {
+	opt := getComparer(.00001)
+	v := New(3, 4, 0, 0)
+	if got := v.Normalize(); !cmp.Equal(got, VecN{0.6, 0.8, 0, 0}, opt) || !cmp.Equal(v, got) {
+		t.Errorf("Normalize() = %v, vector = %v", got, v)
+	}
+	v.Resize(10).Add(New(1, 1, 1, 1)).Sub(New(0, 0, 1, 1)).Mult(2)
+	if !cmp.Equal(v, VecN{14, 18, 0, 0}, opt) {
+		t.Errorf("chained ops = %v, want {14, 18, 0, 0}", v)
+	}
+	z := Zero(3)
+	if got := z.Normalize(); !cmp.Equal(got, VecN{0, 0, 0}) {
+		t.Errorf("Zero(3).Normalize() = %v", got)
+	}
+	a := New(1, 2)
+	a.Assign(New(5, 6))
+	if !cmp.Equal(a, VecN{5, 6}) {
+		t.Errorf("Assign() = %v", a)
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	opt := getComparer(.00001)
+	tests := []struct {
+		a, b VecN
+		want float32
+	}{
+		{New(1, 0, 0, 0), New(0, 1, 0, 0), 0},
+		{New(1, 1, 0, 0), New(2, 2, 0, 0), 1},
+		{New(1, 2, 3, 4), New(-1, -2, -3, -4), -1},
+		{New(0, 0, 0, 0), New(1, 0, 0, 0), float32(math.NaN())},
+	}
+	for _, test := range tests {
+		if got := CosineSimilarity(test.a, test.b); !cmp.Equal(got, test.want, opt) {
+			t.Errorf("CosineSimilarity(%v, %v) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestDimensionMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Dot() with different dimensions did not panic")
+		}
+	}()
+	Dot(New(1, 2), New(1, 2, 3))
+}
@@ -0,0 +1,99 @@
+package verlet
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Material of ropes and cloths. Zero fields take their default value.
+type Material struct {
+	// Mass of each point (default 1)
+	Mass float32
+	// Stiffness of the distance constraints, in (0, 1] (default 1)
+	Stiffness float32
+	// Stiffness of the bending constraints, in [0, 1] (default 0, no bending constraints)
+	Bending float32
+}
+
+func (m Material) withDefaults() Material {
+	if m.Mass <= 0 {
+		m.Mass = 1
+	}
+	if m.Stiffness <= 0 {
+		m.Stiffness = 1
+	}
+	return m
+}
+
+// Adds a rope of n points evenly spaced from start to end, linked by distance
+// constraints and, with a bending material, bending constraints on each three
+// consecutive points. Gives the points in order, nil if n < 2.
+func (s *System) AddRope(start, end *vector.Vector, n int, m Material) []*Point {
+	if n < 2 {
+		return nil
+	}
+	m = m.withDefaults()
+	points := make([]*Point, n)
+	for i := range points {
+		points[i] = s.AddPoint(vector.Lerp2(start, end, n-1, i), m.Mass)
+	}
+	for i := 1; i < n; i++ {
+		s.AddConstraint(NewDistance(points[i-1], points[i], m.Stiffness))
+	}
+	if m.Bending > 0 {
+		for i := 2; i < n; i++ {
+			s.AddConstraint(NewBending(points[i-2], points[i-1], points[i], m.Bending))
+		}
+	}
+	return points
+}
+
+// Adds a cloth of rows x cols points on the parallelogram spanned by u and v
+// from origin: point [r][c] is at origin + u*c/(cols-1) + v*r/(rows-1).
+// Neighbors along the rows and columns and along the diagonals are linked by
+// distance constraints and, with a bending material, each three consecutive
+// points along the rows and columns by bending constraints.
+// Gives the points by row, nil if rows or cols < 2.
+func (s *System) AddCloth(origin, u, v *vector.Vector, rows, cols int, m Material) [][]*Point {
+	if rows < 2 || cols < 2 {
+		return nil
+	}
+	m = m.withDefaults()
+	grid := make([][]*Point, rows)
+	for r := range grid {
+		grid[r] = make([]*Point, cols)
+		for c := range grid[r] {
+			p := vector.Add(origin, u.Copy().Mult(float32(c)/float32(cols-1))).
+				Add(v.Copy().Mult(float32(r) / float32(rows-1)))
+			grid[r][c] = s.AddPoint(p, m.Mass)
+		}
+	}
+	link := func(a, b *Point) { s.AddConstraint(NewDistance(a, b, m.Stiffness)) }
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if c+1 < cols {
+				link(grid[r][c], grid[r][c+1])
+			}
+			if r+1 < rows {
+				link(grid[r][c], grid[r+1][c])
+			}
+			// shear
+			if r+1 < rows && c+1 < cols {
+				link(grid[r][c], grid[r+1][c+1])
+				link(grid[r][c+1], grid[r+1][c])
+			}
+		}
+	}
+	if m.Bending > 0 {
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if c+2 < cols {
+					s.AddConstraint(NewBending(grid[r][c], grid[r][c+1], grid[r][c+2], m.Bending))
+				}
+				if r+2 < rows {
+					s.AddConstraint(NewBending(grid[r][c], grid[r+1][c], grid[r+2][c], m.Bending))
+				}
+			}
+		}
+	}
+	return grid
+}
@@ -0,0 +1,114 @@
+package verlet
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestAddRope(t *testing.T) {
+	tests := []struct {
+		name            string
+		n               int
+		m               Material
+		wantConstraints int
+	}{
+		{"limp", 5, Material{}, 4},
+		{"bending", 5, Material{Bending: 0.5}, 4 + 3},
+		{"too short", 1, Material{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSystem()
+			points := s.AddRope(vector.New(0, 0, 0), vector.New(4, 0, 0), tt.n, tt.m)
+			if tt.n < 2 {
+				if points != nil {
+					t.Errorf("AddRope() = %v, want nil", points)
+				}
+				return
+			}
+			if len(points) != tt.n || len(s.Points) != tt.n {
+				t.Fatalf("got %d points, want %d", len(points), tt.n)
+			}
+			if len(s.Constraints) != tt.wantConstraints {
+				t.Errorf("got %d constraints, want %d", len(s.Constraints), tt.wantConstraints)
+			}
+			for i, p := range points {
+				if diff := cmp.Diff(vector.New(float32(i), 0, 0), p.Position, getComparer(1e-6)); diff != "" {
+					t.Errorf("point %d mismatch (-want +got):\n%s", i, diff)
+				}
+				if p.InvMass != 1 {
+					t.Errorf("point %d InvMass = %v, want 1", i, p.InvMass)
+				}
+			}
+		})
+	}
+}
+
+func TestHangingRope(t *testing.T) {
+	// a horizontal rope pinned at one end swings down and hangs straight
+	s := NewSystem()
+	s.Iterations = 20
+	s.Damping = 0.02
+	points := s.AddRope(vector.New(0, 0, 0), vector.New(5, 0, 0), 11, Material{})
+	s.AddConstraint(NewPin(points[0]))
+	for i := 0; i < 2000; i++ {
+		s.Step(1.0 / 60)
+	}
+	end := points[len(points)-1].Position
+	if diff := cmp.Diff(vector.New(0, -5, 0), end, getComparer(0.05)); diff != "" {
+		t.Errorf("rope end mismatch (-want +got):\n%s", diff)
+	}
+	for i := 1; i < len(points); i++ {
+		if d := points[i].Position.Dist(points[i-1].Position); d < 0.49 || d > 0.51 {
+			t.Errorf("segment %d length = %v, want 0.5", i, d)
+		}
+	}
+}
+
+func TestAddCloth(t *testing.T) {
+	s := NewSystem()
+	grid := s.AddCloth(vector.New(0, 0, 0), vector.New(3, 0, 0), vector.New(0, 0, 2), 3, 4, Material{Mass: 0.5, Bending: 0.1})
+	if len(grid) != 3 || len(grid[0]) != 4 || len(s.Points) != 12 {
+		t.Fatalf("got %dx%d grid and %d points, want 3x4 and 12", len(grid), len(grid[0]), len(s.Points))
+	}
+	// 3*3 horizontal, 2*4 vertical and 2*3*2 shear distances,
+	// 3*2 bending along the rows and 4*1 along the columns
+	if want := 9 + 8 + 12 + 6 + 4; len(s.Constraints) != want {
+		t.Errorf("got %d constraints, want %d", len(s.Constraints), want)
+	}
+	if diff := cmp.Diff(vector.New(2, 0, 1), grid[1][2].Position, getComparer(1e-6)); diff != "" {
+		t.Errorf("grid[1][2] mismatch (-want +got):\n%s", diff)
+	}
+	if grid[0][0].InvMass != 2 {
+		t.Errorf("InvMass = %v, want 2", grid[0][0].InvMass)
+	}
+	if g := s.AddCloth(vector.New(0, 0, 0), vector.New(1, 0, 0), vector.New(0, 0, 1), 1, 4, Material{}); g != nil {
+		t.Errorf("AddCloth() with one row = %v, want nil", g)
+	}
+}
+
+func TestClothOnSphere(t *testing.T) {
+	// a cloth dropped on a sphere drapes over it without going through
+	s := NewSystem()
+	grid := s.AddCloth(vector.New(-2, 2, -2), vector.New(4, 0, 0), vector.New(0, 0, 4), 11, 11, Material{Bending: 0.2})
+	ball := Sphere{Center: vector.New(0, 0, 0), Radius: 1, Friction: 1}
+	s.AddCollider(ball, Plane{Point: vector.New(0, -3, 0), Normal: vector.New(0, 1, 0)})
+	for i := 0; i < 120; i++ {
+		s.Step(1.0 / 60)
+	}
+	for _, p := range s.Points {
+		if d := p.Position.Dist(ball.Center); d < ball.Radius-1e-4 {
+			t.Errorf("point inside the sphere at distance %v", d)
+		}
+		if p.Position.Y < -3-1e-4 {
+			t.Errorf("point below the floor at %v", p.Position)
+		}
+	}
+	// the middle rests on the upper half of the ball
+	mid := grid[5][5].Position
+	if d := mid.Dist(ball.Center); d > ball.Radius+0.05 || mid.Y < 0.5 {
+		t.Errorf("middle point at %v, distance %v from the center, want on top of the sphere", mid, d)
+	}
+}
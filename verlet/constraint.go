@@ -0,0 +1,141 @@
+package verlet
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Relation between points that is enforced by moving them
+type Constraint interface {
+	// Moves the points towards satisfying the constraint
+	Solve()
+}
+
+// Keeps two points at a given distance
+type Distance struct {
+	A, B   *Point
+	Length float32
+	// Fraction of the error corrected at each iteration, in (0, 1]
+	Stiffness float32
+}
+
+// Makes a distance constraint keeping the current distance between a and b
+func NewDistance(a, b *Point, stiffness float32) *Distance {
+	return &Distance{A: a, B: b, Length: a.Position.Dist(b.Position), Stiffness: stiffness}
+}
+
+// Moves both points along the line between them, in proportion to their inverse mass
+func (c *Distance) Solve() {
+	w := c.A.InvMass + c.B.InvMass
+	d := c.A.Position.Dist(c.B.Position)
+	if w == 0 || d == 0 {
+		return
+	}
+	delta := vector.Sub(c.B.Position, c.A.Position).Resize((d - c.Length) * c.Stiffness / w)
+	c.A.Position.Add(delta.Copy().Mult(c.A.InvMass))
+	c.B.Position.Sub(delta.Mult(c.B.InvMass))
+}
+
+// Resists folding at the middle point B of the chain A, B, C by keeping the
+// distance of B from the centroid of the three points
+// (Kelager et al., A Triangle Bending Constraint Model for Position-Based Dynamics, 2010)
+type Bending struct {
+	A, B, C *Point
+	// Distance of B from the centroid at rest
+	Rest float32
+	// Fraction of the error corrected at each iteration, in (0, 1]
+	Stiffness float32
+}
+
+// Makes a bending constraint keeping the current shape of a, b and c
+func NewBending(a, b, c *Point, stiffness float32) *Bending {
+	return &Bending{A: a, B: b, C: c, Rest: b.Position.Dist(centroid(a, b, c)), Stiffness: stiffness}
+}
+
+func centroid(a, b, c *Point) *vector.Vector {
+	return vector.Add(a.Position, b.Position).Add(c.Position).Mult(1.0 / 3)
+}
+
+// Moves B towards the centroid and A and C the other way, keeping the centroid in place
+func (c *Bending) Solve() {
+	w := c.A.InvMass + 2*c.B.InvMass + c.C.InvMass
+	dir := vector.Sub(c.B.Position, centroid(c.A, c.B, c.C))
+	d := dir.Mag()
+	if w == 0 || d == 0 {
+		return
+	}
+	f := dir.Mult((1 - c.Rest/d) * c.Stiffness / w)
+	c.A.Position.Add(f.Copy().Mult(2 * c.A.InvMass))
+	c.B.Position.Sub(f.Copy().Mult(4 * c.B.InvMass))
+	c.C.Position.Add(f.Mult(2 * c.C.InvMass))
+}
+
+// Holds a point at a target, which can be moved to drag the point around
+type Pin struct {
+	P      *Point
+	Target *vector.Vector
+}
+
+// Makes a pin holding p where it is
+func NewPin(p *Point) *Pin {
+	return &Pin{P: p, Target: p.Position.Copy()}
+}
+
+// Puts the point on the target
+func (c *Pin) Solve() {
+	c.P.Position.Assign(c.Target)
+}
+
+// Obstacle that pushes points out of it
+type Collider interface {
+	// Moves the point out of the obstacle
+	Collide(p *Point)
+}
+
+// Solid sphere
+type Sphere struct {
+	Center *vector.Vector
+	Radius float32
+	// Fraction of the sliding velocity lost on contact, in [0, 1]
+	Friction float32
+}
+
+// Pushes the point to the surface of the sphere along the radius
+func (s Sphere) Collide(p *Point) {
+	if p.Position.Dist(s.Center) >= s.Radius {
+		return
+	}
+	n := vector.Sub(p.Position, s.Center)
+	if n.MagSq() == 0 {
+		n = vector.New(0, 1, 0)
+	}
+	p.Position.Assign(vector.Add(s.Center, n.Resize(s.Radius)))
+	friction(p, n.Normalize(), s.Friction)
+}
+
+// Solid half space behind a plane
+type Plane struct {
+	Point *vector.Vector
+	// Unit normal pointing to the free side
+	Normal   *vector.Vector
+	Friction float32
+}
+
+// Pushes the point back on the plane along the normal
+func (s Plane) Collide(p *Point) {
+	d := vector.Dot(vector.Sub(p.Position, s.Point), s.Normal)
+	if d >= 0 {
+		return
+	}
+	p.Position.Sub(s.Normal.Copy().Mult(d))
+	friction(p, s.Normal, s.Friction)
+}
+
+// Removes a fraction of the velocity tangent to the unit normal n
+func friction(p *Point, n *vector.Vector, f float32) {
+	if f == 0 {
+		return
+	}
+	v := vector.Sub(p.Position, p.Previous)
+	tangent := vector.Sub(v, n.Copy().Mult(vector.Dot(v, n)))
+	p.Previous.Add(tangent.Mult(f))
+}
@@ -0,0 +1,111 @@
+package verlet
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name         string
+		massA, massB float32
+		stiffness    float32
+		wantA, wantB *vector.Vector
+	}{
+		{"equal masses", 1, 1, 1, vector.New(-1, 0, 0), vector.New(1, 0, 0)},
+		{"fixed a", 0, 1, 1, vector.New(-2, 0, 0), vector.New(0, 0, 0)},
+		{"heavy b", 1, 3, 1, vector.New(-0.5, 0, 0), vector.New(1.5, 0, 0)},
+		{"half stiff", 1, 1, 0.5, vector.New(-1.5, 0, 0), vector.New(1.5, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewPoint(vector.New(-1, 0, 0), tt.massA)
+			b := NewPoint(vector.New(1, 0, 0), tt.massB)
+			c := NewDistance(a, b, tt.stiffness)
+			if c.Length != 2 {
+				t.Errorf("Length = %v, want 2", c.Length)
+			}
+			// stretch to 4
+			a.Position.X, b.Position.X = -2, 2
+			c.Solve()
+			if diff := cmp.Diff(tt.wantA, a.Position, getComparer(1e-6)); diff != "" {
+				t.Errorf("A mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantB, b.Position, getComparer(1e-6)); diff != "" {
+				t.Errorf("B mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBending(t *testing.T) {
+	a := NewPoint(vector.New(-1, 0, 0), 1)
+	b := NewPoint(vector.New(0, 0, 0), 1)
+	c := NewPoint(vector.New(1, 0, 0), 1)
+	k := NewBending(a, b, c, 1)
+	if k.Rest != 0 {
+		t.Errorf("Rest = %v, want 0", k.Rest)
+	}
+	// fold the chain, one rigid iteration straightens it around the centroid
+	b.Position.Y = 3
+	k.Solve()
+	for _, p := range []*Point{a, b, c} {
+		if math.Abs(float64(p.Position.Y-1)) > 1e-6 {
+			t.Errorf("Y = %v, want 1", p.Position.Y)
+		}
+	}
+	if diff := cmp.Diff(vector.New(0, 1, 0), centroid(a, b, c), getComparer(1e-6)); diff != "" {
+		t.Errorf("centroid mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPin(t *testing.T) {
+	p := NewPoint(vector.New(1, 2, 3), 1)
+	pin := NewPin(p)
+	p.Position = vector.New(0, 0, 0)
+	pin.Target.X = 5
+	pin.Solve()
+	if diff := cmp.Diff(vector.New(5, 2, 3), p.Position, getComparer(0)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestColliders(t *testing.T) {
+	tests := []struct {
+		name     string
+		collider Collider
+		from, to *vector.Vector
+		want     *vector.Vector
+	}{
+		{"sphere", Sphere{Center: vector.New(0, 0, 0), Radius: 2}, vector.New(0, 3, 0), vector.New(0, 1, 0), vector.New(0, 2, 0)},
+		{"sphere outside", Sphere{Center: vector.New(0, 0, 0), Radius: 2}, vector.New(0, 4, 0), vector.New(0, 3, 0), vector.New(0, 3, 0)},
+		{"sphere friction", Sphere{Center: vector.New(0, 0, 0), Radius: 1, Friction: 1}, vector.New(-1, 1, 0), vector.New(0, 0.5, 0), vector.New(0, 1, 0)},
+		{"plane", Plane{Point: vector.New(0, 1, 0), Normal: vector.New(0, 1, 0)}, vector.New(-1, 2, 0), vector.New(1, 0, 0), vector.New(1, 1, 0)},
+		{"plane above", Plane{Point: vector.New(0, 1, 0), Normal: vector.New(0, 1, 0)}, vector.New(0, 3, 0), vector.New(0, 2, 0), vector.New(0, 2, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPoint(tt.to, 1)
+			p.Previous = tt.from.Copy()
+			tt.collider.Collide(p)
+			if diff := cmp.Diff(tt.want, p.Position, getComparer(1e-6)); diff != "" {
+				t.Errorf("Position mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPlaneFriction(t *testing.T) {
+	plane := Plane{Point: vector.New(0, 0, 0), Normal: vector.New(0, 1, 0), Friction: 0.5}
+	p := NewPoint(vector.New(2, -1, 0), 1)
+	p.Previous = vector.New(0, 1, 0)
+	plane.Collide(p)
+	// half of the sliding velocity is lost, the normal velocity is untouched
+	v := vector.Sub(p.Position, p.Previous)
+	if math.Abs(float64(v.X-1)) > 1e-6 || math.Abs(float64(v.Y+1)) > 1e-6 {
+		t.Errorf("velocity = %v, want {1, -1, 0}", v)
+	}
+}
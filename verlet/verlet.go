@@ -0,0 +1,118 @@
+// Package verlet provides position based dynamics on vector.Vector: points are
+// moved with Verlet integration and then projected on distance, bending, pin
+// and collision constraints, good for ropes and cloth.
+// https://en.wikipedia.org/wiki/Verlet_integration
+package verlet
+
+import (
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+// Simulated point, its velocity is implicit in Position - Previous
+type Point struct {
+	Position *vector.Vector
+	// Position at the previous step
+	Previous *vector.Vector
+	// Inverse of the mass, 0 for a point that nothing moves
+	InvMass float32
+
+	force vector.Vector
+}
+
+// Makes a point at rest at position, the vector is copied.
+// A mass of 0 gives a fixed point.
+func NewPoint(position *vector.Vector, mass float32) *Point {
+	p := &Point{Position: position.Copy(), Previous: position.Copy()}
+	if mass > 0 {
+		p.InvMass = 1 / mass
+	}
+	return p
+}
+
+// Adds a force acting on the point during the next step
+func (p *Point) ApplyForce(f *vector.Vector) *Point {
+	p.force.Add(f)
+	return p
+}
+
+// Velocity over the last step of length dt
+func (p *Point) Velocity(dt float32) *vector.Vector {
+	return vector.Sub(p.Position, p.Previous).Mult(1 / dt)
+}
+
+// Moves the point without giving it any velocity
+func (p *Point) Teleport(position *vector.Vector) *Point {
+	p.Previous.Add(vector.Sub(position, p.Position))
+	p.Position.Assign(position)
+	return p
+}
+
+// Points with the constraints between them
+type System struct {
+	Points      []*Point
+	Constraints []Constraint
+	Colliders   []Collider
+	Gravity     *vector.Vector
+	// Number of passes over the constraints each step, more makes them stiffer
+	Iterations int
+	// Fraction of the velocity lost at each step, in [0, 1]
+	Damping float32
+}
+
+// Makes an empty system with gravity along -y and 8 solver iterations
+func NewSystem() *System {
+	return &System{Gravity: vector.New(0, -9.81, 0), Iterations: 8}
+}
+
+// Makes a point and adds it to the system, see NewPoint
+func (s *System) AddPoint(position *vector.Vector, mass float32) *Point {
+	p := NewPoint(position, mass)
+	s.Points = append(s.Points, p)
+	return p
+}
+
+// Adds constraints to the system
+func (s *System) AddConstraint(cs ...Constraint) {
+	s.Constraints = append(s.Constraints, cs...)
+}
+
+// Adds colliders to the system, they act on every point
+func (s *System) AddCollider(cs ...Collider) {
+	s.Colliders = append(s.Colliders, cs...)
+}
+
+// Advances the system by dt: Verlet integration of gravity and the applied
+// forces, then the constraints and colliders are solved Iterations times
+func (s *System) Step(dt float32) {
+	keep := 1 - s.Damping
+	for _, p := range s.Points {
+		if p.InvMass == 0 {
+			p.force = vector.Vector{}
+			continue
+		}
+		acc := p.force.Copy().Mult(p.InvMass)
+		if s.Gravity != nil {
+			acc.Add(s.Gravity)
+		}
+		velocity := vector.Sub(p.Position, p.Previous).Mult(keep)
+		p.Previous.Assign(p.Position)
+		p.Position.Add(velocity).Add(acc.Mult(dt * dt))
+		p.force = vector.Vector{}
+	}
+	iterations := s.Iterations
+	if iterations < 1 {
+		iterations = 1
+	}
+	for i := 0; i < iterations; i++ {
+		for _, c := range s.Constraints {
+			c.Solve()
+		}
+		for _, c := range s.Colliders {
+			for _, p := range s.Points {
+				if p.InvMass != 0 {
+					c.Collide(p)
+				}
+			}
+		}
+	}
+}
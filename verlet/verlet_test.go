@@ -0,0 +1,69 @@
+package verlet
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vaibhav11s/gopkgs/vector"
+)
+
+func getComparer(tolerance float32) cmp.Option {
+	return cmp.Comparer(func(a, b *vector.Vector) bool { return a.Equal(b, tolerance) })
+}
+
+func TestFreeFall(t *testing.T) {
+	s := NewSystem()
+	p := s.AddPoint(vector.New(0, 10, 0), 1)
+	fixed := s.AddPoint(vector.New(1, 10, 0), 0)
+	const dt, n = 0.01, 100
+	for i := 0; i < n; i++ {
+		s.Step(dt)
+	}
+	// starting at rest, step i adds i*g*dt^2 to the position, up to float32 rounding
+	want := vector.New(0, 10-9.81*dt*dt*n*(n+1)/2, 0)
+	if diff := cmp.Diff(want, p.Position, getComparer(5e-3)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+	// the velocity over the last step is g*n*dt
+	if diff := cmp.Diff(vector.New(0, -9.81, 0), p.Velocity(dt), getComparer(1e-2)); diff != "" {
+		t.Errorf("Velocity mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(vector.New(1, 10, 0), fixed.Position, getComparer(0)); diff != "" {
+		t.Errorf("fixed point moved (-want +got):\n%s", diff)
+	}
+}
+
+func TestForceAndDamping(t *testing.T) {
+	s := NewSystem()
+	s.Gravity = nil
+	p := s.AddPoint(vector.New(0, 0, 0), 2)
+	p.ApplyForce(vector.New(400, 0, 0))
+	s.Step(0.1)
+	// a = 200, one step from rest moves a*dt^2
+	if diff := cmp.Diff(vector.New(2, 0, 0), p.Position, getComparer(1e-5)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+	// the force only acts for one step
+	s.Step(0.1)
+	if diff := cmp.Diff(vector.New(4, 0, 0), p.Position, getComparer(1e-5)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+	s.Damping = 0.5
+	s.Step(0.1)
+	if diff := cmp.Diff(vector.New(5, 0, 0), p.Position, getComparer(1e-5)); diff != "" {
+		t.Errorf("damped Position mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTeleport(t *testing.T) {
+	s := NewSystem()
+	s.Gravity = nil
+	p := s.AddPoint(vector.New(0, 0, 0), 1)
+	p.Previous = vector.New(-1, 0, 0)
+	p.Teleport(vector.New(10, 10, 0))
+	s.Step(1)
+	// the velocity is kept
+	if diff := cmp.Diff(vector.New(11, 10, 0), p.Position, getComparer(1e-6)); diff != "" {
+		t.Errorf("Position mismatch (-want +got):\n%s", diff)
+	}
+}